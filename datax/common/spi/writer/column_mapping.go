@@ -0,0 +1,143 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+// ColumnMapping - mapping from a column of the reader to a column of the writer by name
+type ColumnMapping struct {
+	Source  string  `json:"source"`  // column name of the reader, the target is the constant value if empty
+	Target  string  `json:"target"`  // column name of the writer
+	Value   *string `json:"value"`   // constant value of the target when the source is empty
+	Default *string `json:"default"` // default value of the target when the source column is missing or null
+}
+
+// ColumnMappings - mappings of all the columns written
+type ColumnMappings []*ColumnMapping
+
+// Validate - check whether the mappings are valid
+func (m ColumnMappings) Validate() error {
+	targets := make(map[string]bool)
+	for i, v := range m {
+		if v == nil {
+			return fmt.Errorf("%vst column mapping is empty", i)
+		}
+		if v.Target == "" {
+			return fmt.Errorf("%vst column mapping has no target", i)
+		}
+		if v.Source == "" && v.Value == nil {
+			return fmt.Errorf("column mapping of target(%v) has neither source nor value", v.Target)
+		}
+		if v.Source != "" && v.Value != nil {
+			return fmt.Errorf("column mapping of target(%v) has both source and value", v.Target)
+		}
+		if targets[v.Target] {
+			return fmt.Errorf("target(%v) is duplicate", v.Target)
+		}
+		targets[v.Target] = true
+	}
+	return nil
+}
+
+// RecordMapper - a mapper that builds the records of the writer from the records of the reader by column names
+type RecordMapper struct {
+	targets  []string
+	mappings map[string]*ColumnMapping
+}
+
+// NewRecordMapper - create a record mapper by mappings, whose records have the columns of targets in order.
+// The targets are the targets of mappings if empty, and the targets without mappings take the source columns of the same name
+func NewRecordMapper(mappings ColumnMappings, targets []string) (*RecordMapper, error) {
+	if err := mappings.Validate(); err != nil {
+		return nil, err
+	}
+	r := &RecordMapper{
+		targets:  targets,
+		mappings: make(map[string]*ColumnMapping),
+	}
+	for _, v := range mappings {
+		r.mappings[v.Target] = v
+		if len(targets) == 0 {
+			r.targets = append(r.targets, v.Target)
+		}
+	}
+	for _, v := range mappings {
+		if !r.hasTarget(v.Target) {
+			return nil, fmt.Errorf("target(%v) is not in %v", v.Target, targets)
+		}
+	}
+	return r, nil
+}
+
+// Map - build the record of the writer from the record of the reader
+func (r *RecordMapper) Map(record element.Record) (element.Record, error) {
+	mapped := element.NewDefaultRecord()
+	for _, target := range r.targets {
+		col, err := r.mapColumn(record, target)
+		if err != nil {
+			return nil, err
+		}
+		if err = mapped.Add(col); err != nil {
+			return nil, fmt.Errorf("add column(%v) fail. err: %v", target, err)
+		}
+	}
+	return mapped, nil
+}
+
+func (r *RecordMapper) mapColumn(record element.Record, target string) (element.Column, error) {
+	source := target
+	var def *string
+	if m, ok := r.mappings[target]; ok {
+		if m.Source == "" {
+			return newStringColumn(*m.Value, target), nil
+		}
+		source, def = m.Source, m.Default
+	}
+
+	col, err := record.GetByName(source)
+	if err != nil || col.IsNil() {
+		if def != nil {
+			return newStringColumn(*def, target), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("column(%v) of target(%v) is not found. err: %v", source, target, err)
+		}
+	}
+	if col.Name() == target {
+		return col, nil
+	}
+	var cv element.ColumnValue = col
+	if d, ok := col.(*element.DefaultColumn); ok {
+		cv = d.ColumnValue
+	}
+	return element.NewDefaultColumn(cv, target, int(col.ByteSize())), nil
+}
+
+func (r *RecordMapper) hasTarget(target string) bool {
+	for _, v := range r.targets {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func newStringColumn(s string, name string) element.Column {
+	return element.NewDefaultColumn(element.NewStringColumnValue(s), name, len(s))
+}
@@ -0,0 +1,220 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testStringPtr(s string) *string {
+	return &s
+}
+
+func testRecord(columns ...element.Column) element.Record {
+	r := element.NewDefaultRecord()
+	for _, c := range columns {
+		r.Add(c)
+	}
+	return r
+}
+
+func testColumn(name string, v string) element.Column {
+	return element.NewDefaultColumn(element.NewStringColumnValue(v), name, len(v))
+}
+
+func testRecordString(r element.Record) string {
+	var s []string
+	for i := 0; i < r.ColumnNumber(); i++ {
+		c, _ := r.GetByIndex(i)
+		s = append(s, c.Name()+"="+c.String())
+	}
+	return strings.Join(s, " ")
+}
+
+func TestColumnMappings_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       ColumnMappings
+		wantErr bool
+	}{
+		{
+			name: "1",
+			m: ColumnMappings{
+				{Source: "a", Target: "b"},
+				{Target: "c", Value: testStringPtr("1")},
+			},
+		},
+		{
+			name:    "2",
+			m:       ColumnMappings{nil},
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			m:       ColumnMappings{{Source: "a"}},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			m:       ColumnMappings{{Target: "a"}},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			m:       ColumnMappings{{Source: "a", Target: "b", Value: testStringPtr("1")}},
+			wantErr: true,
+		},
+		{
+			name: "6",
+			m: ColumnMappings{
+				{Source: "a", Target: "b"},
+				{Source: "c", Target: "b"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ColumnMappings.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRecordMapper(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings ColumnMappings
+		targets  []string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			mappings: ColumnMappings{{Source: "a", Target: "b"}},
+		},
+		{
+			name:     "2",
+			mappings: ColumnMappings{{Source: "a", Target: "b"}},
+			targets:  []string{"b", "c"},
+		},
+		{
+			name:     "3",
+			mappings: ColumnMappings{{Source: "a", Target: "b"}},
+			targets:  []string{"c"},
+			wantErr:  true,
+		},
+		{
+			name:     "4",
+			mappings: ColumnMappings{{Target: "b"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecordMapper(tt.mappings, tt.targets)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRecordMapper() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecordMapper_Map(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings ColumnMappings
+		targets  []string
+		record   element.Record
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "1",
+			mappings: ColumnMappings{
+				{Source: "b", Target: "y"},
+				{Source: "a", Target: "x"},
+			},
+			record: testRecord(testColumn("a", "1"), testColumn("b", "2")),
+			want:   "y=2 x=1",
+		},
+		{
+			name: "2",
+			mappings: ColumnMappings{
+				{Source: "a", Target: "x"},
+				{Target: "c", Value: testStringPtr("const")},
+			},
+			targets: []string{"c", "b", "x"},
+			record:  testRecord(testColumn("a", "1"), testColumn("b", "2")),
+			want:    "c=const b=2 x=1",
+		},
+		{
+			name: "3",
+			mappings: ColumnMappings{
+				{Source: "a", Target: "x", Default: testStringPtr("def")},
+				{Source: "b", Target: "y", Default: testStringPtr("def")},
+			},
+			record: testRecord(element.NewDefaultColumn(element.NewNilStringColumnValue(), "a", 0)),
+			want:   "x=def y=def",
+		},
+		{
+			name: "4",
+			mappings: ColumnMappings{
+				{Source: "a", Target: "x"},
+			},
+			record: testRecord(element.NewDefaultColumn(element.NewNilStringColumnValue(), "a", 0)),
+			want:   "x=<nil>",
+		},
+		{
+			name: "5",
+			mappings: ColumnMappings{
+				{Source: "a", Target: "x"},
+			},
+			record:  testRecord(testColumn("b", "1")),
+			wantErr: true,
+		},
+		{
+			name: "6",
+			mappings: ColumnMappings{
+				{Source: "a", Target: "x"},
+			},
+			targets: []string{"x", "c"},
+			record:  testRecord(testColumn("a", "1")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRecordMapper(tt.mappings, tt.targets)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.Map(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecordMapper.Map() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if s := testRecordString(got); s != tt.want {
+				t.Errorf("RecordMapper.Map() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
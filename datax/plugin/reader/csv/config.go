@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 
 	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
 	// csv storage
 	"github.com/Breeze0806/go-etl/storage/stream/file/csv"
 )
@@ -25,8 +26,10 @@ import (
 // Config represents the configuration for reading CSV files.
 type Config struct {
 	csv.InConfig
+	file.PathConfig
 
-	Path []string `json:"path"`
+	Path      []string `json:"path"`      // Files, directories or glob patterns
+	SplitSize int64    `json:"splitSize"` // Byte size of ranges into which a large uncompressed file is divided, 0 means no division
 }
 
 // NewConfig reads the JSON configuration conf to obtain the CSV reading configuration.
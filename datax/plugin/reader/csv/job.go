@@ -16,9 +16,12 @@ package csv
 
 import (
 	"context"
+	"io"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	streamfile "github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/Breeze0806/go-etl/storage/stream/file/csv"
 	"github.com/pingcap/errors"
 )
 
@@ -38,17 +41,52 @@ func NewJob() *Job {
 
 // Init - Initializes the Job, setting up any required resources or states
 func (j *Job) Init(ctx context.Context) (err error) {
-	j.conf, err = NewConfig(j.PluginJobConf())
-	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginConf())
+	if j.conf, err = NewConfig(j.PluginJobConf()); err != nil {
+		return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginConf())
+	}
+	return errors.Wrapf(j.ConfigureFileSystems(), "ConfigureFileSystems fail")
 }
 
 // Split - Divides the Job into smaller sub-tasks or sub-jobs for parallel processing or distribution
+// The files found in path are divided into byte ranges of splitSize if uncompressed, and then balanced
+// by size across number tasks
 func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
-	for _, v := range j.conf.Path {
+	var files []file.File
+	if files, err = j.Expand(j.conf.PathConfig, j.conf.Path); err != nil {
+		return nil, errors.Wrapf(err, "Expand fail. path: %v", j.conf.Path)
+	}
+	if len(j.conf.Path) > 0 && len(files) == 0 {
+		return nil, errors.Errorf("no file is found. path: %v", j.conf.Path)
+	}
+	if files, err = file.SplitRange(files, j.conf.SplitSize, j.splittable); err != nil {
+		return nil, errors.Wrapf(err, "SplitRange fail. splitSize: %v", j.conf.SplitSize)
+	}
+
+	for _, v := range file.Balance(files, number) {
 		conf, _ := config.NewJSONFromString("{}")
-		conf.Set("path", v)
+		if err = file.SetPath(conf, v); err != nil {
+			return nil, errors.Wrapf(err, "SetPath fail")
+		}
+		if err = j.SetFileSystems(conf); err != nil {
+			return nil, errors.Wrapf(err, "SetFileSystems fail")
+		}
 		conf.Set("content.0", j.conf.InConfig)
 		configs = append(configs, conf)
 	}
 	return
 }
+
+// splittable checks whether the file is not compressed and its encoding is compatible with ascii
+func (j *Job) splittable(f file.File) (ok bool, err error) {
+	var fd streamfile.ReadFile
+	if fd, err = j.FileSystems().Open(f.Path); err != nil {
+		return false, err
+	}
+	defer fd.Close()
+	header := make([]byte, 8)
+	var n int
+	if n, err = fd.ReadAt(header, 0); err != nil && err != io.EOF {
+		return false, err
+	}
+	return csv.CheckRange(&j.conf.InConfig, f.Path, header[:n]) == nil, nil
+}
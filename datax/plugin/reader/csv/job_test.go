@@ -16,6 +16,8 @@ package csv
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -31,6 +33,21 @@ func testJSONFromString(json string) *config.JSON {
 }
 
 func TestJob_Split(t *testing.T) {
+	dir := t.TempDir()
+	for _, v := range []string{"a_1.csv", "a_2.csv", "a_3.csv"} {
+		if err := os.WriteFile(filepath.Join(dir, v), []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, v := range []string{"z_0.csv", "z_1.csv", "z_2.csv", "z_3.csv"} {
+		data := []byte(v)
+		if v != "z_0.csv" {
+			data = nil
+		}
+		if err := os.WriteFile(filepath.Join(dir, v), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
 	type args struct {
 		ctx    context.Context
 		number int
@@ -52,6 +69,104 @@ func TestJob_Split(t *testing.T) {
 				testJSONFromString(`{"path":"file1","content":[{"column":[],"encoding":"","delimiter":";","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
 			},
 		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(`{"path":["` + filepath.Join(dir, "a_*.csv") + `"],"exclude":["a_3.csv"]}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 1,
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":["` + filepath.Join(dir, "a_1.csv") + `","` + filepath.Join(dir, "a_2.csv") +
+					`"],"content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
+			},
+		},
+		{
+			name:    "4",
+			jobConf: testJSONFromString(`{"path":["` + filepath.Join(dir, "a_1.csv") + `","` + filepath.Join(dir, "a_3.csv") + `"],"splitSize":4}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":["` + filepath.Join(dir, "a_1.csv") + `","` + filepath.Join(dir, "a_1.csv") +
+					`"],"range":[[0,4],[4,7]],"content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
+				testJSONFromString(`{"path":["` + filepath.Join(dir, "a_3.csv") + `","` + filepath.Join(dir, "a_3.csv") +
+					`"],"range":[[0,4],[4,7]],"content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
+			},
+		},
+		{
+			name:    "5",
+			jobConf: testJSONFromString(`{"path":["` + filepath.Join(dir, "a_1.csv") + `"],"splitSize":4,"compress":"gz"}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":"` + filepath.Join(dir, "a_1.csv") +
+					`","content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":"gz"}]}`),
+			},
+		},
+		{
+			name:    "6",
+			jobConf: testJSONFromString(`{"path":["` + filepath.Join(dir, "a_1.csv") + `"],"splitSize":4,"encoding":"utf-16le"}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":"` + filepath.Join(dir, "a_1.csv") +
+					`","content":[{"column":null,"encoding":"utf-16le","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
+			},
+		},
+		{
+			name:    "7",
+			jobConf: testJSONFromString(`{"stdin":true,"splitSize":1,"compress":"gz"}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":"-","content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":"gz"}]}`),
+			},
+		},
+		{
+			name:    "9",
+			jobConf: testJSONFromString(`{"path":["` + filepath.Join(dir, "a_1.csv") + `"],"splitSize":4,"quotedNewline":true}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":"` + filepath.Join(dir, "a_1.csv") +
+					`","content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":"","quotedNewline":true}]}`),
+			},
+		},
+		{
+			name:    "8",
+			jobConf: testJSONFromString(`{"path":["` + filepath.Join(dir, "z_*.csv") + `"]}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 3,
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":"` + filepath.Join(dir, "z_0.csv") +
+					`","content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
+				testJSONFromString(`{"path":["` + filepath.Join(dir, "z_1.csv") + `","` + filepath.Join(dir, "z_3.csv") +
+					`"],"content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
+				testJSONFromString(`{"path":"` + filepath.Join(dir, "z_2.csv") +
+					`","content":[{"column":null,"encoding":"","delimiter":"","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
+			},
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(`{"path":["` + filepath.Join(dir, "b_*.csv") + `"]}`),
+			args: args{
+				ctx:    context.TODO(),
+				number: 1,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
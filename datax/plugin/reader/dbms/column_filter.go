@@ -0,0 +1,95 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+// ColumnWildcard is the column representing all the columns of the table.
+const ColumnWildcard = "*"
+
+// ColumnFilter represents the filter of the columns expanded from the wildcard *.
+type ColumnFilter struct {
+	ExcludeColumns []string `json:"excludeColumns"` // ExcludeColumns are the names of the columns excluded.
+	IncludeRegex   string   `json:"includeRegex"`   // IncludeRegex is the regular expression of the names of the columns included.
+}
+
+// IsEmpty checks whether there is no filter.
+func (c *ColumnFilter) IsEmpty() bool {
+	return len(c.ExcludeColumns) == 0 && c.IncludeRegex == ""
+}
+
+// Validate checks whether the filter is valid for the columns, which should be the wildcard * if filtered.
+func (c *ColumnFilter) Validate(columns []Column) error {
+	if c.IsEmpty() {
+		return nil
+	}
+	if !IsColumnWildcard(columns) {
+		return fmt.Errorf("excludeColumns and includeRegex require column to be [\"*\"] but there are %v columns", len(columns))
+	}
+	if _, err := regexp.Compile(c.IncludeRegex); err != nil {
+		return fmt.Errorf("includeRegex(%v) is not valid. error: %v", c.IncludeRegex, err)
+	}
+	return nil
+}
+
+// FilterFields retains the fields of the table that are included by includeRegex and not excluded by excludeColumns.
+// An error is returned if the excluded columns are not in the table or no fields are retained.
+func (c *ColumnFilter) FilterFields(table database.Table) (err error) {
+	if c.IsEmpty() {
+		return nil
+	}
+	retainer, ok := table.(database.FieldsRetainer)
+	if !ok {
+		return fmt.Errorf("table %v can not retain fields", table.Quoted())
+	}
+
+	var include *regexp.Regexp
+	if include, err = regexp.Compile(c.IncludeRegex); err != nil {
+		return fmt.Errorf("includeRegex(%v) is not valid. error: %v", c.IncludeRegex, err)
+	}
+	excluded := make(map[string]bool)
+	for _, v := range c.ExcludeColumns {
+		excluded[v] = false
+	}
+	for _, f := range table.Fields() {
+		if _, ok := excluded[f.Name()]; ok {
+			excluded[f.Name()] = true
+		}
+	}
+	for _, v := range c.ExcludeColumns {
+		if !excluded[v] {
+			return fmt.Errorf("excluded column(%v) is not in table %v", v, table.Quoted())
+		}
+	}
+
+	retainer.RetainFields(func(f database.Field) bool {
+		_, ok := excluded[f.Name()]
+		return !ok && include.MatchString(f.Name())
+	})
+	if len(table.Fields()) == 0 {
+		return fmt.Errorf("no columns of table %v are retained", table.Quoted())
+	}
+	return nil
+}
+
+// IsColumnWildcard checks whether the columns are the wildcard *.
+func IsColumnWildcard(columns []Column) bool {
+	return len(columns) == 1 && columns[0].GetName() == ColumnWildcard
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+func TestColumnFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       ColumnFilter
+		columns []Column
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			columns: []Column{&BaseColumn{Name: "a"}, &BaseColumn{Name: "b"}},
+		},
+		{
+			name:    "2",
+			c:       ColumnFilter{ExcludeColumns: []string{"a"}},
+			columns: []Column{&BaseColumn{Name: ColumnWildcard}},
+		},
+		{
+			name:    "3",
+			c:       ColumnFilter{IncludeRegex: "^a"},
+			columns: []Column{&BaseColumn{Name: ColumnWildcard}},
+		},
+		{
+			name:    "4",
+			c:       ColumnFilter{ExcludeColumns: []string{"a"}},
+			columns: []Column{&BaseColumn{Name: "a"}, &BaseColumn{Name: "b"}},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			c:       ColumnFilter{IncludeRegex: "("},
+			columns: []Column{&BaseColumn{Name: ColumnWildcard}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(tt.columns); (err != nil) != tt.wantErr {
+				t.Errorf("ColumnFilter.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumnFilter_FilterFields(t *testing.T) {
+	newTable := func() *MockTable {
+		tab := NewMockTable(database.NewBaseTable("db", "schema", "table"))
+		for i, v := range []string{"id", "name", "password_hash", "audit_ts", "audit_user"} {
+			tab.AddField(database.NewBaseField(i, v, NewMockFieldType(database.GoTypeString)))
+		}
+		return tab
+	}
+	tests := []struct {
+		name    string
+		c       ColumnFilter
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			want: []string{"id", "name", "password_hash", "audit_ts", "audit_user"},
+		},
+		{
+			name: "2",
+			c:    ColumnFilter{ExcludeColumns: []string{"audit_ts", "password_hash"}},
+			want: []string{"id", "name", "audit_user"},
+		},
+		{
+			name: "3",
+			c:    ColumnFilter{IncludeRegex: "^audit_"},
+			want: []string{"audit_ts", "audit_user"},
+		},
+		{
+			name: "4",
+			c:    ColumnFilter{ExcludeColumns: []string{"audit_ts"}, IncludeRegex: "^(id|audit_.*)$"},
+			want: []string{"id", "audit_user"},
+		},
+		{
+			name:    "5",
+			c:       ColumnFilter{ExcludeColumns: []string{"audit_time"}},
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			c:       ColumnFilter{IncludeRegex: "^x"},
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			c:       ColumnFilter{IncludeRegex: "("},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tab := newTable()
+			err := tt.c.FilterFields(tab)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ColumnFilter.FilterFields() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			var got []string
+			for _, f := range tab.Fields() {
+				got = append(got, f.Name())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ColumnFilter.FilterFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsColumnWildcard(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		want    bool
+	}{
+		{
+			name:    "1",
+			columns: []Column{&BaseColumn{Name: ColumnWildcard}},
+			want:    true,
+		},
+		{
+			name:    "2",
+			columns: []Column{&BaseColumn{Name: "a"}},
+		},
+		{
+			name:    "3",
+			columns: []Column{&BaseColumn{Name: ColumnWildcard}, &BaseColumn{Name: "a"}},
+		},
+		{
+			name: "4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsColumnWildcard(tt.columns); got != tt.want {
+				t.Errorf("IsColumnWildcard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
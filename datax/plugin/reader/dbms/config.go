@@ -16,21 +16,27 @@ package dbms
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/schedule"
 	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 // Config represents the configuration for a relational data reader.
 type Config interface {
-	GetUsername() string               // GetUsername retrieves the username.
-	GetPassword() string               // GetPassword retrieves the password.
-	GetURL() string                    // GetURL retrieves the connection URL.
-	GetColumns() []Column              // GetColumns retrieves the column information.
-	GetBaseTable() *database.BaseTable // GetBaseTable retrieves the table information.
-	GetWhere() string                  // GetWhere retrieves the query conditions.
-	GetSplitConfig() SplitConfig       // GetSplitConfig retrieves the splitting configuration.
-	GetQuerySQL() []string             // GetQuerySQL retrieves the query SQL.
+	GetUsername() string                                                     // GetUsername retrieves the username.
+	GetPassword() string                                                     // GetPassword retrieves the password.
+	GetURL() string                                                          // GetURL retrieves the connection URL.
+	GetColumns() []Column                                                    // GetColumns retrieves the column information.
+	GetBaseTable() *database.BaseTable                                       // GetBaseTable retrieves the table information.
+	GetWhere() string                                                        // GetWhere retrieves the query conditions.
+	GetSplitConfig() SplitConfig                                             // GetSplitConfig retrieves the splitting configuration.
+	GetQuerySQL() []string                                                   // GetQuerySQL retrieves the query SQL.
+	GetColumnFilter() ColumnFilter                                           // GetColumnFilter retrieves the filter of the columns expanded from *.
+	GetRetryStrategy(j schedule.RetryJudger) (schedule.RetryStrategy, error) // GetRetryStrategy retrieves the retry strategy of reading pages.
 }
 
 // Column represents column information.
@@ -57,6 +63,11 @@ type BaseConfig struct {
 	Where      string      `json:"where"`      // Where is the query condition.
 	Split      SplitConfig `json:"split"`      // SplitKey is the key used for splitting.
 	QuerySQL   []string    `json:"querySql"`   // QuerySQL is the SQL query.
+	Timezone   string      `json:"timezone"`   // Timezone is the time zone of the time types without time zone.
+
+	ColumnFilter
+
+	newRetryStrategy func(j schedule.RetryJudger) (schedule.RetryStrategy, error)
 }
 
 // NewBaseConfig creates a new instance of BaseConfig based on the provided JSON configuration conf.
@@ -66,6 +77,23 @@ func NewBaseConfig(conf *config.JSON) (c *BaseConfig, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if _, err = time.LoadLocation(c.Timezone); err != nil {
+		return nil, fmt.Errorf("timezone(%v) is not valid. error: %v", c.Timezone, err)
+	}
+	if err = c.ColumnFilter.Validate(c.GetColumns()); err != nil {
+		return nil, err
+	}
+	if err = c.Split.validate(c.QuerySQL); err != nil {
+		return nil, fmt.Errorf("check split fail. error: %v", err)
+	}
+
+	var jobsetting *config.JSON
+	if jobsetting, err = conf.GetConfig(coreconst.DataxJobSetting); err != nil {
+		jobsetting, err = config.NewJSONFromString("{}")
+	}
+	c.newRetryStrategy = func(j schedule.RetryJudger) (schedule.RetryStrategy, error) {
+		return schedule.NewRetryStrategy(j, jobsetting)
+	}
 	return
 }
 
@@ -114,6 +142,19 @@ func (b *BaseConfig) GetQuerySQL() []string {
 	return b.QuerySQL
 }
 
+// GetColumnFilter retrieves the filter of the columns expanded from *.
+func (b *BaseConfig) GetColumnFilter() ColumnFilter {
+	return b.ColumnFilter
+}
+
+// GetRetryStrategy retrieves the retry strategy of reading pages from the retry of the job setting.
+func (b *BaseConfig) GetRetryStrategy(j schedule.RetryJudger) (schedule.RetryStrategy, error) {
+	if b.newRetryStrategy == nil {
+		return schedule.NewNoneRetryStrategy(), nil
+	}
+	return b.newRetryStrategy(j)
+}
+
 // ConnConfig represents the configuration for connecting to a database.
 type ConnConfig struct {
 	URL   string      `json:"url"`   // ConnectToDatabase establishes a connection to the database.
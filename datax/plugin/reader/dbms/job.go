@@ -128,6 +128,10 @@ func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, er
 		return
 	}
 
+	if j.Config.GetSplitConfig().Strategy == SplitStrategyChunk {
+		return j.chunkSplit(ctx, number)
+	}
+
 	if j.Config.GetSplitConfig().Key == "" {
 		return []*config.JSON{j.PluginJobConf().CloneConfig()}, nil
 	}
@@ -163,8 +167,20 @@ func (j *Job) split(ctx context.Context, number int, fetcher splitRangeFetcher)
 	}
 	log.Debugf("jobID: %v split fetchMax = %v", j.JobID(), maxColumn)
 
-	ranges, err := split(minColumn, maxColumn, number,
-		j.Config.GetSplitConfig().TimeAccuracy, splitTable.Fields()[0])
+	var ranges []SplitRange
+	if j.Config.GetSplitConfig().Strategy == SplitStrategySample {
+		var samples []element.Column
+		if samples, err = j.fetchSamples(ctx, splitTable, number); err != nil {
+			err = errors.Wrapf(err, "fetchSamples fail")
+			return
+		}
+		log.Debugf("jobID: %v split fetchSamples %v keys", j.JobID(), len(samples))
+		ranges, err = sampleSplit(minColumn, maxColumn, samples, number,
+			j.Config.GetSplitConfig().TimeAccuracy, splitTable.Fields()[0])
+	} else {
+		ranges, err = split(minColumn, maxColumn, number,
+			j.Config.GetSplitConfig().TimeAccuracy, splitTable.Fields()[0])
+	}
 	if err != nil {
 		err = errors.Wrapf(err, "split fail")
 		return
@@ -183,3 +199,77 @@ func (j *Job) split(ctx context.Context, number int, fetcher splitRangeFetcher)
 
 	return
 }
+
+func (j *Job) fetchSamples(ctx context.Context, splitTable database.Table, number int) (samples []element.Column, err error) {
+	handler := database.NewBaseFetchHandler(func() (element.Record, error) {
+		return element.NewDefaultRecord(), nil
+	}, func(r element.Record) (err error) {
+		var c element.Column
+		if c, err = r.GetByIndex(0); err != nil {
+			return
+		}
+		samples = append(samples, c)
+		return
+	})
+	if err = j.Querier.FetchRecord(ctx, NewSampleParam(j.Config, splitTable, number, nil), handler); err != nil {
+		err = errors.Wrapf(err, "FetchRecord fail")
+		return
+	}
+	return
+}
+
+// chunkSplit Split the table by the physical chunks whose conditions are appended to where
+func (j *Job) chunkSplit(ctx context.Context, number int) (configs []*config.JSON, err error) {
+	table := j.Querier.Table(j.Config.GetBaseTable())
+	chunker, ok := table.(database.Chunker)
+	if !ok {
+		return nil, errors.Errorf("table %v can not be split into chunks", table.Quoted())
+	}
+
+	var bounds [][2]string
+	handler := database.NewBaseFetchHandler(func() (element.Record, error) {
+		return element.NewDefaultRecord(), nil
+	}, func(r element.Record) (err error) {
+		var bound [2]string
+		for i := range bound {
+			var c element.Column
+			if c, err = r.GetByIndex(i); err != nil {
+				return
+			}
+			if bound[i], err = c.AsString(); err != nil {
+				return
+			}
+		}
+		bounds = append(bounds, bound)
+		return
+	})
+	param := NewChunkParam(j.Config, table, number, nil)
+	if err = j.Querier.FetchRecord(ctx, param, handler); err != nil {
+		if !param.FallBack(err) {
+			err = errors.Wrapf(err, "FetchRecord fail")
+			return
+		}
+		log.Warnf("jobID: %v split chunks fail and fall back. error: %v", j.JobID(), err)
+		bounds = nil
+		if err = j.Querier.FetchRecord(ctx, param, handler); err != nil {
+			err = errors.Wrapf(err, "FetchRecord fail")
+			return
+		}
+	}
+	log.Debugf("jobID: %v split %v chunks", j.JobID(), len(bounds))
+
+	if len(bounds) == 0 {
+		return []*config.JSON{j.PluginJobConf().CloneConfig()}, nil
+	}
+
+	for i, v := range bounds {
+		clone := j.PluginJobConf().CloneConfig()
+		where := chunker.ChunkWhere(v[0], v[1], i == len(bounds)-1)
+		if j.Config.GetWhere() != "" {
+			where = fmt.Sprintf("(%s) and (%s)", j.Config.GetWhere(), where)
+		}
+		_ = clone.Set("where", where)
+		configs = append(configs, clone)
+	}
+	return
+}
@@ -479,6 +479,146 @@ func TestJob_Split(t *testing.T) {
 				testJSONFromString(`{"where":"(a < 1) and (f1 >= $1 and f1 <= $2)","split":{"range":{"type":"bigInt","layout":"","left":"10000","right":"30000"}}}`),
 			},
 		},
+		{
+			name: "12",
+			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
+				Config: &BaseConfig{
+					Split: SplitConfig{
+						Key:      "f1",
+						Strategy: SplitStrategySample,
+					},
+				},
+				Querier: &MockQuerier{samples: []int64{10003, 10001, 25000, 10002, 40000}},
+				handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+					return &MockQuerier{}, nil
+				}),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			jobConf: testJSONFromString(`{}`),
+			want: []*config.JSON{
+				testJSONFromString(`{"split":{"range":{"type":"bigInt","layout":"","left":"10000","right":"10003"}},"where":"f1 >= $1 and f1 < $2"}`),
+				testJSONFromString(`{"split":{"range":{"type":"bigInt","layout":"","left":"10003","right":"30000"}},"where":"f1 >= $1 and f1 <= $2"}`),
+			},
+		},
+		{
+			name: "13",
+			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
+				Config: &BaseConfig{
+					Where: "a < 1",
+					Split: SplitConfig{
+						Strategy: SplitStrategyChunk,
+					},
+				},
+				Querier: &MockQuerier{chunk: true, chunks: [][2]string{{"0", "100"}, {"100", "200"}}},
+				handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+					return &MockQuerier{}, nil
+				}),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			jobConf: testJSONFromString(`{"where":"a < 1"}`),
+			want: []*config.JSON{
+				testJSONFromString(`{"where":"(a < 1) and (id >= 0 and id < 100)"}`),
+				testJSONFromString(`{"where":"(a < 1) and (id >= 100)"}`),
+			},
+		},
+		{
+			name: "14",
+			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
+				Config: &BaseConfig{
+					Split: SplitConfig{
+						Strategy: SplitStrategyChunk,
+					},
+				},
+				Querier: &MockQuerier{chunk: true},
+				handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+					return &MockQuerier{}, nil
+				}),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			jobConf: testJSONFromString(`{}`),
+			want: []*config.JSON{
+				testJSONFromString(`{}`),
+			},
+		},
+		{
+			name: "15",
+			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
+				Config: &BaseConfig{
+					Split: SplitConfig{
+						Strategy: SplitStrategyChunk,
+					},
+				},
+				Querier: &MockQuerier{},
+				handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+					return &MockQuerier{}, nil
+				}),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
+		{
+			name: "16",
+			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
+				Config: &BaseConfig{
+					Split: SplitConfig{
+						Strategy: SplitStrategyChunk,
+					},
+				},
+				Querier: &MockQuerier{fallback: true, chunkErr: errors.New("mock chunk error"),
+					chunks: [][2]string{{"0", "100"}, {"100", "200"}}},
+				handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+					return &MockQuerier{}, nil
+				}),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			jobConf: testJSONFromString(`{}`),
+			want: []*config.JSON{
+				testJSONFromString(`{"where":"id >= 0 and id < 100"}`),
+				testJSONFromString(`{"where":"id >= 100"}`),
+			},
+		},
+		{
+			name: "17",
+			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
+				Config: &BaseConfig{
+					Split: SplitConfig{
+						Strategy: SplitStrategyChunk,
+					},
+				},
+				Querier: &MockQuerier{fallback: true, chunkErr: errors.New("mock error")},
+				handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+					return &MockQuerier{}, nil
+				}),
+			},
+			args: args{
+				ctx:    context.TODO(),
+				number: 2,
+			},
+			jobConf: testJSONFromString(`{}`),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
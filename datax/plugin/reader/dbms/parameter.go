@@ -17,6 +17,9 @@ package dbms
 import (
 	"bytes"
 	"database/sql"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -99,13 +102,27 @@ func (q *QueryParam) Query(_ []element.Record) (string, error) {
 		return q.Config.GetQuerySQL()[0], nil
 	}
 
+	buf, err := q.selectFrom()
+	if err != nil {
+		return "", err
+	}
+	if q.Config.GetWhere() != "" {
+		buf.WriteString(" where ")
+		buf.WriteString(q.Config.GetWhere())
+	}
+	return buf.String(), nil
+}
+
+// selectFrom Get the select statement of the columns from the table without conditions
+func (q *QueryParam) selectFrom() (*bytes.Buffer, error) {
 	buf := bytes.NewBufferString("select ")
 	if len(q.Table().Fields()) == 0 {
-		return "", errors.NewNoStackError("column is empty")
+		return nil, errors.NewNoStackError("column is empty")
 	}
 
+	// The columns expanded from * are selected by the fields of the table
 	canUseConfig := false
-	if len(q.Table().Fields()) == len(q.Config.GetColumns()) {
+	if len(q.Table().Fields()) == len(q.Config.GetColumns()) && !IsColumnWildcard(q.Config.GetColumns()) {
 		canUseConfig = true
 	}
 
@@ -122,11 +139,7 @@ func (q *QueryParam) Query(_ []element.Record) (string, error) {
 	}
 	buf.WriteString(" from ")
 	buf.WriteString(q.Table().Quoted())
-	if q.Config.GetWhere() != "" {
-		buf.WriteString(" where ")
-		buf.WriteString(q.Config.GetWhere())
-	}
-	return buf.String(), nil
+	return buf, nil
 }
 
 // Agrs Get query parameters
@@ -160,6 +173,174 @@ func (q *QueryParam) Agrs(_ []element.Record) (a []any, err error) {
 	return nil, nil
 }
 
+// PageParam Query parameters of a page ordered by the split key and the tie breaker, which continues from the last record read.
+// Without the tie breaker, a page continues from the split key of the last record read inclusive, and reads one more row,
+// so that the last record read is read again and the duplicate values of the split key can be found instead of skipped
+type PageParam struct {
+	*QueryParam
+
+	last []any // Values of the split key and the tie breaker of the last record read, nil for the first page
+}
+
+// NewPageParam Get the page query parameters through relational database input configuration config, corresponding database table table, and transaction options opts
+func NewPageParam(config Config, table database.Table, opts *sql.TxOptions) *PageParam {
+	return &PageParam{
+		QueryParam: NewQueryParam(config, table, opts),
+	}
+}
+
+// Query Get the query statement of the page
+func (p *PageParam) Query(_ []element.Record) (string, error) {
+	if len(p.Config.GetQuerySQL()) > 0 {
+		return "", errors.NewNoStackError("querySQL can not be paged")
+	}
+
+	keys, err := p.keyFields()
+	if err != nil {
+		return "", err
+	}
+
+	buf, err := p.selectFrom()
+	if err != nil {
+		return "", err
+	}
+
+	var conditions []string
+	if p.Config.GetWhere() != "" {
+		conditions = append(conditions, "("+p.Config.GetWhere()+")")
+	}
+	if p.last != nil {
+		var a []any
+		if a, err = p.QueryParam.Agrs(nil); err != nil {
+			return "", err
+		}
+		key := keys[0]
+		if len(keys) == 1 {
+			conditions = append(conditions, key.Quoted()+" >= "+key.BindVar(len(a)+1))
+		} else {
+			tieBreaker := keys[1]
+			conditions = append(conditions, "("+key.Quoted()+" > "+key.BindVar(len(a)+1)+
+				" or "+key.Quoted()+" = "+key.BindVar(len(a)+2)+
+				" and "+tieBreaker.Quoted()+" > "+tieBreaker.BindVar(len(a)+3)+")")
+		}
+	}
+	if len(conditions) > 0 {
+		buf.WriteString(" where ")
+		buf.WriteString(strings.Join(conditions, " and "))
+	}
+
+	buf.WriteString(" order by ")
+	for i, v := range keys {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(v.Quoted())
+	}
+	buf.WriteString(" ")
+	if limiter, ok := p.Table().(database.Limiter); ok {
+		buf.WriteString(limiter.LimitClause(p.limit()))
+	} else {
+		buf.WriteString("limit " + strconv.Itoa(p.limit()))
+	}
+	return buf.String(), nil
+}
+
+// Agrs Get query parameters, followed by the split key and the tie breaker of the last record read
+func (p *PageParam) Agrs(_ []element.Record) (a []any, err error) {
+	if a, err = p.QueryParam.Agrs(nil); err != nil {
+		return nil, err
+	}
+	switch len(p.last) {
+	case 0:
+	case 1:
+		a = append(a, p.last[0])
+	default:
+		a = append(a, p.last[0], p.last[0], p.last[1])
+	}
+	return
+}
+
+// LastKey Get the values of the split key and the tie breaker of the record r
+func (p *PageParam) LastKey(r element.Record) (v []any, err error) {
+	var keys []database.Field
+	if keys, err = p.keyFields(); err != nil {
+		return
+	}
+	for _, key := range keys {
+		var c element.Column
+		if c, err = r.GetByName(key.Name()); err != nil {
+			return nil, err
+		}
+		var value any
+		if value, err = key.Valuer(c).Value(); err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, errors.Errorf("split key(%v) is null", key.Name())
+		}
+		v = append(v, value)
+	}
+	return
+}
+
+// SetLastKey Set the values of the split key and the tie breaker of the last record read, from which the next page continues
+func (p *PageParam) SetLastKey(v []any) {
+	p.last = v
+}
+
+// limit Get the number of rows of the page, which includes the last record read again without the tie breaker
+func (p *PageParam) limit() int {
+	if p.last != nil && p.Config.GetSplitConfig().TieBreaker == "" {
+		return p.Config.GetSplitConfig().PageSize + 1
+	}
+	return p.Config.GetSplitConfig().PageSize
+}
+
+func (p *PageParam) keyFields() (keys []database.Field, err error) {
+	names := []string{p.Config.GetSplitConfig().Key}
+	if p.Config.GetSplitConfig().TieBreaker != "" {
+		names = append(names, p.Config.GetSplitConfig().TieBreaker)
+	}
+	for _, name := range names {
+		var key database.Field
+		for _, v := range p.Table().Fields() {
+			if name == v.Name() {
+				key = v
+				break
+			}
+		}
+		if key == nil {
+			return nil, errors.Errorf("split key(%v) is not in the columns", name)
+		}
+		keys = append(keys, key)
+	}
+	return
+}
+
+// equalKeys Whether the values a and b of the split key and the tie breaker are equal
+func equalKeys(a, b []any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		switch v := a[i].(type) {
+		case []byte:
+			if w, ok := b[i].([]byte); !ok || !bytes.Equal(v, w) {
+				return false
+			}
+		case time.Time:
+			if w, ok := b[i].(time.Time); !ok || !v.Equal(w) {
+				return false
+			}
+		default:
+			if a[i] != b[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // SplitParam Splitting parameters
 type SplitParam struct {
 	*database.BaseParam
@@ -262,3 +443,105 @@ func (m *MaxParam) Query(_ []element.Record) (string, error) {
 func (m *MaxParam) Agrs(_ []element.Record) ([]any, error) {
 	return nil, nil
 }
+
+// SampleParam Sampling parameters of the split key
+type SampleParam struct {
+	*database.BaseParam
+
+	Config Config
+	number int
+}
+
+// NewSampleParam Get the sampling parameters through relational database input configuration config, split table table, number of splits and transaction options opts
+func NewSampleParam(config Config, table database.Table, number int, opts *sql.TxOptions) *SampleParam {
+	return &SampleParam{
+		BaseParam: database.NewBaseParam(table, opts),
+
+		Config: config,
+		number: number,
+	}
+}
+
+// Query Get the query statement sampling the split key quoted by the split table. The exact quantiles are queried by ntile
+// when the table can not be sampled
+func (s *SampleParam) Query(_ []element.Record) (string, error) {
+	if len(s.Table().Fields()) == 0 {
+		return "", errors.Errorf("split table %v has no split key", s.Table().Quoted())
+	}
+	key := s.Table().Fields()[0].Quoted()
+	if sampler, ok := s.Table().(database.Sampler); ok {
+		conf := s.Config.GetSplitConfig()
+		return sampler.SampleQuery(key, s.Config.GetWhere(), conf.getSampleSize()), nil
+	}
+
+	buf := bytes.NewBufferString("select min(k) from (select ")
+	buf.WriteString(key)
+	buf.WriteString(" k, ntile(")
+	buf.WriteString(strconv.Itoa(s.number))
+	buf.WriteString(") over (order by ")
+	buf.WriteString(key)
+	buf.WriteString(") g from ")
+	buf.WriteString(s.Table().Quoted())
+	if s.Config.GetWhere() != "" {
+		buf.WriteString(" where ")
+		buf.WriteString(s.Config.GetWhere())
+	}
+	buf.WriteString(") t group by g order by g")
+	return buf.String(), nil
+}
+
+// Agrs Get query parameters
+func (s *SampleParam) Agrs(_ []element.Record) ([]any, error) {
+	return nil, nil
+}
+
+// ChunkParam Query parameters of the bounds of the physical chunks of the table
+type ChunkParam struct {
+	*database.BaseParam
+
+	Config   Config
+	number   int
+	fallback error
+}
+
+// NewChunkParam Get the chunk query parameters through relational database input configuration config, corresponding database table table, number of splits and transaction options opts
+func NewChunkParam(config Config, table database.Table, number int, opts *sql.TxOptions) *ChunkParam {
+	return &ChunkParam{
+		BaseParam: database.NewBaseParam(table, opts),
+
+		Config: config,
+		number: number,
+	}
+}
+
+// FallBack Fall back to the query of ChunkFallbackQuery after the query of the bounds of the chunks fails with err,
+// and false is returned if the table can not fall back from err
+func (c *ChunkParam) FallBack(err error) bool {
+	fallbacker, ok := c.Table().(database.ChunkFallbacker)
+	if !ok {
+		return false
+	}
+	if _, ok = fallbacker.ChunkFallbackQuery(c.Config.GetWhere(), c.number, err); !ok {
+		return false
+	}
+	c.fallback = err
+	return true
+}
+
+// Query Get the query statement of the bounds of the chunks
+func (c *ChunkParam) Query(_ []element.Record) (string, error) {
+	chunker, ok := c.Table().(database.Chunker)
+	if !ok {
+		return "", errors.Errorf("table %v can not be split into chunks", c.Table().Quoted())
+	}
+	if c.fallback != nil {
+		query, _ := c.Table().(database.ChunkFallbacker).ChunkFallbackQuery(c.Config.GetWhere(), c.number, c.fallback)
+		return query, nil
+	}
+	return chunker.ChunkQuery(c.Config.GetWhere(), c.number), nil
+}
+
+// Agrs Get query parameters
+func (c *ChunkParam) Agrs(_ []element.Record) ([]any, error) {
+	return nil, nil
+}
@@ -15,8 +15,10 @@
 package dbms
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -256,6 +258,22 @@ func Test_queryParam_Query(t *testing.T) {
 			},
 			want: "select f1,f2,case when f4 is null then 1 else 2 end as f3 from db.schema.table",
 		},
+		{
+			name: "8",
+			t: func() *MockTable {
+				tab := NewMockTable(database.NewBaseTable("db", "schema", "table"))
+				tab.AddField(database.NewBaseField(2, "f2", NewMockFieldType(database.GoTypeInt64)))
+				return tab
+			}(),
+			notFollow: true,
+			config: &BaseConfig{
+				Column: []string{"*"},
+			},
+			args: args{
+				in0: nil,
+			},
+			want: "select f2 from db.schema.table",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -443,6 +461,360 @@ func Test_queryParam_Agrs(t *testing.T) {
 	}
 }
 
+func testPageTable() *MockTable {
+	tab := NewMockTable(database.NewBaseTable("db", "schema", "table"))
+	tab.AddField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)))
+	tab.AddField(database.NewBaseField(1, "f2", NewMockFieldType(database.GoTypeString)))
+	return tab
+}
+
+func TestPageParam_Query(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       *PageParam
+		last    []any
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			p: NewPageParam(&BaseConfig{
+				Column: []string{"f1", "f2"},
+				Split: SplitConfig{
+					Key:      "f1",
+					PageSize: 100,
+				},
+			}, testPageTable(), nil),
+			want: "select f1,f2 from db.schema.table order by f1 limit 100",
+		},
+		{
+			name: "2",
+			p: NewPageParam(&BaseConfig{
+				Column: []string{"f1", "f2"},
+				Where:  "f1 >= $1 and f1 < $2",
+				Split: SplitConfig{
+					Key:      "f1",
+					PageSize: 100,
+					Range: SplitRange{
+						Type:  string(element.TypeBigInt),
+						Left:  "11",
+						Right: "22",
+					},
+				},
+			}, testPageTable(), nil),
+			last: []any{int64(15)},
+			want: "select f1,f2 from db.schema.table where (f1 >= $1 and f1 < $2) and f1 >= $3 order by f1 limit 101",
+		},
+		{
+			name: "3",
+			p: NewPageParam(&BaseConfig{
+				Column: []string{"f1", "f2"},
+				Where:  "f2 = 'a' or f2 = 'b'",
+				Split: SplitConfig{
+					Key:      "f1",
+					PageSize: 100,
+				},
+			}, testPageTable(), nil),
+			want: "select f1,f2 from db.schema.table where (f2 = 'a' or f2 = 'b') order by f1 limit 100",
+		},
+		{
+			name: "4",
+			p: NewPageParam(&BaseConfig{
+				Column: []string{"f1", "f2"},
+				Split: SplitConfig{
+					Key:      "f3",
+					PageSize: 100,
+				},
+			}, testPageTable(), nil),
+			wantErr: true,
+		},
+		{
+			name: "5",
+			p: NewPageParam(&BaseConfig{
+				QuerySQL: []string{"select f1,f2 from table"},
+				Split: SplitConfig{
+					Key:      "f1",
+					PageSize: 100,
+				},
+			}, testPageTable(), nil),
+			wantErr: true,
+		},
+		{
+			name: "6",
+			p: NewPageParam(&BaseConfig{
+				Column: []string{"f1", "f2"},
+				Split: SplitConfig{
+					Key:        "f1",
+					PageSize:   100,
+					TieBreaker: "f2",
+				},
+			}, testPageTable(), nil),
+			want: "select f1,f2 from db.schema.table order by f1,f2 limit 100",
+		},
+		{
+			name: "7",
+			p: NewPageParam(&BaseConfig{
+				Column: []string{"f1", "f2"},
+				Where:  "f1 >= $1 and f1 < $2",
+				Split: SplitConfig{
+					Key:        "f1",
+					PageSize:   100,
+					TieBreaker: "f2",
+					Range: SplitRange{
+						Type:  string(element.TypeBigInt),
+						Left:  "11",
+						Right: "22",
+					},
+				},
+			}, testPageTable(), nil),
+			last: []any{int64(15), "a"},
+			want: "select f1,f2 from db.schema.table where (f1 >= $1 and f1 < $2) and (f1 > $3 or f1 = $4 and f2 > $5) order by f1,f2 limit 100",
+		},
+		{
+			name: "8",
+			p: NewPageParam(&BaseConfig{
+				Column: []string{"f1", "f2"},
+				Split: SplitConfig{
+					Key:        "f1",
+					PageSize:   100,
+					TieBreaker: "f3",
+				},
+			}, testPageTable(), nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.p.SetLastKey(tt.last)
+			got, err := tt.p.Query(nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PageParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PageParam.Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageParam_Agrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       *PageParam
+		last    []any
+		want    []any
+		wantErr bool
+	}{
+		{
+			name: "1",
+			p: NewPageParam(&BaseConfig{
+				Split: SplitConfig{
+					Key:      "f1",
+					PageSize: 100,
+					Range: SplitRange{
+						Type:  string(element.TypeBigInt),
+						Left:  "11",
+						Right: "22",
+					},
+				},
+			}, testPageTable(), nil),
+			want: []any{int64(11), int64(22)},
+		},
+		{
+			name: "2",
+			p: NewPageParam(&BaseConfig{
+				Split: SplitConfig{
+					Key:      "f1",
+					PageSize: 100,
+					Range: SplitRange{
+						Type:  string(element.TypeBigInt),
+						Left:  "11",
+						Right: "22",
+					},
+				},
+			}, testPageTable(), nil),
+			last: []any{int64(15)},
+			want: []any{int64(11), int64(22), int64(15)},
+		},
+		{
+			name: "3",
+			p: NewPageParam(&BaseConfig{
+				Split: SplitConfig{
+					Key:      "f1",
+					PageSize: 100,
+					Range: SplitRange{
+						Type:  string(element.TypeBigInt),
+						Left:  "11a",
+						Right: "22",
+					},
+				},
+			}, testPageTable(), nil),
+			last:    []any{int64(15)},
+			wantErr: true,
+		},
+		{
+			name: "4",
+			p: NewPageParam(&BaseConfig{
+				Split: SplitConfig{
+					Key:        "f1",
+					PageSize:   100,
+					TieBreaker: "f2",
+					Range: SplitRange{
+						Type:  string(element.TypeBigInt),
+						Left:  "11",
+						Right: "22",
+					},
+				},
+			}, testPageTable(), nil),
+			last: []any{int64(15), "a"},
+			want: []any{int64(11), int64(22), int64(15), int64(15), "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.p.SetLastKey(tt.last)
+			got, err := tt.p.Agrs(nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PageParam.Agrs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PageParam.Agrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageParam_LastKey(t *testing.T) {
+	newParam := func(tieBreaker string) *PageParam {
+		return NewPageParam(&BaseConfig{
+			Split: SplitConfig{
+				Key:        "f1",
+				PageSize:   100,
+				TieBreaker: tieBreaker,
+			},
+		}, testPageTable(), nil)
+	}
+	record := func(columns ...element.Column) element.Record {
+		r := element.NewDefaultRecord()
+		for _, v := range columns {
+			r.Add(v)
+		}
+		return r
+	}
+	tests := []struct {
+		name    string
+		p       *PageParam
+		r       element.Record
+		want    []any
+		wantErr bool
+	}{
+		{
+			name: "1",
+			p:    newParam(""),
+			r: record(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(15), "f1", 0),
+				element.NewDefaultColumn(element.NewStringColumnValue("a"), "f2", 0)),
+			want: []any{int64(15)},
+		},
+		{
+			name: "2",
+			p:    newParam(""),
+			r: record(element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "f1", 0),
+				element.NewDefaultColumn(element.NewStringColumnValue("a"), "f2", 0)),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			p:       newParam(""),
+			r:       record(element.NewDefaultColumn(element.NewStringColumnValue("a"), "f2", 0)),
+			wantErr: true,
+		},
+		{
+			name: "4",
+			p:    newParam("f2"),
+			r: record(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(15), "f1", 0),
+				element.NewDefaultColumn(element.NewStringColumnValue("a"), "f2", 0)),
+			want: []any{int64(15), "a"},
+		},
+		{
+			name: "5",
+			p:    newParam("f2"),
+			r: record(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(15), "f1", 0),
+				element.NewDefaultColumn(element.NewNilStringColumnValue(), "f2", 0)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.LastKey(tt.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PageParam.LastKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PageParam.LastKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_equalKeys(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		a    []any
+		b    []any
+		want bool
+	}{
+		{
+			name: "1",
+			a:    []any{int64(1), "a"},
+			b:    []any{int64(1), "a"},
+			want: true,
+		},
+		{
+			name: "2",
+			a:    []any{int64(1), "a"},
+			b:    []any{int64(1), "b"},
+		},
+		{
+			name: "3",
+			a:    []any{int64(1)},
+			b:    []any{int64(1), "a"},
+		},
+		{
+			name: "4",
+			a:    []any{[]byte("a")},
+			b:    []any{[]byte("a")},
+			want: true,
+		},
+		{
+			name: "5",
+			a:    []any{[]byte("a")},
+			b:    []any{"a"},
+		},
+		{
+			name: "6",
+			a:    []any{now},
+			b:    []any{now.UTC()},
+			want: true,
+		},
+		{
+			name: "7",
+			a:    []any{now},
+			b:    []any{now.Add(time.Second)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equalKeys(tt.a, tt.b); got != tt.want {
+				t.Errorf("equalKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSplitParam_Query(t *testing.T) {
 	type args struct {
 		in0 []element.Record
@@ -701,3 +1073,202 @@ func TestMaxParam_Agrs(t *testing.T) {
 		})
 	}
 }
+
+func testSplitTable() *MockTable {
+	t := NewMockTable(database.NewBaseTable("db", "schema", "table"))
+	t.AddField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)))
+	return t
+}
+
+func TestSampleParam_Query(t *testing.T) {
+	type args struct {
+		in0 []element.Record
+	}
+	tests := []struct {
+		name    string
+		s       *SampleParam
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s: NewSampleParam(&BaseConfig{
+				Where: "a <> 1",
+				Split: SplitConfig{
+					Key: "f1",
+				},
+			}, testSplitTable(), 4, nil),
+			args: args{
+				in0: nil,
+			},
+			want: "select min(k) from (select f1 k, ntile(4) over (order by f1) g from db.schema.table where a <> 1) t group by g order by g",
+		},
+		{
+			name: "2",
+			s: NewSampleParam(&BaseConfig{
+				Split: SplitConfig{
+					Key: "f1",
+				},
+			}, testSplitTable(), 4, nil),
+			args: args{
+				in0: nil,
+			},
+			want: "select min(k) from (select f1 k, ntile(4) over (order by f1) g from db.schema.table) t group by g order by g",
+		},
+		{
+			name: "3",
+			s: NewSampleParam(&BaseConfig{
+				Where: "a <> 1",
+				Split: SplitConfig{
+					Key: "f1",
+				},
+			}, &MockSampleTable{MockTable: testSplitTable()}, 4, nil),
+			args: args{
+				in0: nil,
+			},
+			want: "sample f1 a <> 1 10000",
+		},
+		{
+			name: "4",
+			s: NewSampleParam(&BaseConfig{
+				Split: SplitConfig{
+					Key:        "f1",
+					SampleSize: 100,
+				},
+			}, &MockSampleTable{MockTable: testSplitTable()}, 4, nil),
+			args: args{
+				in0: nil,
+			},
+			want: "sample f1  100",
+		},
+		{
+			name: "5",
+			s: NewSampleParam(&BaseConfig{
+				Split: SplitConfig{
+					Key: "f1",
+				},
+			}, NewMockTable(database.NewBaseTable("db", "schema", "table")), 4, nil),
+			args: args{
+				in0: nil,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Query(tt.args.in0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SampleParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SampleParam.Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSampleParam_Agrs(t *testing.T) {
+	type args struct {
+		in0 []element.Record
+	}
+	tests := []struct {
+		name    string
+		s       *SampleParam
+		args    args
+		want    []any
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s: NewSampleParam(&BaseConfig{},
+				NewMockTable(database.NewBaseTable("db", "schema", "table")), 4, nil),
+			args: args{
+				in0: nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Agrs(tt.args.in0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SampleParam.Agrs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SampleParam.Agrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkParam_Query(t *testing.T) {
+	type args struct {
+		in0 []element.Record
+	}
+	tests := []struct {
+		name    string
+		c       *ChunkParam
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c: NewChunkParam(&BaseConfig{},
+				&MockChunkTable{MockTable: NewMockTable(database.NewBaseTable("db", "schema", "table"))}, 4, nil),
+			args: args{
+				in0: nil,
+			},
+			want: "chunk 4",
+		},
+		{
+			name: "3",
+			c: func() *ChunkParam {
+				c := NewChunkParam(&BaseConfig{}, &MockChunkFallbackTable{MockChunkTable: &MockChunkTable{
+					MockTable: NewMockTable(database.NewBaseTable("db", "schema", "table"))}}, 4, nil)
+				c.FallBack(errors.New("mock chunk error"))
+				return c
+			}(),
+			args: args{
+				in0: nil,
+			},
+			want: "ntile 4",
+		},
+		{
+			name: "4",
+			c: func() *ChunkParam {
+				c := NewChunkParam(&BaseConfig{}, &MockChunkFallbackTable{MockChunkTable: &MockChunkTable{
+					MockTable: NewMockTable(database.NewBaseTable("db", "schema", "table"))}}, 4, nil)
+				c.FallBack(errors.New("mock error"))
+				return c
+			}(),
+			args: args{
+				in0: nil,
+			},
+			want: "chunk 4",
+		},
+		{
+			name: "2",
+			c: NewChunkParam(&BaseConfig{},
+				NewMockTable(database.NewBaseTable("db", "schema", "table")), 4, nil),
+			args: args{
+				in0: nil,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Query(tt.args.in0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChunkParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ChunkParam.Query() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
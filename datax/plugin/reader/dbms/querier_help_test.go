@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
@@ -103,6 +104,40 @@ func (m *MockTable) AddField(bf *database.BaseField) {
 	m.AppendField(NewMockField(bf, NewMockFieldType(database.GoType(i))))
 }
 
+type MockSampleTable struct {
+	*MockTable
+}
+
+func (m *MockSampleTable) SampleQuery(column, where string, n int) string {
+	return "sample " + column + " " + where + " " + strconv.Itoa(n)
+}
+
+type MockChunkTable struct {
+	*MockTable
+}
+
+func (m *MockChunkTable) ChunkQuery(where string, n int) string {
+	return "chunk " + strconv.Itoa(n)
+}
+
+func (m *MockChunkTable) ChunkWhere(left, right string, last bool) string {
+	if last {
+		return "id >= " + left
+	}
+	return "id >= " + left + " and id < " + right
+}
+
+type MockChunkFallbackTable struct {
+	*MockChunkTable
+}
+
+func (m *MockChunkFallbackTable) ChunkFallbackQuery(where string, n int, err error) (string, bool) {
+	if err == nil || err.Error() != "mock chunk error" {
+		return "", false
+	}
+	return "ntile " + strconv.Itoa(n), true
+}
+
 type MockQuerier struct {
 	PingErr     error
 	QueryErr    error
@@ -111,9 +146,20 @@ type MockQuerier struct {
 	FetchMaxErr error
 	isTime      bool
 	config      *config.JSON
+	chunk       bool
+	fallback    bool
+	chunkErr    error
+	samples     []int64
+	chunks      [][2]string
 }
 
 func (m *MockQuerier) Table(bt *database.BaseTable) database.Table {
+	if m.fallback {
+		return &MockChunkFallbackTable{MockChunkTable: &MockChunkTable{MockTable: NewMockTable(bt)}}
+	}
+	if m.chunk {
+		return &MockChunkTable{MockTable: NewMockTable(bt)}
+	}
 	return NewMockTable(bt)
 }
 
@@ -141,6 +187,34 @@ func (m *MockQuerier) FetchTableWithParam(ctx context.Context,
 
 func (m *MockQuerier) FetchRecord(ctx context.Context,
 	param database.Parameter, handler database.FetchHandler) (err error) {
+	switch param.(type) {
+	case *SampleParam:
+		for _, v := range m.samples {
+			r := element.NewDefaultRecord()
+			r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(v), "f1", 0))
+			if err = handler.OnRecord(r); err != nil {
+				return
+			}
+		}
+		return
+	case *ChunkParam:
+		var query string
+		if query, err = param.Query(nil); err != nil {
+			return
+		}
+		if m.chunkErr != nil && strings.HasPrefix(query, "chunk") {
+			return m.chunkErr
+		}
+		for _, v := range m.chunks {
+			r := element.NewDefaultRecord()
+			r.Add(element.NewDefaultColumn(element.NewStringColumnValue(v[0]), "chunk_left", 0))
+			r.Add(element.NewDefaultColumn(element.NewStringColumnValue(v[1]), "chunk_right", 0))
+			if err = handler.OnRecord(r); err != nil {
+				return
+			}
+		}
+		return
+	}
 
 	r, err := handler.CreateRecord()
 	if err != nil {
@@ -17,6 +17,7 @@ package dbms
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/Breeze0806/go-etl/element"
@@ -30,6 +31,15 @@ const (
 	maxDuration time.Duration = 1<<63 - 1
 )
 
+// Splitting Strategies
+const (
+	SplitStrategyRange  = "range"  // split [min, max] of the key evenly
+	SplitStrategySample = "sample" // split [min, max] of the key by the quantiles of the sampled keys
+	SplitStrategyChunk  = "chunk"  // split the table by the physical chunks such as ROWID ranges or blocks
+)
+
+var defaultSampleSize = 10000
+
 type splitRangeFetcher interface {
 	fetchMax(ctx context.Context, splitTable database.Table) (element.Column, error)
 	fetchMin(ctx context.Context, splitTable database.Table) (element.Column, error)
@@ -41,6 +51,50 @@ type SplitConfig struct {
 	// day (Day), min (Minute), s (Second), ms (Millisecond), us (Microsecond), ns (Nanosecond)
 	TimeAccuracy string     `json:"timeAccuracy"` // Splitting Time Precision (Default - day)
 	Range        SplitRange `json:"range"`        // Splitting Range
+	PageSize     int        `json:"pageSize"`     // Number of Rows of Each Page Read in Order of the Key, Not Paged if 0
+	TieBreaker   string     `json:"tieBreaker"`   // Unique Column Ordering the Rows of the Same Key in Pages
+	Strategy     string     `json:"strategy"`     // Splitting Strategy - range (Default), sample, chunk
+	SampleSize   int        `json:"sampleSize"`   // Number of Keys Sampled by the sample Strategy (Default - 10000)
+}
+
+func (s *SplitConfig) validate(querySQL []string) error {
+	switch s.Strategy {
+	case "", SplitStrategyRange, SplitStrategySample, SplitStrategyChunk:
+	default:
+		return fmt.Errorf("strategy(%v) is not valid", s.Strategy)
+	}
+	if s.SampleSize < 0 {
+		return fmt.Errorf("sampleSize(%v) can not be less than 0", s.SampleSize)
+	}
+	if s.Strategy == SplitStrategyChunk && s.PageSize > 0 {
+		return fmt.Errorf("chunk strategy can not be paged")
+	}
+	if s.Strategy == SplitStrategyChunk && s.Key != "" {
+		return fmt.Errorf("key can not be set with chunk strategy")
+	}
+	if s.PageSize < 0 {
+		return fmt.Errorf("pageSize(%v) can not be less than 0", s.PageSize)
+	}
+	if s.PageSize > 0 && s.Key == "" {
+		return fmt.Errorf("key is required when pageSize is set")
+	}
+	if s.PageSize > 0 && len(querySQL) > 0 {
+		return fmt.Errorf("querySql can not be paged")
+	}
+	if s.TieBreaker != "" && s.PageSize == 0 {
+		return fmt.Errorf("pageSize is required when tieBreaker is set")
+	}
+	if s.TieBreaker != "" && s.TieBreaker == s.Key {
+		return fmt.Errorf("tieBreaker(%v) can not be key", s.TieBreaker)
+	}
+	return nil
+}
+
+func (s *SplitConfig) getSampleSize() int {
+	if s.SampleSize == 0 {
+		return defaultSampleSize
+	}
+	return s.SampleSize
 }
 
 func (s *SplitConfig) fetchMin(ctx context.Context,
@@ -178,7 +232,68 @@ func split(min, max element.Column, num int,
 	}
 
 	results := doSplit(left, right, num)
+	return newSplitRanges(c, results, splitField), nil
+}
+
+// sampleSplit - split [min, max] into at most num ranges by the quantiles of the sampled keys,
+// where the duplicate quantiles of skewed keys are merged
+func sampleSplit(min, max element.Column, samples []element.Column, num int,
+	timeAccuracy string, splitField database.Field) (ranges []SplitRange, err error) {
+	if num < 1 {
+		err = errors.Errorf("splitNumber(%d) can not less than 1.", num)
+		return
+	}
+
+	if min == nil || max == nil {
+		err = errors.New("split min or max can not be nil")
+		return
+	}
+
+	var c convertor
+	if c, err = newConvertor(min, timeAccuracy); err != nil {
+		return
+	}
+
+	var left, right *apd.BigInt
+	if left, err = c.toBigInt(min); err != nil {
+		return
+	}
+	if right, err = c.toBigInt(max); err != nil {
+		return
+	}
+	if left.Cmp(right) > 0 {
+		left, right = right, left
+	}
+
+	var values []*apd.BigInt
+	for _, v := range samples {
+		if v == nil || v.IsNil() {
+			continue
+		}
+		var bi *apd.BigInt
+		if bi, err = c.toBigInt(v); err != nil {
+			return
+		}
+		if bi.Cmp(left) > 0 && bi.Cmp(right) < 0 {
+			values = append(values, bi)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+
+	results := []*apd.BigInt{left}
+	for i := 1; i < num && len(values) > 0; i++ {
+		if v := values[i*len(values)/num]; v.Cmp(results[len(results)-1]) > 0 {
+			results = append(results, v)
+		}
+	}
+	results = append(results, right)
+	return newSplitRanges(c, results, splitField), nil
+}
 
+// newSplitRanges - get the ranges between the adjacent bounds in results, the last of which includes the right bound
+func newSplitRanges(c convertor, results []*apd.BigInt, splitField database.Field) (ranges []SplitRange) {
 	for i := 0; i < len(results)-1; i++ {
 		format := "%s >= %s and %s < %s"
 		if i == len(results)-2 {
@@ -1029,6 +1029,134 @@ func Test_split(t *testing.T) {
 	}
 }
 
+func Test_sampleSplit(t *testing.T) {
+	type args struct {
+		min          element.Column
+		max          element.Column
+		samples      []element.Column
+		num          int
+		timeAccuracy string
+		splitField   database.Field
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantRanges []SplitRange
+		wantErr    bool
+	}{
+		{
+			name: "1",
+			args: args{
+				min: element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10000)), "", 0),
+				max: element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(50000)), "", 0),
+				samples: []element.Column{
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10004)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10001)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10003)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10002)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(40000)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(60000)), "", 0),
+					element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "", 0),
+				},
+				num:        2,
+				splitField: NewMockField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)), NewMockFieldType(database.GoTypeInt64)),
+			},
+			wantRanges: []SplitRange{
+				{
+					Type:  element.TypeBigInt.String(),
+					Left:  "10000",
+					Right: "10003",
+					where: "f1 >= $1 and f1 < $2",
+				},
+				{
+					Type:  element.TypeBigInt.String(),
+					Left:  "10003",
+					Right: "50000",
+					where: "f1 >= $1 and f1 <= $2",
+				},
+			},
+		},
+		{
+			name: "2",
+			args: args{
+				min: element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10000)), "", 0),
+				max: element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(50000)), "", 0),
+				samples: []element.Column{
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10001)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10001)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10001)), "", 0),
+					element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10001)), "", 0),
+				},
+				num:        4,
+				splitField: NewMockField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)), NewMockFieldType(database.GoTypeInt64)),
+			},
+			wantRanges: []SplitRange{
+				{
+					Type:  element.TypeBigInt.String(),
+					Left:  "10000",
+					Right: "10001",
+					where: "f1 >= $1 and f1 < $2",
+				},
+				{
+					Type:  element.TypeBigInt.String(),
+					Left:  "10001",
+					Right: "50000",
+					where: "f1 >= $1 and f1 <= $2",
+				},
+			},
+		},
+		{
+			name: "3",
+			args: args{
+				min:        element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10000)), "", 0),
+				max:        element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(50000)), "", 0),
+				num:        4,
+				splitField: NewMockField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)), NewMockFieldType(database.GoTypeInt64)),
+			},
+			wantRanges: []SplitRange{
+				{
+					Type:  element.TypeBigInt.String(),
+					Left:  "10000",
+					Right: "50000",
+					where: "f1 >= $1 and f1 <= $2",
+				},
+			},
+		},
+		{
+			name: "4",
+			args: args{
+				min:        element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10000)), "", 0),
+				max:        element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(50000)), "", 0),
+				num:        0,
+				splitField: NewMockField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)), NewMockFieldType(database.GoTypeInt64)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "5",
+			args: args{
+				min:        element.NewDefaultColumn(element.NewBigIntColumnValue(apd.NewBigInt(10000)), "", 0),
+				max:        nil,
+				num:        4,
+				splitField: NewMockField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)), NewMockFieldType(database.GoTypeInt64)),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRanges, err := sampleSplit(tt.args.min, tt.args.max, tt.args.samples, tt.args.num, tt.args.timeAccuracy, tt.args.splitField)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sampleSplit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotRanges, tt.wantRanges) {
+				t.Errorf("sampleSplit() = %v, want %v", gotRanges, tt.wantRanges)
+			}
+		})
+	}
+}
+
 func TestSplitConfig_fetchMin(t *testing.T) {
 	type args struct {
 		ctx   context.Context
@@ -1332,3 +1460,126 @@ func TestSplitConfig_setLayout(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitConfig_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        *SplitConfig
+		querySQL []string
+		wantErr  bool
+	}{
+		{
+			name: "1",
+			s:    &SplitConfig{},
+		},
+		{
+			name: "2",
+			s: &SplitConfig{
+				Key:      "id",
+				PageSize: 1000,
+			},
+		},
+		{
+			name: "3",
+			s: &SplitConfig{
+				Key:      "id",
+				PageSize: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "4",
+			s: &SplitConfig{
+				PageSize: 1000,
+			},
+			wantErr: true,
+		},
+		{
+			name: "5",
+			s: &SplitConfig{
+				Key:      "id",
+				PageSize: 1000,
+			},
+			querySQL: []string{"select * from t"},
+			wantErr:  true,
+		},
+		{
+			name: "6",
+			s: &SplitConfig{
+				Key:        "id",
+				Strategy:   SplitStrategySample,
+				SampleSize: 1000,
+			},
+		},
+		{
+			name: "7",
+			s: &SplitConfig{
+				Strategy: SplitStrategyChunk,
+			},
+		},
+		{
+			name: "8",
+			s: &SplitConfig{
+				Strategy: "unknown",
+			},
+			wantErr: true,
+		},
+		{
+			name: "9",
+			s: &SplitConfig{
+				Key:        "id",
+				Strategy:   SplitStrategySample,
+				SampleSize: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "10",
+			s: &SplitConfig{
+				Key:      "id",
+				Strategy: SplitStrategyChunk,
+			},
+			wantErr: true,
+		},
+		{
+			name: "11",
+			s: &SplitConfig{
+				Strategy: SplitStrategyChunk,
+				PageSize: 1000,
+			},
+			wantErr: true,
+		},
+		{
+			name: "12",
+			s: &SplitConfig{
+				Key:        "id",
+				PageSize:   1000,
+				TieBreaker: "name",
+			},
+		},
+		{
+			name: "13",
+			s: &SplitConfig{
+				Key:        "id",
+				TieBreaker: "name",
+			},
+			wantErr: true,
+		},
+		{
+			name: "14",
+			s: &SplitConfig{
+				Key:        "id",
+				PageSize:   1000,
+				TieBreaker: "id",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.validate(tt.querySQL); (err != nil) != tt.wantErr {
+				t.Errorf("SplitConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -23,7 +23,9 @@ import (
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
 	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/schedule"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/pingcap/errors"
 )
 
 // Task normal dbms task
@@ -78,13 +80,19 @@ func (t *Task) Init(ctx context.Context) (err error) {
 
 	param := t.handler.TableParam(t.Config, t.Querier)
 	if setter, ok := param.Table().(database.ConfigSetter); ok {
-		setter.SetConfig(t.PluginJobConf())
+		if err = setter.SetConfig(t.PluginJobConf()); err != nil {
+			return t.Wrapf(err, "SetConfig fail")
+		}
 	}
 
 	if len(t.Config.GetQuerySQL()) == 0 {
 		if t.Table, err = t.Querier.FetchTableWithParam(ctx, param); err != nil {
 			return t.Wrapf(err, "FetchTableWithParam fail")
 		}
+		filter := t.Config.GetColumnFilter()
+		if err = filter.FilterFields(t.Table); err != nil {
+			return t.Wrapf(err, "FilterFields fail")
+		}
 		return
 	}
 	t.Table = param.Table()
@@ -140,19 +148,109 @@ func (b *BaseBatchReader) TaskGroupID() int64 {
 	return b.task.TaskGroupID()
 }
 
-// Parameter Query parameters
+// Parameter Query parameters, which are page query parameters when pageSize of split is set
 func (b *BaseBatchReader) Parameter() database.Parameter {
+	if b.task.Config.GetSplitConfig().PageSize > 0 {
+		return NewPageParam(b.task.Config, b.task.Table, b.opts)
+	}
 	return NewQueryParam(b.task.Config, b.task.Table, b.opts)
 }
 
 // Query through context ctx, description, and database handler
 func (b *BaseBatchReader) Read(ctx context.Context, param database.Parameter, handler database.FetchHandler) (err error) {
+	if page, ok := param.(*PageParam); ok {
+		return b.readPages(ctx, page, handler)
+	}
+	return b.read(ctx, param, handler)
+}
+
+func (b *BaseBatchReader) read(ctx context.Context, param database.Parameter, handler database.FetchHandler) (err error) {
 	if b.mode == "Tx" {
 		return b.task.Querier.FetchRecordWithTx(ctx, param, handler)
 	}
 	return b.task.Querier.FetchRecord(ctx, param, handler)
 }
 
+// readPages Read pages in order of the split key and the tie breaker until a page is not full.
+// A page failing to be read is read again from the last record read by the retry strategy.
+// Without the tie breaker, the last record read is skipped when read again in the next page,
+// and the other records of the same split key fail the reading instead of being skipped
+func (b *BaseBatchReader) readPages(ctx context.Context, param *PageParam, handler database.FetchHandler) (err error) {
+	var strategy schedule.RetryStrategy
+	if strategy, err = b.task.Config.GetRetryStrategy(&pageRetryJudger{}); err != nil {
+		return errors.Wrapf(err, "GetRetryStrategy fail")
+	}
+
+	var (
+		n      int   // number of records read in the page
+		start  []any // values of the split key and the tie breaker of the last record read before the page
+		reread bool  // whether the last record read before the page is read again
+	)
+	pageHandler := database.NewBaseFetchHandler(handler.CreateRecord, func(r element.Record) error {
+		n++
+		last, err := param.LastKey(r)
+		if err != nil {
+			return &handleError{err: err}
+		}
+		if start != nil && b.task.Config.GetSplitConfig().TieBreaker == "" && equalKeys(last, start) {
+			if !reread {
+				reread = true
+				return nil
+			}
+			return &handleError{err: errors.Errorf("split key(%v) has duplicate value %v at the end of a page, which requires tieBreaker",
+				b.task.Config.GetSplitConfig().Key, last[0])}
+		}
+		if err = handler.OnRecord(r); err != nil {
+			return &handleError{err: err}
+		}
+		param.SetLastKey(last)
+		return nil
+	})
+
+	for page := 1; ; page++ {
+		limit := 0
+		retry := schedule.NewRetryTask(ctx, strategy, schedule.TaskFunc(func() error {
+			n, start, reread, limit = 0, param.last, false, param.limit()
+			err := b.read(ctx, param, pageHandler)
+			if err != nil {
+				log.Warnf("jobid %v taskgroupid %v taskid %v read page %v fail. error: %v",
+					b.JobID(), b.TaskGroupID(), b.TaskID(), page, err)
+			}
+			return err
+		}))
+		if err = retry.Do(); err != nil {
+			return err
+		}
+		log.Debugf("jobid %v taskgroupid %v taskid %v read page %v with %v records",
+			b.JobID(), b.TaskGroupID(), b.TaskID(), page, n)
+		if n < limit {
+			return nil
+		}
+	}
+}
+
+// handleError Error of handling the records read, which is not retried
+type handleError struct {
+	err error
+}
+
+func (h *handleError) Error() string {
+	return h.err.Error()
+}
+
+// pageRetryJudger Judge whether to retry reading a page. Reading a page again after the last record read is harmless,
+// so all the errors except those of handling the records and the context are retried
+type pageRetryJudger struct{}
+
+func (p *pageRetryJudger) ShouldRetry(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case nil, *handleError:
+		return false
+	default:
+		return cause != context.Canceled && cause != context.DeadlineExceeded
+	}
+}
+
 // StartRead Start reading
 func StartRead(ctx context.Context, reader BatchReader, sender plugin.RecordSender) (err error) {
 	handler := database.NewBaseFetchHandler(func() (element.Record, error) {
@@ -22,6 +22,8 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func TestTask_Init(t *testing.T) {
@@ -320,3 +322,235 @@ func TestStartRead(t *testing.T) {
 		})
 	}
 }
+
+type mockPageQuerier struct {
+	*MockQuerier
+
+	keys     []int64  // values of f1 in order
+	ids      []string // values of f2 in order of f1 and f2, "a" if nil
+	failures int      // number of pages failing after the first record
+	fetches  int
+}
+
+func (m *mockPageQuerier) id(i int) string {
+	if m.ids == nil {
+		return "a"
+	}
+	return m.ids[i]
+}
+
+func (m *mockPageQuerier) FetchRecord(ctx context.Context,
+	param database.Parameter, handler database.FetchHandler) (err error) {
+	m.fetches++
+	p := param.(*PageParam)
+	last, limit := p.last, p.limit()
+	n := 0
+	for i, v := range m.keys {
+		switch len(last) {
+		case 1:
+			if v < last[0].(int64) {
+				continue
+			}
+		case 2:
+			if v < last[0].(int64) || v == last[0].(int64) && m.id(i) <= last[1].(string) {
+				continue
+			}
+		}
+		if n == limit {
+			break
+		}
+		if n == 1 && m.failures > 0 {
+			m.failures--
+			return errors.New("mock error")
+		}
+		var r element.Record
+		if r, err = handler.CreateRecord(); err != nil {
+			return
+		}
+		r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(v), "f1", 0))
+		r.Add(element.NewDefaultColumn(element.NewStringColumnValue(m.id(i)), "f2", 0))
+		if err = handler.OnRecord(r); err != nil {
+			return
+		}
+		n++
+	}
+	return
+}
+
+func (m *mockPageQuerier) FetchRecordWithTx(ctx context.Context,
+	param database.Parameter, handler database.FetchHandler) (err error) {
+	return m.FetchRecord(ctx, param, handler)
+}
+
+func TestBaseBatchReader_readPages(t *testing.T) {
+	newConfig := func(retry bool, tieBreaker string) *BaseConfig {
+		setting := `{}`
+		if retry {
+			setting = `{"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}}`
+		}
+		conf, err := NewBaseConfig(testJSONFromString(`{
+			"column":["f1","f2"],
+			"split":{"key":"f1","pageSize":2,"tieBreaker":"` + tieBreaker + `"},
+			"job":{"setting":` + setting + `}
+		}`))
+		if err != nil {
+			panic(err)
+		}
+		return conf
+	}
+	tests := []struct {
+		name        string
+		q           *mockPageQuerier
+		conf        *BaseConfig
+		mode        string
+		sendErr     error
+		want        []string
+		wantFetches int
+		wantErr     bool
+	}{
+		{
+			name:        "1",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 3, 4, 5}},
+			conf:        newConfig(false, ""),
+			want:        []string{"1a", "2a", "3a", "4a", "5a"},
+			wantFetches: 3,
+		},
+		{
+			name:        "2",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 3, 4}},
+			conf:        newConfig(false, ""),
+			mode:        "Tx",
+			want:        []string{"1a", "2a", "3a", "4a"},
+			wantFetches: 3,
+		},
+		{
+			name:        "3",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 3, 4, 5}, failures: 2},
+			conf:        newConfig(true, ""),
+			want:        []string{"1a", "2a", "3a", "4a", "5a"},
+			wantFetches: 5,
+		},
+		{
+			name:        "4",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 3, 4, 5}, failures: 1},
+			conf:        newConfig(false, ""),
+			want:        []string{"1a"},
+			wantFetches: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "5",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 3, 4, 5}},
+			conf:        newConfig(true, ""),
+			sendErr:     errors.New("mock error"),
+			wantFetches: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "6",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 2, 3}, ids: []string{"a", "a", "b", "a"}},
+			conf:        newConfig(false, ""),
+			want:        []string{"1a", "2a"},
+			wantFetches: 2,
+			wantErr:     true,
+		},
+		{
+			name:        "7",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 2, 3}, ids: []string{"a", "a", "b", "a"}},
+			conf:        newConfig(false, "f2"),
+			want:        []string{"1a", "2a", "2b", "3a"},
+			wantFetches: 3,
+		},
+		{
+			name:        "8",
+			q:           &mockPageQuerier{keys: []int64{1, 1, 1, 2, 2, 2}, ids: []string{"a", "b", "c", "a", "b", "c"}},
+			conf:        newConfig(true, "f2"),
+			want:        []string{"1a", "1b", "1c", "2a", "2b", "2c"},
+			wantFetches: 4,
+		},
+		{
+			name:        "9",
+			q:           &mockPageQuerier{keys: []int64{1, 2, 2, 3}, ids: []string{"a", "a", "b", "a"}, failures: 1},
+			conf:        newConfig(true, "f2"),
+			want:        []string{"1a", "2a", "2b", "3a"},
+			wantFetches: 3,
+		},
+		{
+			name:        "10",
+			q:           &mockPageQuerier{keys: []int64{1, 1, 2, 3}, ids: []string{"a", "b", "a", "a"}},
+			conf:        newConfig(false, ""),
+			want:        []string{"1a", "1b"},
+			wantFetches: 2,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewBaseBatchReader(&Task{
+				BaseTask: plugin.NewBaseTask(),
+				Querier:  tt.q,
+				Config:   tt.conf,
+				Table:    testPageTable(),
+			}, tt.mode, nil)
+			var got []string
+			handler := database.NewBaseFetchHandler(func() (element.Record, error) {
+				return element.NewDefaultRecord(), nil
+			}, func(r element.Record) error {
+				if tt.sendErr != nil {
+					return tt.sendErr
+				}
+				key, err := r.GetByName("f1")
+				if err != nil {
+					return err
+				}
+				id, err := r.GetByName("f2")
+				if err != nil {
+					return err
+				}
+				got = append(got, key.String()+id.String())
+				return nil
+			})
+			if err := reader.Read(context.TODO(), reader.Parameter(), handler); (err != nil) != tt.wantErr {
+				t.Errorf("BaseBatchReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BaseBatchReader.Read() = %v, want %v", got, tt.want)
+			}
+			if tt.q.fetches != tt.wantFetches {
+				t.Errorf("BaseBatchReader.Read() fetches = %v, want %v", tt.q.fetches, tt.wantFetches)
+			}
+		})
+	}
+}
+
+func Test_pageRetryJudger_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "1",
+		},
+		{
+			name: "2",
+			err:  errors.New("mock error"),
+			want: true,
+		},
+		{
+			name: "3",
+			err:  &handleError{err: errors.New("mock error")},
+		},
+		{
+			name: "4",
+			err:  context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (&pageRetryJudger{}).ShouldRetry(tt.err); got != tt.want {
+				t.Errorf("pageRetryJudger.ShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,22 +17,63 @@ package file
 import (
 	"context"
 
+	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	_ "github.com/Breeze0806/go-etl/storage/stream/file/s3"
+	_ "github.com/Breeze0806/go-etl/storage/stream/file/sftp"
 )
 
 // Job normal file job
 type Job struct {
 	*plugin.BaseJob
+
+	fsys       *file.FS
+	files      []File
+	postAction PostAction
 }
 
 // NewJob create normal file job
 func NewJob() *Job {
 	return &Job{
-		plugin.NewBaseJob(),
+		BaseJob: plugin.NewBaseJob(),
 	}
 }
 
 // Destroy destroy normal file job
 func (j *Job) Destroy(ctx context.Context) (err error) {
+	return j.fsys.Close()
+}
+
+// ConfigureFileSystems creates the file systems of the job, in which the remote file systems such as s3
+// are configured by the job configuration under their schemes, the registered file systems are not changed
+func (j *Job) ConfigureFileSystems() (err error) {
+	j.fsys, err = file.NewFS(j.PluginJobConf())
 	return
 }
+
+// SetFileSystems sets the configurations of the file systems of the job into the task configuration conf,
+// so that the task creates the same file systems
+func (j *Job) SetFileSystems(conf *config.JSON) error {
+	return j.fsys.SetConfig(conf)
+}
+
+// FileSystems gets the file systems of the job
+func (j *Job) FileSystems() *file.FS {
+	return j.fsys
+}
+
+// Expand expands paths into files by conf, and the files are kept for the post action
+func (j *Job) Expand(conf PathConfig, paths []string) (files []File, err error) {
+	if files, err = conf.Expand(j.fsys, paths); err != nil {
+		return nil, err
+	}
+	j.files = append(j.files, files...)
+	j.postAction = conf.PostAction
+	return
+}
+
+// Post moves or renames the files read after the job succeeds
+func (j *Job) Post(ctx context.Context) (err error) {
+	return j.postAction.Do(j.fsys, j.files)
+}
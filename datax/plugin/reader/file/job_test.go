@@ -16,6 +16,8 @@ package file
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -46,3 +48,28 @@ func TestJob_Destroy(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_Post(t *testing.T) {
+	dir := t.TempDir()
+	testFiles(t, dir, map[string]int{
+		"a.csv": 1,
+		"b.csv": 1,
+	})
+	j := NewJob()
+	conf := PathConfig{
+		PostAction: PostAction{
+			Suffix: ".done",
+		},
+	}
+	if _, err := j.Expand(conf, []string{filepath.Join(dir, "*.csv")}); err != nil {
+		t.Fatalf("Job.Expand() error = %v", err)
+	}
+	if err := j.Post(context.TODO()); err != nil {
+		t.Fatalf("Job.Post() error = %v", err)
+	}
+	for _, v := range []string{"a.csv.done", "b.csv.done"} {
+		if _, err := os.Stat(filepath.Join(dir, v)); err != nil {
+			t.Errorf("Job.Post() error = %v", err)
+		}
+	}
+}
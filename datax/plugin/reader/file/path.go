@@ -0,0 +1,443 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/pingcap/errors"
+)
+
+// PathConfig path configuration, each path can be a file, a directory, a glob pattern or - for standard input
+type PathConfig struct {
+	Stdin      bool       `json:"stdin"`      // Whether to read standard input instead of the paths
+	Recursive  bool       `json:"recursive"`  // Whether to find files in the subdirectories of directories
+	Include    []string   `json:"include"`    // Glob patterns of the file names to include, all files are included if empty
+	Exclude    []string   `json:"exclude"`    // Glob patterns of the file names to exclude
+	PostAction PostAction `json:"postAction"` // Action on the read files after the job succeeds
+}
+
+// File file found by path
+type File struct {
+	Path  string // File path
+	Size  int64  // File size, or the size of byte range if End is positive
+	Start int64  // Start of the byte range [Start, End) of the file
+	End   int64  // End of the byte range [Start, End) of the file, the whole file is read if End is 0
+
+	root string // Directory or non-pattern prefix of glob where the file is found
+}
+
+// Expand expands the paths into files, directories are listed and glob patterns are matched,
+// the files found are filtered by include and exclude.
+// A path without glob pattern that does not exist is kept, so that the error is reported when opening it.
+// The files are found in the file systems fsys. Standard input is the only file if stdin is true
+func (p *PathConfig) Expand(fsys *file.FS, paths []string) (files []File, err error) {
+	if p.Stdin {
+		return []File{{Path: file.Stdin}}, nil
+	}
+
+	exists := make(map[string]bool)
+	add := func(f File) {
+		if !exists[f.Path] {
+			exists[f.Path] = true
+			files = append(files, f)
+		}
+	}
+
+	for _, path := range paths {
+		if path == file.Stdin {
+			add(File{Path: path})
+			continue
+		}
+		if !hasMeta(path) {
+			var fi os.FileInfo
+			if fi, err = fsys.Stat(path); err != nil || !fi.IsDir() {
+				f := File{Path: path}
+				if err == nil {
+					f.Size = fi.Size()
+				}
+				err = nil
+				add(f)
+				continue
+			}
+			if err = p.walk(fsys, path, path, add); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var matches []string
+		if matches, err = glob(fsys, path); err != nil {
+			return nil, errors.Wrapf(err, "Glob(%v) fail", path)
+		}
+		root := globRoot(path)
+		for _, m := range matches {
+			var fi os.FileInfo
+			if fi, err = fsys.Stat(m); err != nil {
+				return nil, errors.Wrapf(err, "Stat(%v) fail", m)
+			}
+			if fi.IsDir() {
+				if err = p.walk(fsys, m, root, add); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			var ok bool
+			if ok, err = p.match(file.Base(m)); err != nil {
+				return nil, err
+			}
+			if ok {
+				add(File{Path: m, Size: fi.Size(), root: root})
+			}
+		}
+	}
+	return
+}
+
+// walk finds the files in dir, and in its subdirectories if recursive
+func (p *PathConfig) walk(fsys *file.FS, dir, root string, add func(File)) error {
+	if !file.IsLocal(dir) {
+		return p.walkRemote(fsys, dir, root, add)
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return errors.Wrapf(err, "WalkDir(%v) fail", path)
+		}
+		if d.IsDir() {
+			if path != dir && !p.Recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ok, err := p.match(d.Name())
+		if err != nil || !ok {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return errors.Wrapf(err, "Info(%v) fail", path)
+		}
+		if fi.Mode().IsRegular() {
+			add(File{Path: path, Size: fi.Size(), root: root})
+		}
+		return nil
+	})
+}
+
+// walkRemote finds the files in dir of the remote file system, and in its subdirectories if recursive
+func (p *PathConfig) walkRemote(fsys *file.FS, dir, root string, add func(File)) error {
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "ReadDir(%v) fail", dir)
+	}
+	for _, d := range entries {
+		path := file.Join(dir, d.Name())
+		if d.IsDir() {
+			if p.Recursive {
+				if err = p.walkRemote(fsys, path, root, add); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		ok, err := p.match(d.Name())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return errors.Wrapf(err, "Info(%v) fail", path)
+		}
+		add(File{Path: path, Size: fi.Size(), root: root})
+	}
+	return nil
+}
+
+// glob gets the names of the files or directories matching pattern,
+// the pattern is matched segment by segment by listing directories in the remote file systems
+func glob(fsys *file.FS, pattern string) (matches []string, err error) {
+	if file.IsLocal(pattern) {
+		return filepath.Glob(pattern)
+	}
+	if _, err = pathpkg.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	root := globRoot(pattern)
+	matches = []string{root}
+	for _, seg := range strings.Split(strings.TrimPrefix(pattern, strings.TrimSuffix(root, "/")+"/"), "/") {
+		var next []string
+		for _, dir := range matches {
+			var entries []fs.DirEntry
+			if entries, err = fsys.ReadDir(dir); err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			for _, d := range entries {
+				if ok, _ := pathpkg.Match(seg, d.Name()); ok {
+					next = append(next, file.Join(dir, d.Name()))
+				}
+			}
+		}
+		matches = next
+	}
+	return matches, nil
+}
+
+// rel gets the relative path of the file named 'name' to the directory root
+func rel(root, name string) (string, error) {
+	if file.IsLocal(name) {
+		return filepath.Rel(root, name)
+	}
+	prefix := strings.TrimSuffix(root, "/") + "/"
+	if !strings.HasPrefix(name, prefix) {
+		return "", fmt.Errorf("%v is not in %v", name, root)
+	}
+	return strings.TrimPrefix(name, prefix), nil
+}
+
+// match checks whether the file name is included and not excluded
+func (p *PathConfig) match(name string) (ok bool, err error) {
+	ok = len(p.Include) == 0
+	for _, v := range p.Include {
+		var matched bool
+		if matched, err = filepath.Match(v, name); err != nil {
+			return false, errors.Wrapf(err, "include %v is not valid", v)
+		}
+		if matched {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return
+	}
+
+	for _, v := range p.Exclude {
+		var matched bool
+		if matched, err = filepath.Match(v, name); err != nil {
+			return false, errors.Wrapf(err, "exclude %v is not valid", v)
+		}
+		if matched {
+			return false, nil
+		}
+	}
+	return
+}
+
+func hasMeta(path string) bool {
+	return strings.ContainsAny(path, `*?[`)
+}
+
+// globRoot gets the longest directory prefix of the pattern without glob meta characters
+func globRoot(pattern string) string {
+	dir := file.Dir(pattern)
+	for hasMeta(dir) {
+		dir = file.Dir(dir)
+	}
+	return dir
+}
+
+// Balance divides files into at most number groups with the nearly equal total size, the ties
+// of size such as empty files are broken by the number of files, and empty groups are omitted.
+// Each file is a group when number is not positive or not less than the number of files
+func Balance(files []File, number int) (groups [][]File) {
+	if number <= 0 || number >= len(files) {
+		for _, f := range files {
+			groups = append(groups, []File{f})
+		}
+		return
+	}
+
+	indexes := make([]int, len(files))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return files[indexes[i]].Size > files[indexes[j]].Size
+	})
+
+	balanced := make([][]File, number)
+	sizes := make([]int64, number)
+	for _, i := range indexes {
+		min := 0
+		for g := range sizes {
+			if sizes[g] < sizes[min] ||
+				sizes[g] == sizes[min] && len(balanced[g]) < len(balanced[min]) {
+				min = g
+			}
+		}
+		balanced[min] = append(balanced[min], files[i])
+		sizes[min] += files[i].Size
+	}
+
+	for _, g := range balanced {
+		if len(g) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return
+}
+
+// SplitRange divides each file larger than size into byte ranges of size if splittable returns true,
+// the records starting in each range are read by the opener implementing file.RangeOpener
+func SplitRange(files []File, size int64, splittable func(File) (bool, error)) (ranges []File, err error) {
+	if size <= 0 {
+		return files, nil
+	}
+	for _, f := range files {
+		ok := false
+		if f.Size > size {
+			if ok, err = splittable(f); err != nil {
+				return nil, err
+			}
+		}
+		if !ok {
+			ranges = append(ranges, f)
+			continue
+		}
+		for start := int64(0); start < f.Size; start += size {
+			r := f
+			r.Start = start
+			r.End = start + size
+			if r.End > f.Size {
+				r.End = f.Size
+			}
+			r.Size = r.End - r.Start
+			ranges = append(ranges, r)
+		}
+	}
+	return
+}
+
+// SetPath sets the paths of files into the task configuration conf,
+// a single file is set as a string, and multiple files are set as an array.
+// The byte ranges are set as an array of [start, end] if any file is a byte range
+func SetPath(conf *config.JSON, files []File) (err error) {
+	if len(files) == 0 {
+		return errors.New("files are empty")
+	}
+	if len(files) == 1 {
+		err = conf.Set("path", files[0].Path)
+	} else {
+		var paths []string
+		for _, f := range files {
+			paths = append(paths, f.Path)
+		}
+		err = conf.Set("path", paths)
+	}
+	if err != nil {
+		return
+	}
+
+	for _, f := range files {
+		if f.End > 0 {
+			var ranges [][2]int64
+			for _, f := range files {
+				ranges = append(ranges, [2]int64{f.Start, f.End})
+			}
+			return conf.Set("range", ranges)
+		}
+	}
+	return
+}
+
+// getFiles gets the files in the task configuration conf set by SetPath
+func getFiles(conf *config.JSON) (files []File, err error) {
+	if !conf.IsArray("path") {
+		var path string
+		if path, err = conf.GetString("path"); err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path})
+	} else {
+		var a []*config.JSON
+		if a, err = conf.GetConfigArray("path"); err != nil {
+			return nil, err
+		}
+		for i := range a {
+			var path string
+			if path, err = conf.GetString("path." + strconv.Itoa(i)); err != nil {
+				return nil, err
+			}
+			files = append(files, File{Path: path})
+		}
+	}
+
+	if !conf.Exists("range") {
+		return
+	}
+	for i := range files {
+		prefix := "range." + strconv.Itoa(i)
+		if files[i].Start, err = conf.GetInt64(prefix + ".0"); err != nil {
+			return nil, err
+		}
+		if files[i].End, err = conf.GetInt64(prefix + ".1"); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// PostAction action on the read files after the job succeeds
+type PostAction struct {
+	MoveTo string `json:"moveTo"` // Directory to move the files to, the relative path to the directory or glob where the file is found is kept
+	Suffix string `json:"suffix"` // Suffix appended to the file names
+}
+
+// Do moves or renames files in the file systems fsys except standard input, do nothing if both MoveTo and Suffix are empty
+func (p *PostAction) Do(fsys *file.FS, files []File) (err error) {
+	if p.MoveTo == "" && p.Suffix == "" {
+		return
+	}
+
+	for _, f := range files {
+		if f.Path == file.Stdin {
+			continue
+		}
+		target := f.Path
+		if p.MoveTo != "" {
+			name := file.Base(f.Path)
+			if f.root != "" {
+				if name, err = rel(f.root, f.Path); err != nil {
+					return errors.Wrapf(err, "Rel(%v, %v) fail", f.root, f.Path)
+				}
+			}
+			target = file.Join(p.MoveTo, name)
+		}
+		target += p.Suffix
+
+		if err = fsys.MkdirAll(file.Dir(target)); err != nil {
+			return errors.Wrapf(err, "MkdirAll(%v) fail", file.Dir(target))
+		}
+		if err = fsys.Rename(f.Path, target); err != nil {
+			return errors.Wrapf(err, "Rename(%v, %v) fail", f.Path, target)
+		}
+	}
+	return
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+// memFileSystem is an object storage of the file names mem://key without directories
+type memFileSystem struct {
+	objects map[string]int64
+}
+
+func (m *memFileSystem) key(name string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(name, "mem://"), "/")
+}
+
+func (m *memFileSystem) Open(name string) (file.ReadFile, error) {
+	return nil, errors.New("mock error")
+}
+
+func (m *memFileSystem) Create(name string) (file.WriteFile, error) {
+	return nil, errors.New("mock error")
+}
+
+func (m *memFileSystem) Stat(name string) (fs.FileInfo, error) {
+	key := m.key(name)
+	if size, ok := m.objects[key]; ok {
+		return &memFileInfo{name: key, size: size}, nil
+	}
+	for k := range m.objects {
+		if strings.HasPrefix(k, key+"/") {
+			return &memFileInfo{name: key, dir: true}, nil
+		}
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m *memFileSystem) ReadDir(name string) (entries []fs.DirEntry, err error) {
+	prefix := m.key(name) + "/"
+	dirs := make(map[string]bool)
+	for k, size := range m.objects {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(k, prefix)
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			if !dirs[rest[:i]] {
+				dirs[rest[:i]] = true
+				entries = append(entries, fs.FileInfoToDirEntry(&memFileInfo{name: rest[:i], dir: true}))
+			}
+			continue
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(&memFileInfo{name: rest, size: size}))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return
+}
+
+func (m *memFileSystem) Rename(oldname, newname string) error {
+	size, ok := m.objects[m.key(oldname)]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldname, Err: fs.ErrNotExist}
+	}
+	delete(m.objects, m.key(oldname))
+	m.objects[m.key(newname)] = size
+	return nil
+}
+
+func (m *memFileSystem) Remove(name string) error {
+	delete(m.objects, m.key(name))
+	return nil
+}
+
+type memFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (m *memFileInfo) Name() string {
+	return m.name[strings.LastIndexByte(m.name, '/')+1:]
+}
+
+func (m *memFileInfo) Size() int64 {
+	return m.size
+}
+
+func (m *memFileInfo) Mode() fs.FileMode {
+	if m.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+func (m *memFileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (m *memFileInfo) IsDir() bool {
+	return m.dir
+}
+
+func (m *memFileInfo) Sys() any {
+	return nil
+}
+
+var memFS = &memFileSystem{}
+var registerMemFS sync.Once
+
+func testMemFiles(objects map[string]int64) {
+	registerMemFS.Do(func() {
+		file.RegisterFileSystem("mem", memFS)
+	})
+	memFS.objects = objects
+}
+
+func TestPathConfig_Expand_Remote(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       *PathConfig
+		paths   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			p:     &PathConfig{},
+			paths: []string{"mem://bucket/in"},
+			want:  []string{"mem://bucket/in/a_1.csv", "mem://bucket/in/b_1.csv", "mem://bucket/in/c.txt"},
+		},
+		{
+			name:  "2",
+			p:     &PathConfig{Recursive: true, Include: []string{"a_*"}},
+			paths: []string{"mem://bucket/in/"},
+			want:  []string{"mem://bucket/in/a_1.csv", "mem://bucket/in/sub/a_2.csv", "mem://bucket/in/sub/d/a_3.csv"},
+		},
+		{
+			name:  "3",
+			p:     &PathConfig{},
+			paths: []string{"mem://bucket/in/*_1.csv", "mem://bucket/in/*/a_?.csv"},
+			want:  []string{"mem://bucket/in/a_1.csv", "mem://bucket/in/b_1.csv", "mem://bucket/in/sub/a_2.csv"},
+		},
+		{
+			name:  "4",
+			p:     &PathConfig{Recursive: true, Exclude: []string{"*.txt"}},
+			paths: []string{"mem://bucket/*"},
+			want: []string{"mem://bucket/in/a_1.csv", "mem://bucket/in/b_1.csv", "mem://bucket/in/sub/a_2.csv",
+				"mem://bucket/in/sub/d/a_3.csv", "mem://bucket/out.csv"},
+		},
+		{
+			name:  "5",
+			p:     &PathConfig{},
+			paths: []string{"mem://bucket/none.csv", "mem://bucket/none/*.csv"},
+			want:  []string{"mem://bucket/none.csv"},
+		},
+		{
+			name:    "6",
+			p:       &PathConfig{},
+			paths:   []string{"mem://bucket/[a.csv"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testMemFiles(map[string]int64{
+				"bucket/in/a_1.csv":       1,
+				"bucket/in/b_1.csv":       2,
+				"bucket/in/c.txt":         3,
+				"bucket/in/sub/a_2.csv":   4,
+				"bucket/in/sub/d/a_3.csv": 5,
+				"bucket/out.csv":          6,
+			})
+			got, err := tt.p.Expand(nil, tt.paths)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PathConfig.Expand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(testPaths(got), tt.want) {
+				t.Errorf("PathConfig.Expand() = %v, want %v", testPaths(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestPostAction_Do_Remote(t *testing.T) {
+	testMemFiles(map[string]int64{
+		"bucket/in/a.csv":     1,
+		"bucket/in/sub/b.csv": 1,
+	})
+	p := &PathConfig{
+		Recursive: true,
+		PostAction: PostAction{
+			MoveTo: "mem://bucket/done",
+			Suffix: ".done",
+		},
+	}
+	files, err := p.Expand(nil, []string{"mem://bucket/in"})
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	if err = p.PostAction.Do(nil, files); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	want := map[string]int64{
+		"bucket/done/a.csv.done":     1,
+		"bucket/done/sub/b.csv.done": 1,
+	}
+	if !reflect.DeepEqual(memFS.objects, want) {
+		t.Errorf("objects = %v, want %v", memFS.objects, want)
+	}
+
+	p.PostAction = PostAction{MoveTo: os.TempDir()}
+	files = []File{{Path: "mem://bucket/done/a.csv.done"}}
+	if err = p.PostAction.Do(nil, files); err == nil {
+		t.Errorf("Do() error = %v, wantErr true", err)
+	}
+}
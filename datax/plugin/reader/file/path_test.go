@@ -0,0 +1,375 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testFiles(t *testing.T, dir string, files map[string]int) {
+	for name, size := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testPaths(files []File) (paths []string) {
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	return
+}
+
+func TestPathConfig_Expand(t *testing.T) {
+	dir := t.TempDir()
+	testFiles(t, dir, map[string]int{
+		"a_20261018.csv":       1,
+		"a_20261019.csv":       2,
+		"b_20261019.csv":       3,
+		"c.txt":                4,
+		"sub/a_20261017.csv":   5,
+		"sub/d/a_20261016.csv": 6,
+	})
+	tests := []struct {
+		name    string
+		p       *PathConfig
+		paths   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			p:     &PathConfig{},
+			paths: []string{filepath.Join(dir, "a_*.csv")},
+			want: []string{
+				filepath.Join(dir, "a_20261018.csv"),
+				filepath.Join(dir, "a_20261019.csv"),
+			},
+		},
+		{
+			name:  "2",
+			p:     &PathConfig{},
+			paths: []string{dir},
+			want: []string{
+				filepath.Join(dir, "a_20261018.csv"),
+				filepath.Join(dir, "a_20261019.csv"),
+				filepath.Join(dir, "b_20261019.csv"),
+				filepath.Join(dir, "c.txt"),
+			},
+		},
+		{
+			name: "3",
+			p: &PathConfig{
+				Recursive: true,
+				Include:   []string{"*.csv"},
+				Exclude:   []string{"b_*"},
+			},
+			paths: []string{dir},
+			want: []string{
+				filepath.Join(dir, "a_20261018.csv"),
+				filepath.Join(dir, "a_20261019.csv"),
+				filepath.Join(dir, "sub", "a_20261017.csv"),
+				filepath.Join(dir, "sub", "d", "a_20261016.csv"),
+			},
+		},
+		{
+			name: "4",
+			p:    &PathConfig{},
+			paths: []string{
+				filepath.Join(dir, "c.txt"),
+				filepath.Join(dir, "*.txt"),
+				filepath.Join(dir, "none.csv"),
+			},
+			want: []string{
+				filepath.Join(dir, "c.txt"),
+				filepath.Join(dir, "none.csv"),
+			},
+		},
+		{
+			name:  "5",
+			p:     &PathConfig{},
+			paths: []string{filepath.Join(dir, "*", "*.csv")},
+			want: []string{
+				filepath.Join(dir, "sub", "a_20261017.csv"),
+			},
+		},
+		{
+			name:    "6",
+			p:       &PathConfig{},
+			paths:   []string{filepath.Join(dir, "[")},
+			wantErr: true,
+		},
+		{
+			name: "7",
+			p: &PathConfig{
+				Include: []string{"["},
+			},
+			paths:   []string{dir},
+			wantErr: true,
+		},
+		{
+			name:  "8",
+			p:     &PathConfig{},
+			paths: []string{"-", filepath.Join(dir, "c.txt"), "-"},
+			want: []string{
+				"-",
+				filepath.Join(dir, "c.txt"),
+			},
+		},
+		{
+			name: "9",
+			p: &PathConfig{
+				Stdin: true,
+			},
+			paths: []string{dir},
+			want:  []string{"-"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotFiles, err := tt.p.Expand(nil, tt.paths)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PathConfig.Expand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := testPaths(gotFiles); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PathConfig.Expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBalance(t *testing.T) {
+	files := []File{
+		{Path: "1", Size: 1},
+		{Path: "2", Size: 7},
+		{Path: "3", Size: 3},
+		{Path: "4", Size: 5},
+		{Path: "5", Size: 4},
+	}
+	tests := []struct {
+		name   string
+		number int
+		want   [][]string
+	}{
+		{
+			name:   "1",
+			number: 0,
+			want:   [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}},
+		},
+		{
+			name:   "2",
+			number: 6,
+			want:   [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}},
+		},
+		{
+			name:   "3",
+			number: 2,
+			want:   [][]string{{"2", "3"}, {"4", "5", "1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, g := range Balance(files, tt.number) {
+				got = append(got, testPaths(g))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Balance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBalance_ZeroSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  []File
+		number int
+		want   [][]string
+	}{
+		{
+			name:   "1",
+			files:  []File{{Path: "a", Size: 100}, {Path: "b"}, {Path: "c"}, {Path: "d"}},
+			number: 3,
+			want:   [][]string{{"a"}, {"b", "d"}, {"c"}},
+		},
+		{
+			name:   "2",
+			files:  []File{{Path: "1"}, {Path: "2"}, {Path: "3"}, {Path: "4"}, {Path: "5"}},
+			number: 2,
+			want:   [][]string{{"1", "3", "5"}, {"2", "4"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, g := range Balance(tt.files, tt.number) {
+				got = append(got, testPaths(g))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Balance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []File
+		want  string
+	}{
+		{
+			name:  "1",
+			files: []File{{Path: "a.csv"}},
+			want:  `{"path":"a.csv"}`,
+		},
+		{
+			name:  "2",
+			files: []File{{Path: "a.csv"}, {Path: "b.csv"}},
+			want:  `{"path":["a.csv","b.csv"]}`,
+		},
+		{
+			name:  "3",
+			files: []File{{Path: "a.csv", Start: 10, End: 20}, {Path: "b.csv"}},
+			want:  `{"path":["a.csv","b.csv"],"range":[[10,20],[0,0]]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := testJSONFromString(`{}`)
+			if err := SetPath(conf, tt.files); err != nil {
+				t.Fatalf("SetPath() error = %v", err)
+			}
+			if conf.String() != tt.want {
+				t.Fatalf("SetPath() = %v, want %v", conf.String(), tt.want)
+			}
+			files, err := getFiles(conf)
+			if err != nil {
+				t.Fatalf("getFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(files, tt.files) {
+				t.Errorf("getFiles() = %v, want %v", files, tt.files)
+			}
+		})
+	}
+}
+
+func TestSetPath_Empty(t *testing.T) {
+	conf := testJSONFromString(`{}`)
+	if err := SetPath(conf, nil); err == nil {
+		t.Errorf("SetPath() error = nil, wantErr true")
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	files := []File{
+		{Path: "a.csv", Size: 25},
+		{Path: "b.csv.gz", Size: 25},
+		{Path: "c.csv", Size: 5},
+	}
+	splittable := func(f File) (bool, error) {
+		return f.Path != "b.csv.gz", nil
+	}
+	tests := []struct {
+		name string
+		size int64
+		want []File
+	}{
+		{
+			name: "1",
+			size: 0,
+			want: files,
+		},
+		{
+			name: "2",
+			size: 10,
+			want: []File{
+				{Path: "a.csv", Size: 10, Start: 0, End: 10},
+				{Path: "a.csv", Size: 10, Start: 10, End: 20},
+				{Path: "a.csv", Size: 5, Start: 20, End: 25},
+				{Path: "b.csv.gz", Size: 25},
+				{Path: "c.csv", Size: 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitRange(files, tt.size, splittable)
+			if err != nil {
+				t.Fatalf("SplitRange() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostAction_Do(t *testing.T) {
+	dir := t.TempDir()
+	testFiles(t, dir, map[string]int{
+		"in/a.csv":     1,
+		"in/sub/b.csv": 1,
+		"c.csv":        1,
+	})
+	p := &PathConfig{
+		Recursive: true,
+		PostAction: PostAction{
+			MoveTo: filepath.Join(dir, "done"),
+			Suffix: ".done",
+		},
+	}
+	files, err := p.Expand(nil, []string{filepath.Join(dir, "in"), filepath.Join(dir, "c.csv")})
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+	if err = p.PostAction.Do(nil, files); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	for _, v := range []string{"a.csv.done", "sub/b.csv.done", "c.csv.done"} {
+		if _, err = os.Stat(filepath.Join(dir, "done", v)); err != nil {
+			t.Errorf("Do() error = %v", err)
+		}
+	}
+
+	p.PostAction = PostAction{Suffix: ".bak"}
+	files = []File{{Path: filepath.Join(dir, "done", "c.csv.done")}}
+	if err = p.PostAction.Do(nil, files); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "done", "c.csv.done.bak")); err != nil {
+		t.Errorf("Do() error = %v", err)
+	}
+
+	files = []File{{Path: "-"}}
+	if err = p.PostAction.Do(nil, files); err != nil {
+		t.Errorf("Do() error = %v", err)
+	}
+
+	files = []File{{Path: filepath.Join(dir, "none.csv")}}
+	if err = p.PostAction.Do(nil, files); err == nil {
+		t.Errorf("Do() error = %v, wantErr true", err)
+	}
+}
@@ -27,7 +27,10 @@ import (
 type Task struct {
 	*plugin.BaseTask
 
+	fsys     *file.FS
 	streamer *file.InStreamer
+	opener   string
+	files    []File
 }
 
 // NewTask Create normal file task
@@ -39,26 +42,37 @@ func NewTask() *Task {
 
 // Init Initialize the task
 func (t *Task) Init(ctx context.Context) (err error) {
-	var name string
-	if name, err = t.PluginConf().GetString("opener"); err != nil {
+	if t.opener, err = t.PluginConf().GetString("opener"); err != nil {
 		return t.Wrapf(err, "GetString fail")
 	}
-	var filename string
-	if filename, err = t.PluginJobConf().GetString("path"); err != nil {
-		return t.Wrapf(err, "GetString fail")
+	if t.files, err = getFiles(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "getFiles fail")
+	}
+	if t.fsys, err = file.NewFS(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewFS fail")
 	}
 
-	if t.streamer, err = file.NewInStreamer(name, filename); err != nil {
-		return t.Wrapf(err, "GetString fail")
+	if t.streamer, err = t.newStreamer(t.files[0]); err != nil {
+		return t.Wrapf(err, "newStreamer fail")
 	}
 	return
 }
 
+func (t *Task) newStreamer(f File) (*file.InStreamer, error) {
+	if f.End > 0 {
+		return file.NewRangeInStreamer(t.fsys, t.opener, f.Path, f.Start, f.End)
+	}
+	return file.NewInStreamer(t.fsys, t.opener, f.Path)
+}
+
 // Destroy Destroy normal file task
 func (t *Task) Destroy(ctx context.Context) (err error) {
 	if t.streamer != nil {
 		err = t.streamer.Close()
 	}
+	if cerr := t.fsys.Close(); err == nil {
+		err = cerr
+	}
 	return t.Wrapf(err, "Close fail")
 }
 
@@ -95,9 +109,20 @@ func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err e
 		return t.Wrapf(err, "GetConfigArray fail.")
 	}
 
-	for _, conf := range configs {
-		if err = t.streamer.Read(ctx, conf, handler); err != nil {
-			return t.Wrapf(err, "Read fail.")
+	for i, f := range t.files {
+		if i > 0 {
+			if err = t.streamer.Close(); err != nil {
+				return t.Wrapf(err, "Close fail.")
+			}
+			t.streamer = nil
+			if t.streamer, err = t.newStreamer(f); err != nil {
+				return t.Wrapf(err, "newStreamer fail.")
+			}
+		}
+		for _, conf := range configs {
+			if err = t.streamer.Read(ctx, conf, handler); err != nil {
+				return t.Wrapf(err, "Read(%v) fail.", f.Path)
+			}
 		}
 	}
 	return nil
@@ -72,7 +72,7 @@ type mockOpener struct {
 	err error
 }
 
-func (m *mockOpener) Open(filename string) (stream file.InStream, err error) {
+func (m *mockOpener) Open(fsys *file.FS, filename string) (stream file.InStream, err error) {
 	return &mockInStream{err: m.err}, nil
 }
 
@@ -153,6 +153,15 @@ func TestTask_StartRead(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"opener":"mock"}`),
+			jobConf: testJSONFromString(`{"path":["mockfile1","mockfile2"],"content":[{}]}`),
+			args: args{
+				ctx:    context.TODO(),
+				sender: &MockSender{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -216,6 +225,15 @@ func TestTask_Init(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"opener":"mock1"}`),
+			jobConf: testJSONFromString(`{"path":"mockfile","range":[[0,10]],"content":[{},{}]}`),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,127 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/pingcap/errors"
+	"golang.org/x/time/rate"
+)
+
+const maxErrorBody = 512
+
+// StatusError error of the response with the status code other than 2xx
+type StatusError struct {
+	StatusCode int    // Status code
+	Body       string // Beginning of the response body
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http status %v: %v", e.StatusCode, e.Body)
+}
+
+// client sends the requests of the configuration with rate limiting and retries
+type client struct {
+	conf     *Config
+	http     *http.Client
+	limiter  *rate.Limiter // nil if qps is 0
+	strategy schedule.RetryStrategy
+}
+
+func newClient(conf *Config) *client {
+	c := &client{
+		conf: conf,
+		http: &http.Client{
+			Timeout: conf.timeout(),
+		},
+		strategy: schedule.NewNoneRetryStrategy(),
+	}
+	if conf.QPS > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(conf.QPS), 1)
+	}
+	return c
+}
+
+// fetch requests url until it succeeds or the retry strategy gives up,
+// and each attempt waits for the rate limiter
+func (c *client) fetch(ctx context.Context, url string) (header http.Header, body []byte, err error) {
+	err = schedule.NewRetryTask(ctx, c.strategy, schedule.TaskFunc(func() (err error) {
+		if c.limiter != nil {
+			if err = c.limiter.Wait(ctx); err != nil {
+				return
+			}
+		}
+		header, body, err = c.do(ctx, url)
+		if err != nil {
+			log.Warnf("request %v fail. err: %v", url, err)
+		}
+		return
+	})).Do()
+	return
+}
+
+// do sends the request to url and reads the whole response body
+func (c *client) do(ctx context.Context, url string) (header http.Header, body []byte, err error) {
+	var reqBody io.Reader
+	if len(c.conf.Body) > 0 {
+		reqBody = bytes.NewReader(c.conf.Body)
+	}
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, c.conf.method(), url, reqBody); err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.conf.Header {
+		req.Header.Set(k, v)
+	}
+
+	var resp *http.Response
+	if resp, err = c.http.Do(req); err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if body, err = io.ReadAll(resp.Body); err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if len(body) > maxErrorBody {
+			body = body[:maxErrorBody]
+		}
+		return nil, nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return resp.Header, body, nil
+}
+
+// ShouldRetry retries the requests of too many requests, server errors and network errors
+func (c *client) ShouldRetry(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *StatusError:
+		return cause.StatusCode == http.StatusTooManyRequests || cause.StatusCode >= http.StatusInternalServerError
+	case net.Error:
+		return true
+	default:
+		return cause == io.ErrUnexpectedEOF
+	}
+}
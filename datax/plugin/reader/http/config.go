@@ -0,0 +1,182 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go/time2"
+	"github.com/vjeantet/jodaTime"
+)
+
+// pagination types
+const (
+	PaginationNone   = ""       // single request
+	PaginationPage   = "page"   // page number in the query parameter
+	PaginationOffset = "offset" // offset of records in the query parameter
+	PaginationCursor = "cursor" // cursor token taken from the response body
+	PaginationLink   = "link"   // next url in the Link header
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Config http reader configuration
+type Config struct {
+	URL        string            `json:"url"`        // URL of the first request, which may include query parameters
+	Method     string            `json:"method"`     // Request method, GET by default
+	Header     map[string]string `json:"header"`     // Request headers
+	Query      map[string]string `json:"query"`      // Query parameters added to url
+	Body       json.RawMessage   `json:"body"`       // JSON request body
+	Timeout    time2.Duration    `json:"timeout"`    // Timeout of each request, 30s by default
+	DataPath   string            `json:"dataPath"`   // JSON path of the array of records in the response body, the body is the array if empty
+	Columns    []Column          `json:"column"`     // Columns mapped from the fields of each record
+	Pagination Pagination        `json:"pagination"` // Pagination
+	QPS        float64           `json:"qps"`        // Maximum requests per second, no limit if 0
+}
+
+// Pagination pagination configuration
+type Pagination struct {
+	Type        string `json:"type"`        // Pagination type: page, offset, cursor or link
+	PageParam   string `json:"pageParam"`   // Query parameter of the page number of page, page by default
+	Start       int64  `json:"start"`       // First page number of page, 1 by default
+	OffsetParam string `json:"offsetParam"` // Query parameter of the offset of offset, offset by default
+	SizeParam   string `json:"sizeParam"`   // Query parameter of the page size of page and offset, not sent if empty
+	Size        int64  `json:"size"`        // Page size, the last page is the one with fewer records if positive
+	CursorParam string `json:"cursorParam"` // Query parameter of the cursor of cursor, cursor by default
+	CursorPath  string `json:"cursorPath"`  // JSON path of the next cursor in the response body of cursor
+	MaxPages    int64  `json:"maxPages"`    // Maximum number of requests, no limit if 0
+}
+
+// Column column mapped from the field of record
+type Column struct {
+	Name     string `json:"name"`   // Column name
+	Path     string `json:"path"`   // JSON path of the field in the record, name if empty
+	Type     string `json:"type"`   // Type (bool, bigInt, decimal, string, time, json), inferred from the JSON value if empty
+	Format   string `json:"format"` // Joda time format of time, RFC 3339 if empty
+	goLayout string
+}
+
+// NewConfig gets the http reader configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if err = c.validate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (c *Config) validate() (err error) {
+	var u *url.URL
+	if u, err = url.Parse(c.URL); err != nil {
+		return fmt.Errorf("url %v is not valid: %v", c.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url %v is not http or https", c.URL)
+	}
+	switch strings.ToUpper(c.Method) {
+	case "", http.MethodGet, http.MethodPost:
+	default:
+		return fmt.Errorf("method %v is not GET or POST", c.Method)
+	}
+	if c.QPS < 0 {
+		return fmt.Errorf("qps should not be negative")
+	}
+
+	if len(c.Columns) == 0 {
+		return fmt.Errorf("column is empty")
+	}
+	for i := range c.Columns {
+		if c.Columns[i].Name == "" {
+			return fmt.Errorf("name of column %v is empty", i+1)
+		}
+		switch element.ColumnType(c.Columns[i].Type) {
+		case "", element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+			element.TypeString, element.TypeTime, element.TypeJSON:
+		default:
+			return fmt.Errorf("type %v of column %v is not valid", c.Columns[i].Type, c.Columns[i].Name)
+		}
+	}
+
+	p := &c.Pagination
+	switch p.Type {
+	case PaginationNone, PaginationPage, PaginationCursor, PaginationLink:
+	case PaginationOffset:
+		if p.Size <= 0 {
+			return fmt.Errorf("size of pagination offset should be positive")
+		}
+	default:
+		return fmt.Errorf("pagination type %v is not valid", p.Type)
+	}
+	if p.Type == PaginationCursor && p.CursorPath == "" {
+		return fmt.Errorf("cursorPath of pagination cursor is empty")
+	}
+	if p.Size < 0 || p.MaxPages < 0 {
+		return fmt.Errorf("size and maxPages of pagination should not be negative")
+	}
+	return nil
+}
+
+// method gets the request method
+func (c *Config) method() string {
+	if c.Method == "" {
+		return http.MethodGet
+	}
+	return strings.ToUpper(c.Method)
+}
+
+// timeout gets the timeout of each request
+func (c *Config) timeout() time.Duration {
+	if c.Timeout.Duration == 0 {
+		return defaultTimeout
+	}
+	return c.Timeout.Duration
+}
+
+// dataPath gets the JSON path of the array of records
+func (c *Config) dataPath() string {
+	if c.DataPath == "" {
+		return "@this"
+	}
+	return c.DataPath
+}
+
+func (c *Column) path() string {
+	if c.Path == "" {
+		return c.Name
+	}
+	return c.Path
+}
+
+// layout converts the joda time format to the go layout
+func (c *Column) layout() string {
+	if c.goLayout != "" {
+		return c.goLayout
+	}
+	c.goLayout = time.RFC3339Nano
+	if c.Format != "" {
+		c.goLayout = jodaTime.GetLayout(c.Format)
+	}
+	return c.goLayout
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{"url":"https://api.example.com/items","method":"post","body":{"q":1},
+				"timeout":"10s","dataPath":"data","column":[{"name":"id","type":"bigInt"},{"name":"v"}],
+				"pagination":{"type":"offset","size":100},"qps":2}`),
+		},
+		{
+			name:    "2",
+			conf:    testJSONFromString(`{"url":"ftp://api.example.com/items","column":[{"name":"id"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items","method":"PUT","column":[{"name":"id"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items"}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items","column":[{"name":"id","type":"bytes"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items","column":[{"name":"id"}],"pagination":{"type":"offset"}}`),
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items","column":[{"name":"id"}],"pagination":{"type":"cursor"}}`),
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items","column":[{"name":"id"}],"pagination":{"type":"scroll"}}`),
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items","column":[{"name":"id"}],"qps":-1}`),
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			conf:    testJSONFromString(`{"url":"https://api.example.com/items","column":[{"name":""}]}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/pingcap/errors"
+)
+
+// Job - A unit of work to be performed
+type Job struct {
+	*plugin.BaseJob
+
+	conf *Config
+}
+
+// NewJob - Creates a new instance of a Job
+func NewJob() *Job {
+	return &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+}
+
+// Init - Initializes the Job, setting up any required resources or states
+func (j *Job) Init(ctx context.Context) (err error) {
+	j.conf, err = NewConfig(j.PluginJobConf())
+	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+}
+
+// Destroy - Cleans up or destroys the Job, releasing any resources or states
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+// Split - Divides the Job into smaller sub-tasks or sub-jobs for parallel processing or distribution
+// The pages are requested in sequence, so there is always only one task
+func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
+	return []*config.JSON{j.PluginJobConf().CloneConfig()}, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// pager builds the url of each page and decides the next page by the response of the current page
+type pager struct {
+	conf   *Pagination
+	url    *url.URL // url of the current page
+	pages  int64    // number of the requested pages
+	offset int64    // offset of the next page of offset
+	cursor string   // cursor of the current page of cursor
+}
+
+// newPager creates the pager of the first page of the url in conf
+func newPager(conf *Config) (p *pager, err error) {
+	p = &pager{
+		conf: &conf.Pagination,
+	}
+	if p.url, err = url.Parse(conf.URL); err != nil {
+		return nil, err
+	}
+	query := p.url.Query()
+	for k, v := range conf.Query {
+		query.Set(k, v)
+	}
+	switch p.conf.Type {
+	case PaginationPage:
+		query.Set(p.pageParam(), strconv.FormatInt(p.start(), 10))
+	case PaginationOffset:
+		query.Set(p.offsetParam(), "0")
+	}
+	if p.conf.SizeParam != "" && p.conf.Size > 0 {
+		query.Set(p.conf.SizeParam, strconv.FormatInt(p.conf.Size, 10))
+	}
+	p.url.RawQuery = query.Encode()
+	return
+}
+
+// URL gets the url of the current page
+func (p *pager) URL() string {
+	return p.url.String()
+}
+
+// Next moves to the next page by the response of the current page with n records,
+// and returns false if the current page is the last one
+func (p *pager) Next(header http.Header, body []byte, n int) bool {
+	p.pages++
+	if p.conf.MaxPages > 0 && p.pages >= p.conf.MaxPages {
+		return false
+	}
+
+	var next *url.URL
+	switch p.conf.Type {
+	case PaginationPage:
+		if n == 0 || (p.conf.Size > 0 && int64(n) < p.conf.Size) {
+			return false
+		}
+		next = p.withQuery(p.pageParam(), strconv.FormatInt(p.start()+p.pages, 10))
+	case PaginationOffset:
+		if int64(n) < p.conf.Size {
+			return false
+		}
+		p.offset += int64(n)
+		next = p.withQuery(p.offsetParam(), strconv.FormatInt(p.offset, 10))
+	case PaginationCursor:
+		cursor := gjson.GetBytes(body, p.conf.CursorPath)
+		// an empty page or the same cursor would request the same page forever
+		if n == 0 || cursor.Type == gjson.Null || cursor.String() == "" || cursor.String() == p.cursor {
+			return false
+		}
+		p.cursor = cursor.String()
+		next = p.withQuery(p.cursorParam(), p.cursor)
+	case PaginationLink:
+		link := nextLink(header)
+		if link == "" {
+			return false
+		}
+		var err error
+		if next, err = p.url.Parse(link); err != nil {
+			return false
+		}
+	default:
+		return false
+	}
+	p.url = next
+	return true
+}
+
+func (p *pager) withQuery(key, value string) *url.URL {
+	u := *p.url
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+func (p *pager) pageParam() string {
+	if p.conf.PageParam == "" {
+		return "page"
+	}
+	return p.conf.PageParam
+}
+
+func (p *pager) start() int64 {
+	if p.conf.Start == 0 {
+		return 1
+	}
+	return p.conf.Start
+}
+
+func (p *pager) offsetParam() string {
+	if p.conf.OffsetParam == "" {
+		return "offset"
+	}
+	return p.conf.OffsetParam
+}
+
+func (p *pager) cursorParam() string {
+	if p.conf.CursorParam == "" {
+		return "cursor"
+	}
+	return p.conf.CursorParam
+}
+
+// nextLink gets the url of rel="next" in the Link headers defined by RFC 8288,
+// such as <https://api.example.com/items?page=2>; rel="next"
+func nextLink(header http.Header) string {
+	for _, v := range header.Values("Link") {
+		for _, link := range strings.Split(v, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				k, v, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(strings.TrimSpace(k), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(v), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type testPage struct {
+	header http.Header
+	body   string
+	n      int
+}
+
+func TestPager_Next(t *testing.T) {
+	tests := []struct {
+		name  string
+		conf  *Config
+		pages []testPage
+		want  []string
+	}{
+		{
+			name:  "1",
+			conf:  &Config{URL: "http://127.0.0.1/items?a=1"},
+			pages: []testPage{{n: 2}},
+			want:  []string{"http://127.0.0.1/items?a=1"},
+		},
+		{
+			name: "2",
+			conf: &Config{
+				URL:        "http://127.0.0.1/items",
+				Query:      map[string]string{"a": "1"},
+				Pagination: Pagination{Type: PaginationPage, SizeParam: "per_page", Size: 2},
+			},
+			pages: []testPage{{n: 2}, {n: 2}, {n: 1}},
+			want: []string{
+				"http://127.0.0.1/items?a=1&page=1&per_page=2",
+				"http://127.0.0.1/items?a=1&page=2&per_page=2",
+				"http://127.0.0.1/items?a=1&page=3&per_page=2",
+			},
+		},
+		{
+			name: "3",
+			conf: &Config{
+				URL:        "http://127.0.0.1/items",
+				Pagination: Pagination{Type: PaginationPage, PageParam: "p", Start: 0},
+			},
+			pages: []testPage{{n: 3}, {n: 0}},
+			want: []string{
+				"http://127.0.0.1/items?p=1",
+				"http://127.0.0.1/items?p=2",
+			},
+		},
+		{
+			name: "4",
+			conf: &Config{
+				URL:        "http://127.0.0.1/items",
+				Pagination: Pagination{Type: PaginationOffset, SizeParam: "limit", Size: 2},
+			},
+			pages: []testPage{{n: 2}, {n: 2}, {n: 0}},
+			want: []string{
+				"http://127.0.0.1/items?limit=2&offset=0",
+				"http://127.0.0.1/items?limit=2&offset=2",
+				"http://127.0.0.1/items?limit=2&offset=4",
+			},
+		},
+		{
+			name: "5",
+			conf: &Config{
+				URL:        "http://127.0.0.1/items",
+				Pagination: Pagination{Type: PaginationCursor, CursorPath: "meta.next"},
+			},
+			pages: []testPage{
+				{body: `{"meta":{"next":"x"}}`, n: 1},
+				{body: `{"meta":{"next":"x"}}`, n: 1},
+			},
+			want: []string{
+				"http://127.0.0.1/items",
+				"http://127.0.0.1/items?cursor=x",
+			},
+		},
+		{
+			name: "6",
+			conf: &Config{
+				URL:        "http://127.0.0.1/items",
+				Pagination: Pagination{Type: PaginationCursor, CursorParam: "after", CursorPath: "next"},
+			},
+			pages: []testPage{
+				{body: `{"next":"x"}`, n: 1},
+				{body: `{"next":null}`, n: 1},
+			},
+			want: []string{
+				"http://127.0.0.1/items",
+				"http://127.0.0.1/items?after=x",
+			},
+		},
+		{
+			name: "7",
+			conf: &Config{
+				URL:        "http://127.0.0.1/v1/items",
+				Pagination: Pagination{Type: PaginationLink},
+			},
+			pages: []testPage{
+				{header: http.Header{"Link": {`<items?page=2>; rel="next", <items?page=9>; rel="last"`}}, n: 1},
+				{header: http.Header{"Link": {`<https://127.0.0.2/items?page=3>; rel="prev next"`}}, n: 1},
+				{header: http.Header{"Link": {`<items?page=2>; rel="prev"`}}, n: 1},
+			},
+			want: []string{
+				"http://127.0.0.1/v1/items",
+				"http://127.0.0.1/v1/items?page=2",
+				"https://127.0.0.2/items?page=3",
+			},
+		},
+		{
+			name: "8",
+			conf: &Config{
+				URL:        "http://127.0.0.1/items",
+				Pagination: Pagination{Type: PaginationPage, MaxPages: 2},
+			},
+			pages: []testPage{{n: 1}, {n: 1}},
+			want: []string{
+				"http://127.0.0.1/items?page=1",
+				"http://127.0.0.1/items?page=2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newPager(tt.conf)
+			if err != nil {
+				t.Fatalf("newPager() error = %v", err)
+			}
+			var got []string
+			for i, page := range tt.pages {
+				got = append(got, p.URL())
+				next := p.Next(page.header, []byte(page.body), page.n)
+				if next != (i < len(tt.pages)-1) {
+					t.Fatalf("pager.Next() of page %v = %v", i+1, next)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pager.URL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
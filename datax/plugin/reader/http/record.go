@@ -0,0 +1,124 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+	"github.com/tidwall/gjson"
+)
+
+// column gets the column of the field in the record
+func (c *Column) column(record gjson.Result) (element.Column, error) {
+	field := record.Get(c.path())
+	v, err := c.value(field)
+	if err != nil {
+		return nil, errors.Wrapf(err, "column %v value %v is not valid", c.Name, field.Raw)
+	}
+	return element.NewDefaultColumn(v, c.Name, len(field.Raw)), nil
+}
+
+// value converts the JSON value field to the column value of its type,
+// the type is inferred from the JSON value if not configured
+func (c *Column) value(field gjson.Result) (element.ColumnValue, error) {
+	typ := element.ColumnType(c.Type)
+	if typ == "" {
+		typ = inferType(field)
+	}
+	if field.Type == gjson.Null {
+		return nilValue(typ), nil
+	}
+
+	switch typ {
+	case element.TypeBool:
+		switch field.Type {
+		case gjson.True, gjson.False:
+			return element.NewBoolColumnValue(field.Bool()), nil
+		case gjson.String:
+			b, err := strconv.ParseBool(field.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewBoolColumnValue(b), nil
+		}
+	case element.TypeBigInt:
+		switch field.Type {
+		case gjson.Number:
+			return element.NewBigIntColumnValueFromString(field.Raw)
+		case gjson.String:
+			return element.NewBigIntColumnValueFromString(field.Str)
+		}
+	case element.TypeDecimal:
+		switch field.Type {
+		case gjson.Number:
+			return element.NewDecimalColumnValueFromString(field.Raw)
+		case gjson.String:
+			return element.NewDecimalColumnValueFromString(field.Str)
+		}
+	case element.TypeString:
+		if field.Type == gjson.String {
+			return element.NewStringColumnValue(field.Str), nil
+		}
+		return element.NewStringColumnValue(field.Raw), nil
+	case element.TypeTime:
+		if field.Type == gjson.String {
+			layout := c.layout()
+			t, err := time.Parse(layout, field.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+		}
+	case element.TypeJSON:
+		return element.NewJsonColumnValueFromString(field.Raw)
+	}
+	return nil, errors.Errorf("%v can not be converted to %v", field.Type, typ)
+}
+
+// inferType infers the column type of the JSON value, the integer is bigInt and other numbers are decimal
+func inferType(field gjson.Result) element.ColumnType {
+	switch field.Type {
+	case gjson.True, gjson.False:
+		return element.TypeBool
+	case gjson.Number:
+		if strings.ContainsAny(field.Raw, ".eE") {
+			return element.TypeDecimal
+		}
+		return element.TypeBigInt
+	case gjson.JSON:
+		return element.TypeJSON
+	}
+	return element.TypeString
+}
+
+func nilValue(typ element.ColumnType) element.ColumnValue {
+	switch typ {
+	case element.TypeBool:
+		return element.NewNilBoolColumnValue()
+	case element.TypeBigInt:
+		return element.NewNilBigIntColumnValue()
+	case element.TypeDecimal:
+		return element.NewNilDecimalColumnValue()
+	case element.TypeTime:
+		return element.NewNilTimeColumnValue()
+	case element.TypeJSON:
+		return element.NewNilJsonColumnValue()
+	}
+	return element.NewNilStringColumnValue()
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestColumn_value(t *testing.T) {
+	record := `{"i":1,"f":1.5e2,"s":"a","b":false,"o":{"k":[1]},"n":null,"bs":"true","is":"12","t":"2026-10-19 08:00:00"}`
+	tests := []struct {
+		name    string
+		c       *Column
+		want    string
+		wantErr bool
+	}{
+		{name: "1", c: &Column{Name: "i"}, want: "1"},
+		{name: "2", c: &Column{Name: "f"}, want: "150"},
+		{name: "3", c: &Column{Name: "s"}, want: "a"},
+		{name: "4", c: &Column{Name: "b"}, want: "false"},
+		{name: "5", c: &Column{Name: "o"}, want: `{"k":[1]}`},
+		{name: "6", c: &Column{Name: "n"}, want: "<nil>"},
+		{name: "7", c: &Column{Name: "none"}, want: "<nil>"},
+		{name: "8", c: &Column{Name: "bs", Type: "bool"}, want: "true"},
+		{name: "9", c: &Column{Name: "is", Type: "bigInt"}, want: "12"},
+		{name: "10", c: &Column{Name: "i", Type: "string"}, want: "1"},
+		{name: "11", c: &Column{Name: "t", Type: "time", Format: "yyyy-MM-dd HH:mm:ss"}, want: "2026-10-19 08:00:00Z"},
+		{name: "12", c: &Column{Name: "t", Type: "time"}, wantErr: true},
+		{name: "13", c: &Column{Name: "s", Type: "bigInt"}, wantErr: true},
+		{name: "14", c: &Column{Name: "o", Type: "decimal"}, wantErr: true},
+		{name: "15", c: &Column{Name: "s", Type: "bool"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.c.column(gjson.Parse(record))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Column.column() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.String(); got != tt.want {
+				t.Errorf("Column.column() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/pingcap/errors"
+	"github.com/tidwall/gjson"
+)
+
+// Task - A unit of work to be performed
+type Task struct {
+	*plugin.BaseTask
+
+	conf   *Config
+	client *client
+}
+
+// NewTask - Creates a new instance of a Task
+func NewTask() *Task {
+	return &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+}
+
+// Init - Initializes the Task, setting up any required resources or states
+func (t *Task) Init(ctx context.Context) (err error) {
+	if t.conf, err = NewConfig(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewConfig fail")
+	}
+	t.client = newClient(t.conf)
+	if t.client.strategy, err = schedule.NewRetryStrategy(t.client, t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewRetryStrategy fail")
+	}
+	return
+}
+
+// Destroy - Cleans up or destroys the Task, releasing any resources or states
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+// StartRead - Begins reading the pages and sends the records of each page
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	log.Infof(t.Format("startRead begin"))
+	defer func() {
+		sender.Terminate()
+		log.Infof(t.Format("startRead end"))
+	}()
+
+	var p *pager
+	var header http.Header
+	var body []byte
+	if p, err = newPager(t.conf); err != nil {
+		return t.Wrapf(err, "newPager fail")
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		header, body, err = t.client.fetch(ctx, p.URL())
+		if err != nil {
+			return t.Wrapf(err, "fetch fail. url: %v", p.URL())
+		}
+		data := gjson.GetBytes(body, t.conf.dataPath())
+		if !data.IsArray() {
+			return errors.Errorf(t.Format("%v of the response of %v is not an array"), t.conf.dataPath(), p.URL())
+		}
+		n := 0
+		for _, v := range data.Array() {
+			if err = t.send(sender, v); err != nil {
+				return err
+			}
+			n++
+		}
+		if !p.Next(header, body, n) {
+			return nil
+		}
+	}
+}
+
+// send sends the record converted from the JSON object v
+func (t *Task) send(sender plugin.RecordSender, v gjson.Result) (err error) {
+	var record element.Record
+	if record, err = sender.CreateRecord(); err != nil {
+		return t.Wrapf(err, "CreateRecord fail")
+	}
+	for i := range t.conf.Columns {
+		var c element.Column
+		if c, err = t.conf.Columns[i].column(v); err != nil {
+			return t.Wrapf(err, "column fail")
+		}
+		if err = record.Add(c); err != nil {
+			return t.Wrapf(err, "Add fail")
+		}
+	}
+	if err = sender.SendWriter(record); err != nil {
+		return t.Wrapf(err, "SendWriter fail")
+	}
+	return
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockSender struct {
+	records []element.Record
+	sendErr error
+}
+
+func (m *mockSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), nil
+}
+
+func (m *mockSender) SendWriter(record element.Record) error {
+	m.records = append(m.records, record)
+	return m.sendErr
+}
+
+func (m *mockSender) Flush() error {
+	return nil
+}
+
+func (m *mockSender) Terminate() error {
+	return nil
+}
+
+func (m *mockSender) Shutdown() error {
+	return nil
+}
+
+func testRead(t *testing.T, conf *config.JSON, sender *mockSender) (records []string, err error) {
+	task := NewTask()
+	task.SetPluginJobConf(conf)
+	if err = task.Init(context.TODO()); err != nil {
+		return
+	}
+	defer task.Destroy(context.TODO())
+	err = task.StartRead(context.TODO(), sender)
+	for _, r := range sender.records {
+		records = append(records, r.String())
+	}
+	return
+}
+
+// testServer serves the items 1 to 5 by page and page_size, and fails the requests
+// with the status codes of failures in order before succeeding
+func testServer(t *testing.T, failures ...int) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(failures) > 0 {
+			w.WriteHeader(failures[0])
+			failures = failures[1:]
+			return
+		}
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if r.Header.Get("Content-Type") != "application/json" || string(body) != `{"q":"a"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+		if r.Header.Get("X-Token") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var page, size int
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		fmt.Sscan(r.URL.Query().Get("page_size"), &size)
+		if page == 0 {
+			page, size = 1, 5
+		}
+		items := ""
+		for i := (page-1)*size + 1; i <= page*size && i <= 5; i++ {
+			if items != "" {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"id":%d,"price":%d.5,"tag":{"name":"t%d"},"ok":true,"at":"2026-10-0%dT08:00:00Z","x":null}`, i, i, i, i)
+		}
+		fmt.Fprintf(w, `{"data":{"items":[%s]}}`, items)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestTask_StartRead(t *testing.T) {
+	conf := `{"url":"%v","header":{"X-Token":"1"},"dataPath":"data.items",
+		"column":[{"name":"id"},{"name":"price","type":"decimal"},{"name":"tag","path":"tag.name"},{"name":"x","type":"bigInt"}]%v}`
+	tests := []struct {
+		name     string
+		failures []int
+		conf     string
+		sender   *mockSender
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:   "1",
+			conf:   `,"pagination":{"type":"page","sizeParam":"page_size","size":2}`,
+			sender: &mockSender{},
+			want: []string{
+				"id=1 price=1.5 tag=t1 x=<nil>",
+				"id=2 price=2.5 tag=t2 x=<nil>",
+				"id=3 price=3.5 tag=t3 x=<nil>",
+				"id=4 price=4.5 tag=t4 x=<nil>",
+				"id=5 price=5.5 tag=t5 x=<nil>",
+			},
+		},
+		{
+			name:     "2",
+			failures: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+			conf:     `,"pagination":{"type":"page","sizeParam":"page_size","size":3},"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}`,
+			sender:   &mockSender{},
+			want: []string{
+				"id=1 price=1.5 tag=t1 x=<nil>",
+				"id=2 price=2.5 tag=t2 x=<nil>",
+				"id=3 price=3.5 tag=t3 x=<nil>",
+				"id=4 price=4.5 tag=t4 x=<nil>",
+				"id=5 price=5.5 tag=t5 x=<nil>",
+			},
+		},
+		{
+			name:     "3",
+			failures: []int{http.StatusNotFound},
+			conf:     `,"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}`,
+			sender:   &mockSender{},
+			wantErr:  true,
+		},
+		{
+			name:     "4",
+			failures: []int{http.StatusBadGateway},
+			sender:   &mockSender{},
+			wantErr:  true,
+		},
+		{
+			name:    "5",
+			conf:    `,"dataPath":"data"`,
+			sender:  &mockSender{},
+			wantErr: true,
+		},
+		{
+			name:   "6",
+			conf:   `,"method":"POST","body":{"q":"a"},"qps":100,"pagination":{"type":"page","sizeParam":"page_size","size":5}`,
+			sender: &mockSender{},
+			want: []string{
+				"id=1 price=1.5 tag=t1 x=<nil>",
+				"id=2 price=2.5 tag=t2 x=<nil>",
+				"id=3 price=3.5 tag=t3 x=<nil>",
+				"id=4 price=4.5 tag=t4 x=<nil>",
+				"id=5 price=5.5 tag=t5 x=<nil>",
+			},
+		},
+		{
+			name:    "7",
+			sender:  &mockSender{sendErr: errors.New("mock error")},
+			want:    []string{"id=1 price=1.5 tag=t1 x=<nil>"},
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			conf:    `,"retry":{"type":"ntimes"}`,
+			sender:  &mockSender{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testServer(t, tt.failures...)
+			got, err := testRead(t, testJSONFromString(fmt.Sprintf(conf, s.URL, tt.conf)), tt.sender)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
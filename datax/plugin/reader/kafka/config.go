@@ -0,0 +1,140 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+)
+
+// meta columns taken from the messages
+const (
+	MetaKey       = "key"       // message key as string
+	MetaTopic     = "topic"     // topic name as string
+	MetaPartition = "partition" // partition as bigInt
+	MetaOffset    = "offset"    // offset as bigInt
+	MetaTimestamp = "timestamp" // message timestamp as time
+)
+
+const defaultFetchSize = 1 << 20
+
+// Config kafka reader configuration
+type Config struct {
+	kafka.Config
+
+	Topic        string          `json:"topic"`        // Topic name
+	Partitions   []int32         `json:"partitions"`   // Partitions to read, all partitions if empty
+	StartOffsets map[int32]int64 `json:"startOffsets"` // Offsets to start from by partition, which take precedence over startTime
+	StartTime    string          `json:"startTime"`    // RFC 3339 time of the first messages to read, the earliest messages if empty
+	StopOffsets  map[int32]int64 `json:"stopOffsets"`  // Offsets to stop before by partition, which take precedence over stopTime
+	StopTime     string          `json:"stopTime"`     // RFC 3339 time of the messages to stop before, the latest offsets when the job starts if empty
+	Format       string          `json:"format"`       // Format of the message values, json or avro, json if empty
+	Columns      []Column        `json:"column"`       // Columns of the records
+	SchemaID     int32           `json:"schemaId"`     // Schema id of the confluent schema registry before avro values if positive
+	FetchSize    int32           `json:"fetchSize"`    // Maximum bytes of each fetch, 1MiB by default
+
+	Assignments []Assignment `json:"assignments"` // Offset ranges of the partitions of the task set by the job
+
+	startTime time.Time
+	stopTime  time.Time
+}
+
+// Column column of the record, which is decoded from the message value or taken from the meta of the message
+type Column struct {
+	kafka.Column
+
+	Meta string `json:"meta"` // Meta of the message: key, topic, partition, offset or timestamp
+}
+
+// Assignment offset range [Start, End) of the partition
+type Assignment struct {
+	Partition int32 `json:"partition"`
+	Start     int64 `json:"start"`
+	End       int64 `json:"end"`
+}
+
+// NewConfig gets the kafka reader configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if len(c.Brokers) == 0 {
+		return nil, fmt.Errorf("brokers is empty")
+	}
+	if c.Topic == "" {
+		return nil, fmt.Errorf("topic is empty")
+	}
+	if c.StartTime != "" {
+		if c.startTime, err = time.Parse(time.RFC3339, c.StartTime); err != nil {
+			return nil, fmt.Errorf("startTime %v is not RFC 3339: %v", c.StartTime, err)
+		}
+	}
+	if c.StopTime != "" {
+		if c.stopTime, err = time.Parse(time.RFC3339, c.StopTime); err != nil {
+			return nil, fmt.Errorf("stopTime %v is not RFC 3339: %v", c.StopTime, err)
+		}
+	}
+	if c.FetchSize < 0 {
+		return nil, fmt.Errorf("fetchSize should not be negative")
+	}
+
+	if len(c.Columns) == 0 {
+		return nil, fmt.Errorf("column is empty")
+	}
+	for i, col := range c.Columns {
+		if col.Name == "" {
+			return nil, fmt.Errorf("name of column %v is empty", i+1)
+		}
+		switch col.Meta {
+		case "", MetaKey, MetaTopic, MetaPartition, MetaOffset, MetaTimestamp:
+		default:
+			return nil, fmt.Errorf("meta %v of column %v is not valid", col.Meta, col.Name)
+		}
+	}
+	if c.Format == "" {
+		c.Format = kafka.FormatJSON
+	}
+	if _, err = c.NewCodec(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// NewCodec creates the codec of the columns decoded from the message values, nil if all columns are meta
+func (c *Config) NewCodec() (*kafka.Codec, error) {
+	var columns []kafka.Column
+	for _, col := range c.Columns {
+		if col.Meta == "" {
+			columns = append(columns, col.Column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	return kafka.NewCodec(c.Format, columns, c.SchemaID)
+}
+
+// GetFetchSize gets the maximum bytes of each fetch
+func (c *Config) GetFetchSize() int32 {
+	if c.FetchSize == 0 {
+		return defaultFetchSize
+	}
+	return c.FetchSize
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		wantErr bool
+	}{
+		{name: "1", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id"}]}`},
+		{name: "2", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"k","meta":"key"}],"format":"avro"}`},
+		{name: "3", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id","type":"bigInt"},{"name":"o","meta":"offset"}],
+			"format":"avro","startOffsets":{"0":10},"stopTime":"2026-10-19T08:00:00+08:00"}`},
+		{name: "4", conf: `{"topic":"orders","column":[{"name":"id"}]}`, wantErr: true},
+		{name: "5", conf: `{"brokers":["127.0.0.1:9092"],"column":[{"name":"id"}]}`, wantErr: true},
+		{name: "6", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders"}`, wantErr: true},
+		{name: "7", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id","meta":"headers"}]}`, wantErr: true},
+		{name: "8", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id"}],"format":"avro"}`, wantErr: true},
+		{name: "9", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id"}],"startTime":"2026-10-19"}`, wantErr: true},
+		{name: "10", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id"}],"stopTime":"now"}`, wantErr: true},
+		{name: "11", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id"}],"fetchSize":-1}`, wantErr: true},
+		{name: "12", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"meta":"key"}]}`, wantErr: true},
+		{name: "13", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id"}],"startOffsets":{"a":1}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(testJSONFromString(tt.conf))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Default(t *testing.T) {
+	c, err := NewConfig(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"k","meta":"key"}],
+		"startTime":"2026-10-19T08:00:00Z"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.GetFetchSize() != defaultFetchSize || !c.startTime.Equal(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)) || !c.stopTime.IsZero() {
+		t.Errorf("NewConfig() = %+v", c)
+	}
+	if codec, err := c.NewCodec(); codec != nil || err != nil {
+		t.Errorf("NewCodec() = %v, %v", codec, err)
+	}
+}
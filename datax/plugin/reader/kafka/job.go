@@ -0,0 +1,198 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/pingcap/errors"
+)
+
+// Job kafka reader job
+type Job struct {
+	*plugin.BaseJob
+
+	conf     *Config
+	client   client
+	strategy schedule.RetryStrategy
+}
+
+// NewJob creates a new kafka reader job
+func NewJob() *Job {
+	return &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+}
+
+// Init initializes the job
+func (j *Job) Init(ctx context.Context) (err error) {
+	if j.conf, err = NewConfig(j.PluginJobConf()); err != nil {
+		return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+	}
+	if j.strategy, err = schedule.NewRetryStrategy(j, j.PluginJobConf()); err != nil {
+		return errors.Wrapf(err, "NewRetryStrategy fail")
+	}
+	j.client, err = newClient(&j.conf.Config)
+	return errors.Wrapf(err, "NewClient fail")
+}
+
+// Destroy closes the connections to the brokers
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	if j.client != nil {
+		err = j.client.Close()
+	}
+	return errors.Wrapf(err, "Close fail")
+}
+
+// Split gets the offset ranges of the partitions from the start to the stop condition, which
+// are fixed when the job starts so that the job terminates, and assigns them to at most number
+// tasks, balancing the numbers of messages of the tasks
+func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
+	partitions := j.conf.Partitions
+	if len(partitions) == 0 {
+		if err = j.retry(ctx, func() (err error) {
+			partitions, err = j.client.Partitions(ctx, j.conf.Topic)
+			return
+		}); err != nil {
+			return nil, errors.Wrapf(err, "Partitions fail. topic: %v", j.conf.Topic)
+		}
+	}
+	if len(partitions) == 0 {
+		return nil, errors.Errorf("topic %v has no partitions", j.conf.Topic)
+	}
+
+	var assignments []Assignment
+	for _, p := range partitions {
+		var a Assignment
+		if a, err = j.assignment(ctx, p); err != nil {
+			return nil, errors.Wrapf(err, "get offsets of partition %v fail", p)
+		}
+		log.Infof("partition %v of topic %v is read from offset %v to %v", p, j.conf.Topic, a.Start, a.End)
+		assignments = append(assignments, a)
+	}
+
+	for _, tasks := range assign(assignments, number) {
+		conf := j.PluginJobConf().CloneConfig()
+		if err = conf.Set("assignments", tasks); err != nil {
+			return nil, errors.Wrapf(err, "Set fail")
+		}
+		configs = append(configs, conf)
+	}
+	return
+}
+
+// assignment gets the offset range of partition p, which is limited by the earliest
+// and latest offsets when the job starts
+func (j *Job) assignment(ctx context.Context, p int32) (a Assignment, err error) {
+	a.Partition = p
+	var earliest, latest int64
+	if earliest, err = j.listOffset(ctx, p, kafka.OffsetEarliest); err != nil {
+		return
+	}
+	if latest, err = j.listOffset(ctx, p, kafka.OffsetLatest); err != nil {
+		return
+	}
+
+	a.Start = earliest
+	if offset, ok := j.conf.StartOffsets[p]; ok {
+		a.Start = offset
+	} else if !j.conf.startTime.IsZero() {
+		if a.Start, err = j.listOffset(ctx, p, j.conf.startTime.UnixMilli()); err != nil {
+			return
+		}
+	}
+	a.End = latest
+	if offset, ok := j.conf.StopOffsets[p]; ok {
+		a.End = offset
+	} else if !j.conf.stopTime.IsZero() {
+		if a.End, err = j.listOffset(ctx, p, j.conf.stopTime.UnixMilli()); err != nil {
+			return
+		}
+	}
+
+	if a.Start < earliest {
+		log.Warnf("start offset %v of partition %v is before the earliest offset %v", a.Start, p, earliest)
+		a.Start = earliest
+	}
+	if a.End > latest {
+		log.Warnf("stop offset %v of partition %v is after the latest offset %v", a.End, p, latest)
+		a.End = latest
+	}
+	if a.Start > a.End {
+		a.Start = a.End
+	}
+	return
+}
+
+// listOffset gets the offset of timestamp, which is the latest offset
+// if there is no message from timestamp
+func (j *Job) listOffset(ctx context.Context, p int32, timestamp int64) (offset int64, err error) {
+	err = j.retry(ctx, func() (err error) {
+		offset, err = j.client.ListOffset(ctx, j.conf.Topic, p, timestamp)
+		return
+	})
+	if err == nil && offset < 0 {
+		return j.listOffset(ctx, p, kafka.OffsetLatest)
+	}
+	return
+}
+
+func (j *Job) retry(ctx context.Context, do func() error) error {
+	return schedule.NewRetryTask(ctx, j.strategy, schedule.TaskFunc(do)).Do()
+}
+
+// ShouldRetry retries the retriable errors of kafka and the network errors
+func (j *Job) ShouldRetry(err error) bool {
+	return kafka.IsRetriable(errors.Cause(err))
+}
+
+// assign assigns the offset ranges to at most number tasks, each range is assigned
+// to the task with the fewest messages in descending order of the numbers of messages
+func assign(assignments []Assignment, number int) (tasks [][]Assignment) {
+	if number > len(assignments) {
+		number = len(assignments)
+	}
+	if number < 1 {
+		number = 1
+	}
+	sorted := append([]Assignment(nil), assignments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].End-sorted[i].Start > sorted[j].End-sorted[j].Start
+	})
+
+	tasks = make([][]Assignment, number)
+	sizes := make([]int64, number)
+	for _, a := range sorted {
+		min := 0
+		for i := range sizes {
+			if sizes[i] < sizes[min] {
+				min = i
+			}
+		}
+		tasks[min] = append(tasks[min], a)
+		sizes[min] += a.End - a.Start
+	}
+	for _, t := range tasks {
+		sort.Slice(t, func(i, j int) bool {
+			return t[i].Partition < t[j].Partition
+		})
+	}
+	return
+}
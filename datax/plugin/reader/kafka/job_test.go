@@ -0,0 +1,234 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+var testStart = time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+// mockClient client of the topic orders, whose partition p has the messages {"id":i}
+// of key i at testStart+i seconds for i in [0, sizes[p]), and the messages are in
+// the batches of 100 messages of 10 bytes
+type mockClient struct {
+	sizes   []int
+	err     error // error of the next request
+	empty   bool  // fetch no message below the high watermark
+	fetches int
+}
+
+// testClient makes the job and the tasks use the mock client of the partitions of sizes
+func testClient(t *testing.T, sizes ...int) *mockClient {
+	m := &mockClient{sizes: sizes}
+	old := newClient
+	newClient = func(conf *kafka.Config) (client, error) {
+		return m, nil
+	}
+	t.Cleanup(func() { newClient = old })
+	return m
+}
+
+func (m *mockClient) check(topic string, partition int32) error {
+	if err := m.err; err != nil {
+		m.err = nil
+		return err
+	}
+	if topic != "orders" || partition < 0 || int(partition) >= len(m.sizes) {
+		return kafkago.UnknownTopicOrPartition
+	}
+	return nil
+}
+
+func (m *mockClient) Partitions(ctx context.Context, topic string) (partitions []int32, err error) {
+	if err = m.check(topic, 0); err != nil {
+		return
+	}
+	for p := range m.sizes {
+		partitions = append(partitions, int32(p))
+	}
+	return
+}
+
+func (m *mockClient) ListOffset(ctx context.Context, topic string, partition int32, timestamp int64) (int64, error) {
+	if err := m.check(topic, partition); err != nil {
+		return 0, err
+	}
+	n := int64(m.sizes[partition])
+	switch timestamp {
+	case kafka.OffsetEarliest:
+		return 0, nil
+	case kafka.OffsetLatest:
+		return n, nil
+	}
+	// the first message at or after timestamp
+	offset := (timestamp - testStart.UnixMilli() + 999) / 1000
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= n {
+		return -1, nil
+	}
+	return offset, nil
+}
+
+func (m *mockClient) Fetch(ctx context.Context, topic string, partition int32, offset int64, maxBytes int32) (*kafka.FetchResult, error) {
+	m.fetches++
+	if err := m.check(topic, partition); err != nil {
+		return nil, err
+	}
+	n := int64(m.sizes[partition])
+	res := &kafka.FetchResult{
+		NextOffset:    offset,
+		HighWatermark: n,
+	}
+	if m.empty {
+		return res, nil
+	}
+	// at least the batch of offset is fetched
+	end := offset
+	for end < n && (end == offset || (end/100*100+100-offset)*10 <= int64(maxBytes)) {
+		end = end/100*100 + 100
+	}
+	if end > n {
+		end = n
+	}
+	for i := offset; i < end; i++ {
+		res.Messages = append(res.Messages, kafka.Message{
+			Offset: i,
+			Key:    []byte(fmt.Sprint(i)),
+			Value:  []byte(fmt.Sprintf(`{"id":%v}`, i)),
+			Time:   time.UnixMilli(testStart.Add(time.Duration(i) * time.Second).UnixMilli()),
+		})
+	}
+	res.NextOffset = end
+	return res, nil
+}
+
+func (m *mockClient) Close() error {
+	return nil
+}
+
+func TestJob_Split(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		number  int
+		want    [][]Assignment
+		wantErr bool
+	}{
+		{
+			name:   "1",
+			number: 2,
+			want:   [][]Assignment{{{0, 0, 10}}, {{1, 0, 5}, {2, 0, 0}}},
+		},
+		{
+			name:   "2",
+			conf:   `"partitions":[0,1],"startOffsets":{"0":3,"1":-5},"stopOffsets":{"0":8,"1":100}`,
+			number: 4,
+			want:   [][]Assignment{{{0, 3, 8}}, {{1, 0, 5}}},
+		},
+		{
+			name:   "3",
+			conf:   `"startTime":"2026-10-19T08:00:02Z","stopTime":"2026-10-19T16:00:06+08:00"`,
+			number: 1,
+			want:   [][]Assignment{{{0, 2, 6}, {1, 2, 5}, {2, 0, 0}}},
+		},
+		{
+			name:   "4",
+			conf:   `"startTime":"2026-10-19T09:00:00Z","stopOffsets":{"0":4}`,
+			number: 1,
+			want:   [][]Assignment{{{0, 4, 4}, {1, 5, 5}, {2, 0, 0}}},
+		},
+		{
+			name:    "5",
+			conf:    `"topic":"unknown"`,
+			number:  1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient(t, 10, 5, 0)
+			conf := `{"brokers":["127.0.0.1:9092"],"topic":"orders","column":[{"name":"id"}]}`
+			if tt.conf != "" {
+				conf = conf[:len(conf)-1] + "," + tt.conf + "}"
+			}
+			job := NewJob()
+			job.SetPluginJobConf(testJSONFromString(conf))
+			if err := job.Init(context.Background()); err != nil {
+				t.Fatalf("Job.Init() error = %v", err)
+			}
+			defer job.Destroy(context.Background())
+			configs, err := job.Split(context.Background(), tt.number)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Job.Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got [][]Assignment
+			for _, c := range configs {
+				conf, err := NewConfig(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, conf.Assignments)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	tests := []struct {
+		name        string
+		assignments []Assignment
+		number      int
+		want        [][]Assignment
+	}{
+		{
+			name:        "1",
+			assignments: []Assignment{{0, 0, 10}, {1, 0, 6}, {2, 0, 5}, {3, 0, 4}},
+			number:      2,
+			want:        [][]Assignment{{{0, 0, 10}, {3, 0, 4}}, {{1, 0, 6}, {2, 0, 5}}},
+		},
+		{
+			name:        "2",
+			assignments: []Assignment{{0, 0, 1}},
+			number:      3,
+			want:        [][]Assignment{{{0, 0, 1}}},
+		},
+		{
+			name:        "3",
+			assignments: []Assignment{{0, 0, 1}, {1, 0, 1}},
+			number:      0,
+			want:        [][]Assignment{{{0, 0, 1}, {1, 0, 1}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := assign(tt.assignments, tt.number); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assign() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
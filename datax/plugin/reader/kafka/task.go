@@ -0,0 +1,190 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/pingcap/errors"
+)
+
+var (
+	emptyFetchWait  = 100 * time.Millisecond // wait before fetching again after an empty fetch, growing with the empty fetches
+	maxEmptyFetches = 10                     // number of the consecutive empty fetches below the high watermark to fail
+)
+
+// Task kafka reader task
+type Task struct {
+	*plugin.BaseTask
+
+	conf     *Config
+	client   client
+	codec    *kafka.Codec
+	strategy schedule.RetryStrategy
+}
+
+// NewTask creates a new kafka reader task
+func NewTask() *Task {
+	return &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+}
+
+// Init initializes the task
+func (t *Task) Init(ctx context.Context) (err error) {
+	if t.conf, err = NewConfig(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewConfig fail")
+	}
+	if t.codec, err = t.conf.NewCodec(); err != nil {
+		return t.Wrapf(err, "NewCodec fail")
+	}
+	if t.strategy, err = schedule.NewRetryStrategy(t, t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewRetryStrategy fail")
+	}
+	t.client, err = newClient(&t.conf.Config)
+	return t.Wrapf(err, "NewClient fail")
+}
+
+// Destroy closes the connections to the brokers
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.client != nil {
+		err = t.client.Close()
+	}
+	return t.Wrapf(err, "Close fail")
+}
+
+// StartRead reads the messages of the offset ranges assigned to the task and sends them as records
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	log.Infof(t.Format("startRead begin"))
+	defer func() {
+		sender.Terminate()
+		log.Infof(t.Format("startRead end"))
+	}()
+
+	for _, a := range t.conf.Assignments {
+		if err = t.read(ctx, sender, a); err != nil {
+			return t.Wrapf(err, "read partition %v fail", a.Partition)
+		}
+	}
+	return nil
+}
+
+// read fetches the messages of the partition from the start offset until the end offset,
+// and fails after maxEmptyFetches consecutive fetches get no message below the high watermark
+func (t *Task) read(ctx context.Context, sender plugin.RecordSender, a Assignment) (err error) {
+	empty := 0
+	for offset := a.Start; offset < a.End; {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		var res *kafka.FetchResult
+		err = schedule.NewRetryTask(ctx, t.strategy, schedule.TaskFunc(func() (err error) {
+			res, err = t.client.Fetch(ctx, t.conf.Topic, a.Partition, offset, t.conf.GetFetchSize())
+			return
+		})).Do()
+		if err != nil {
+			return errors.Wrapf(err, "Fetch fail. offset: %v", offset)
+		}
+
+		for _, m := range res.Messages {
+			if m.Offset >= a.End {
+				return nil
+			}
+			if err = t.send(sender, a.Partition, m); err != nil {
+				return err
+			}
+		}
+		if res.NextOffset <= offset {
+			if res.HighWatermark <= offset {
+				log.Warnf(t.Format("partition %v ends at %v before the end offset %v"), a.Partition, res.HighWatermark, a.End)
+				return nil
+			}
+			if empty++; empty >= maxEmptyFetches {
+				return errors.Errorf("no message is fetched from offset %v below the high watermark %v in %v fetches",
+					offset, res.HighWatermark, empty)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Duration(empty) * emptyFetchWait):
+			}
+			continue
+		}
+		empty = 0
+		offset = res.NextOffset
+	}
+	return nil
+}
+
+// send sends the record converted from the message m of the partition
+func (t *Task) send(sender plugin.RecordSender, partition int32, m kafka.Message) (err error) {
+	var values []element.Column
+	if t.codec != nil {
+		if values, err = t.codec.Decode(m.Value); err != nil {
+			return errors.Wrapf(err, "decode message fail. offset: %v", m.Offset)
+		}
+	}
+
+	var record element.Record
+	if record, err = sender.CreateRecord(); err != nil {
+		return errors.Wrapf(err, "CreateRecord fail")
+	}
+	for _, col := range t.conf.Columns {
+		var c element.Column
+		if col.Meta == "" {
+			if len(values) == 0 {
+				return errors.Errorf("message has fewer values than the columns. offset: %v", m.Offset)
+			}
+			c, values = values[0], values[1:]
+		} else {
+			c = metaColumn(col, partition, t.conf.Topic, m)
+		}
+		if err = record.Add(c); err != nil {
+			return errors.Wrapf(err, "Add fail")
+		}
+	}
+	return errors.Wrapf(sender.SendWriter(record), "SendWriter fail")
+}
+
+// metaColumn gets the column of the meta of the message
+func metaColumn(col Column, partition int32, topic string, m kafka.Message) element.Column {
+	switch col.Meta {
+	case MetaKey:
+		if m.Key == nil {
+			return element.NewDefaultColumn(element.NewNilStringColumnValue(), col.Name, 0)
+		}
+		return element.NewDefaultColumn(element.NewStringColumnValue(string(m.Key)), col.Name, len(m.Key))
+	case MetaTopic:
+		return element.NewDefaultColumn(element.NewStringColumnValue(topic), col.Name, len(topic))
+	case MetaPartition:
+		return element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(int64(partition)), col.Name, 4)
+	case MetaOffset:
+		return element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(m.Offset), col.Name, 8)
+	}
+	return element.NewDefaultColumn(element.NewTimeColumnValue(m.Time), col.Name, 8)
+}
+
+// ShouldRetry retries the retriable errors of kafka and the network errors
+func (t *Task) ShouldRetry(err error) bool {
+	return kafka.IsRetriable(errors.Cause(err))
+}
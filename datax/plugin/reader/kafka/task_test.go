@@ -0,0 +1,192 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type mockSender struct {
+	records []element.Record
+	sendErr error
+}
+
+func (m *mockSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), nil
+}
+
+func (m *mockSender) SendWriter(record element.Record) error {
+	m.records = append(m.records, record)
+	return m.sendErr
+}
+
+func (m *mockSender) Flush() error {
+	return nil
+}
+
+func (m *mockSender) Terminate() error {
+	return nil
+}
+
+func (m *mockSender) Shutdown() error {
+	return nil
+}
+
+func TestTask_StartRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		inject  error
+		sendErr error
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: `"column":[{"name":"id","type":"bigInt"},{"name":"p","meta":"partition"},{"name":"o","meta":"offset"},{"name":"k","meta":"key"}],
+				"assignments":[{"partition":0,"start":1,"end":3},{"partition":1,"start":4,"end":5}]`,
+			want: []string{"id=1 p=0 o=1 k=1", "id=2 p=0 o=2 k=2", "id=4 p=1 o=4 k=4"},
+		},
+		{
+			name: "2",
+			conf: `"column":[{"name":"t","meta":"timestamp"},{"name":"topic","meta":"topic"}],"assignments":[{"partition":0,"start":9,"end":10}]`,
+			want: []string{fmt.Sprintf("t=%v topic=orders", element.NewTimeColumnValue(testStart.Add(9*time.Second).Local()))},
+		},
+		{
+			name:   "3",
+			conf:   `"column":[{"name":"id"}],"assignments":[{"partition":1,"start":0,"end":1}],"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}`,
+			inject: kafkago.NotLeaderForPartition,
+			want:   []string{"id=0"},
+		},
+		{
+			name:    "4",
+			conf:    `"column":[{"name":"id"}],"assignments":[{"partition":1,"start":0,"end":1}]`,
+			inject:  kafkago.TopicAuthorizationFailed,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    `"column":[{"name":"id"}],"assignments":[{"partition":0,"start":0,"end":1}]`,
+			sendErr: errors.New("mock error"),
+			want:    []string{"id=0"},
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    `"format":"avro","column":[{"name":"id","type":"bigInt"}],"assignments":[{"partition":0,"start":0,"end":1}]`,
+			wantErr: true,
+		},
+		{
+			name: "7",
+			conf: `"column":[{"name":"id"}],"assignments":[{"partition":2,"start":0,"end":0}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testClient(t, 10, 5, 0)
+			m.err = tt.inject
+			task := NewTask()
+			task.SetPluginJobConf(testJSONFromString(fmt.Sprintf(`{"brokers":["127.0.0.1:9092"],"topic":"orders",%v}`, tt.conf)))
+			if err := task.Init(context.Background()); err != nil {
+				t.Fatalf("Task.Init() error = %v", err)
+			}
+			defer task.Destroy(context.Background())
+			sender := &mockSender{sendErr: tt.sendErr}
+			err := task.StartRead(context.Background(), sender)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, r := range sender.records {
+				got = append(got, r.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadBatches(t *testing.T) {
+	m := testClient(t, 250)
+	task := NewTask()
+	task.SetPluginJobConf(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"orders","fetchSize":1,
+		"column":[{"name":"o","meta":"offset"}],"assignments":[{"partition":0,"start":50,"end":230}]}`))
+	if err := task.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer task.Destroy(context.Background())
+	sender := &mockSender{}
+	if err := task.StartRead(context.Background(), sender); err != nil {
+		t.Fatalf("Task.StartRead() error = %v", err)
+	}
+	if len(sender.records) != 180 {
+		t.Fatalf("Task.StartRead() = %v records", len(sender.records))
+	}
+	for i, r := range sender.records {
+		if want := fmt.Sprintf("o=%v", 50+i); r.String() != want {
+			t.Fatalf("Task.StartRead() = %v, want %v", r, want)
+		}
+	}
+	if m.fetches != 3 {
+		t.Errorf("Fetch() is called %v times, want 3", m.fetches)
+	}
+}
+
+func TestTask_StartReadEmptyFetches(t *testing.T) {
+	old := emptyFetchWait
+	emptyFetchWait = time.Millisecond
+	t.Cleanup(func() { emptyFetchWait = old })
+
+	m := testClient(t, 10)
+	m.empty = true
+	task := NewTask()
+	task.SetPluginJobConf(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"orders",
+		"column":[{"name":"o","meta":"offset"}],"assignments":[{"partition":0,"start":0,"end":5}]}`))
+	if err := task.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer task.Destroy(context.Background())
+	if err := task.StartRead(context.Background(), &mockSender{}); err == nil {
+		t.Fatal("Task.StartRead() error = nil, wantErr true")
+	}
+	if m.fetches != maxEmptyFetches {
+		t.Errorf("Fetch() is called %v times, want %v", m.fetches, maxEmptyFetches)
+	}
+}
+
+func TestTask_sendFewerValues(t *testing.T) {
+	testClient(t, 10)
+	task := NewTask()
+	task.SetPluginJobConf(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"orders",
+		"column":[{"name":"id"}],"assignments":[{"partition":0,"start":0,"end":1}]}`))
+	if err := task.Init(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer task.Destroy(context.Background())
+	task.codec = nil
+	if err := task.send(&mockSender{}, 0, kafka.Message{Value: []byte(`{"id":1}`)}); err == nil {
+		t.Fatal("Task.send() error = nil, wantErr true")
+	}
+}
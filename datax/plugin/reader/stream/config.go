@@ -0,0 +1,135 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/vjeantet/jodaTime"
+)
+
+// generator types of columns
+const (
+	TypeConstant      = "constant"      // constant string
+	TypeSequence      = "sequence"      // integer sequence from start by step
+	TypeRandomInt     = "randomInt"     // random integer in [min, max]
+	TypeRandomDecimal = "randomDecimal" // random decimal in [min, max) with scale
+	TypeRandomString  = "randomString"  // random string of letters and digits with length
+	TypeRandomTime    = "randomTime"    // random time in [min, max) with format
+	TypeEnum          = "enum"          // random value of values
+)
+
+const defaultTimeFormat = "yyyy-MM-dd HH:mm:ss"
+
+// Config stream reader configuration
+type Config struct {
+	RecordCount int64    `json:"recordCount"` // Total number of records, divided across tasks
+	Seed        int64    `json:"seed"`        // Random seed for reproducible data, random data differs each time if 0
+	Offset      int64    `json:"offset"`      // Index of the first record of the task, set by the job
+	Columns     []Column `json:"column"`      // Column generators
+}
+
+// Column column generator configuration
+type Column struct {
+	Name      string   `json:"name"`      // Column name
+	Type      string   `json:"type"`      // Generator type
+	Value     string   `json:"value"`     // Value of constant
+	Start     int64    `json:"start"`     // Start of sequence
+	Step      int64    `json:"step"`      // Step of sequence, 1 if 0
+	Min       string   `json:"min"`       // Minimum of randomInt, randomDecimal and randomTime
+	Max       string   `json:"max"`       // Maximum of randomInt, randomDecimal and randomTime
+	Scale     int      `json:"scale"`     // Scale of randomDecimal
+	Length    int      `json:"length"`    // Length of randomString
+	Format    string   `json:"format"`    // Joda time format of min and max of randomTime, yyyy-MM-dd HH:mm:ss if empty
+	Values    []string `json:"values"`    // Values of enum
+	NullRatio float64  `json:"nullRatio"` // Ratio of null values in [0, 1]
+}
+
+// NewConfig gets the stream reader configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if c.RecordCount < 0 {
+		return nil, fmt.Errorf("recordCount should not be negative")
+	}
+	if len(c.Columns) == 0 {
+		return nil, fmt.Errorf("column is empty")
+	}
+	for i := range c.Columns {
+		if _, err = c.Columns[i].generator(); err != nil {
+			return nil, fmt.Errorf("column %v is not valid: %v", c.Columns[i].Name, err)
+		}
+	}
+	return
+}
+
+func (c *Column) step() int64 {
+	if c.Step == 0 {
+		return 1
+	}
+	return c.Step
+}
+
+func (c *Column) layout() string {
+	if c.Format == "" {
+		return jodaTime.GetLayout(defaultTimeFormat)
+	}
+	return jodaTime.GetLayout(c.Format)
+}
+
+func (c *Column) intRange() (min, max int64, err error) {
+	if min, err = strconv.ParseInt(c.Min, 10, 64); err != nil {
+		return
+	}
+	if max, err = strconv.ParseInt(c.Max, 10, 64); err != nil {
+		return
+	}
+	if min > max {
+		err = fmt.Errorf("min %v is greater than max %v", min, max)
+	}
+	return
+}
+
+func (c *Column) decimalRange() (min, max float64, err error) {
+	if min, err = strconv.ParseFloat(c.Min, 64); err != nil {
+		return
+	}
+	if max, err = strconv.ParseFloat(c.Max, 64); err != nil {
+		return
+	}
+	if min > max {
+		err = fmt.Errorf("min %v is greater than max %v", min, max)
+	}
+	return
+}
+
+func (c *Column) timeRange() (min, max time.Time, err error) {
+	if min, err = time.Parse(c.layout(), c.Min); err != nil {
+		return
+	}
+	if max, err = time.Parse(c.layout(), c.Max); err != nil {
+		return
+	}
+	if min.After(max) {
+		err = fmt.Errorf("min %v is after max %v", c.Min, c.Max)
+	}
+	return
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{"recordCount":10,"column":[
+				{"name":"a","type":"constant","value":"x"},
+				{"name":"b","type":"sequence","start":1,"step":2},
+				{"name":"c","type":"randomInt","min":"-1","max":"1","nullRatio":0.5},
+				{"name":"d","type":"randomDecimal","min":"0","max":"1","scale":2},
+				{"name":"e","type":"randomString","length":4},
+				{"name":"f","type":"randomTime","min":"2026-01-01","max":"2026-02-01","format":"yyyy-MM-dd"},
+				{"name":"g","type":"enum","values":["x","y"]}]}`),
+		},
+		{
+			name:    "2",
+			conf:    testJSONFromString(`{"recordCount":-1,"column":[{"name":"a","type":"constant"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"recordCount":1}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"unknown"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"randomInt","min":"2","max":"1"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"randomInt","min":"a","max":"1"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"randomDecimal","min":"0","max":"1","scale":-1}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"randomString"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"randomTime","min":"2026-02-01 00:00:00","max":"2026-01-01 00:00:00"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"enum"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "11",
+			conf:    testJSONFromString(`{"column":[{"name":"a","type":"constant","nullRatio":1.5}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "12",
+			conf:    testJSONFromString(`{"recordCount":"1","column":[{"name":"a","type":"constant"}]}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumn_generator(t *testing.T) {
+	tests := []struct {
+		name  string
+		c     *Column
+		check func(s string) bool
+	}{
+		{
+			name:  "1",
+			c:     &Column{Type: TypeConstant, Value: "abc"},
+			check: func(s string) bool { return s == "abc" },
+		},
+		{
+			name:  "2",
+			c:     &Column{Type: TypeRandomInt, Min: "5", Max: "6"},
+			check: func(s string) bool { return s == "5" || s == "6" },
+		},
+		{
+			name:  "3",
+			c:     &Column{Type: TypeRandomInt, Min: "-9223372036854775808", Max: "9223372036854775807"},
+			check: func(s string) bool { return s != "" },
+		},
+		{
+			name:  "4",
+			c:     &Column{Type: TypeRandomDecimal, Min: "1", Max: "2", Scale: 3},
+			check: func(s string) bool { return len(s) <= 5 && (s[0] == '1' || s == "2") },
+		},
+		{
+			name:  "5",
+			c:     &Column{Type: TypeRandomString, Length: 7},
+			check: func(s string) bool { return len(s) == 7 },
+		},
+		{
+			name:  "6",
+			c:     &Column{Type: TypeRandomTime, Min: "2026-10-18", Max: "2026-10-19", Format: "yyyy-MM-dd"},
+			check: func(s string) bool { return s >= "2026-10-18" && s < "2026-10-19" },
+		},
+		{
+			name:  "7",
+			c:     &Column{Type: TypeRandomTime, Min: "2026-10-18 00:00:00", Max: "2026-10-18 00:00:00"},
+			check: func(s string) bool { return s[:19] == "2026-10-18 00:00:00" },
+		},
+		{
+			name:  "8",
+			c:     &Column{Type: TypeEnum, Values: []string{"x", "y"}},
+			check: func(s string) bool { return s == "x" || s == "y" },
+		},
+		{
+			name:  "9",
+			c:     &Column{Type: TypeConstant, Value: "abc", NullRatio: 1},
+			check: func(s string) bool { return s == "<nil>" },
+		},
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := tt.c.generator()
+			if err != nil {
+				t.Fatalf("Column.generator() error = %v", err)
+			}
+			for n := int64(0); n < 100; n++ {
+				v, _, err := g(r, n)
+				if err != nil {
+					t.Fatalf("generate() error = %v", err)
+				}
+				if !tt.check(v.String()) {
+					t.Fatalf("generate() = %v", v.String())
+				}
+			}
+		})
+	}
+}
+
+func TestColumn_generatorSequence(t *testing.T) {
+	c := &Column{Type: TypeSequence, Start: 10, Step: -2}
+	g, err := c.generator()
+	if err != nil {
+		t.Fatalf("Column.generator() error = %v", err)
+	}
+	for n, want := range []string{"10", "8", "6"} {
+		v, _, _ := g(nil, int64(n))
+		if v.String() != want {
+			t.Errorf("generate() = %v, want %v", v.String(), want)
+		}
+	}
+}
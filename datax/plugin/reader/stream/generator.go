@@ -0,0 +1,142 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// numberSize is the byte size of integer and time values
+const numberSize = 8
+
+// generate generates the column value of the n-th record and its byte size
+type generate func(r *rand.Rand, n int64) (v element.ColumnValue, size int, err error)
+
+// generator creates the generator of the column
+func (c *Column) generator() (g generate, err error) {
+	var null func() element.ColumnValue
+	switch c.Type {
+	case TypeConstant:
+		v := c.Value
+		null = element.NewNilStringColumnValue
+		g = func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+			return element.NewStringColumnValue(v), len(v), nil
+		}
+	case TypeSequence:
+		start, step := c.Start, c.step()
+		null = element.NewNilBigIntColumnValue
+		g = func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+			return element.NewBigIntColumnValueFromInt64(start + n*step), numberSize, nil
+		}
+	case TypeRandomInt:
+		var min, max int64
+		if min, max, err = c.intRange(); err != nil {
+			return nil, err
+		}
+		null = element.NewNilBigIntColumnValue
+		g = func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+			return element.NewBigIntColumnValueFromInt64(randomInt(r, min, max)), numberSize, nil
+		}
+	case TypeRandomDecimal:
+		var min, max float64
+		if min, max, err = c.decimalRange(); err != nil {
+			return nil, err
+		}
+		if c.Scale < 0 {
+			return nil, fmt.Errorf("scale should not be negative")
+		}
+		scale := c.Scale
+		null = element.NewNilDecimalColumnValue
+		g = func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+			s := strconv.FormatFloat(min+r.Float64()*(max-min), 'f', scale, 64)
+			v, err := element.NewDecimalColumnValueFromString(s)
+			return v, len(s), err
+		}
+	case TypeRandomString:
+		if c.Length <= 0 {
+			return nil, fmt.Errorf("length should be positive")
+		}
+		length := c.Length
+		null = element.NewNilStringColumnValue
+		g = func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+			b := make([]byte, length)
+			for i := range b {
+				b[i] = letters[r.Intn(len(letters))]
+			}
+			return element.NewStringColumnValue(string(b)), length, nil
+		}
+	case TypeRandomTime:
+		var min, max time.Time
+		if min, max, err = c.timeRange(); err != nil {
+			return nil, err
+		}
+		d := int64(max.Sub(min))
+		null = element.NewNilTimeColumnValue
+		g = func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+			t := min
+			if d > 0 {
+				t = min.Add(time.Duration(r.Int63n(d)))
+			}
+			return element.NewTimeColumnValue(t), numberSize, nil
+		}
+	case TypeEnum:
+		if len(c.Values) == 0 {
+			return nil, fmt.Errorf("values is empty")
+		}
+		values := c.Values
+		null = element.NewNilStringColumnValue
+		g = func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+			v := values[r.Intn(len(values))]
+			return element.NewStringColumnValue(v), len(v), nil
+		}
+	default:
+		return nil, fmt.Errorf("type %v is not valid", c.Type)
+	}
+
+	if c.NullRatio < 0 || c.NullRatio > 1 {
+		return nil, fmt.Errorf("nullRatio should be in [0, 1]")
+	}
+	if c.NullRatio == 0 {
+		return g, nil
+	}
+	ratio, notNull := c.NullRatio, g
+	return func(r *rand.Rand, n int64) (element.ColumnValue, int, error) {
+		if r.Float64() < ratio {
+			return null(), 0, nil
+		}
+		return notNull(r, n)
+	}, nil
+}
+
+// randomInt gets a random integer in [min, max]
+func randomInt(r *rand.Rand, min, max int64) int64 {
+	if d := max - min; d >= 0 && d < math.MaxInt64 {
+		return min + r.Int63n(d+1)
+	}
+	// the range overflows int64
+	for {
+		if v := int64(r.Uint64()); v >= min && v <= max {
+			return v
+		}
+	}
+}
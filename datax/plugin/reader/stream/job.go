@@ -0,0 +1,76 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/pingcap/errors"
+)
+
+// Job stream reader job
+type Job struct {
+	*plugin.BaseJob
+
+	conf *Config
+}
+
+// NewJob creates a new stream reader job
+func NewJob() *Job {
+	return &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+}
+
+// Init initializes the job
+func (j *Job) Init(ctx context.Context) (err error) {
+	j.conf, err = NewConfig(j.PluginJobConf())
+	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+}
+
+// Destroy destroys the job
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+// Split divides recordCount into number tasks, each task generates the records from its offset,
+// so that the sequences do not overlap and the random seeds differ across tasks
+func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
+	if number < 1 {
+		number = 1
+	}
+	if j.conf.RecordCount > 0 && int64(number) > j.conf.RecordCount {
+		number = int(j.conf.RecordCount)
+	}
+
+	offset := int64(0)
+	for i := 0; i < number; i++ {
+		count := j.conf.RecordCount / int64(number)
+		if int64(i) < j.conf.RecordCount%int64(number) {
+			count++
+		}
+		conf := j.PluginJobConf().CloneConfig()
+		conf.Set("recordCount", count)
+		conf.Set("offset", offset)
+		if j.conf.Seed != 0 {
+			conf.Set("seed", j.conf.Seed+int64(i))
+		}
+		configs = append(configs, conf)
+		offset += count
+	}
+	return
+}
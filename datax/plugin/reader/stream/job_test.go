@@ -0,0 +1,84 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func TestJob_Split(t *testing.T) {
+	tests := []struct {
+		name        string
+		jobConf     *config.JSON
+		number      int
+		wantConfigs []*config.JSON
+	}{
+		{
+			name:    "1",
+			jobConf: testJSONFromString(`{"recordCount":5,"column":[{"name":"a","type":"sequence"}]}`),
+			number:  2,
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"recordCount":3,"column":[{"name":"a","type":"sequence"}],"offset":0}`),
+				testJSONFromString(`{"recordCount":2,"column":[{"name":"a","type":"sequence"}],"offset":3}`),
+			},
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(`{"recordCount":2,"seed":7,"column":[{"name":"a","type":"sequence"}]}`),
+			number:  4,
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"recordCount":1,"seed":7,"column":[{"name":"a","type":"sequence"}],"offset":0}`),
+				testJSONFromString(`{"recordCount":1,"seed":8,"column":[{"name":"a","type":"sequence"}],"offset":1}`),
+			},
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(`{"column":[{"name":"a","type":"sequence"}]}`),
+			number:  0,
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"column":[{"name":"a","type":"sequence"}],"recordCount":0,"offset":0}`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			defer j.Destroy(context.TODO())
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); err != nil {
+				t.Fatalf("Job.Init() error = %v", err)
+			}
+			gotConfigs, err := j.Split(context.TODO(), tt.number)
+			if err != nil {
+				t.Fatalf("Job.Split() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotConfigs, tt.wantConfigs) {
+				t.Errorf("Job.Split() = %v, want %v", gotConfigs, tt.wantConfigs)
+			}
+		})
+	}
+}
+
+func TestJob_Init(t *testing.T) {
+	j := NewJob()
+	j.SetPluginJobConf(testJSONFromString(`{"recordCount":1}`))
+	if err := j.Init(context.TODO()); err == nil {
+		t.Errorf("Job.Init() error = nil, wantErr true")
+	}
+}
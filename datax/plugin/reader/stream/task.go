@@ -0,0 +1,99 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+// Task stream reader task
+type Task struct {
+	*plugin.BaseTask
+
+	conf       *Config
+	generators []generate
+}
+
+// NewTask creates a new stream reader task
+func NewTask() *Task {
+	return &Task{
+		BaseTask: plugin.NewBaseTask(),
+	}
+}
+
+// Init initializes the task
+func (t *Task) Init(ctx context.Context) (err error) {
+	if t.conf, err = NewConfig(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewConfig fail")
+	}
+	for i := range t.conf.Columns {
+		var g generate
+		if g, err = t.conf.Columns[i].generator(); err != nil {
+			return t.Wrapf(err, "generator fail")
+		}
+		t.generators = append(t.generators, g)
+	}
+	return
+}
+
+// Destroy destroys the task
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+// StartRead generates recordCount records and sends them to the sender
+func (t *Task) StartRead(ctx context.Context, sender plugin.RecordSender) (err error) {
+	log.Infof(t.Format("startRead begin"))
+	defer func() {
+		sender.Terminate()
+		log.Infof(t.Format("startRead end"))
+	}()
+
+	seed := t.conf.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano() + t.conf.Offset
+	}
+	r := rand.New(rand.NewSource(seed))
+	for n := t.conf.Offset; n < t.conf.Offset+t.conf.RecordCount; n++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		var record element.Record
+		if record, err = sender.CreateRecord(); err != nil {
+			return t.Wrapf(err, "CreateRecord fail")
+		}
+		for i, g := range t.generators {
+			v, size, err := g(r, n)
+			if err != nil {
+				return t.Wrapf(err, "generate column %v fail", t.conf.Columns[i].Name)
+			}
+			if err = record.Add(element.NewDefaultColumn(v, t.conf.Columns[i].Name, size)); err != nil {
+				return t.Wrapf(err, "Add fail")
+			}
+		}
+		if err = sender.SendWriter(record); err != nil {
+			return t.Wrapf(err, "SendWriter fail")
+		}
+	}
+	return nil
+}
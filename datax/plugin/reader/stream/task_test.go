@@ -0,0 +1,143 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockSender struct {
+	records   []element.Record
+	createErr error
+	sendErr   error
+	cancel    func()
+}
+
+func (m *mockSender) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), m.createErr
+}
+
+func (m *mockSender) SendWriter(record element.Record) error {
+	m.records = append(m.records, record)
+	if m.cancel != nil {
+		m.cancel()
+	}
+	return m.sendErr
+}
+
+func (m *mockSender) Flush() error {
+	return nil
+}
+
+func (m *mockSender) Terminate() error {
+	return nil
+}
+
+func (m *mockSender) Shutdown() error {
+	return nil
+}
+
+func testRead(t *testing.T, conf *config.JSON, sender *mockSender) (records []string, err error) {
+	task := NewTask()
+	task.SetPluginJobConf(conf)
+	if err = task.Init(context.TODO()); err != nil {
+		return
+	}
+	defer task.Destroy(context.TODO())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if sender.cancel != nil {
+		sender.cancel = cancel
+	}
+	err = task.StartRead(ctx, sender)
+	for _, r := range sender.records {
+		records = append(records, r.String())
+	}
+	return
+}
+
+func TestTask_StartRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		sender  *mockSender
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "1",
+			conf:   testJSONFromString(`{"recordCount":2,"offset":3,"column":[{"name":"id","type":"sequence","start":1},{"name":"c","type":"constant","value":"x"}]}`),
+			sender: &mockSender{},
+			want:   []string{"id=4 c=x", "id=5 c=x"},
+		},
+		{
+			name:   "2",
+			conf:   testJSONFromString(`{"recordCount":2,"column":[{"name":"id","type":"sequence"}]}`),
+			sender: &mockSender{cancel: func() {}},
+			want:   []string{"id=0"},
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"recordCount":2,"column":[{"name":"id","type":"sequence"}]}`),
+			sender:  &mockSender{createErr: errors.New("mock error")},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"recordCount":2,"column":[{"name":"id","type":"sequence"}]}`),
+			sender:  &mockSender{sendErr: errors.New("mock error")},
+			want:    []string{"id=0"},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"recordCount":2,"column":[{"name":"id","type":"unknown"}]}`),
+			sender:  &mockSender{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testRead(t, tt.conf, tt.sender)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.StartRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartRead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartReadSeed(t *testing.T) {
+	conf := `{"recordCount":3,"seed":42,"column":[{"name":"s","type":"randomString","length":8},{"name":"i","type":"randomInt","min":"0","max":"1000"}]}`
+	first, err := testRead(t, testJSONFromString(conf), &mockSender{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := testRead(t, testJSONFromString(conf), &mockSender{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 3 || !reflect.DeepEqual(first, second) {
+		t.Errorf("records with the same seed = %v and %v", first, second)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 
 	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
 	// xlsx storage - Storage or handling of data in the XLSX format.
 	"github.com/Breeze0806/go-etl/storage/stream/file/xlsx"
 )
@@ -25,12 +26,13 @@ import (
 // Config xlsx input configuration - Configuration settings for reading or processing data from an XLSX file.
 type Config struct {
 	xlsx.InConfig
+	file.PathConfig
 	Xlsxs []Xlsx `json:"xlsxs"`
 }
 
 // Xlsx file configuration - Specifies the configuration details for an XLSX file, such as its location, format, or specific settings.
 type Xlsx struct {
-	Path   string   `json:"path"`
+	Path   string   `json:"path"` // File, directory or glob pattern
 	Sheets []string `json:"sheets"`
 }
 
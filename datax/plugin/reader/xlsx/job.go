@@ -39,22 +39,38 @@ func NewJob() *Job {
 
 // Init - Initializes or sets up the Job for execution
 func (j *Job) Init(ctx context.Context) (err error) {
-	j.conf, err = NewConfig(j.PluginJobConf())
-	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+	if j.conf, err = NewConfig(j.PluginJobConf()); err != nil {
+		return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+	}
+	return errors.Wrapf(j.ConfigureFileSystems(), "ConfigureFileSystems fail")
 }
 
 // Split - To divide or separate into smaller parts or segments
+// Each file found in the path of xlsxs is a task
 func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
 	for _, x := range j.conf.Xlsxs {
-		conf, _ := config.NewJSONFromString("{}")
-		conf.Set("path", x.Path)
+		var files []file.File
+		if files, err = j.Expand(j.conf.PathConfig, []string{x.Path}); err != nil {
+			return nil, errors.Wrapf(err, "Expand fail. path: %v", x.Path)
+		}
+		if len(files) == 0 {
+			return nil, errors.Errorf("no file is found. path: %v", x.Path)
+		}
+
+		for _, f := range files {
+			conf, _ := config.NewJSONFromString("{}")
+			conf.Set("path", f.Path)
+			if err = j.SetFileSystems(conf); err != nil {
+				return nil, errors.Wrapf(err, "SetFileSystems fail")
+			}
 
-		for i, v := range x.Sheets {
-			xlsxConfig := j.conf.InConfig
-			xlsxConfig.Sheet = v
-			conf.Set("content."+strconv.Itoa(i), xlsxConfig)
+			for i, v := range x.Sheets {
+				xlsxConfig := j.conf.InConfig
+				xlsxConfig.Sheet = v
+				conf.Set("content."+strconv.Itoa(i), xlsxConfig)
+			}
+			configs = append(configs, conf)
 		}
-		configs = append(configs, conf)
 	}
 	return
 }
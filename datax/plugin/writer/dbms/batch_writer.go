@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	spiwriter "github.com/Breeze0806/go-etl/datax/common/spi/writer"
 	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/schedule"
@@ -53,6 +54,8 @@ type BaseBatchWriter struct {
 	strategy schedule.RetryStrategy
 	judger   database.Judger
 	opts     *database.ParameterOptions
+	casts    map[int]*ColumnType
+	mapper   *spiwriter.RecordMapper
 }
 
 // NewBaseBatchWriter - Creates a new instance of the basic batch writer based on the task, execution mode, and transaction options.
@@ -76,6 +79,9 @@ func NewBaseBatchWriter(task *Task, execMode string, opts *sql.TxOptions) *BaseB
 		w.strategy = schedule.NewNoneRetryStrategy()
 	}
 
+	w.casts = newColumnCasts(task)
+	w.mapper = task.mapper
+
 	w.opts = &database.ParameterOptions{
 		Table:     task.Table,
 		Mode:      task.Config.GetWriteMode(),
@@ -110,8 +116,13 @@ func (b *BaseBatchWriter) BatchTimeout() time.Duration {
 }
 
 // BatchWrite - The process of writing data in batches.
+// Records failing to be mapped by columnMapping or cast by columnTypes are collected as dirty records and are not written.
 func (b *BaseBatchWriter) BatchWrite(ctx context.Context, records []element.Record) (err error) {
-	retry := schedule.NewRetryTask(ctx, b.strategy, newWriteTask(func() error {
+	if records = b.prepareRecords(records); len(records) == 0 {
+		return nil
+	}
+
+	retry := schedule.NewRetryTask(ctx, b.strategy, schedule.TaskFunc(func() error {
 		return b.batchWriteWithLog(ctx, records, "")
 	}))
 	err = retry.Do()
@@ -119,7 +130,7 @@ func (b *BaseBatchWriter) BatchWrite(ctx context.Context, records []element.Reco
 	if b.judger != nil {
 		if b.judger.ShouldOneByOne(err) {
 			for i := range records {
-				retry := schedule.NewRetryTask(ctx, b.strategy, newWriteTask(func() error {
+				retry := schedule.NewRetryTask(ctx, b.strategy, schedule.TaskFunc(func() error {
 					return b.batchWriteWithLog(ctx, []element.Record{records[i]}, "one by one")
 				}))
 				err = retry.Do()
@@ -135,6 +146,94 @@ func (b *BaseBatchWriter) BatchWrite(ctx context.Context, records []element.Reco
 	return err
 }
 
+// prepareRecords - Map the records by columnMapping and cast their columns by columnTypes, and return the records prepared successfully
+func (b *BaseBatchWriter) prepareRecords(records []element.Record) []element.Record {
+	if len(b.casts) == 0 && b.mapper == nil {
+		return records
+	}
+	prepared := make([]element.Record, 0, len(records))
+	for _, r := range records {
+		p, err := b.prepareRecord(r)
+		if err != nil {
+			log.Errorf("jobID: %v taskgroupID:%v taskID: %v dirty record %v. err: %v",
+				b.JobID(), b.TaskGroupID(), b.TaskID(), r, err)
+			if c := b.Task.TaskCollector(); c != nil {
+				c.CollectDirtyRecordWithError(r, err)
+			}
+			continue
+		}
+		prepared = append(prepared, p)
+	}
+	return prepared
+}
+
+func (b *BaseBatchWriter) prepareRecord(r element.Record) (element.Record, error) {
+	if b.mapper != nil {
+		mapped, err := b.mapper.Map(r)
+		if err != nil {
+			return nil, err
+		}
+		r = mapped
+	}
+	if err := b.castRecord(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// castRecord - Cast the columns of the record, which is not changed when any column fails to be cast
+func (b *BaseBatchWriter) castRecord(r element.Record) (err error) {
+	columns := make(map[int]element.Column, len(b.casts))
+	for i, ct := range b.casts {
+		var c element.Column
+		if c, err = r.GetByIndex(i); err != nil {
+			return err
+		}
+		if columns[i], err = ct.Cast(c); err != nil {
+			return err
+		}
+	}
+	for i, c := range columns {
+		if err = r.Set(i, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newColumnCasts - Map the indexes of the fields of the table to the types that the columns are cast to
+func newColumnCasts(task *Task) map[int]*ColumnType {
+	if task.Config == nil || task.Table == nil {
+		return nil
+	}
+	types := make(map[string]*ColumnType)
+	for _, v := range task.Config.GetColumnTypes() {
+		types[v.Name] = v
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	casts := make(map[int]*ColumnType)
+	for i, f := range task.Table.Fields() {
+		if ct, ok := types[f.Name()]; ok {
+			casts[i] = ct
+		}
+	}
+	return casts
+}
+
+// newRecordMapper - Create the mapper building records with the fields of the table in order by columnMapping, nil if no mapping
+func newRecordMapper(conf Config, table database.Table) (*spiwriter.RecordMapper, error) {
+	if len(conf.GetColumnMappings()) == 0 {
+		return nil, nil
+	}
+	var targets []string
+	for _, f := range table.Fields() {
+		targets = append(targets, f.Name())
+	}
+	return spiwriter.NewRecordMapper(conf.GetColumnMappings(), targets)
+}
+
 func (b *BaseBatchWriter) batchWrite(ctx context.Context, records []element.Record) error {
 	b.opts.Records = records
 	defer func() {
@@ -159,20 +258,6 @@ func (b *BaseBatchWriter) batchWriteWithLog(ctx context.Context, records []eleme
 	return
 }
 
-type writeTask struct {
-	do func() error
-}
-
-func newWriteTask(do func() error) *writeTask {
-	return &writeTask{
-		do: do,
-	}
-}
-
-func (t *writeTask) Do() error {
-	return t.do()
-}
-
 // StartWrite - Begins the process of writing records to the database using the batch writer and record receiver.
 func StartWrite(ctx context.Context, w BatchWriter,
 	receiver plugin.RecordReceiver) (err error) {
@@ -17,6 +17,7 @@ package dbms
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -335,3 +336,180 @@ func TestBaseBatchWriter_BatchWrite(t *testing.T) {
 		})
 	}
 }
+
+type mockCollector struct {
+	dirty []string
+}
+
+func (m *mockCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	m.dirty = append(m.dirty, record.String())
+}
+
+func (m *mockCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {}
+
+func (m *mockCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {}
+
+func (m *mockCollector) CollectMessage(key string, value string) {}
+
+func testCastRecord(id string, flag string) element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue(id), "id", 0))
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue(flag), "flag", 0))
+	return r
+}
+
+func TestBaseBatchWriter_prepareRecords(t *testing.T) {
+	table := NewMockTable(database.NewBaseTable("instance", "schema", "table"))
+	table.AddField(database.NewBaseField(0, "id", NewMockFieldType(database.GoTypeInt64)))
+	table.AddField(database.NewBaseField(1, "flag", NewMockFieldType(database.GoTypeBool)))
+	collector := &mockCollector{}
+	task := &Task{
+		BaseTask: spiwriter.NewBaseTask(),
+		Execer:   &MockExecer{},
+		Table:    table,
+		Config: testBaseConfig(testJSONFromString(`{"column":["id","flag"],"columnTypes":[
+			{"name":"id","type":"bigint"},
+			{"name":"flag","type":"bool","trueValues":["Y"],"falseValues":["N"]}]}`)),
+	}
+	task.SetTaskCollector(collector)
+	b := NewBaseBatchWriter(task, "", nil)
+
+	got := b.prepareRecords([]element.Record{
+		testCastRecord("1", "Y"),
+		testCastRecord("x", "N"),
+		testCastRecord("3", "?"),
+		testCastRecord("4", "N"),
+	})
+	if len(got) != 2 {
+		t.Fatalf("prepareRecords() = %v, want 2 records", got)
+	}
+	for i, want := range []struct {
+		id   string
+		flag string
+	}{
+		{"1", "true"},
+		{"4", "false"},
+	} {
+		id, _ := got[i].GetByIndex(0)
+		flag, _ := got[i].GetByIndex(1)
+		if id.Type() != element.TypeBigInt || id.String() != want.id {
+			t.Errorf("prepareRecords()[%v] id = %v(%v), want %v", i, id, id.Type(), want.id)
+		}
+		if flag.Type() != element.TypeBool || flag.String() != want.flag {
+			t.Errorf("prepareRecords()[%v] flag = %v(%v), want %v", i, flag, flag.Type(), want.flag)
+		}
+	}
+	if len(collector.dirty) != 2 {
+		t.Errorf("dirty = %v, want 2 records", collector.dirty)
+	}
+
+	if err := b.BatchWrite(context.TODO(), []element.Record{testCastRecord("x", "Y")}); err != nil {
+		t.Errorf("BatchWrite() error = %v", err)
+	}
+	if len(collector.dirty) != 3 {
+		t.Errorf("dirty = %v, want 3 records", collector.dirty)
+	}
+}
+
+func TestBaseBatchWriter_prepareRecordsWithMapping(t *testing.T) {
+	table := NewMockTable(database.NewBaseTable("instance", "schema", "table"))
+	table.AddField(database.NewBaseField(0, "id", NewMockFieldType(database.GoTypeInt64)))
+	table.AddField(database.NewBaseField(1, "name", NewMockFieldType(database.GoTypeString)))
+	table.AddField(database.NewBaseField(2, "status", NewMockFieldType(database.GoTypeString)))
+	conf := testBaseConfig(testJSONFromString(`{"column":["id","name","status"],
+		"columnMapping":[
+			{"source":"user_id","target":"id"},
+			{"source":"nickname","target":"name","default":"anonymous"},
+			{"target":"status","value":"active"}],
+		"columnTypes":[{"name":"id","type":"bigint"}]}`))
+	mapper, err := newRecordMapper(conf, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collector := &mockCollector{}
+	task := &Task{
+		BaseTask: spiwriter.NewBaseTask(),
+		Execer:   &MockExecer{},
+		Table:    table,
+		Config:   conf,
+		mapper:   mapper,
+	}
+	task.SetTaskCollector(collector)
+	b := NewBaseBatchWriter(task, "", nil)
+
+	newRecord := func(columns ...element.Column) element.Record {
+		r := element.NewDefaultRecord()
+		for _, c := range columns {
+			r.Add(c)
+		}
+		return r
+	}
+	got := b.prepareRecords([]element.Record{
+		newRecord(element.NewDefaultColumn(element.NewStringColumnValue("alice"), "nickname", 0),
+			element.NewDefaultColumn(element.NewStringColumnValue("1"), "user_id", 0)),
+		newRecord(element.NewDefaultColumn(element.NewStringColumnValue("2"), "user_id", 0),
+			element.NewDefaultColumn(element.NewNilStringColumnValue(), "nickname", 0)),
+		newRecord(element.NewDefaultColumn(element.NewStringColumnValue("3"), "uid", 0)),
+	})
+	want := []string{
+		"id=1 name=alice status=active",
+		"id=2 name=anonymous status=active",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("prepareRecords() = %v, want %v", got, want)
+	}
+	for i, r := range got {
+		var s []string
+		for j := 0; j < r.ColumnNumber(); j++ {
+			c, _ := r.GetByIndex(j)
+			s = append(s, c.Name()+"="+c.String())
+		}
+		if strings.Join(s, " ") != want[i] {
+			t.Errorf("prepareRecords()[%v] = %v, want %v", i, strings.Join(s, " "), want[i])
+		}
+	}
+	if c, _ := got[0].GetByIndex(0); c.Type() != element.TypeBigInt {
+		t.Errorf("prepareRecords()[0] id type = %v, want %v", c.Type(), element.TypeBigInt)
+	}
+	if len(collector.dirty) != 1 {
+		t.Errorf("dirty = %v, want 1 record", collector.dirty)
+	}
+}
+
+func TestNewRecordMapper(t *testing.T) {
+	table := NewMockTable(database.NewBaseTable("instance", "schema", "table"))
+	table.AddField(database.NewBaseField(0, "id", NewMockFieldType(database.GoTypeInt64)))
+	tests := []struct {
+		name       string
+		conf       Config
+		wantMapper bool
+		wantErr    bool
+	}{
+		{
+			name: "1",
+			conf: testBaseConfig(testJSONFromString(`{"column":["*"]}`)),
+		},
+		{
+			name:       "2",
+			conf:       testBaseConfig(testJSONFromString(`{"column":["*"],"columnMapping":[{"source":"uid","target":"id"}]}`)),
+			wantMapper: true,
+		},
+		{
+			name:    "3",
+			conf:    testBaseConfig(testJSONFromString(`{"column":["*"],"columnMapping":[{"source":"uid","target":"user_id"}]}`)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newRecordMapper(tt.conf, table)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newRecordMapper() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got != nil) != tt.wantMapper {
+				t.Errorf("newRecordMapper() = %v, wantMapper %v", got, tt.wantMapper)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/cockroachdb/apd/v3"
+)
+
+// Target types of column casts
+const (
+	CastTypeBool    = "bool"    // Boolean
+	CastTypeBigInt  = "bigint"  // Integer
+	CastTypeDecimal = "decimal" // High-precision Real Number
+	CastTypeString  = "string"  // String
+	CastTypeTime    = "time"    // Time
+	CastTypeJSON    = "json"    // JSON
+	CastTypeBytes   = "bytes"   // Byte Stream
+)
+
+// Units of epoch integers cast to time
+const (
+	EpochUnitSecond      = "s"  // Seconds
+	EpochUnitMillisecond = "ms" // Milliseconds
+	EpochUnitMicrosecond = "us" // Microseconds
+	EpochUnitNanosecond  = "ns" // Nanoseconds
+)
+
+// ColumnType - The type that an incoming column is cast to before it is written, which is matched with the column of the writer by name
+type ColumnType struct {
+	Name        string   `json:"name"`        // Column Name of the Writer
+	Type        string   `json:"type"`        // Target Type: bool, bigint, decimal, string, time, json or bytes
+	Scale       *int     `json:"scale"`       // Number of Digits after the Decimal Point of decimal, rounded half up, not rounded if empty
+	Layout      string   `json:"layout"`      // Go Layout of Time Strings, parsed for time and formatted for string
+	Unit        string   `json:"unit"`        // Unit of Epoch Integers for time: s, ms, us or ns
+	TrueValues  []string `json:"trueValues"`  // Strings Treated as True for bool
+	FalseValues []string `json:"falseValues"` // Strings Treated as False for bool
+
+	loc *time.Location
+}
+
+// validate - Check whether the column type is valid
+func (c *ColumnType) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	switch c.Type {
+	case CastTypeBool, CastTypeBigInt, CastTypeString, CastTypeJSON, CastTypeBytes:
+	case CastTypeDecimal:
+		if c.Scale != nil && *c.Scale < 0 {
+			return fmt.Errorf("scale(%v) is negative", *c.Scale)
+		}
+	case CastTypeTime:
+		switch c.Unit {
+		case "", EpochUnitSecond, EpochUnitMillisecond, EpochUnitMicrosecond, EpochUnitNanosecond:
+		default:
+			return fmt.Errorf("unit(%v) is not one of s, ms, us and ns", c.Unit)
+		}
+		if c.Unit != "" && c.Layout != "" {
+			return fmt.Errorf("unit and layout are both set")
+		}
+	default:
+		return fmt.Errorf("type(%v) is not supported", c.Type)
+	}
+	return nil
+}
+
+// Cast - Cast the column to the target type. Null values are kept as null values of the target type
+func (c *ColumnType) Cast(col element.Column) (element.Column, error) {
+	var cv element.ColumnValue
+	var err error
+	switch c.Type {
+	case CastTypeBool:
+		cv, err = c.castBool(col)
+	case CastTypeBigInt:
+		cv, err = c.castBigInt(col)
+	case CastTypeDecimal:
+		cv, err = c.castDecimal(col)
+	case CastTypeString:
+		cv, err = c.castString(col)
+	case CastTypeTime:
+		cv, err = c.castTime(col)
+	case CastTypeJSON:
+		cv, err = c.castJSON(col)
+	case CastTypeBytes:
+		cv, err = c.castBytes(col)
+	default:
+		return nil, fmt.Errorf("type(%v) is not supported", c.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cast column(%v) to %v fail. err: %v", c.Name, c.Type, err)
+	}
+	return element.NewDefaultColumn(cv, col.Name(), int(col.ByteSize())), nil
+}
+
+func (c *ColumnType) castBool(col element.Column) (element.ColumnValue, error) {
+	if col.IsNil() {
+		return element.NewNilBoolColumnValue(), nil
+	}
+	if len(c.TrueValues) > 0 || len(c.FalseValues) > 0 {
+		s, err := col.AsString()
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range c.TrueValues {
+			if s == v {
+				return element.NewBoolColumnValue(true), nil
+			}
+		}
+		for _, v := range c.FalseValues {
+			if s == v {
+				return element.NewBoolColumnValue(false), nil
+			}
+		}
+		return nil, fmt.Errorf("%v is neither in trueValues nor in falseValues", s)
+	}
+	b, err := col.AsBool()
+	if err != nil {
+		return nil, err
+	}
+	return element.NewBoolColumnValue(b), nil
+}
+
+func (c *ColumnType) castBigInt(col element.Column) (element.ColumnValue, error) {
+	if col.IsNil() {
+		return element.NewNilBigIntColumnValue(), nil
+	}
+	b, err := col.AsBigInt()
+	if err != nil {
+		return nil, err
+	}
+	return element.NewBigIntColumnValue(b.AsBigInt()), nil
+}
+
+func (c *ColumnType) castDecimal(col element.Column) (element.ColumnValue, error) {
+	if col.IsNil() {
+		return element.NewNilDecimalColumnValue(), nil
+	}
+	d, err := col.AsDecimal()
+	if err != nil {
+		return nil, err
+	}
+	if c.Scale == nil {
+		return element.NewDecimalColumnValue(d.AsDecimal()), nil
+	}
+	x := d.AsDecimal()
+	ctx := apd.BaseContext.WithPrecision(uint32(x.NumDigits()) + uint32(*c.Scale) + 1)
+	ctx.Rounding = apd.RoundHalfUp
+	var r apd.Decimal
+	if _, err = ctx.Quantize(&r, x, -int32(*c.Scale)); err != nil {
+		return nil, err
+	}
+	return element.NewDecimalColumnValue(&r), nil
+}
+
+func (c *ColumnType) castString(col element.Column) (element.ColumnValue, error) {
+	if col.IsNil() {
+		return element.NewNilStringColumnValue(), nil
+	}
+	if c.Layout != "" && col.Type() == element.TypeTime {
+		t, err := col.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewStringColumnValue(t.Format(c.Layout)), nil
+	}
+	s, err := col.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return element.NewStringColumnValue(s), nil
+}
+
+func (c *ColumnType) castTime(col element.Column) (element.ColumnValue, error) {
+	if col.IsNil() {
+		return element.NewNilTimeColumnValue(), nil
+	}
+	switch {
+	case c.Unit != "":
+		i, err := col.AsInt64()
+		if err != nil {
+			return nil, err
+		}
+		var t time.Time
+		switch c.Unit {
+		case EpochUnitSecond:
+			t = time.Unix(i, 0)
+		case EpochUnitMillisecond:
+			t = time.UnixMilli(i)
+		case EpochUnitMicrosecond:
+			t = time.UnixMicro(i)
+		default:
+			t = time.Unix(0, i)
+		}
+		return element.NewTimeColumnValue(t.In(c.location())), nil
+	case c.Layout != "":
+		s, err := col.AsString()
+		if err != nil {
+			return nil, err
+		}
+		t, err := time.ParseInLocation(c.Layout, s, c.location())
+		if err != nil {
+			return nil, err
+		}
+		return element.NewTimeColumnValue(t), nil
+	}
+	t, err := col.AsTime()
+	if err != nil {
+		return nil, err
+	}
+	return element.NewTimeColumnValue(t), nil
+}
+
+func (c *ColumnType) castJSON(col element.Column) (element.ColumnValue, error) {
+	if col.IsNil() {
+		return element.NewNilJsonColumnValue(), nil
+	}
+	j, err := col.AsJSON()
+	if err != nil {
+		return nil, err
+	}
+	return element.NewJsonColumnValueFromBytes(j.ToBytes())
+}
+
+func (c *ColumnType) castBytes(col element.Column) (element.ColumnValue, error) {
+	if col.IsNil() {
+		return element.NewNilBytesColumnValue(), nil
+	}
+	b, err := col.AsBytes()
+	if err != nil {
+		return nil, err
+	}
+	return element.NewBytesColumnValue(b), nil
+}
+
+// location - The time zone of epoch integers and time strings, UTC by default
+func (c *ColumnType) location() *time.Location {
+	if c.loc == nil {
+		return time.UTC
+	}
+	return c.loc
+}
@@ -0,0 +1,212 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testIntPtr(i int) *int {
+	return &i
+}
+
+func testColumn(cv element.ColumnValue) element.Column {
+	return element.NewDefaultColumn(cv, "a", 0)
+}
+
+func testStringColumn(s string) element.Column {
+	return testColumn(element.NewStringColumnValue(s))
+}
+
+func TestColumnType_Cast(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		c        *ColumnType
+		col      element.Column
+		wantType element.ColumnType
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			c:        &ColumnType{Name: "a", Type: CastTypeBool, TrueValues: []string{"Y"}, FalseValues: []string{"N"}},
+			col:      testStringColumn("Y"),
+			wantType: element.TypeBool,
+			want:     "true",
+		},
+		{
+			name:     "2",
+			c:        &ColumnType{Name: "a", Type: CastTypeBool, TrueValues: []string{"Y"}, FalseValues: []string{"N"}},
+			col:      testStringColumn("N"),
+			wantType: element.TypeBool,
+			want:     "false",
+		},
+		{
+			name:    "3",
+			c:       &ColumnType{Name: "a", Type: CastTypeBool, TrueValues: []string{"Y"}, FalseValues: []string{"N"}},
+			col:     testStringColumn("y"),
+			wantErr: true,
+		},
+		{
+			name:     "4",
+			c:        &ColumnType{Name: "a", Type: CastTypeBool},
+			col:      testColumn(element.NewBigIntColumnValueFromInt64(1)),
+			wantType: element.TypeBool,
+			want:     "true",
+		},
+		{
+			name:     "5",
+			c:        &ColumnType{Name: "a", Type: CastTypeBigInt},
+			col:      testStringColumn("123"),
+			wantType: element.TypeBigInt,
+			want:     "123",
+		},
+		{
+			name:    "6",
+			c:       &ColumnType{Name: "a", Type: CastTypeBigInt},
+			col:     testStringColumn("abc"),
+			wantErr: true,
+		},
+		{
+			name:     "7",
+			c:        &ColumnType{Name: "a", Type: CastTypeDecimal, Scale: testIntPtr(2)},
+			col:      testStringColumn("123.455"),
+			wantType: element.TypeDecimal,
+			want:     "123.46",
+		},
+		{
+			name:     "8",
+			c:        &ColumnType{Name: "a", Type: CastTypeDecimal, Scale: testIntPtr(0)},
+			col:      testStringColumn("-123.5"),
+			wantType: element.TypeDecimal,
+			want:     "-124",
+		},
+		{
+			name:     "9",
+			c:        &ColumnType{Name: "a", Type: CastTypeDecimal},
+			col:      testStringColumn("123.455"),
+			wantType: element.TypeDecimal,
+			want:     "123.455",
+		},
+		{
+			name:    "10",
+			c:       &ColumnType{Name: "a", Type: CastTypeDecimal, Scale: testIntPtr(2)},
+			col:     testStringColumn("1.2.3"),
+			wantErr: true,
+		},
+		{
+			name:     "11",
+			c:        &ColumnType{Name: "a", Type: CastTypeTime, Unit: EpochUnitMillisecond},
+			col:      testColumn(element.NewBigIntColumnValueFromInt64(1700000000123)),
+			wantType: element.TypeTime,
+			want:     "2023-11-14 22:13:20.123Z",
+		},
+		{
+			name:     "12",
+			c:        &ColumnType{Name: "a", Type: CastTypeTime, Unit: EpochUnitSecond, loc: shanghai},
+			col:      testColumn(element.NewBigIntColumnValueFromInt64(1700000000)),
+			wantType: element.TypeTime,
+			want:     "2023-11-15 06:13:20+08:00",
+		},
+		{
+			name:     "13",
+			c:        &ColumnType{Name: "a", Type: CastTypeTime, Layout: "20060102150405"},
+			col:      testStringColumn("20231114221320"),
+			wantType: element.TypeTime,
+			want:     "2023-11-14 22:13:20Z",
+		},
+		{
+			name:    "14",
+			c:       &ColumnType{Name: "a", Type: CastTypeTime, Layout: "20060102150405"},
+			col:     testStringColumn("2023-11-14"),
+			wantErr: true,
+		},
+		{
+			name:     "15",
+			c:        &ColumnType{Name: "a", Type: CastTypeString, Layout: "2006/01/02"},
+			col:      testColumn(element.NewTimeColumnValue(time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC))),
+			wantType: element.TypeString,
+			want:     "2023/11/14",
+		},
+		{
+			name:     "16",
+			c:        &ColumnType{Name: "a", Type: CastTypeString},
+			col:      testColumn(element.NewBigIntColumnValueFromInt64(123)),
+			wantType: element.TypeString,
+			want:     "123",
+		},
+		{
+			name:     "17",
+			c:        &ColumnType{Name: "a", Type: CastTypeJSON},
+			col:      testStringColumn(`{"a":1}`),
+			wantType: element.TypeJSON,
+			want:     `{"a":1}`,
+		},
+		{
+			name:    "18",
+			c:       &ColumnType{Name: "a", Type: CastTypeJSON},
+			col:     testStringColumn(`{"a":`),
+			wantErr: true,
+		},
+		{
+			name:     "19",
+			c:        &ColumnType{Name: "a", Type: CastTypeBytes},
+			col:      testStringColumn("abc"),
+			wantType: element.TypeBytes,
+			want:     "abc",
+		},
+		{
+			name:     "20",
+			c:        &ColumnType{Name: "a", Type: CastTypeDecimal, Scale: testIntPtr(2)},
+			col:      testColumn(element.NewNilStringColumnValue()),
+			wantType: element.TypeDecimal,
+			want:     "<nil>",
+		},
+		{
+			name:    "21",
+			c:       &ColumnType{Name: "a", Type: "integer"},
+			col:     testStringColumn("1"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Cast(tt.col)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ColumnType.Cast() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type() != tt.wantType {
+				t.Errorf("ColumnType.Cast() type = %v, want %v", got.Type(), tt.wantType)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ColumnType.Cast() = %v, want %v", got.String(), tt.want)
+			}
+			if got.Name() != tt.col.Name() {
+				t.Errorf("ColumnType.Cast() name = %v, want %v", got.Name(), tt.col.Name())
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	spiwriter "github.com/Breeze0806/go-etl/datax/common/spi/writer"
 	dbmsreader "github.com/Breeze0806/go-etl/datax/plugin/reader/dbms"
 	"github.com/Breeze0806/go-etl/schedule"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -48,21 +49,31 @@ type Config interface {
 	IgnoreOneByOneError() bool                                               // Ignore Individual Retry Errors
 	GetPreSQL() []string                                                     // Get Prepared SQL Statement
 	GetPostSQL() []string                                                    // Get Ending SQL Statement
+	GetColumnTypes() []*ColumnType                                           // Get Types that Columns are Cast to
+	GetColumnMappings() spiwriter.ColumnMappings                             // Get Mappings from Reader Columns to Writer Columns
+	GetColumnFilter() dbmsreader.ColumnFilter                                // Get Filter of Columns Expanded from *
+	GetSchemaDrift() SchemaDriftConfig                                       // Get Check of Schema Drift between Reader and Target Table
 }
 
 // BaseConfig - Basic Relational Database Configuration for writers. Unless there are special requirements, this configuration can be used to quickly implement writers.
 type BaseConfig struct {
-	Username            string                `json:"username"`     // Username
-	Password            string                `json:"password"`     // Password
-	Column              []string              `json:"column"`       // Column Information
-	Connection          dbmsreader.ConnConfig `json:"connection"`   // Connection Information
-	WriteMode           string                `json:"writeMode"`    // Write Mode, e.g., Insert
-	BatchSize           int                   `json:"batchSize"`    // Batch Size for Single Write
-	BatchTimeout        time2.Duration        `json:"batchTimeout"` // Batch Timeout for Single Write
-	PreSQL              []string              `json:"preSQL"`       // Prepared SQL Statement
-	PostSQL             []string              `json:"postSQL"`      // Ending SQL Statement
-	ignoreOneByOneError bool                  // Ignore Individual Retry Errors
+	Username            string                   `json:"username"`      // Username
+	Password            string                   `json:"password"`      // Password
+	Column              []string                 `json:"column"`        // Column Information
+	Connection          dbmsreader.ConnConfig    `json:"connection"`    // Connection Information
+	WriteMode           string                   `json:"writeMode"`     // Write Mode, e.g., Insert
+	BatchSize           int                      `json:"batchSize"`     // Batch Size for Single Write
+	BatchTimeout        time2.Duration           `json:"batchTimeout"`  // Batch Timeout for Single Write
+	PreSQL              []string                 `json:"preSQL"`        // Prepared SQL Statement
+	PostSQL             []string                 `json:"postSQL"`       // Ending SQL Statement
+	Timezone            string                   `json:"timezone"`      // Time Zone of Time Types without Time Zone
+	ColumnTypes         []*ColumnType            `json:"columnTypes"`   // Types that Columns are Cast to before Writing
+	ColumnMapping       spiwriter.ColumnMappings `json:"columnMapping"` // Mappings from Reader Columns to Writer Columns by Name
+	SchemaDrift         SchemaDriftConfig        `json:"schemaDrift"`   // Check of Schema Drift between Reader and Target Table in Preparation
+	ignoreOneByOneError bool                     // Ignore Individual Retry Errors
 	newRetryStrategy    func(j schedule.RetryJudger) (schedule.RetryStrategy, error)
+
+	dbmsreader.ColumnFilter
 }
 
 // NewBaseConfig - Extract relational database configuration from the configuration file.
@@ -90,6 +101,27 @@ func NewBaseConfig(conf *config.JSON) (c *BaseConfig, err error) {
 	if err = checkHasSelect(c.PostSQL); err != nil {
 		return nil, fmt.Errorf("check postSQL fail. error: %v", err)
 	}
+
+	var loc *time.Location
+	if loc, err = time.LoadLocation(c.Timezone); err != nil {
+		return nil, fmt.Errorf("timezone(%v) is not valid. error: %v", c.Timezone, err)
+	}
+
+	if err = c.checkColumnTypes(loc); err != nil {
+		return nil, fmt.Errorf("check columnTypes fail. error: %v", err)
+	}
+
+	if err = c.checkColumnMapping(); err != nil {
+		return nil, fmt.Errorf("check columnMapping fail. error: %v", err)
+	}
+
+	if err = c.ColumnFilter.Validate(c.GetColumns()); err != nil {
+		return nil, err
+	}
+
+	if err = c.SchemaDrift.validate(); err != nil {
+		return nil, fmt.Errorf("check schemaDrift fail. error: %v", err)
+	}
 	return
 }
 
@@ -164,6 +196,26 @@ func (b *BaseConfig) IgnoreOneByOneError() bool {
 	return b.ignoreOneByOneError
 }
 
+// GetColumnTypes - Retrieve the types that columns are cast to.
+func (b *BaseConfig) GetColumnTypes() []*ColumnType {
+	return b.ColumnTypes
+}
+
+// GetColumnMappings - Retrieve the mappings from reader columns to writer columns.
+func (b *BaseConfig) GetColumnMappings() spiwriter.ColumnMappings {
+	return b.ColumnMapping
+}
+
+// GetColumnFilter - Retrieve the filter of the columns expanded from *.
+func (b *BaseConfig) GetColumnFilter() dbmsreader.ColumnFilter {
+	return b.ColumnFilter
+}
+
+// GetSchemaDrift - Retrieve the check of schema drift between the reader and the target table.
+func (b *BaseConfig) GetSchemaDrift() SchemaDriftConfig {
+	return b.SchemaDrift
+}
+
 // GetRetryStrategy - Retrieve the retry strategy.
 func (b *BaseConfig) GetRetryStrategy(j schedule.RetryJudger) (schedule.RetryStrategy,
 	error) {
@@ -188,3 +240,47 @@ func checkHasSelect(sqls []string) (err error) {
 	}
 	return
 }
+
+// checkColumnTypes - Check whether column types are valid and their names are in the columns
+func (b *BaseConfig) checkColumnTypes(loc *time.Location) (err error) {
+	names := make(map[string]bool)
+	for i, v := range b.ColumnTypes {
+		if v == nil {
+			return fmt.Errorf("%vst column type is empty", i)
+		}
+		if err = v.validate(); err != nil {
+			return fmt.Errorf("%vst column type is not valid. error: %v", i, err)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("column(%v) is duplicate", v.Name)
+		}
+		names[v.Name] = true
+		if !b.hasColumn(v.Name) {
+			return fmt.Errorf("column(%v) is not in column", v.Name)
+		}
+		v.loc = loc
+	}
+	return
+}
+
+// checkColumnMapping - Check whether column mappings are valid and their targets are in the columns
+func (b *BaseConfig) checkColumnMapping() (err error) {
+	if err = b.ColumnMapping.Validate(); err != nil {
+		return
+	}
+	for _, v := range b.ColumnMapping {
+		if !b.hasColumn(v.Target) {
+			return fmt.Errorf("target(%v) is not in column", v.Target)
+		}
+	}
+	return
+}
+
+func (b *BaseConfig) hasColumn(name string) bool {
+	for _, v := range b.Column {
+		if v == dbmsreader.ColumnWildcard || v == name {
+			return true
+		}
+	}
+	return false
+}
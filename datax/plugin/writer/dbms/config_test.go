@@ -315,6 +315,83 @@ func TestNewBaseConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "7",
+			args: args{
+				conf: testJSONFromString(`{"timezone":"Mars/Olympus"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "8",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnTypes":[{"name":"a","type":"integer"}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "9",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnTypes":[{"name":"b","type":"bigint"}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "10",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnTypes":[{"name":"a","type":"bigint"},{"name":"a","type":"string"}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "11",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnTypes":[{"name":"a","type":"time","unit":"min"}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "12",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnTypes":[{"name":"a","type":"time","unit":"ms","layout":"2006"}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "13",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnTypes":[{"name":"a","type":"decimal","scale":-1}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "14",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnMapping":[{"source":"b","target":"c"}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "15",
+			args: args{
+				conf: testJSONFromString(`{"column":["a"],"columnMapping":[{"target":"a"}]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "16",
+			args: args{
+				conf: testJSONFromString(`{"column":["a","b"],"excludeColumns":["a"]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "17",
+			args: args{
+				conf: testJSONFromString(`{"schemaDrift":{"policy":"ignore"}}`),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -330,6 +407,38 @@ func TestNewBaseConfig(t *testing.T) {
 	}
 }
 
+func TestBaseConfig_GetColumnTypes(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		b    *BaseConfig
+		want []*ColumnType
+	}{
+		{
+			name: "1",
+			b:    testBaseConfig(testJSONFromString(`{"column":["a"]}`)),
+		},
+		{
+			name: "2",
+			b: testBaseConfig(testJSONFromString(`{"column":["*"],"timezone":"Asia/Shanghai",
+				"columnTypes":[{"name":"a","type":"time","unit":"ms"}]}`)),
+			want: []*ColumnType{
+				{Name: "a", Type: CastTypeTime, Unit: EpochUnitMillisecond, loc: shanghai},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.GetColumnTypes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BaseConfig.GetColumnTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBaseConfig_GetWriteMode(t *testing.T) {
 	type args struct {
 		conf *config.JSON
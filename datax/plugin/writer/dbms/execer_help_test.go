@@ -99,13 +99,18 @@ func (m *MockTable) Quoted() string {
 	return m.Instance() + "." + m.Schema() + "." + m.Name()
 }
 
+func (m *MockTable) AddColumnSQL(column, typ string) string {
+	return "alter table " + m.Quoted() + " add " + column + " " + typ
+}
+
 func (m *MockTable) AddField(bf *database.BaseField) {
 	i, _ := strconv.Atoi(bf.FieldType().DatabaseTypeName())
 	m.AppendField(NewMockField(bf, NewMockFieldType(database.GoType(i))))
 }
 
-func (m *MockTable) SetConfig(conf *config.JSON) {
+func (m *MockTable) SetConfig(conf *config.JSON) error {
 	m.conf = conf
+	return nil
 }
 
 type MockTableWithJudger struct {
@@ -139,7 +144,10 @@ type MockExecer struct {
 	BatchN   int
 	BatchErr error
 	ExecErr  error
+	DB       *sql.DB // database queried if not nil
 	config   *config.JSON
+	table    database.Table
+	execs    []string
 }
 
 func (m *MockExecer) Table(bt *database.BaseTable) database.Table {
@@ -151,10 +159,14 @@ func (m *MockExecer) PingContext(ctx context.Context) error {
 }
 
 func (m *MockExecer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if m.DB != nil {
+		return m.DB.QueryContext(ctx, query, args...)
+	}
 	return nil, m.QueryErr
 }
 
 func (m *MockExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	m.execs = append(m.execs, query)
 	if query == "wait" {
 		time.Sleep(100 * time.Millisecond)
 	}
@@ -162,6 +174,9 @@ func (m *MockExecer) ExecContext(ctx context.Context, query string, args ...any)
 }
 
 func (m *MockExecer) FetchTableWithParam(ctx context.Context, param database.Parameter) (database.Table, error) {
+	if m.table != nil {
+		return m.table, m.FetchErr
+	}
 	return NewMockTable(nil), m.FetchErr
 }
 
@@ -16,11 +16,14 @@ package dbms
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	dbmsreader "github.com/Breeze0806/go-etl/datax/plugin/reader/dbms"
+	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/pingcap/errors"
 )
 
@@ -31,6 +34,7 @@ type Job struct {
 	Handler DbHandler // Database handle
 	Execer  Execer    // Executor
 	conf    Config    // Configuration
+	dialect string    // Database dialect name
 }
 
 // NewJob Get work through database handle
@@ -47,20 +51,13 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	if name, err = j.PluginConf().GetString("dialect"); err != nil {
 		return errors.Wrapf(err, "GetString fail")
 	}
+	j.dialect = name
 
 	if j.conf, err = j.Handler.Config(j.PluginJobConf()); err != nil {
 		return errors.Wrapf(err, "Config fail")
 	}
 
-	var jobSettingConf *config.JSON
-	if jobSettingConf, err = j.PluginJobConf().GetConfig(coreconst.DataxJobSetting); err != nil {
-		jobSettingConf, _ = config.NewJSONFromString("{}")
-		err = nil
-	}
-	jobSettingConf.Set("username", j.conf.GetUsername())
-	jobSettingConf.Set("password", j.conf.GetPassword())
-	jobSettingConf.Set("url", j.conf.GetURL())
-
+	jobSettingConf := execerConf(j.PluginJobConf(), j.conf.GetUsername(), j.conf.GetPassword(), j.conf.GetURL())
 	if j.Execer, err = j.Handler.Execer(name, jobSettingConf); err != nil {
 		return errors.Wrapf(err, "Execer fail")
 	}
@@ -86,6 +83,16 @@ func (j *Job) Prepare(ctx context.Context) (err error) {
 			return errors.Wrapf(err, "ExecContext(%v) fail.", v)
 		}
 	}
+
+	if err = j.checkColumns(ctx); err != nil {
+		return errors.Wrapf(err, "checkColumns fail")
+	}
+
+	if schemaDrift := j.conf.GetSchemaDrift(); !schemaDrift.IsEmpty() {
+		if err = j.checkSchemaDrift(ctx, schemaDrift); err != nil {
+			return errors.Wrapf(err, "checkSchemaDrift fail")
+		}
+	}
 	return
 }
 
@@ -120,3 +127,214 @@ func (j *Job) Split(ctx context.Context, number int) (confs []*config.JSON, err
 	}
 	return confs, nil
 }
+
+// checkColumns Check whether the columns of the reader correspond in order to the columns of the writer
+// resolved as its tasks do, which is skipped with columnMapping as the records are mapped by the column names,
+// or when the columns of the reader can not be fetched
+func (j *Job) checkColumns(ctx context.Context) (err error) {
+	if len(j.conf.GetColumnMappings()) > 0 {
+		return
+	}
+	if !j.isPeerReaderOfDBMS() {
+		log.Warnf("jobID: %v skip checking columns because %v is not a reader of relational database",
+			j.JobID(), j.PeerPluginName())
+		return
+	}
+
+	var fields []database.Field
+	if fields, err = j.fetchReaderFields(ctx); err != nil {
+		return errors.Wrapf(err, "fetchReaderFields fail")
+	}
+	if fields == nil {
+		return
+	}
+	var target database.Table
+	if target, err = j.fetchWriterTable(ctx); err != nil {
+		return errors.Wrapf(err, "fetchWriterTable fail")
+	}
+	filter := j.conf.GetColumnFilter()
+	if err = filter.FilterFields(target); err != nil {
+		return errors.Wrapf(err, "FilterFields fail")
+	}
+
+	sources, targets := fieldNames(fields), fieldNames(target.Fields())
+	if len(sources) != len(targets) {
+		return errors.Errorf("columns %v of %v do not correspond to columns %v of %v",
+			sources, j.PeerPluginName(), targets, j.conf.GetBaseTable())
+	}
+	for i := range sources {
+		if !strings.EqualFold(sources[i], targets[i]) {
+			return errors.Errorf("columns %v of %v do not correspond to columns %v of %v",
+				sources, j.PeerPluginName(), targets, j.conf.GetBaseTable())
+		}
+	}
+	return
+}
+
+// checkSchemaDrift Compare the columns of the reader with the target table and handle the drifts by the policy,
+// the policy alter requires the reader of the same database as the types of its columns are added to the target table
+func (j *Job) checkSchemaDrift(ctx context.Context, schemaDrift SchemaDriftConfig) (err error) {
+	if schemaDrift.Policy == SchemaDriftAlter && j.PeerPluginName() != j.dialect+"reader" {
+		return errors.Errorf("policy %v requires the reader of %v, but it is %v",
+			SchemaDriftAlter, j.dialect, j.PeerPluginName())
+	}
+
+	var fields []database.Field
+	if fields, err = j.fetchReaderFields(ctx); err != nil {
+		return errors.Wrapf(err, "fetchReaderFields fail")
+	}
+	if fields == nil {
+		return
+	}
+
+	var target database.Table
+	if target, err = j.fetchTable(ctx); err != nil {
+		return errors.Wrapf(err, "fetchTable fail")
+	}
+
+	var targets []database.Field
+	if targets, err = catalogFields(ctx, j.Execer, target, target.Fields()); err != nil {
+		return errors.Wrapf(err, "catalogFields fail")
+	}
+	drifts := compareSchema(sourceColumns(fields, j.conf.GetColumnMappings()),
+		targets, schemaDrift.IgnoreColumns)
+	if len(drifts) == 0 {
+		return
+	}
+
+	switch schemaDrift.Policy {
+	case SchemaDriftFail:
+		return errors.Errorf("schema of %v drifts: %v", j.conf.GetBaseTable(), drifts)
+	case SchemaDriftAlter:
+		if drifts, err = j.addMissingColumns(ctx, target, drifts); err != nil {
+			return errors.Wrapf(err, "addMissingColumns fail")
+		}
+	}
+	for _, v := range drifts {
+		log.Warnf("jobID: %v schema of %v drifts: %v", j.JobID(), j.conf.GetBaseTable(), v)
+	}
+	return
+}
+
+// addMissingColumns Add the missing columns to the target table and return the other drifts
+func (j *Job) addMissingColumns(ctx context.Context, table database.Table, drifts []*SchemaDrift) (others []*SchemaDrift, err error) {
+	adder, ok := table.(database.ColumnAdder)
+	if !ok {
+		return nil, errors.Errorf("table %v can not add columns", j.conf.GetBaseTable())
+	}
+	for _, v := range drifts {
+		if v.Kind != DriftMissingColumn {
+			others = append(others, v)
+			continue
+		}
+		var typ string
+		if typ, err = columnDefinition(table, v.Source.Type()); err != nil {
+			return nil, errors.Wrapf(err, "type of column %v can not be defined", v.Column)
+		}
+		query := adder.AddColumnSQL(v.Column, typ)
+		if _, err = j.Execer.ExecContext(ctx, query); err != nil {
+			return nil, errors.Wrapf(err, "ExecContext(%v) fail.", query)
+		}
+		log.Infof("jobID: %v add column %v to %v by %v", j.JobID(), v.Column, j.conf.GetBaseTable(), query)
+	}
+	return
+}
+
+// fetchTable Fetch the target table with all its columns
+func (j *Job) fetchTable(ctx context.Context) (table database.Table, err error) {
+	param := database.NewTableQueryParam(j.Execer.Table(j.conf.GetBaseTable()))
+	if setter, ok := param.Table().(database.ConfigSetter); ok {
+		if err = setter.SetConfig(j.PluginJobConf()); err != nil {
+			return nil, errors.Wrapf(err, "SetConfig fail")
+		}
+	}
+	if table, err = j.Execer.FetchTableWithParam(ctx, param); err != nil {
+		return nil, errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+	return
+}
+
+// fetchWriterTable Fetch the target table with the columns of the writer in the same way as its tasks
+func (j *Job) fetchWriterTable(ctx context.Context) (table database.Table, err error) {
+	param := j.Handler.TableParam(j.conf, j.Execer)
+	if setter, ok := param.Table().(database.ConfigSetter); ok {
+		if err = setter.SetConfig(j.PluginJobConf()); err != nil {
+			return nil, errors.Wrapf(err, "SetConfig fail")
+		}
+	}
+	if table, err = j.Execer.FetchTableWithParam(ctx, param); err != nil {
+		return nil, errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+	return
+}
+
+// isPeerReaderOfDBMS Check whether the peer plugin is a reader of relational database,
+// which is a reader connecting to the url of a database
+func (j *Job) isPeerReaderOfDBMS() bool {
+	return j.PeerPluginJobConf() != nil && strings.HasSuffix(j.PeerPluginName(), "reader") &&
+		j.PeerPluginJobConf().Exists("connection.url")
+}
+
+// fetchReaderFields Fetch the columns of the reader through the configuration of the peer plugin,
+// return nil when the reader uses querySql
+func (j *Job) fetchReaderFields(ctx context.Context) (fields []database.Field, err error) {
+	name := strings.TrimSuffix(j.PeerPluginName(), "reader")
+	if j.PeerPluginJobConf() == nil || name == j.PeerPluginName() {
+		return nil, errors.Errorf("peer plugin %v is not a reader of relational database", j.PeerPluginName())
+	}
+
+	var conf *dbmsreader.BaseConfig
+	if conf, err = dbmsreader.NewBaseConfig(j.PeerPluginJobConf()); err != nil {
+		return nil, errors.Wrapf(err, "NewBaseConfig fail")
+	}
+	if len(conf.GetQuerySQL()) > 0 {
+		log.Warnf("jobID: %v skip fetching columns of %v because it uses querySql", j.JobID(), j.PeerPluginName())
+		return nil, nil
+	}
+
+	var execer Execer
+	if execer, err = j.Handler.Execer(name,
+		execerConf(j.PeerPluginJobConf(), conf.GetUsername(), conf.GetPassword(), conf.GetURL())); err != nil {
+		return nil, errors.Wrapf(err, "Execer fail")
+	}
+	defer execer.Close()
+
+	param := dbmsreader.NewTableParam(conf, execer, nil)
+	if setter, ok := param.Table().(database.ConfigSetter); ok {
+		if err = setter.SetConfig(j.PeerPluginJobConf()); err != nil {
+			return nil, errors.Wrapf(err, "SetConfig fail")
+		}
+	}
+	var table database.Table
+	if table, err = execer.FetchTableWithParam(ctx, param); err != nil {
+		return nil, errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+	filter := conf.GetColumnFilter()
+	if err = filter.FilterFields(table); err != nil {
+		return nil, errors.Wrapf(err, "FilterFields fail")
+	}
+	if fields, err = catalogFields(ctx, execer, table, table.Fields()); err != nil {
+		return nil, errors.Wrapf(err, "catalogFields fail")
+	}
+	return
+}
+
+// fieldNames Get the names of the fields
+func fieldNames(fields []database.Field) (names []string) {
+	for _, f := range fields {
+		names = append(names, f.Name())
+	}
+	return
+}
+
+// execerConf Get the configuration of the executor from the job setting of jobConf and the connection information
+func execerConf(jobConf *config.JSON, username, password, url string) *config.JSON {
+	jobSettingConf, err := jobConf.GetConfig(coreconst.DataxJobSetting)
+	if err != nil {
+		jobSettingConf, _ = config.NewJSONFromString("{}")
+	}
+	jobSettingConf.Set("username", username)
+	jobSettingConf.Set("password", password)
+	jobSettingConf.Set("url", url)
+	return jobSettingConf
+}
@@ -23,6 +23,9 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	spiwriter "github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	dbmsreader "github.com/Breeze0806/go-etl/datax/plugin/reader/dbms"
+	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func newMockDbHandler(newExecer func(name string, conf *config.JSON) (Execer, error)) DbHandler {
@@ -266,6 +269,7 @@ func TestJob_Prepare(t *testing.T) {
 		{
 			name: "1",
 			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
 				conf: &BaseConfig{
 					PreSQL: []string{
 						"delate",
@@ -282,6 +286,7 @@ func TestJob_Prepare(t *testing.T) {
 		{
 			name: "2",
 			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
 				conf: &BaseConfig{
 					PreSQL: []string{
 						"wait",
@@ -300,6 +305,7 @@ func TestJob_Prepare(t *testing.T) {
 		{
 			name: "3",
 			j: &Job{
+				BaseJob: plugin.NewBaseJob(),
 				conf: &BaseConfig{
 					PreSQL: []string{
 						"drop",
@@ -332,6 +338,145 @@ func TestJob_Prepare(t *testing.T) {
 	}
 }
 
+func testSchemaDriftJob(peerName string, peerConf string, policy string, reader, writer *MockExecer) *Job {
+	j := NewJob(newMockDbHandler(func(name string, conf *config.JSON) (Execer, error) {
+		if name != "mock" {
+			return nil, errors.New("mock error")
+		}
+		return reader, nil
+	}))
+	j.SetPeerPluginName(peerName)
+	j.SetPeerPluginJobConf(testJSONFromString(peerConf))
+	j.Execer = writer
+	j.dialect = "mock"
+	j.conf = &BaseConfig{
+		Connection: dbmsreader.ConnConfig{
+			Table: dbmsreader.TableConfig{
+				Db:     "db",
+				Schema: "schema",
+				Name:   "table",
+			},
+		},
+		SchemaDrift: SchemaDriftConfig{
+			Policy: policy,
+		},
+	}
+	return j
+}
+
+func TestJob_checkSchemaDrift(t *testing.T) {
+	peerConf := `{
+		"connection":{
+			"url":"breeze0806.xxx",
+			"table":{
+				"db":"db",
+				"name":"source"
+			}
+		},
+		"column":["*"]
+	}`
+	readerTable := func() *MockTable {
+		return testDriftTable(
+			testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"}),
+			testDriftField(1, "name", &testDriftFieldType{typeName: "VARCHAR", length: 20}),
+		)
+	}
+	writerTable := func() *MockTable {
+		return testDriftTable(
+			testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"}),
+		)
+	}
+	tests := []struct {
+		name      string
+		j         *Job
+		wantExecs []string
+		wantErr   bool
+	}{
+		{
+			name: "1",
+			j: testSchemaDriftJob("mockreader", peerConf, SchemaDriftFail,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable()}),
+			wantErr: true,
+		},
+		{
+			name: "2",
+			j: testSchemaDriftJob("mockreader", peerConf, SchemaDriftWarn,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable()}),
+		},
+		{
+			name: "3",
+			j: testSchemaDriftJob("mockreader", peerConf, SchemaDriftAlter,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable()}),
+			wantExecs: []string{"alter table db.schema.table add name VARCHAR(20)"},
+		},
+		{
+			name: "4",
+			j: testSchemaDriftJob("mockreader", peerConf, SchemaDriftFail,
+				&MockExecer{table: writerTable()}, &MockExecer{table: writerTable()}),
+		},
+		{
+			name: "5",
+			j: testSchemaDriftJob("mockreader", `{
+				"connection":{
+					"url":"breeze0806.xxx"
+				},
+				"querySql":["select * from source"]
+			}`, SchemaDriftFail,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable()}),
+		},
+		{
+			name: "6",
+			j: testSchemaDriftJob("mock", peerConf, SchemaDriftFail,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable()}),
+			wantErr: true,
+		},
+		{
+			name: "7",
+			j: testSchemaDriftJob("csvreader", peerConf, SchemaDriftFail,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable()}),
+			wantErr: true,
+		},
+		{
+			name: "8",
+			j: testSchemaDriftJob("mockreader", peerConf, SchemaDriftFail,
+				&MockExecer{table: readerTable(), FetchErr: errors.New("mock error")}, &MockExecer{table: writerTable()}),
+			wantErr: true,
+		},
+		{
+			name: "9",
+			j: testSchemaDriftJob("mockreader", peerConf, SchemaDriftAlter,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable(), ExecErr: errors.New("mock error")}),
+			wantExecs: []string{"alter table db.schema.table add name VARCHAR(20)"},
+			wantErr:   true,
+		},
+		{
+			name: "10",
+			j: testSchemaDriftJob("mysqlreader", peerConf, SchemaDriftAlter,
+				&MockExecer{table: readerTable()}, &MockExecer{table: writerTable()}),
+			wantErr: true,
+		},
+		{
+			name: "11",
+			j: testSchemaDriftJob("mockreader", peerConf, SchemaDriftAlter,
+				&MockExecer{table: testDriftTable(
+					testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"}),
+					testDriftField(1, "name", &testDriftFieldType{}),
+				)}, &MockExecer{table: writerTable()}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.j.checkSchemaDrift(context.TODO(), tt.j.conf.GetSchemaDrift()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.checkSchemaDrift() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := tt.j.Execer.(*MockExecer).execs; !reflect.DeepEqual(got, tt.wantExecs) {
+				t.Errorf("Job.checkSchemaDrift() execs = %v, want %v", got, tt.wantExecs)
+			}
+		})
+	}
+}
+
 func TestJob_Post(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -409,3 +554,113 @@ func TestJob_Post(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_checkColumns(t *testing.T) {
+	peerConf := `{
+		"connection":{
+			"url":"breeze0806.xxx",
+			"table":{
+				"db":"db",
+				"name":"source"
+			}
+		},
+		"column":["*"]
+	}`
+	testTable := func(names ...string) *MockTable {
+		var fields []database.Field
+		for i, v := range names {
+			fields = append(fields, testDriftField(i, v, &testDriftFieldType{typeName: "VARCHAR"}))
+		}
+		return testDriftTable(fields...)
+	}
+	testJob := func(peerName string, peerConf string, conf *BaseConfig, reader, writer *MockExecer) *Job {
+		j := testSchemaDriftJob(peerName, peerConf, "", reader, writer)
+		j.conf.(*BaseConfig).Column = conf.Column
+		j.conf.(*BaseConfig).ColumnFilter = conf.ColumnFilter
+		j.conf.(*BaseConfig).ColumnMapping = conf.ColumnMapping
+		return j
+	}
+	tests := []struct {
+		name    string
+		j       *Job
+		wantErr bool
+	}{
+		{
+			name: "1",
+			j: testJob("mockreader", peerConf, &BaseConfig{Column: []string{"*"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("ID", "NAME")}),
+		},
+		{
+			name: "2",
+			j: testJob("mockreader", peerConf, &BaseConfig{Column: []string{"*"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("name", "id")}),
+			wantErr: true,
+		},
+		{
+			name: "3",
+			j: testJob("mockreader", peerConf, &BaseConfig{Column: []string{"*"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("id", "name", "audit_ts")}),
+			wantErr: true,
+		},
+		{
+			name: "4",
+			j: testJob("mockreader", peerConf, &BaseConfig{
+				Column:       []string{"*"},
+				ColumnFilter: dbmsreader.ColumnFilter{ExcludeColumns: []string{"audit_ts"}},
+			}, &MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("id", "name", "audit_ts")}),
+		},
+		{
+			name: "5",
+			j: testJob("mockreader", peerConf, &BaseConfig{Column: []string{"id", "name"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("id", "name")}),
+		},
+		{
+			name: "6",
+			j: testJob("mockreader", peerConf, &BaseConfig{
+				Column:        []string{"*"},
+				ColumnMapping: spiwriter.ColumnMappings{{Source: "id", Target: "id"}},
+			}, &MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("name", "id")}),
+		},
+		{
+			name: "7",
+			j: testJob("csvreader", `{"path":["a.csv"]}`, &BaseConfig{Column: []string{"*"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("name", "id")}),
+		},
+		{
+			name: "8",
+			j: testJob("mockreader", `{
+				"connection":{
+					"url":"breeze0806.xxx"
+				},
+				"querySql":["select * from source"]
+			}`, &BaseConfig{Column: []string{"*"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("name", "id")}),
+		},
+		{
+			name: "9",
+			j: testJob("mockreader", peerConf, &BaseConfig{Column: []string{"*"}},
+				&MockExecer{table: testTable("id", "name")},
+				&MockExecer{table: testTable("id", "name"), FetchErr: errors.New("mock error")}),
+			wantErr: true,
+		},
+		{
+			name: "10",
+			j: testJob("mockreader", peerConf, &BaseConfig{Column: []string{"name", "id"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("name", "id")}),
+			wantErr: true,
+		},
+		{
+			name: "11",
+			j: testJob("mockreader", peerConf, &BaseConfig{Column: []string{"id"}},
+				&MockExecer{table: testTable("id", "name")}, &MockExecer{table: testTable("id")}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.j.checkColumns(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.checkColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
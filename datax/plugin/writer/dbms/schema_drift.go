@@ -0,0 +1,268 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	spiwriter "github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/pingcap/errors"
+)
+
+// Schema drift policies
+const (
+	SchemaDriftFail  = "fail"  // fail the job when the schema drifts
+	SchemaDriftWarn  = "warn"  // only log the drifts
+	SchemaDriftAlter = "alter" // add the missing columns to the target table and log the others
+)
+
+// Schema drift kinds
+const (
+	DriftMissingColumn = "missing column"        // column of the reader that the target table does not have
+	DriftExtraNotNull  = "extra not null column" // not null column of the target table that the reader does not write
+	DriftNarrowing     = "narrowing type"        // column whose length or precision in the target table is smaller
+)
+
+// SchemaDriftConfig - configuration of the check comparing the columns of the reader with the target table in preparation
+type SchemaDriftConfig struct {
+	Policy        string   `json:"policy"`        // fail, warn or alter, no check if empty
+	IgnoreColumns []string `json:"ignoreColumns"` // not null columns of the target table that are not reported as extra, such as ones with default values
+}
+
+// IsEmpty - whether the check is disabled
+func (s *SchemaDriftConfig) IsEmpty() bool {
+	return s.Policy == ""
+}
+
+func (s *SchemaDriftConfig) validate() error {
+	switch s.Policy {
+	case "", SchemaDriftFail, SchemaDriftWarn, SchemaDriftAlter:
+	default:
+		return fmt.Errorf("policy(%v) is not valid", s.Policy)
+	}
+	return nil
+}
+
+// SchemaDrift - difference between a column of the reader and the target table
+type SchemaDrift struct {
+	Kind   string         // kind of drift
+	Column string         // column name in the target table
+	Source database.Field // column of the reader, nil for the extra not null column
+	Target database.Field // column of the target table, nil for the missing column
+}
+
+func (s *SchemaDrift) String() string {
+	switch s.Kind {
+	case DriftMissingColumn:
+		return fmt.Sprintf("%v %v(%v)", s.Kind, s.Column, typeDefinition(s.Source.Type()))
+	case DriftNarrowing:
+		return fmt.Sprintf("%v %v from %v to %v", s.Kind, s.Column,
+			typeDefinition(s.Source.Type()), typeDefinition(s.Target.Type()))
+	}
+	return fmt.Sprintf("%v %v", s.Kind, s.Column)
+}
+
+// sourceColumn - column written to the target table, field is nil for the constant value of the column mapping
+type sourceColumn struct {
+	name  string
+	field database.Field
+}
+
+// sourceColumns - get the columns written to the target table by the reader fields renamed by the column mappings
+func sourceColumns(fields []database.Field, mappings spiwriter.ColumnMappings) (columns []sourceColumn) {
+	renames := make(map[string][]string)
+	renamed := make(map[string]bool)
+	for _, v := range mappings {
+		if v.Source == "" {
+			columns = append(columns, sourceColumn{name: v.Target})
+			continue
+		}
+		renames[v.Source] = append(renames[v.Source], v.Target)
+		renamed[v.Target] = true
+	}
+
+	for _, f := range fields {
+		if targets, ok := renames[f.Name()]; ok {
+			for _, v := range targets {
+				columns = append(columns, sourceColumn{name: v, field: f})
+			}
+			continue
+		}
+		if !renamed[f.Name()] {
+			columns = append(columns, sourceColumn{name: f.Name(), field: f})
+		}
+	}
+	return
+}
+
+// catalogField - field whose nullable, default, length, precision and scale are taken from the catalog of the database
+type catalogField struct {
+	database.Field
+
+	typ *database.CatalogFieldType
+}
+
+// Type - field type taken from the catalog
+func (c *catalogField) Type() database.FieldType {
+	return c.typ
+}
+
+// catalogFields - get the fields of the table whose metadata is taken from the catalog of the database queried by execer
+// if the table implements database.ColumnCataloger, since the metadata reported by some drivers is incomplete.
+// The fields are returned unchanged if the table does not implement it, and the fields not in the catalog are kept
+func catalogFields(ctx context.Context, execer Execer, table database.Table, fields []database.Field) ([]database.Field, error) {
+	cataloger, ok := table.(database.ColumnCataloger)
+	if !ok {
+		return fields, nil
+	}
+	query, args := cataloger.CatalogQuery()
+	rows, err := execer.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "QueryContext(%v) fail", query)
+	}
+	defer rows.Close()
+	catalogs := make(map[string]*database.ColumnCatalog)
+	for rows.Next() {
+		var c *database.ColumnCatalog
+		if c, err = database.ScanColumnCatalog(rows); err != nil {
+			return nil, errors.Wrapf(err, "ScanColumnCatalog fail")
+		}
+		catalogs[c.Name] = c
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "rows fail")
+	}
+
+	cataloged := make([]database.Field, 0, len(fields))
+	for _, f := range fields {
+		if c, ok := catalogs[f.Name()]; ok {
+			f = &catalogField{
+				Field: f,
+				typ:   database.NewCatalogFieldType(f.Type(), c),
+			}
+		}
+		cataloged = append(cataloged, f)
+	}
+	return cataloged, nil
+}
+
+// hasDefault - whether the column has a default value in the catalog of the database
+func hasDefault(typ database.FieldType) bool {
+	c, ok := typ.(*database.CatalogFieldType)
+	return ok && c.HasDefault()
+}
+
+// compareSchema - compare the columns written with the columns of the target table by case-insensitive names,
+// the not null columns in ignores or with default values are not reported as extra
+func compareSchema(sources []sourceColumn, targets []database.Field, ignores []string) (drifts []*SchemaDrift) {
+	targetMap := make(map[string]database.Field)
+	for _, v := range targets {
+		targetMap[strings.ToLower(v.Name())] = v
+	}
+	written := make(map[string]bool)
+	for _, v := range sources {
+		written[strings.ToLower(v.name)] = true
+		if v.field == nil {
+			continue
+		}
+		target, ok := targetMap[strings.ToLower(v.name)]
+		if !ok {
+			drifts = append(drifts, &SchemaDrift{
+				Kind:   DriftMissingColumn,
+				Column: v.name,
+				Source: v.field,
+			})
+			continue
+		}
+		if isNarrowing(v.field.Type(), target.Type()) {
+			drifts = append(drifts, &SchemaDrift{
+				Kind:   DriftNarrowing,
+				Column: target.Name(),
+				Source: v.field,
+				Target: target,
+			})
+		}
+	}
+
+	for _, v := range ignores {
+		written[strings.ToLower(v)] = true
+	}
+	for _, v := range targets {
+		if written[strings.ToLower(v.Name())] || hasDefault(v.Type()) {
+			continue
+		}
+		if nullable, ok := v.Type().Nullable(); ok && !nullable {
+			drifts = append(drifts, &SchemaDrift{
+				Kind:   DriftExtraNotNull,
+				Column: v.Name(),
+				Target: v,
+			})
+		}
+	}
+	return
+}
+
+// isNarrowing - whether the length, precision or scale of the target is smaller than the source,
+// which can not be known if neither the catalog of the database nor the driver reports them
+func isNarrowing(source, target database.ColumnType) bool {
+	if sl, ok := source.Length(); ok && isLimited(sl) {
+		if tl, ok := target.Length(); ok && isLimited(tl) && tl < sl {
+			return true
+		}
+	}
+	if sp, ss, ok := source.DecimalSize(); ok && isLimited(sp) {
+		if tp, ts, ok := target.DecimalSize(); ok && isLimited(tp) && (tp < sp || ts < ss) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLimited - whether the length or precision is limited, some drivers return the max int64 for unlimited types
+// like text, and go-sql-driver/mysql returns it as the precision of float and double
+func isLimited(n int64) bool {
+	return n > 0 && n != math.MaxInt64
+}
+
+// typeDefinition - type definition of the column such as varchar(10) or decimal(10,2) by the type name,
+// the length, precision and scale reported by the driver
+func typeDefinition(typ database.ColumnType) string {
+	if precision, scale, ok := typ.DecimalSize(); ok && isLimited(precision) {
+		if !isLimited(scale) {
+			scale = 0
+		}
+		return fmt.Sprintf("%v(%v,%v)", typ.DatabaseTypeName(), precision, scale)
+	}
+	if length, ok := typ.Length(); ok && isLimited(length) {
+		return fmt.Sprintf("%v(%v)", typ.DatabaseTypeName(), length)
+	}
+	return typ.DatabaseTypeName()
+}
+
+// columnDefinition - type definition of the column type of the reader in the target table of the same database,
+// which is got by the target table if it implements database.ColumnTypeDefiner
+func columnDefinition(table database.Table, typ database.ColumnType) (string, error) {
+	if definer, ok := table.(database.ColumnTypeDefiner); ok {
+		return definer.TypeDefinition(typ)
+	}
+	if typ.DatabaseTypeName() == "" {
+		return "", fmt.Errorf("type is unknown")
+	}
+	return typeDefinition(typ), nil
+}
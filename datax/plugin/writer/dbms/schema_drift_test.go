@@ -0,0 +1,496 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	spiwriter "github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+type testDriftFieldType struct {
+	*MockFieldType
+
+	typeName  string
+	length    int64
+	precision int64
+	scale     int64
+	nullable  int // 0 unknown, 1 nullable, 2 not null
+}
+
+func (t *testDriftFieldType) DatabaseTypeName() string {
+	return t.typeName
+}
+
+func (t *testDriftFieldType) Length() (int64, bool) {
+	return t.length, t.length != 0
+}
+
+func (t *testDriftFieldType) DecimalSize() (int64, int64, bool) {
+	return t.precision, t.scale, t.precision != 0
+}
+
+func (t *testDriftFieldType) Nullable() (bool, bool) {
+	return t.nullable == 1, t.nullable != 0
+}
+
+func testDriftField(index int, name string, typ *testDriftFieldType) database.Field {
+	typ.MockFieldType = NewMockFieldType(database.GoTypeString)
+	return NewMockField(database.NewBaseField(index, name, typ), typ)
+}
+
+func testDriftTable(fields ...database.Field) *MockTable {
+	table := NewMockTable(database.NewBaseTable("db", "schema", "table"))
+	for _, v := range fields {
+		table.AppendField(v)
+	}
+	return table
+}
+
+func testDriftNames(drifts []*SchemaDrift) (names []string) {
+	for _, v := range drifts {
+		names = append(names, v.String())
+	}
+	return
+}
+
+func TestSchemaDriftConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       *SchemaDriftConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s:    &SchemaDriftConfig{},
+		},
+		{
+			name: "2",
+			s: &SchemaDriftConfig{
+				Policy: SchemaDriftAlter,
+			},
+		},
+		{
+			name: "3",
+			s: &SchemaDriftConfig{
+				Policy: "ignore",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SchemaDriftConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSourceColumns(t *testing.T) {
+	value := "1"
+	fields := []database.Field{
+		testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"}),
+		testDriftField(1, "name", &testDriftFieldType{typeName: "VARCHAR"}),
+		testDriftField(2, "age", &testDriftFieldType{typeName: "INT"}),
+	}
+	tests := []struct {
+		name     string
+		mappings spiwriter.ColumnMappings
+		want     []string
+	}{
+		{
+			name: "1",
+			want: []string{"id", "name", "age"},
+		},
+		{
+			name: "2",
+			mappings: spiwriter.ColumnMappings{
+				{Source: "name", Target: "user_name"},
+				{Target: "flag", Value: &value},
+			},
+			want: []string{"flag", "id", "user_name", "age"},
+		},
+		{
+			name: "3",
+			mappings: spiwriter.ColumnMappings{
+				{Source: "name", Target: "age"},
+			},
+			want: []string{"id", "age"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range sourceColumns(fields, tt.mappings) {
+				got = append(got, v.name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sourceColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSchema(t *testing.T) {
+	type args struct {
+		sources []sourceColumn
+		targets []database.Field
+		ignores []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "1",
+			args: args{
+				sources: []sourceColumn{
+					{name: "id", field: testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"})},
+					{name: "name", field: testDriftField(1, "name", &testDriftFieldType{typeName: "VARCHAR", length: 20})},
+				},
+				targets: []database.Field{
+					testDriftField(0, "ID", &testDriftFieldType{typeName: "BIGINT", nullable: 2}),
+					testDriftField(1, "NAME", &testDriftFieldType{typeName: "VARCHAR", length: 20}),
+				},
+			},
+		},
+		{
+			name: "2",
+			args: args{
+				sources: []sourceColumn{
+					{name: "id", field: testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"})},
+					{name: "name", field: testDriftField(1, "name", &testDriftFieldType{typeName: "VARCHAR", length: 20})},
+					{name: "price", field: testDriftField(2, "price", &testDriftFieldType{typeName: "DECIMAL", precision: 10, scale: 2})},
+					{name: "flag"},
+				},
+				targets: []database.Field{
+					testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT", nullable: 2}),
+					testDriftField(1, "price", &testDriftFieldType{typeName: "DECIMAL", precision: 10, scale: 1}),
+					testDriftField(2, "flag", &testDriftFieldType{typeName: "INT", nullable: 2}),
+					testDriftField(3, "created", &testDriftFieldType{typeName: "DATETIME", nullable: 2}),
+					testDriftField(4, "updated", &testDriftFieldType{typeName: "DATETIME", nullable: 2}),
+					testDriftField(5, "memo", &testDriftFieldType{typeName: "TEXT", nullable: 1}),
+				},
+				ignores: []string{"UPDATED"},
+			},
+			want: []string{
+				"missing column name(VARCHAR(20))",
+				"narrowing type price from DECIMAL(10,2) to DECIMAL(10,1)",
+				"extra not null column created",
+			},
+		},
+		{
+			name: "3",
+			args: args{
+				sources: []sourceColumn{
+					{name: "name", field: testDriftField(0, "name", &testDriftFieldType{typeName: "VARCHAR", length: 20})},
+					{name: "memo", field: testDriftField(1, "memo", &testDriftFieldType{typeName: "TEXT", length: math.MaxInt64})},
+				},
+				targets: []database.Field{
+					testDriftField(0, "name", &testDriftFieldType{typeName: "VARCHAR", length: 10}),
+					testDriftField(1, "memo", &testDriftFieldType{typeName: "VARCHAR", length: 100}),
+				},
+			},
+			want: []string{
+				"narrowing type name from VARCHAR(20) to VARCHAR(10)",
+			},
+		},
+		{
+			name: "4",
+			args: args{
+				// go-sql-driver/mysql reports no length, the max int64 as the precision of double
+				// and the fractional seconds precision of datetime as the precision and scale
+				sources: []sourceColumn{
+					{name: "name", field: testDriftField(0, "name", &testDriftFieldType{typeName: "VARCHAR"})},
+					{name: "rate", field: testDriftField(1, "rate", &testDriftFieldType{typeName: "DOUBLE", precision: math.MaxInt64, scale: 31})},
+					{name: "created", field: testDriftField(2, "created", &testDriftFieldType{typeName: "DATETIME", precision: 6, scale: 6})},
+				},
+				targets: []database.Field{
+					testDriftField(0, "name", &testDriftFieldType{typeName: "VARCHAR"}),
+					testDriftField(1, "rate", &testDriftFieldType{typeName: "DOUBLE", precision: math.MaxInt64, scale: 2}),
+					testDriftField(2, "created", &testDriftFieldType{typeName: "DATETIME", precision: 3, scale: 3}),
+				},
+			},
+			want: []string{
+				"narrowing type created from DATETIME(6,6) to DATETIME(3,3)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testDriftNames(compareSchema(tt.args.sources, tt.args.targets, tt.args.ignores))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("compareSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testCatalogDriver - driver whose query returns the column catalogs of the data source name
+type testCatalogDriver struct{}
+
+var testCatalogs = map[string][][]driver.Value{
+	"catalog": {
+		{"id", "NO", nil, nil, nil, nil},
+		{"name", "YES", nil, int64(5), nil, nil},
+		{"price", "YES", nil, nil, int64(10), int64(1)},
+		{"code", "NO", nil, int64(10), nil, nil},
+		{"created", "NO", "CURRENT_TIMESTAMP", nil, nil, nil},
+	},
+	"invalid": {
+		{"id", "NO", nil, "a", nil, nil},
+	},
+}
+
+func init() {
+	sql.Register("testCatalog", &testCatalogDriver{})
+}
+
+func (d *testCatalogDriver) Open(name string) (driver.Conn, error) {
+	return &testCatalogConn{name: name}, nil
+}
+
+type testCatalogConn struct {
+	name string
+}
+
+func (c *testCatalogConn) Prepare(query string) (driver.Stmt, error) {
+	return &testCatalogStmt{name: c.name}, nil
+}
+
+func (c *testCatalogConn) Close() error {
+	return nil
+}
+
+func (c *testCatalogConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("mock error")
+}
+
+type testCatalogStmt struct {
+	name string
+}
+
+func (s *testCatalogStmt) Close() error {
+	return nil
+}
+
+func (s *testCatalogStmt) NumInput() int {
+	return -1
+}
+
+func (s *testCatalogStmt) Exec(_ []driver.Value) (driver.Result, error) {
+	return nil, errors.New("mock error")
+}
+
+func (s *testCatalogStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	values, ok := testCatalogs[s.name]
+	if !ok {
+		return nil, errors.New("mock error")
+	}
+	return &testCatalogRows{values: values}, nil
+}
+
+type testCatalogRows struct {
+	values [][]driver.Value
+}
+
+func (r *testCatalogRows) Columns() []string {
+	return []string{"column_name", "is_nullable", "column_default",
+		"character_maximum_length", "numeric_precision", "numeric_scale"}
+}
+
+func (r *testCatalogRows) Close() error {
+	return nil
+}
+
+func (r *testCatalogRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+type testCatalogTable struct {
+	*MockTable
+}
+
+func (t *testCatalogTable) CatalogQuery() (string, []any) {
+	return "catalog", []any{t.Name()}
+}
+
+func TestCatalogFields(t *testing.T) {
+	// the driver reports neither the nullable nor the length and precision, such as lib/pq and go-sql-driver/mysql
+	targets := []database.Field{
+		testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"}),
+		testDriftField(1, "name", &testDriftFieldType{typeName: "VARCHAR"}),
+		testDriftField(2, "price", &testDriftFieldType{typeName: "DECIMAL"}),
+		testDriftField(3, "code", &testDriftFieldType{typeName: "VARCHAR"}),
+		testDriftField(4, "created", &testDriftFieldType{typeName: "DATETIME"}),
+		testDriftField(5, "memo", &testDriftFieldType{typeName: "TEXT", nullable: 2}),
+	}
+	sources := []sourceColumn{
+		{name: "id", field: testDriftField(0, "id", &testDriftFieldType{typeName: "BIGINT"})},
+		{name: "name", field: testDriftField(1, "name", &testDriftFieldType{typeName: "VARCHAR", length: 10})},
+		{name: "price", field: testDriftField(2, "price", &testDriftFieldType{typeName: "DECIMAL", precision: 10, scale: 2})},
+	}
+	tests := []struct {
+		name    string
+		dsn     string
+		table   database.Table
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			dsn:   "catalog",
+			table: &testCatalogTable{MockTable: testDriftTable()},
+			want: []string{
+				"narrowing type name from VARCHAR(10) to VARCHAR(5)",
+				"narrowing type price from DECIMAL(10,2) to DECIMAL(10,1)",
+				"extra not null column code",
+				"extra not null column memo",
+			},
+		},
+		{
+			name:  "2",
+			dsn:   "catalog",
+			table: testDriftTable(),
+			want: []string{
+				"extra not null column memo",
+			},
+		},
+		{
+			name:    "3",
+			dsn:     "error",
+			table:   &testCatalogTable{MockTable: testDriftTable()},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			dsn:     "invalid",
+			table:   &testCatalogTable{MockTable: testDriftTable()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := sql.Open("testCatalog", tt.dsn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			fields, err := catalogFields(context.TODO(), &MockExecer{DB: db}, tt.table, targets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("catalogFields() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := testDriftNames(compareSchema(sources, fields, nil))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("compareSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testDefinerTable struct {
+	*MockTable
+}
+
+func (t *testDefinerTable) TypeDefinition(typ database.ColumnType) (string, error) {
+	if typ.DatabaseTypeName() == "ENUM" {
+		return "", errors.New("mock error")
+	}
+	return "defined " + typ.DatabaseTypeName(), nil
+}
+
+func TestColumnDefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   database.Table
+		typ     *testDriftFieldType
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			table: testDriftTable(),
+			typ:   &testDriftFieldType{typeName: "VARCHAR", length: 20},
+			want:  "VARCHAR(20)",
+		},
+		{
+			name:  "2",
+			table: testDriftTable(),
+			typ:   &testDriftFieldType{typeName: "NUMBER", precision: 10, scale: 2},
+			want:  "NUMBER(10,2)",
+		},
+		{
+			name:  "3",
+			table: testDriftTable(),
+			typ:   &testDriftFieldType{typeName: "DOUBLE", precision: math.MaxInt64, scale: math.MaxInt64},
+			want:  "DOUBLE",
+		},
+		{
+			name:  "4",
+			table: testDriftTable(),
+			typ:   &testDriftFieldType{typeName: "TEXT", length: math.MaxInt64},
+			want:  "TEXT",
+		},
+		{
+			name:    "5",
+			table:   testDriftTable(),
+			typ:     &testDriftFieldType{},
+			wantErr: true,
+		},
+		{
+			name:  "6",
+			table: &testDefinerTable{MockTable: testDriftTable()},
+			typ:   &testDriftFieldType{typeName: "VARCHAR", length: 20},
+			want:  "defined VARCHAR",
+		},
+		{
+			name:    "7",
+			table:   &testDefinerTable{MockTable: testDriftTable()},
+			typ:     &testDriftFieldType{typeName: "ENUM"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.typ.MockFieldType = NewMockFieldType(database.GoTypeString)
+			got, err := columnDefinition(tt.table, tt.typ)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("columnDefinition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("columnDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
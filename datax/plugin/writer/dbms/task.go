@@ -32,6 +32,8 @@ type Task struct {
 	Execer  Execer
 	Config  Config
 	Table   database.Table
+
+	mapper *writer.RecordMapper
 }
 
 // NewTask Create a task through the database handle
@@ -74,12 +76,23 @@ func (t *Task) Init(ctx context.Context) (err error) {
 
 	param := t.Handler.TableParam(t.Config, t.Execer)
 	if setter, ok := param.Table().(database.ConfigSetter); ok {
-		setter.SetConfig(t.PluginJobConf())
+		if err = setter.SetConfig(t.PluginJobConf()); err != nil {
+			return t.Wrapf(err, "SetConfig fail")
+		}
 	}
 	if t.Table, err = t.Execer.FetchTableWithParam(ctx, param); err != nil {
 		return t.Wrapf(err, "FetchTableWithParam fail")
 	}
 
+	filter := t.Config.GetColumnFilter()
+	if err = filter.FilterFields(t.Table); err != nil {
+		return t.Wrapf(err, "FilterFields fail")
+	}
+
+	if t.mapper, err = newRecordMapper(t.Config, t.Table); err != nil {
+		return t.Wrapf(err, "newRecordMapper fail")
+	}
+
 	return
 }
 
@@ -0,0 +1,133 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+const maxErrorBody = 512
+
+// StatusError error of the response with the status code other than 2xx
+type StatusError struct {
+	StatusCode int    // Status code
+	Body       string // Beginning of the response body
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http status %v: %v", e.StatusCode, e.Body)
+}
+
+// rejectedError error of the documents rejected by 429 in the bulk response
+type rejectedError struct {
+	StatusError
+}
+
+// bulkResponse response of the bulk API, whose items are in the order of the actions
+type bulkResponse struct {
+	Errors bool                  `json:"errors"`
+	Items  []map[string]bulkItem `json:"items"`
+}
+
+// bulkItem result of an action
+type bulkItem struct {
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// result gets the result of the only action of the item
+func (r *bulkResponse) result(i int) bulkItem {
+	for _, v := range r.Items[i] {
+		return v
+	}
+	return bulkItem{}
+}
+
+// client client of the bulk API
+type client struct {
+	conf *Config
+	http *http.Client
+	url  string
+}
+
+func newClient(conf *Config) *client {
+	return &client{
+		conf: conf,
+		http: &http.Client{
+			Timeout: conf.timeout(),
+		},
+		url: strings.TrimSuffix(conf.URL, "/") + "/_bulk",
+	}
+}
+
+// bulk sends the body of the actions and their sources in NDJSON
+func (c *client) bulk(ctx context.Context, body []byte) (r *bulkResponse, err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body)); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("Accept", "application/json")
+	if c.conf.Username != "" {
+		req.SetBasicAuth(c.conf.Username, c.conf.Password)
+	}
+	for k, v := range c.conf.Header {
+		req.Header.Set(k, v)
+	}
+
+	var resp *http.Response
+	if resp, err = c.http.Do(req); err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var data []byte
+	if data, err = io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if len(data) > maxErrorBody {
+			data = data[:maxErrorBody]
+		}
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+	r = &bulkResponse{}
+	if err = json.Unmarshal(data, r); err != nil {
+		return nil, errors.Wrapf(err, "response is not valid")
+	}
+	return
+}
+
+// ShouldRetry retries the bulk requests of too many requests, server errors and network errors
+func (c *client) ShouldRetry(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *rejectedError:
+		return true
+	case *StatusError:
+		return cause.StatusCode == http.StatusTooManyRequests || cause.StatusCode >= http.StatusInternalServerError
+	case net.Error:
+		return true
+	default:
+		return cause == io.ErrUnexpectedEOF
+	}
+}
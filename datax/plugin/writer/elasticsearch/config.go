@@ -0,0 +1,132 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go/time2"
+	"github.com/vjeantet/jodaTime"
+)
+
+// bulk actions
+const (
+	ActionIndex  = "index"  // creates or replaces the document
+	ActionUpsert = "upsert" // updates the fields of the document, or creates it if not exists
+)
+
+const (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+	defaultTimeout      = 60 * time.Second
+)
+
+// Config elasticsearch writer configuration
+type Config struct {
+	URL          string            `json:"url"`          // URL of the cluster, such as http://127.0.0.1:9200
+	Username     string            `json:"username"`     // Username of basic authentication
+	Password     string            `json:"password"`     // Password of basic authentication
+	Header       map[string]string `json:"header"`       // Request headers, such as Authorization of api keys
+	Index        string            `json:"index"`        // Index name, which can be templated by {column} or {column:format}
+	IDColumn     string            `json:"idColumn"`     // Column of the document id, generated by the cluster if empty
+	Action       string            `json:"action"`       // Bulk action, index or upsert, index if empty
+	Columns      []string          `json:"column"`       // Columns written into the documents, all columns if empty
+	TimeFormat   string            `json:"timeFormat"`   // Joda time format of time columns, RFC 3339 if empty
+	Timeout      time2.Duration    `json:"timeout"`      // Timeout of each bulk request, 60s by default
+	BatchSize    int               `json:"batchSize"`    // Number of documents of each bulk request
+	BatchTimeout time2.Duration    `json:"batchTimeout"` // Maximum time of waiting for a batch
+	MaxDirty     int64             `json:"maxDirty"`     // Maximum number of failed documents of each task, no limit if negative
+
+	index    *indexTemplate
+	goLayout string
+	setting  *config.JSON // job setting including retry
+}
+
+// NewConfig gets the elasticsearch writer configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+
+	var u *url.URL
+	if u, err = url.Parse(c.URL); err != nil {
+		return nil, fmt.Errorf("url %v is not valid: %v", c.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("url %v is not http or https", c.URL)
+	}
+	if c.index, err = parseIndexTemplate(c.Index); err != nil {
+		return nil, fmt.Errorf("index %v is not valid: %v", c.Index, err)
+	}
+	switch c.Action {
+	case "":
+		c.Action = ActionIndex
+	case ActionIndex:
+	case ActionUpsert:
+		if c.IDColumn == "" {
+			return nil, fmt.Errorf("idColumn is empty with action upsert")
+		}
+	default:
+		return nil, fmt.Errorf("action %v is not supported", c.Action)
+	}
+	if c.BatchSize < 0 {
+		return nil, fmt.Errorf("batchSize should not be negative")
+	}
+	c.goLayout = time.RFC3339Nano
+	if c.TimeFormat != "" {
+		c.goLayout = jodaTime.GetLayout(c.TimeFormat)
+	}
+
+	if c.setting, err = conf.GetConfig(coreconst.DataxJobSetting); err != nil {
+		c.setting, _ = config.NewJSONFromString("{}")
+		err = nil
+	}
+	return
+}
+
+// GetBatchSize gets the number of documents of each bulk request
+func (c *Config) GetBatchSize() int {
+	if c.BatchSize == 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
+
+// GetBatchTimeout gets the maximum time of waiting for a batch
+func (c *Config) GetBatchTimeout() time.Duration {
+	if c.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return c.BatchTimeout.Duration
+}
+
+// GetRetryStrategy gets the retry strategy of the bulk requests from the retry of the job setting
+func (c *Config) GetRetryStrategy(j schedule.RetryJudger) (schedule.RetryStrategy, error) {
+	return schedule.NewRetryStrategy(j, c.setting)
+}
+
+func (c *Config) timeout() time.Duration {
+	if c.Timeout.Duration == 0 {
+		return defaultTimeout
+	}
+	return c.Timeout.Duration
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: `{"url":"http://127.0.0.1:9200","index":"orders-{created:yyyy.MM}","idColumn":"id","action":"upsert",
+				"batchSize":500,"batchTimeout":"2s","timeFormat":"yyyy-MM-dd","job":{"setting":{"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1s"}}}}}`,
+		},
+		{
+			name:    "2",
+			conf:    `{"url":"127.0.0.1:9200","index":"orders"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    `{"url":"http://127.0.0.1:9200"}`,
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    `{"url":"http://127.0.0.1:9200","index":"orders-{created"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    `{"url":"http://127.0.0.1:9200","index":"orders","action":"upsert"}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    `{"url":"http://127.0.0.1:9200","index":"orders","action":"delete"}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			conf:    `{"url":"http://127.0.0.1:9200","index":"orders","batchSize":-1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(testJSONFromString(tt.conf))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Default(t *testing.T) {
+	c, err := NewConfig(testJSONFromString(`{"url":"http://127.0.0.1:9200","index":"orders"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Action != ActionIndex || c.GetBatchSize() != defaultBatchSize ||
+		c.GetBatchTimeout() != defaultBatchTimeout || c.timeout() != defaultTimeout {
+		t.Errorf("NewConfig() = %+v", c)
+	}
+}
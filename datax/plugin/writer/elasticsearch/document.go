@@ -0,0 +1,144 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// document action and source of the record in the bulk request
+type document struct {
+	record element.Record
+	action []byte
+	source []byte
+}
+
+type actionMeta struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id,omitempty"`
+}
+
+// newDocument converts record to the action and source of the configured bulk action
+func newDocument(conf *Config, record element.Record) (d *document, err error) {
+	d = &document{
+		record: record,
+	}
+	meta := actionMeta{}
+	if meta.Index, err = conf.index.name(record); err != nil {
+		return nil, err
+	}
+	if conf.IDColumn != "" {
+		var c element.Column
+		if c, err = record.GetByName(conf.IDColumn); err != nil {
+			return nil, err
+		}
+		if c.IsNil() {
+			return nil, errors.Errorf("idColumn %v is null", conf.IDColumn)
+		}
+		if meta.ID, err = c.AsString(); err != nil {
+			return nil, errors.Wrapf(err, "idColumn %v is not string", conf.IDColumn)
+		}
+	}
+
+	var source []byte
+	if source, err = documentSource(conf, record); err != nil {
+		return nil, err
+	}
+	switch conf.Action {
+	case ActionUpsert:
+		d.action, _ = json.Marshal(map[string]actionMeta{"update": meta})
+		d.source = append(append([]byte(`{"doc":`), source...), `,"doc_as_upsert":true}`...)
+	default:
+		d.action, _ = json.Marshal(map[string]actionMeta{"index": meta})
+		d.source = source
+	}
+	return
+}
+
+// documentSource gets the JSON object of the column names and values of record
+func documentSource(conf *Config, record element.Record) (source []byte, err error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	add := func(i int, c element.Column) error {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(c.Name())
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := jsonValue(conf, c)
+		if err != nil {
+			return errors.Wrapf(err, "column %v", c.Name())
+		}
+		buf.Write(value)
+		return nil
+	}
+
+	var c element.Column
+	if len(conf.Columns) == 0 {
+		for i := 0; i < record.ColumnNumber(); i++ {
+			if c, err = record.GetByIndex(i); err != nil {
+				return
+			}
+			if err = add(i, c); err != nil {
+				return
+			}
+		}
+	} else {
+		for i, name := range conf.Columns {
+			if c, err = record.GetByName(name); err != nil {
+				return
+			}
+			if err = add(i, c); err != nil {
+				return
+			}
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonValue gets the JSON value of the column, numbers are kept without losing precision
+// and times are formatted by timeFormat
+func jsonValue(conf *Config, c element.Column) ([]byte, error) {
+	if c.IsNil() {
+		return []byte("null"), nil
+	}
+	switch c.Type() {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal:
+		return []byte(c.String()), nil
+	case element.TypeJSON:
+		j, err := c.AsJSON()
+		if err != nil {
+			return nil, err
+		}
+		return j.ToBytes(), nil
+	case element.TypeTime:
+		t, err := c.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(t.Format(conf.goLayout))
+	}
+	s, err := c.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
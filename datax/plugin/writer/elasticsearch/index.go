@@ -0,0 +1,105 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+	"github.com/vjeantet/jodaTime"
+)
+
+// indexTemplate index name with the placeholders {column} replaced by the column value,
+// and {column:format} replaced by the time column formatted by the joda time format
+type indexTemplate struct {
+	parts []indexPart
+}
+
+type indexPart struct {
+	text   string // literal text if column is empty
+	column string
+	layout string // go layout of format
+}
+
+func parseIndexTemplate(s string) (t *indexTemplate, err error) {
+	if s == "" {
+		return nil, fmt.Errorf("index is empty")
+	}
+	t = &indexTemplate{}
+	for s != "" {
+		i := strings.IndexAny(s, "{}")
+		if i < 0 {
+			t.parts = append(t.parts, indexPart{text: s})
+			break
+		}
+		if s[i] == '}' {
+			return nil, fmt.Errorf("unexpected }")
+		}
+		if i > 0 {
+			t.parts = append(t.parts, indexPart{text: s[:i]})
+		}
+		s = s[i+1:]
+		j := strings.IndexAny(s, "{}")
+		if j < 0 || s[j] == '{' {
+			return nil, fmt.Errorf("{ is not closed")
+		}
+		column, format, _ := strings.Cut(s[:j], ":")
+		if column == "" {
+			return nil, fmt.Errorf("column of placeholder is empty")
+		}
+		p := indexPart{column: column}
+		if format != "" {
+			p.layout = jodaTime.GetLayout(format)
+		}
+		t.parts = append(t.parts, p)
+		s = s[j+1:]
+	}
+	return
+}
+
+// name gets the index name of record
+func (t *indexTemplate) name(record element.Record) (string, error) {
+	var b strings.Builder
+	for _, p := range t.parts {
+		if p.column == "" {
+			b.WriteString(p.text)
+			continue
+		}
+		c, err := record.GetByName(p.column)
+		if err != nil {
+			return "", err
+		}
+		if c.IsNil() {
+			return "", errors.Errorf("column %v of index is null", p.column)
+		}
+		if p.layout != "" {
+			tm, err := c.AsTime()
+			if err != nil {
+				return "", errors.Wrapf(err, "column %v of index is not time", p.column)
+			}
+			b.WriteString(tm.Format(p.layout))
+			continue
+		}
+		s, err := c.AsString()
+		if err != nil {
+			return "", errors.Wrapf(err, "column %v of index is not string", p.column)
+		}
+		b.WriteString(s)
+	}
+	// index names of elasticsearch must be lowercase
+	return strings.ToLower(b.String()), nil
+}
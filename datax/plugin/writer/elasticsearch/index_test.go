@@ -0,0 +1,70 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testRecord() element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0))
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue("CN"), "region", 0))
+	r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)), "created", 0))
+	r.Add(element.NewDefaultColumn(element.NewNilStringColumnValue(), "note", 0))
+	return r
+}
+
+func TestIndexTemplate_name(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{name: "1", template: "orders", want: "orders"},
+		{name: "2", template: "orders-{region}-{created:yyyy.MM.dd}", want: "orders-cn-2026.10.19"},
+		{name: "3", template: "{id}", want: "1"},
+		{name: "4", template: "orders-{none}", wantErr: true},
+		{name: "5", template: "orders-{note}", wantErr: true},
+		{name: "6", template: "orders-{region:yyyy}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseIndexTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("parseIndexTemplate() error = %v", err)
+			}
+			got, err := tmpl.name(testRecord())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("indexTemplate.name() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("indexTemplate.name() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIndexTemplate(t *testing.T) {
+	for _, s := range []string{"", "a}", "a{", "a{b{c}}", "a{}", "a{:yyyy}"} {
+		if _, err := parseIndexTemplate(s); err == nil {
+			t.Errorf("parseIndexTemplate(%q) error = nil", s)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/dbms"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/pingcap/errors"
+)
+
+// Task elasticsearch writer task
+type Task struct {
+	*writer.BaseTask
+
+	conf     *Config
+	client   *client
+	strategy schedule.RetryStrategy
+	dirty    int64 // number of failed documents
+}
+
+// NewTask creates a new elasticsearch writer task
+func NewTask() *Task {
+	return &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+}
+
+// Init initializes the task
+func (t *Task) Init(ctx context.Context) (err error) {
+	if t.conf, err = NewConfig(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewConfig fail")
+	}
+	t.client = newClient(t.conf)
+	if t.strategy, err = t.conf.GetRetryStrategy(t.client); err != nil {
+		return t.Wrapf(err, "GetRetryStrategy fail")
+	}
+	return
+}
+
+// Destroy destroys the task
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+// StartWrite writes the records from the receiver by bulk requests in batches
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+	return dbms.StartWrite(ctx, t, receiver)
+}
+
+// BatchSize gets the number of documents of each bulk request
+func (t *Task) BatchSize() int {
+	return t.conf.GetBatchSize()
+}
+
+// BatchTimeout gets the maximum time of waiting for a batch
+func (t *Task) BatchTimeout() time.Duration {
+	return t.conf.GetBatchTimeout()
+}
+
+// BatchWrite writes records by a bulk request. The documents rejected by 429 are
+// sent again by the retry strategy, and the other failed documents are collected
+// as dirty records, which fail the task when their number exceeds maxDirty
+func (t *Task) BatchWrite(ctx context.Context, records []element.Record) (err error) {
+	var docs []*document
+	for _, r := range records {
+		d, err := newDocument(t.conf, r)
+		if err != nil {
+			t.collectDirty(r, err)
+			continue
+		}
+		docs = append(docs, d)
+	}
+
+	if len(docs) > 0 {
+		err = schedule.NewRetryTask(ctx, t.strategy, schedule.TaskFunc(func() (err error) {
+			docs, err = t.bulk(ctx, docs)
+			return
+		})).Do()
+		if _, ok := errors.Cause(err).(*rejectedError); ok {
+			for _, d := range docs {
+				t.collectDirty(d.record, err)
+			}
+			err = nil
+		}
+		if err != nil {
+			return t.Wrapf(err, "bulk fail")
+		}
+	}
+
+	if t.conf.MaxDirty >= 0 && t.dirty > t.conf.MaxDirty {
+		return errors.Errorf(t.Format("the number of failed documents %v exceeds maxDirty %v"), t.dirty, t.conf.MaxDirty)
+	}
+	return nil
+}
+
+// bulk sends docs by a bulk request and returns the documents rejected by 429 with rejectedError
+func (t *Task) bulk(ctx context.Context, docs []*document) (rejected []*document, err error) {
+	var buf bytes.Buffer
+	for _, d := range docs {
+		buf.Write(d.action)
+		buf.WriteByte('\n')
+		buf.Write(d.source)
+		buf.WriteByte('\n')
+	}
+
+	var r *bulkResponse
+	if r, err = t.client.bulk(ctx, buf.Bytes()); err != nil {
+		log.Warnf(t.Format("bulk fail. err: %v"), err)
+		return docs, err
+	}
+	if len(r.Items) != len(docs) {
+		return docs, errors.Errorf("the number of items %v is not %v", len(r.Items), len(docs))
+	}
+	if !r.Errors {
+		return nil, nil
+	}
+	for i, d := range docs {
+		item := r.result(i)
+		switch {
+		case item.Status >= 200 && item.Status < 300:
+		case item.Status == http.StatusTooManyRequests:
+			rejected = append(rejected, d)
+		default:
+			t.collectDirty(d.record, &StatusError{StatusCode: item.Status, Body: string(item.Error)})
+		}
+	}
+	if len(rejected) > 0 {
+		log.Warnf(t.Format("%v documents are rejected"), len(rejected))
+		return rejected, &rejectedError{StatusError{StatusCode: http.StatusTooManyRequests, Body: "documents are rejected"}}
+	}
+	return nil, nil
+}
+
+// collectDirty collects the failed record into the task collector
+func (t *Task) collectDirty(record element.Record, err error) {
+	t.dirty++
+	log.Errorf(t.Format("dirty record %v. err: %v"), record, err)
+	if c := t.TaskCollector(); c != nil {
+		c.CollectDirtyRecordWithError(record, err)
+	}
+}
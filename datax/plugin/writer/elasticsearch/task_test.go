@@ -0,0 +1,247 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	if len(m.records) == 0 {
+		return nil, m.err
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+type mockCollector struct {
+	dirty []string
+}
+
+func (m *mockCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	m.dirty = append(m.dirty, record.String())
+}
+
+func (m *mockCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {}
+
+func (m *mockCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {}
+
+func (m *mockCollector) CollectMessage(key string, value string) {}
+
+// mockCluster stand-in of the bulk API, the documents with "bad" fail with 400,
+// and the documents with "busy" are rejected with 429 for rejects times
+type mockCluster struct {
+	mu       sync.Mutex
+	status   int      // status code of the whole response if not 0
+	rejects  int      // times of rejecting the busy documents
+	lines    []string // action and source lines of the succeeded documents
+	requests int
+}
+
+func (m *mockCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests++
+	if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if user, password, _ := r.BasicAuth(); user != "elastic" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if m.status != 0 {
+		w.WriteHeader(m.status)
+		m.status = 0
+		return
+	}
+
+	var items []string
+	errs := false
+	reject := m.rejects > 0
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		action := scanner.Text()
+		scanner.Scan()
+		source := scanner.Text()
+		status := http.StatusCreated
+		switch {
+		case strings.Contains(source, "bad"):
+			status = http.StatusBadRequest
+		case strings.Contains(source, "busy") && reject:
+			status = http.StatusTooManyRequests
+		default:
+			m.lines = append(m.lines, action, source)
+		}
+		if status != http.StatusCreated {
+			errs = true
+			items = append(items, fmt.Sprintf(`{"index":{"status":%d,"error":{"type":"error%d"}}}`, status, status))
+			continue
+		}
+		items = append(items, `{"index":{"status":201}}`)
+	}
+	if reject {
+		m.rejects--
+	}
+	fmt.Fprintf(w, `{"took":1,"errors":%v,"items":[%v]}`, errs, strings.Join(items, ","))
+}
+
+func testRecords(values ...string) (records []element.Record) {
+	for i, v := range values {
+		r := element.NewDefaultRecord()
+		r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(int64(i+1)), "id", 0))
+		r.Add(element.NewDefaultColumn(element.NewStringColumnValue(v), "v", 0))
+		r.Add(element.NewDefaultColumn(element.NewDecimalColumnValueFromFloat(1.5), "d", 0))
+		r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)), "t", 0))
+		r.Add(element.NewDefaultColumn(element.NewNilBoolColumnValue(), "b", 0))
+		records = append(records, r)
+	}
+	return
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name      string
+		conf      string
+		cluster   *mockCluster
+		records   []element.Record
+		wantLines []string
+		wantDirty []string
+		wantErr   bool
+	}{
+		{
+			name:    "1",
+			conf:    `"index":"orders-{t:yyyy.MM}","idColumn":"id","column":["id","v","t","b"],"batchSize":2`,
+			cluster: &mockCluster{},
+			records: testRecords("a", "b"),
+			wantLines: []string{
+				`{"index":{"_index":"orders-2026.10","_id":"1"}}`,
+				`{"id":1,"v":"a","t":"2026-10-19T08:00:00Z","b":null}`,
+				`{"index":{"_index":"orders-2026.10","_id":"2"}}`,
+				`{"id":2,"v":"b","t":"2026-10-19T08:00:00Z","b":null}`,
+			},
+		},
+		{
+			name:    "2",
+			conf:    `"index":"orders","idColumn":"v","action":"upsert","timeFormat":"yyyy-MM-dd","maxDirty":-1`,
+			cluster: &mockCluster{},
+			records: testRecords("a", "bad"),
+			wantLines: []string{
+				`{"update":{"_index":"orders","_id":"a"}}`,
+				`{"doc":{"id":1,"v":"a","d":1.5,"t":"2026-10-19","b":null},"doc_as_upsert":true}`,
+			},
+			wantDirty: []string{"id=2 v=bad d=1.5 t=2026-10-19 08:00:00Z b=<nil>"},
+		},
+		{
+			name:      "3",
+			conf:      `"index":"orders","column":["v"],"maxDirty":0`,
+			cluster:   &mockCluster{},
+			records:   testRecords("bad"),
+			wantDirty: []string{"id=1 v=bad d=1.5 t=2026-10-19 08:00:00Z b=<nil>"},
+			wantErr:   true,
+		},
+		{
+			name:    "4",
+			conf:    `"index":"orders","column":["v"],"job":{"setting":{"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}}}`,
+			cluster: &mockCluster{status: http.StatusServiceUnavailable, rejects: 1},
+			records: testRecords("a", "busy"),
+			wantLines: []string{
+				`{"index":{"_index":"orders"}}`,
+				`{"v":"a"}`,
+				`{"index":{"_index":"orders"}}`,
+				`{"v":"busy"}`,
+			},
+		},
+		{
+			name:      "5",
+			conf:      `"index":"orders","column":["v"],"maxDirty":1,"job":{"setting":{"retry":{"type":"ntimes","strategy":{"n":1,"wait":"1ms"}}}}`,
+			cluster:   &mockCluster{rejects: 2},
+			records:   testRecords("busy"),
+			wantDirty: []string{"id=1 v=busy d=1.5 t=2026-10-19 08:00:00Z b=<nil>"},
+		},
+		{
+			name:    "6",
+			conf:    `"index":"orders"`,
+			cluster: &mockCluster{status: http.StatusServiceUnavailable},
+			records: testRecords("a"),
+			wantErr: true,
+		},
+		{
+			name:      "7",
+			conf:      `"index":"orders-{none}","maxDirty":-1`,
+			cluster:   &mockCluster{},
+			records:   testRecords("a"),
+			wantDirty: []string{"id=1 v=a d=1.5 t=2026-10-19 08:00:00Z b=<nil>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(tt.cluster)
+			defer s.Close()
+			task := NewTask()
+			task.SetPluginJobConf(testJSONFromString(fmt.Sprintf(`{"url":"%v","username":"elastic","password":"secret",%v}`, s.URL, tt.conf)))
+			collector := &mockCollector{}
+			task.SetTaskCollector(collector)
+			if err := task.Init(context.Background()); err != nil {
+				t.Fatalf("Task.Init() error = %v", err)
+			}
+			err := task.StartWrite(context.Background(), &mockReceiver{records: tt.records, err: exchange.ErrTerminate})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.cluster.lines, tt.wantLines) {
+				t.Errorf("Task.StartWrite() lines = %v, want %v", tt.cluster.lines, tt.wantLines)
+			}
+			if !reflect.DeepEqual(collector.dirty, tt.wantDirty) {
+				t.Errorf("Task.StartWrite() dirty = %v, want %v", collector.dirty, tt.wantDirty)
+			}
+		})
+	}
+}
+
+func TestBulkResponse(t *testing.T) {
+	var r bulkResponse
+	data := `{"errors":true,"items":[{"update":{"status":404,"error":{"type":"document_missing_exception"}}}]}`
+	if err := json.NewDecoder(bytes.NewBufferString(data)).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	if item := r.result(0); item.Status != 404 || string(item.Error) != `{"type":"document_missing_exception"}` {
+		t.Errorf("bulkResponse.result() = %+v", item)
+	}
+}
@@ -16,9 +16,11 @@ package file
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
+	spiwriter "github.com/Breeze0806/go-etl/datax/common/spi/writer"
 	"github.com/Breeze0806/go/time2"
 )
 
@@ -29,14 +31,35 @@ var (
 
 // Config - File Stream Output Configuration
 type Config interface {
-	GetBatchSize() int              // Single Batch Write Size - The number of records to be written in a single batch.
-	GetBatchTimeout() time.Duration // Single Batch Write Timeout - The maximum time allowed for a single batch write operation.
+	GetBatchSize() int                           // Single Batch Write Size - The number of records to be written in a single batch.
+	GetBatchTimeout() time.Duration              // Single Batch Write Timeout - The maximum time allowed for a single batch write operation.
+	GetOutputConfig() OutputConfig               // Output Files Configuration - Rotation, partitions and atomic renaming of the output files.
+	GetColumnMappings() spiwriter.ColumnMappings // Column Mappings - Mappings from the columns of the reader to the columns written by name.
 }
 
 // BaseConfig - Basic File Stream Output Configuration
 type BaseConfig struct {
-	BatchSize    int            `json:"batchSize"`    // Single Batch Write Size - The number of records to be written in a single batch.
-	BatchTimeout time2.Duration `json:"batchTimeout"` // Single Batch Write Timeout - The maximum time allowed for a single batch write operation.
+	BatchSize     int                      `json:"batchSize"`               // Single Batch Write Size - The number of records to be written in a single batch.
+	BatchTimeout  time2.Duration           `json:"batchTimeout"`            // Single Batch Write Timeout - The maximum time allowed for a single batch write operation.
+	ColumnMapping spiwriter.ColumnMappings `json:"columnMapping,omitempty"` // Column Mappings - The columns written are the targets in order if not empty.
+
+	OutputConfig
+}
+
+// OutputConfig - Output Files Configuration
+type OutputConfig struct {
+	FileRecords int64       `json:"fileRecords,omitempty"` // Maximum Records of a File - A new file is rolled to when reached, no limit if 0.
+	FileSize    int64       `json:"fileSize,omitempty"`    // Maximum Bytes of a File - Checked after each flush, a new file is rolled to when reached, no limit if 0.
+	Partitions  []Partition `json:"partition,omitempty"`   // Partitions - Records are routed into the directories derived from the column values.
+	Atomic      bool        `json:"atomic,omitempty"`      // Atomic - Files are written as temporary files and renamed when closed.
+}
+
+// Partition - Directory Partition by Column Value
+type Partition struct {
+	Name   string `json:"name"`   // Partition Name - The directory is name=value, or value if empty.
+	Column string `json:"column"` // Column Name - The column of the record used as the value.
+	Index  int    `json:"index"`  // Column Index - The column of the record starting from 1, used if Column is empty.
+	Format string `json:"format"` // Joda Time Format - The format of the value if the column is time.
 }
 
 // NewBaseConfig - Retrieves the basic file stream output configuration from a JSON configuration.
@@ -45,6 +68,21 @@ func NewBaseConfig(conf *config.JSON) (*BaseConfig, error) {
 	if err := json.Unmarshal([]byte(conf.String()), c); err != nil {
 		return nil, err
 	}
+
+	if c.FileRecords < 0 {
+		return nil, fmt.Errorf("fileRecords should not be negative")
+	}
+	if c.FileSize < 0 {
+		return nil, fmt.Errorf("fileSize should not be negative")
+	}
+	if err := c.ColumnMapping.Validate(); err != nil {
+		return nil, fmt.Errorf("columnMapping is not valid. error: %v", err)
+	}
+	for _, v := range c.Partitions {
+		if v.Column == "" && v.Index < 1 {
+			return nil, fmt.Errorf("partition %v should have column or index not less than 1", v.Name)
+		}
+	}
 	return c, nil
 }
 
@@ -64,3 +102,18 @@ func (b *BaseConfig) GetBatchSize() int {
 
 	return b.BatchSize
 }
+
+// GetOutputConfig - Retrieves the configuration of rotation, partitions and atomic renaming of the output files.
+func (b *BaseConfig) GetOutputConfig() OutputConfig {
+	return b.OutputConfig
+}
+
+// GetColumnMappings - Retrieves the mappings from the columns of the reader to the columns written.
+func (b *BaseConfig) GetColumnMappings() spiwriter.ColumnMappings {
+	return b.ColumnMapping
+}
+
+// rolled - Whether the output file names have sequence numbers.
+func (o *OutputConfig) rolled() bool {
+	return o.FileRecords > 0 || o.FileSize > 0 || len(o.Partitions) > 0
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
+	spiwriter "github.com/Breeze0806/go-etl/datax/common/spi/writer"
 )
 
 func testJSONFromString(json string) *config.JSON {
@@ -89,3 +90,73 @@ func TestBaseConfig_GetBatchSize(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBaseConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		want    *BaseConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{"fileRecords":100,"fileSize":1024,"atomic":true,"partition":[{"name":"dt","column":"c1","format":"yyyy-MM-dd"}]}`),
+			want: &BaseConfig{
+				OutputConfig: OutputConfig{
+					FileRecords: 100,
+					FileSize:    1024,
+					Atomic:      true,
+					Partitions: []Partition{
+						{Name: "dt", Column: "c1", Format: "yyyy-MM-dd"},
+					},
+				},
+			},
+		},
+		{
+			name:    "2",
+			conf:    testJSONFromString(`{"fileRecords":-1}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"fileSize":-1}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"partition":[{"name":"dt"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"fileRecords":"1"}`),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    testJSONFromString(`{"columnMapping":[{"source":"a"}]}`),
+			wantErr: true,
+		},
+		{
+			name: "7",
+			conf: testJSONFromString(`{"columnMapping":[{"source":"a","target":"b"}]}`),
+			want: &BaseConfig{
+				ColumnMapping: spiwriter.ColumnMappings{
+					{Source: "a", Target: "b"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBaseConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBaseConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBaseConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/pingcap/errors"
+	"github.com/vjeantet/jodaTime"
+)
+
+const (
+	tempPrefix       = ".tmp-"    // prefix of temporary file names, the extension is kept for the creator
+	nullPartition    = "__NULL__" // partition value of null columns
+	partitionReplace = "_"        // replacement of path separators in partition values
+)
+
+// output - the output file being written
+type output struct {
+	fsys     *file.FS // file systems of the file
+	path     string   // path of the file
+	temp     string   // path of the temporary file renamed to path when committed, empty if not atomic
+	streamer *file.OutStreamer
+	writer   file.StreamWriter
+	records  int64
+}
+
+// name - the name of the file being written
+func (o *output) name() string {
+	if o.temp != "" {
+		return o.temp
+	}
+	return o.path
+}
+
+// finish closes the writer and the stream of the file
+func (o *output) finish() (err error) {
+	if o.writer != nil {
+		err = errors.Wrapf(o.writer.Close(), "Close writer of %v fail", o.name())
+		o.writer = nil
+	}
+	if o.streamer != nil {
+		if cerr := o.streamer.Close(); err == nil {
+			err = errors.Wrapf(cerr, "Close %v fail", o.name())
+		}
+		o.streamer = nil
+	}
+	return
+}
+
+// commit renames the temporary file of the finished file to path if commit is true,
+// or removes it otherwise
+func (o *output) commit(commit bool) error {
+	if o.temp == "" {
+		return nil
+	}
+	if !commit {
+		o.fsys.Remove(o.temp)
+		return nil
+	}
+	return errors.Wrapf(o.fsys.Rename(o.temp, o.path), "Rename(%v, %v) fail", o.temp, o.path)
+}
+
+// outputs - the output files of a task, rolled by records or bytes and routed by partitions
+type outputs struct {
+	fsys    *file.FS
+	creator string
+	path    string
+	content *config.JSON
+	conf    OutputConfig
+
+	seqs    map[string]int     // the last sequence number of each partition directory
+	opened  map[string]*output // the file being written of each partition directory
+	rotated []*output          // the finished files committed together with the opened files
+}
+
+func newOutputs(fsys *file.FS, creator, path string, content *config.JSON, conf OutputConfig) *outputs {
+	return &outputs{
+		fsys:    fsys,
+		creator: creator,
+		path:    path,
+		content: content,
+		conf:    conf,
+		seqs:    make(map[string]int),
+		opened:  make(map[string]*output),
+	}
+}
+
+// filename gets the file name of the partition directory dir with the sequence number seq.
+// The file name is path if not rolled, otherwise the sequence number is inserted before the
+// extension of path, such as dir/part.csv.gz becomes dir/dt=2026-10-18/part-0001.csv.gz
+func (o *outputs) filename(dir string, seq int) string {
+	if !o.conf.rolled() {
+		return o.path
+	}
+	base := file.Base(o.path)
+	name, ext := base, ""
+	if i := strings.IndexByte(base, '.'); i > 0 {
+		name, ext = base[:i], base[i:]
+	}
+	return file.Join(file.Dir(o.path), dir, fmt.Sprintf("%s-%04d%s", name, seq, ext))
+}
+
+// open opens the next file of the partition directory dir
+func (o *outputs) open(dir string) (out *output, err error) {
+	o.seqs[dir]++
+	out = &output{
+		fsys: o.fsys,
+		path: o.filename(dir, o.seqs[dir]),
+	}
+	if o.conf.Atomic {
+		out.temp = file.Join(file.Dir(out.path), tempPrefix+file.Base(out.path))
+	}
+	if dir != "" {
+		if err = o.fsys.MkdirAll(file.Dir(out.path)); err != nil {
+			return nil, errors.Wrapf(err, "MkdirAll(%v) fail", file.Dir(out.path))
+		}
+	}
+	if out.streamer, err = file.NewOutStreamer(o.fsys, o.creator, out.name()); err != nil {
+		return nil, errors.Wrapf(err, "NewOutStreamer(%v) fail", out.name())
+	}
+	o.opened[dir] = out
+	return
+}
+
+// start creates the writers of the opened files
+func (o *outputs) start() (err error) {
+	for _, out := range o.opened {
+		if out.writer == nil {
+			if out.writer, err = out.streamer.Writer(o.content); err != nil {
+				return errors.Wrapf(err, "Writer of %v fail", out.name())
+			}
+		}
+	}
+	return
+}
+
+// write writes the record into the file of its partition directory,
+// and the file is rotated when the maximum records are reached
+func (o *outputs) write(record element.Record) (err error) {
+	var dir string
+	if dir, err = o.partition(record); err != nil {
+		return
+	}
+	out, ok := o.opened[dir]
+	if !ok {
+		if out, err = o.open(dir); err != nil {
+			return
+		}
+	}
+	if out.writer == nil {
+		if out.writer, err = out.streamer.Writer(o.content); err != nil {
+			return errors.Wrapf(err, "Writer of %v fail", out.name())
+		}
+	}
+	if err = out.writer.Write(record); err != nil {
+		return
+	}
+	out.records++
+	if o.conf.FileRecords > 0 && out.records >= o.conf.FileRecords {
+		return o.rotate(dir, out)
+	}
+	return
+}
+
+// rotate finishes the file of the partition directory dir, which is held until
+// all the files are committed or rolled back when closed
+func (o *outputs) rotate(dir string, out *output) (err error) {
+	delete(o.opened, dir)
+	if err = out.finish(); err != nil {
+		out.commit(false)
+		return
+	}
+	o.rotated = append(o.rotated, out)
+	return
+}
+
+// flush flushes all the opened files,
+// and the files are rotated when the maximum bytes are reached
+func (o *outputs) flush() (err error) {
+	for dir, out := range o.opened {
+		if out.writer == nil {
+			continue
+		}
+		if err = out.writer.Flush(); err != nil {
+			return
+		}
+		if o.conf.FileSize <= 0 {
+			continue
+		}
+		// some files such as xlsx or objects of the remote file systems
+		// are written only when closed
+		var fi os.FileInfo
+		if fi, err = o.fsys.Stat(out.name()); os.IsNotExist(err) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Stat(%v) fail", out.name())
+		}
+		if fi.Size() >= o.conf.FileSize {
+			if err = o.rotate(dir, out); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// close finishes all the opened files, and then the rotated and opened files are committed
+// together if commit is true and all of them are finished, or rolled back otherwise.
+// A failed rename in the middle of the commit leaves the files renamed before it committed
+func (o *outputs) close(commit bool) (err error) {
+	for dir, out := range o.opened {
+		if cerr := out.finish(); err == nil {
+			err = cerr
+		}
+		o.rotated = append(o.rotated, out)
+		delete(o.opened, dir)
+	}
+	for _, out := range o.rotated {
+		if cerr := out.commit(commit && err == nil); err == nil {
+			err = cerr
+		}
+	}
+	o.rotated = nil
+	return
+}
+
+// partition gets the partition directory of the record
+func (o *outputs) partition(record element.Record) (dir string, err error) {
+	if len(o.conf.Partitions) == 0 {
+		return
+	}
+	var dirs []string
+	for _, p := range o.conf.Partitions {
+		var v string
+		if v, err = p.value(record); err != nil {
+			return
+		}
+		if p.Name != "" {
+			v = p.Name + "=" + v
+		}
+		dirs = append(dirs, v)
+	}
+	return path.Join(dirs...), nil
+}
+
+// value gets the partition value of the record, which is safe as a directory name
+func (p *Partition) value(record element.Record) (v string, err error) {
+	var col element.Column
+	if p.Column != "" {
+		col, err = record.GetByName(p.Column)
+	} else {
+		col, err = record.GetByIndex(p.Index - 1)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "get column of partition %v fail", p.Name)
+	}
+
+	switch {
+	case col.IsNil():
+		return nullPartition, nil
+	case p.Format != "" && col.Type() == element.TypeTime:
+		var t time.Time
+		if t, err = col.AsTime(); err != nil {
+			return "", errors.Wrapf(err, "AsTime of partition %v fail", p.Name)
+		}
+		v = t.Format(jodaTime.GetLayout(p.Format))
+	default:
+		if v, err = col.AsString(); err != nil {
+			return "", errors.Wrapf(err, "AsString of partition %v fail", p.Name)
+		}
+	}
+
+	v = strings.NewReplacer("/", partitionReplace, `\`, partitionReplace).Replace(v)
+	if v == "" || v == "." || v == ".." {
+		v = partitionReplace + v
+	}
+	return
+}
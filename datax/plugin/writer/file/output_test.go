@@ -0,0 +1,384 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+// mockFileStream writes the string of the first column of each record as a line
+type mockFileStream struct {
+	f *os.File
+}
+
+func (m *mockFileStream) Writer(conf *config.JSON) (file.StreamWriter, error) {
+	return &mockFileWriter{f: m.f}, nil
+}
+
+func (m *mockFileStream) Close() (err error) {
+	return m.f.Close()
+}
+
+type mockFileWriter struct {
+	f *os.File
+}
+
+func (m *mockFileWriter) Write(record element.Record) (err error) {
+	var col element.Column
+	if col, err = record.GetByIndex(0); err != nil {
+		return
+	}
+	_, err = m.f.WriteString(col.String() + "\n")
+	return
+}
+
+func (m *mockFileWriter) Flush() (err error) {
+	return
+}
+
+func (m *mockFileWriter) Close() (err error) {
+	return
+}
+
+type mockFileCreater struct{}
+
+func (m *mockFileCreater) Create(fsys *file.FS, filename string) (file.OutStream, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &mockFileStream{f: f}, nil
+}
+
+func testRecord(values ...element.ColumnValue) element.Record {
+	r := element.NewDefaultRecord()
+	for i, v := range values {
+		r.Add(element.NewDefaultColumn(v, "c"+string(rune('1'+i)), 0))
+	}
+	return r
+}
+
+// testFiles gets the relative paths and contents of the files in dir
+func testFiles(dir string) (files map[string]string) {
+	files = make(map[string]string)
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, _ := os.ReadFile(path)
+		rel, _ := filepath.Rel(dir, path)
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	return
+}
+
+func TestOutputs(t *testing.T) {
+	file.UnregisterAllCreater()
+	file.RegisterCreator("mockFile", &mockFileCreater{})
+	day := time.Date(2026, 10, 18, 1, 2, 3, 0, time.UTC)
+	records := []element.Record{
+		testRecord(element.NewStringColumnValue("a"), element.NewTimeColumnValue(day)),
+		testRecord(element.NewStringColumnValue("b"), element.NewTimeColumnValue(day)),
+		testRecord(element.NewStringColumnValue("c"), element.NewTimeColumnValue(day.AddDate(0, 0, 1))),
+		testRecord(element.NewStringColumnValue("../d"), element.NewTimeColumnValue(day)),
+		testRecord(element.NewStringColumnValue("e"), element.NewNilStringColumnValue()),
+	}
+	tests := []struct {
+		name   string
+		conf   OutputConfig
+		commit bool
+		want   map[string]string
+	}{
+		{
+			name:   "1",
+			conf:   OutputConfig{},
+			commit: true,
+			want: map[string]string{
+				"part.csv.gz": "a\nb\nc\n../d\ne\n",
+			},
+		},
+		{
+			name:   "2",
+			conf:   OutputConfig{FileRecords: 2},
+			commit: true,
+			want: map[string]string{
+				"part-0001.csv.gz": "a\nb\n",
+				"part-0002.csv.gz": "c\n../d\n",
+				"part-0003.csv.gz": "e\n",
+			},
+		},
+		{
+			name:   "3",
+			conf:   OutputConfig{FileSize: 1},
+			commit: true,
+			want: map[string]string{
+				"part-0001.csv.gz": "a\nb\n",
+				"part-0002.csv.gz": "c\n../d\n",
+				"part-0003.csv.gz": "e\n",
+			},
+		},
+		{
+			name: "4",
+			conf: OutputConfig{
+				Partitions: []Partition{
+					{Name: "dt", Index: 2, Format: "yyyy-MM-dd"},
+				},
+			},
+			commit: true,
+			want: map[string]string{
+				"dt=2026-10-18/part-0001.csv.gz": "a\nb\n../d\n",
+				"dt=2026-10-19/part-0001.csv.gz": "c\n",
+				"dt=__NULL__/part-0001.csv.gz":   "e\n",
+			},
+		},
+		{
+			name: "5",
+			conf: OutputConfig{
+				Partitions: []Partition{
+					{Column: "c1"},
+				},
+				FileRecords: 1,
+				Atomic:      true,
+			},
+			commit: true,
+			want: map[string]string{
+				"a/part-0001.csv.gz":    "a\n",
+				"b/part-0001.csv.gz":    "b\n",
+				"c/part-0001.csv.gz":    "c\n",
+				".._d/part-0001.csv.gz": "../d\n",
+				"e/part-0001.csv.gz":    "e\n",
+			},
+		},
+		{
+			name:   "6",
+			conf:   OutputConfig{Atomic: true},
+			commit: false,
+			want:   map[string]string{},
+		},
+		{
+			name:   "7",
+			conf:   OutputConfig{FileRecords: 2, Atomic: true},
+			commit: false,
+			want:   map[string]string{},
+		},
+		{
+			name:   "8",
+			conf:   OutputConfig{FileSize: 1, Atomic: true},
+			commit: false,
+			want:   map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			o := newOutputs(nil, "mockFile", filepath.Join(dir, "part.csv.gz"), nil, tt.conf)
+			for i, r := range records {
+				if err := o.write(r); err != nil {
+					t.Fatalf("outputs.write() error = %v", err)
+				}
+				// flush after every two records
+				if i%2 == 1 {
+					if err := o.flush(); err != nil {
+						t.Fatalf("outputs.flush() error = %v", err)
+					}
+				}
+			}
+			if err := o.close(tt.commit); err != nil {
+				t.Fatalf("outputs.close() error = %v", err)
+			}
+			if got := testFiles(dir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputs_Atomic(t *testing.T) {
+	file.UnregisterAllCreater()
+	file.RegisterCreator("mockFile", &mockFileCreater{})
+	dir := t.TempDir()
+	o := newOutputs(nil, "mockFile", filepath.Join(dir, "a.csv"), nil, OutputConfig{Atomic: true})
+	if err := o.write(testRecord(element.NewStringColumnValue("a"))); err != nil {
+		t.Fatalf("outputs.write() error = %v", err)
+	}
+	// only the temporary file is visible before closed
+	var got []string
+	for k := range testFiles(dir) {
+		got = append(got, k)
+	}
+	sort.Strings(got)
+	if want := []string{tempPrefix + "a.csv"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+	if err := o.close(true); err != nil {
+		t.Fatalf("outputs.close() error = %v", err)
+	}
+	if got, want := testFiles(dir), map[string]string{"a.csv": "a\n"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+}
+
+func TestOutputs_AtomicRotated(t *testing.T) {
+	file.UnregisterAllCreater()
+	file.RegisterCreator("mockFile", &mockFileCreater{})
+	dir := t.TempDir()
+	o := newOutputs(nil, "mockFile", filepath.Join(dir, "a.csv"), nil, OutputConfig{FileRecords: 1, Atomic: true})
+	for _, v := range []string{"a", "b"} {
+		if err := o.write(testRecord(element.NewStringColumnValue(v))); err != nil {
+			t.Fatalf("outputs.write() error = %v", err)
+		}
+	}
+	// the rotated files are not committed before closed
+	var got []string
+	for k := range testFiles(dir) {
+		got = append(got, k)
+	}
+	sort.Strings(got)
+	if want := []string{tempPrefix + "a-0001.csv", tempPrefix + "a-0002.csv"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+	if err := o.close(true); err != nil {
+		t.Fatalf("outputs.close() error = %v", err)
+	}
+	if got, want := testFiles(dir), map[string]string{"a-0001.csv": "a\n", "a-0002.csv": "b\n"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+}
+
+func TestPartition_value(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       *Partition
+		record  element.Record
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "1",
+			p:      &Partition{Index: 1},
+			record: testRecord(element.NewStringColumnValue(`a/b\c`)),
+			want:   "a_b_c",
+		},
+		{
+			name:   "2",
+			p:      &Partition{Index: 1},
+			record: testRecord(element.NewStringColumnValue("")),
+			want:   "_",
+		},
+		{
+			name:   "3",
+			p:      &Partition{Column: "c1"},
+			record: testRecord(element.NewBigIntColumnValueFromInt64(10)),
+			want:   "10",
+		},
+		{
+			name:    "4",
+			p:       &Partition{Column: "c2"},
+			record:  testRecord(element.NewBigIntColumnValueFromInt64(10)),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			p:       &Partition{Index: 2},
+			record:  testRecord(element.NewBigIntColumnValueFromInt64(10)),
+			wantErr: true,
+		},
+		{
+			name:   "6",
+			p:      &Partition{Index: 1, Format: "yyyyMM"},
+			record: testRecord(element.NewTimeColumnValue(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))),
+			want:   "202610",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.value(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Partition.value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Partition.value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputs_filename(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		conf OutputConfig
+		dir  string
+		seq  int
+		want string
+	}{
+		{
+			name: "1",
+			path: "out/a.csv",
+			want: "out/a.csv",
+		},
+		{
+			name: "2",
+			path: "out/a.csv.gz",
+			conf: OutputConfig{FileRecords: 1},
+			seq:  12,
+			want: "out/a-0012.csv.gz",
+		},
+		{
+			name: "3",
+			path: "out/a",
+			conf: OutputConfig{FileSize: 1},
+			dir:  "dt=1",
+			seq:  1,
+			want: "out/dt=1/a-0001",
+		},
+		{
+			name: "4",
+			path: ".a",
+			conf: OutputConfig{FileSize: 1},
+			seq:  1,
+			want: ".a-0001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOutputs(nil, "", tt.path, nil, tt.conf)
+			if got := o.filename(tt.dir, tt.seq); filepath.ToSlash(got) != tt.want {
+				t.Errorf("outputs.filename() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputs_Error(t *testing.T) {
+	file.UnregisterAllCreater()
+	o := newOutputs(nil, "mockFile", "a.csv", nil, OutputConfig{})
+	if err := o.write(testRecord(element.NewStringColumnValue("a"))); err == nil || !strings.Contains(err.Error(), "NewOutStreamer") {
+		t.Errorf("outputs.write() error = %v", err)
+	}
+}
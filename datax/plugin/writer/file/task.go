@@ -31,10 +31,12 @@ import (
 type Task struct {
 	*writer.BaseTask
 
-	streamer  *file.OutStreamer
+	fsys      *file.FS
+	outputs   *outputs
 	conf      Config
 	newConfig func(conf *config.JSON) (Config, error)
 	content   *config.JSON
+	mapper    *writer.RecordMapper
 }
 
 // NewTask Create a task by obtaining the configuration newConfig
@@ -64,26 +66,41 @@ func (t *Task) Init(ctx context.Context) (err error) {
 		return t.Wrapf(err, "newConfig fail")
 	}
 
-	if t.streamer, err = file.NewOutStreamer(name, filename); err != nil {
-		return t.Wrapf(err, "NewOutStreamer fail")
+	if len(t.conf.GetColumnMappings()) > 0 {
+		if t.mapper, err = writer.NewRecordMapper(t.conf.GetColumnMappings(), nil); err != nil {
+			return t.Wrapf(err, "NewRecordMapper fail")
+		}
+	}
+
+	if t.fsys, err = file.NewFS(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewFS fail")
 	}
 
+	t.outputs = newOutputs(t.fsys, name, filename, t.content, t.conf.GetOutputConfig())
+	// Without partitions, the first file is known and opened in advance
+	if len(t.conf.GetOutputConfig().Partitions) == 0 {
+		if _, err = t.outputs.open(""); err != nil {
+			return t.Wrapf(err, "open fail")
+		}
+	}
 	return
 }
 
 // Destroy Destruction
 func (t *Task) Destroy(ctx context.Context) (err error) {
-	if t.streamer != nil {
-		err = t.streamer.Close()
+	if t.outputs != nil {
+		err = t.outputs.close(false)
+	}
+	if cerr := t.fsys.Close(); err == nil {
+		err = cerr
 	}
 	return t.Wrapf(err, "Close fail")
 }
 
 // StartWrite Start writing
 func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
-	var sw file.StreamWriter
-	if sw, err = t.streamer.Writer(t.content); err != nil {
-		return t.Wrapf(err, "Writer fail")
+	if err = t.outputs.start(); err != nil {
+		return t.Wrapf(err, "start fail")
 	}
 
 	recordChan := make(chan element.Record)
@@ -134,7 +151,7 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 			if !ok {
 				// When writing ends, write the remaining records to the database
 				if cnt > 0 {
-					if err = sw.Flush(); err != nil {
+					if err = t.outputs.flush(); err != nil {
 						log.Errorf(t.Format("Flush error: %v"), err)
 					}
 				}
@@ -144,15 +161,25 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 				goto End
 			}
 
+			// Records failing to be mapped are collected as dirty records and are not written
+			if t.mapper != nil {
+				mapped, merr := t.mapper.Map(record)
+				if merr != nil {
+					t.collectDirty(record, merr)
+					continue
+				}
+				record = mapped
+			}
+
 			// Write to file
-			if err = sw.Write(record); err != nil {
+			if err = t.outputs.write(record); err != nil {
 				log.Errorf(t.Format("Write error: %v"), err)
 				goto End
 			}
 			cnt++
 			// When the data volume exceeds the single batch size, write to the file
 			if cnt >= t.conf.GetBatchSize() {
-				if err = sw.Flush(); err != nil {
+				if err = t.outputs.flush(); err != nil {
 					log.Errorf(t.Format("Flush error: %v"), err)
 					goto End
 				}
@@ -161,7 +188,7 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 		// When the written data does not reach the single batch size, write even if the timeout occurs
 		case <-ticker.C:
 			if cnt > 0 {
-				if err = sw.Flush(); err != nil {
+				if err = t.outputs.flush(); err != nil {
 					log.Errorf(t.Format("Flush error: %v"), err)
 					goto End
 				}
@@ -170,7 +197,9 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 		}
 	}
 End:
-	if cerr := sw.Close(); cerr != nil {
+	// The files are committed only when all records are written
+	commit := ctx.Err() == nil && (err == nil || err == exchange.ErrTerminate)
+	if cerr := t.outputs.close(commit); cerr != nil {
 		log.Errorf(t.Format("Close error: %v"), cerr)
 	}
 	cancel()
@@ -188,3 +217,11 @@ End:
 	}
 	return t.Wrapf(err, "")
 }
+
+// collectDirty collects the failed record into the task collector
+func (t *Task) collectDirty(record element.Record, err error) {
+	log.Errorf(t.Format("dirty record %v. err: %v"), record, err)
+	if c := t.TaskCollector(); c != nil {
+		c.CollectDirtyRecordWithError(record, err)
+	}
+}
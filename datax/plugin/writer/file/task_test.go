@@ -102,7 +102,7 @@ type mockCreater struct {
 	stream file.OutStream
 }
 
-func (m *mockCreater) Create(filename string) (stream file.OutStream, err error) {
+func (m *mockCreater) Create(fsys *file.FS, filename string) (stream file.OutStream, err error) {
 	return m.stream, nil
 }
 
@@ -321,6 +321,66 @@ func TestTask_StartWrite(t *testing.T) {
 	}
 }
 
+func TestTask_StartWriteWithMapping(t *testing.T) {
+	file.UnregisterAllCreater()
+	w := &mockStreamWriter{}
+	file.RegisterCreator("mock", &mockCreater{
+		stream: &mockOutStream{
+			writer: w,
+		},
+	})
+	newTask := func() *Task {
+		return NewTask(func(conf *config.JSON) (Config, error) {
+			c, err := NewBaseConfig(conf)
+			if err != nil {
+				return nil, err
+			}
+			return c, nil
+		})
+	}
+	tests := []struct {
+		name    string
+		t       *Task
+		jobConf *config.JSON
+		want    string
+	}{
+		{
+			name:    "1",
+			t:       newTask(),
+			jobConf: testJSONFromString(`{"path":"file1","content":{"columnMapping":[{"target":"c","value":"1"}]}}`),
+			want:    "c=1",
+		},
+		{
+			name:    "2",
+			t:       newTask(),
+			jobConf: testJSONFromString(`{"path":"file1","content":{"columnMapping":[{"source":"a","target":"c"}]}}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w.record = nil
+			tt.t.SetPluginConf(testJSONFromString(`{"creator":"mock"}`))
+			tt.t.SetPluginJobConf(tt.jobConf)
+			defer tt.t.Destroy(context.TODO())
+			if err := tt.t.Init(context.TODO()); err != nil {
+				t.Errorf("Task.Init() error = %v", err)
+				return
+			}
+			if err := tt.t.StartWrite(context.TODO(), NewMockReceiverWithoutWait(10, exchange.ErrTerminate)); err != nil {
+				t.Errorf("Task.StartWrite() error = %v", err)
+				return
+			}
+			got := ""
+			if w.record != nil {
+				got = w.record.String()
+			}
+			if got != tt.want {
+				t.Errorf("Task.StartWrite() record = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTask_Init(t *testing.T) {
 	file.UnregisterAllCreater()
 	type args struct {
@@ -0,0 +1,39 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// client client of the kafka cluster used by the tasks
+type client interface {
+	Partitions(ctx context.Context, topic string) ([]int32, error)
+	Produce(ctx context.Context, topic string, partition int32, acks int16,
+		codec kafkago.CompressionCodec, msgs []kafka.Message) error
+	Close() error
+}
+
+// newClient creates the client by conf, which is replaced in tests
+var newClient = func(conf *kafka.Config) (client, error) {
+	c, err := kafka.NewClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/Breeze0806/go/time2"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// partitioners
+const (
+	PartitionerHash       = "hash"       // partition by murmur2 of the key like the Java client
+	PartitionerRoundRobin = "roundRobin" // partition in turn
+	PartitionerRandom     = "random"     // partition randomly
+)
+
+const (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+	defaultAcks         = -1
+)
+
+// Config kafka writer configuration
+type Config struct {
+	kafka.Config
+
+	Topic        string         `json:"topic"`        // Topic name
+	KeyColumn    string         `json:"keyColumn"`    // Column of the message key, no key if empty
+	Partitioner  string         `json:"partitioner"`  // Partitioner, hash if keyColumn is set or roundRobin otherwise
+	Acks         *int16         `json:"acks"`         // Acknowledgements, 1 for the leader and -1 for all in-sync replicas, -1 by default
+	Compression  string         `json:"compression"`  // Compression of the record batches, none, gzip, snappy, lz4 or zstd, none if empty
+	Format       string         `json:"format"`       // Format of the message values, json or avro, json if empty
+	Columns      []kafka.Column `json:"column"`       // Columns of the message values, all columns of json if empty
+	SchemaID     int32          `json:"schemaId"`     // Schema id of the confluent schema registry put before avro values if positive
+	BatchSize    int            `json:"batchSize"`    // Number of messages of each produce request
+	BatchTimeout time2.Duration `json:"batchTimeout"` // Maximum time of waiting for a batch
+
+	compression kafkago.CompressionCodec // codec of the compression, nil for none
+	setting     *config.JSON             // job setting including retry
+}
+
+// NewConfig gets the kafka writer configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if len(c.Brokers) == 0 {
+		return nil, fmt.Errorf("brokers is empty")
+	}
+	if c.Topic == "" {
+		return nil, fmt.Errorf("topic is empty")
+	}
+	switch c.Partitioner {
+	case "":
+		c.Partitioner = PartitionerRoundRobin
+		if c.KeyColumn != "" {
+			c.Partitioner = PartitionerHash
+		}
+	case PartitionerHash:
+		if c.KeyColumn == "" {
+			return nil, fmt.Errorf("keyColumn is empty with partitioner hash")
+		}
+	case PartitionerRoundRobin, PartitionerRandom:
+	default:
+		return nil, fmt.Errorf("partitioner %v is not supported", c.Partitioner)
+	}
+	if c.Acks == nil {
+		acks := int16(defaultAcks)
+		c.Acks = &acks
+	}
+	switch *c.Acks {
+	case 1, -1:
+	default:
+		return nil, fmt.Errorf("acks %v is not 1 or -1", *c.Acks)
+	}
+	if c.compression, err = kafka.CompressionCodec(c.Compression); err != nil {
+		return nil, err
+	}
+	if c.Format == "" {
+		c.Format = kafka.FormatJSON
+	}
+	if _, err = c.NewCodec(); err != nil {
+		return nil, err
+	}
+	if c.BatchSize < 0 {
+		return nil, fmt.Errorf("batchSize should not be negative")
+	}
+
+	if c.setting, err = conf.GetConfig(coreconst.DataxJobSetting); err != nil {
+		c.setting, _ = config.NewJSONFromString("{}")
+		err = nil
+	}
+	return
+}
+
+// NewCodec creates the codec of the message values
+func (c *Config) NewCodec() (*kafka.Codec, error) {
+	return kafka.NewCodec(c.Format, c.Columns, c.SchemaID)
+}
+
+// GetBatchSize gets the number of messages of each produce request
+func (c *Config) GetBatchSize() int {
+	if c.BatchSize == 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
+
+// GetBatchTimeout gets the maximum time of waiting for a batch
+func (c *Config) GetBatchTimeout() time.Duration {
+	if c.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return c.BatchTimeout.Duration
+}
+
+// GetRetryStrategy gets the retry strategy of the produce requests from the retry of the job setting
+func (c *Config) GetRetryStrategy(j schedule.RetryJudger) (schedule.RetryStrategy, error) {
+	return schedule.NewRetryStrategy(j, c.setting)
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		wantErr bool
+	}{
+		{name: "1", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders"}`},
+		{name: "2", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","keyColumn":"id","acks":1,"compression":"zstd"}`},
+		{name: "3", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","format":"avro","column":[{"name":"id","type":"bigInt"}]}`},
+		{name: "4", conf: `{"topic":"orders"}`, wantErr: true},
+		{name: "5", conf: `{"brokers":["127.0.0.1:9092"]}`, wantErr: true},
+		{name: "6", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","partitioner":"hash"}`, wantErr: true},
+		{name: "7", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","partitioner":"sticky"}`, wantErr: true},
+		{name: "8", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","acks":2}`, wantErr: true},
+		{name: "9", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","compression":"brotli"}`, wantErr: true},
+		{name: "10", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","format":"avro"}`, wantErr: true},
+		{name: "11", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","batchSize":-1}`, wantErr: true},
+		{name: "12", conf: `{"brokers":"127.0.0.1:9092","topic":"orders"}`, wantErr: true},
+		{name: "13", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","acks":0}`, wantErr: true},
+		{name: "14", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","compression":"lz4"}`},
+		{name: "15", conf: `{"brokers":["127.0.0.1:9092"],"topic":"orders","compression":"snappy","acks":-1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(testJSONFromString(tt.conf))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Default(t *testing.T) {
+	c, err := NewConfig(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"orders"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Partitioner != PartitionerRoundRobin || *c.Acks != defaultAcks || c.Format != kafka.FormatJSON ||
+		c.compression != nil || c.GetBatchSize() != defaultBatchSize ||
+		c.GetBatchTimeout() != defaultBatchTimeout {
+		t.Errorf("NewConfig() = %+v", c)
+	}
+
+	c, err = NewConfig(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"orders","keyColumn":"id"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Partitioner != PartitionerHash {
+		t.Errorf("NewConfig() partitioner = %v", c.Partitioner)
+	}
+}
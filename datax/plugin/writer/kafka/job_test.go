@@ -0,0 +1,36 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import "testing"
+
+func TestAvroName(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		want  string
+	}{
+		{name: "1", topic: "orders", want: "orders"},
+		{name: "2", topic: "db.orders-v2", want: "db_orders_v2"},
+		{name: "3", topic: "2026", want: "_026"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := avroName(tt.topic); got != tt.want {
+				t.Errorf("avroName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
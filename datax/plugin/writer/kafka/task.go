@@ -0,0 +1,179 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/dbms"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	"github.com/pingcap/errors"
+)
+
+// Task kafka writer task
+type Task struct {
+	*writer.BaseTask
+
+	conf       *Config
+	client     client
+	codec      *kafka.Codec
+	strategy   schedule.RetryStrategy
+	partitions []int32
+	next       int // next partition of roundRobin
+}
+
+// NewTask creates a new kafka writer task
+func NewTask() *Task {
+	return &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+}
+
+// Init initializes the task and gets the partitions of the topic
+func (t *Task) Init(ctx context.Context) (err error) {
+	if t.conf, err = NewConfig(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewConfig fail")
+	}
+	if t.codec, err = t.conf.NewCodec(); err != nil {
+		return t.Wrapf(err, "NewCodec fail")
+	}
+	if t.strategy, err = t.conf.GetRetryStrategy(t); err != nil {
+		return t.Wrapf(err, "GetRetryStrategy fail")
+	}
+	if t.client, err = newClient(&t.conf.Config); err != nil {
+		return t.Wrapf(err, "NewClient fail")
+	}
+	err = schedule.NewRetryTask(ctx, t.strategy, schedule.TaskFunc(func() (err error) {
+		t.partitions, err = t.client.Partitions(ctx, t.conf.Topic)
+		return
+	})).Do()
+	if err != nil {
+		return t.Wrapf(err, "Partitions fail. topic: %v", t.conf.Topic)
+	}
+	if len(t.partitions) == 0 {
+		return errors.Errorf(t.Format("topic %v has no partitions"), t.conf.Topic)
+	}
+	return
+}
+
+// Destroy closes the connections to the brokers
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.client != nil {
+		err = t.client.Close()
+	}
+	return t.Wrapf(err, "Close fail")
+}
+
+// StartWrite writes the records from the receiver by produce requests in batches
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+	return dbms.StartWrite(ctx, t, receiver)
+}
+
+// BatchSize gets the number of messages of each produce request
+func (t *Task) BatchSize() int {
+	return t.conf.GetBatchSize()
+}
+
+// BatchTimeout gets the maximum time of waiting for a batch
+func (t *Task) BatchTimeout() time.Duration {
+	return t.conf.GetBatchTimeout()
+}
+
+// BatchWrite writes records as messages by a produce request of each partition, and the messages
+// of the failed partitions are sent again by the retry strategy
+func (t *Task) BatchWrite(ctx context.Context, records []element.Record) (err error) {
+	msgs := make(map[int32][]kafka.Message)
+	now := time.Now()
+	for _, r := range records {
+		var m kafka.Message
+		if m, err = t.message(r); err != nil {
+			return t.Wrapf(err, "encode record fail. record: %v", r)
+		}
+		m.Time = now
+		p := t.partition(m.Key)
+		msgs[p] = append(msgs[p], m)
+	}
+
+	err = schedule.NewRetryTask(ctx, t.strategy, schedule.TaskFunc(func() error {
+		return t.produce(ctx, msgs)
+	})).Do()
+	return t.Wrapf(err, "produce fail")
+}
+
+// produce sends the messages of the partitions in order of the partitions, and removes
+// the messages of the succeeded partitions from msgs
+func (t *Task) produce(ctx context.Context, msgs map[int32][]kafka.Message) error {
+	var partitions []int32
+	for p := range msgs {
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i] < partitions[j]
+	})
+	for _, p := range partitions {
+		if err := t.client.Produce(ctx, t.conf.Topic, p, *t.conf.Acks, t.conf.compression, msgs[p]); err != nil {
+			log.Warnf(t.Format("produce partition %v fail. err: %v"), p, err)
+			return errors.Wrapf(err, "partition %v", p)
+		}
+		delete(msgs, p)
+	}
+	return nil
+}
+
+// message converts the record into the message
+func (t *Task) message(r element.Record) (m kafka.Message, err error) {
+	if t.conf.KeyColumn != "" {
+		var col element.Column
+		if col, err = r.GetByName(t.conf.KeyColumn); err != nil {
+			return
+		}
+		if !col.IsNil() {
+			var key []byte
+			if key, err = col.AsBytes(); err != nil {
+				return
+			}
+			m.Key = key
+		}
+	}
+	m.Value, err = t.codec.Encode(r)
+	return
+}
+
+// partition gets the partition of the message with key by the partitioner,
+// and the messages without key are partitioned in turn
+func (t *Task) partition(key []byte) int32 {
+	n := int32(len(t.partitions))
+	switch {
+	case t.conf.Partitioner == PartitionerHash && key != nil:
+		return t.partitions[kafka.HashPartition(key, n)]
+	case t.conf.Partitioner == PartitionerRandom:
+		return t.partitions[rand.Int31n(n)]
+	}
+	p := t.partitions[t.next%len(t.partitions)]
+	t.next++
+	return p
+}
+
+// ShouldRetry retries the retriable errors of kafka and the network errors
+func (t *Task) ShouldRetry(err error) bool {
+	return kafka.IsRetriable(errors.Cause(err))
+}
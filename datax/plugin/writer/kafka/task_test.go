@@ -0,0 +1,194 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	if len(m.records) == 0 {
+		return nil, m.err
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testRecords(n int) (records []element.Record) {
+	for i := 0; i < n; i++ {
+		r := element.NewDefaultRecord()
+		r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(int64(i)), "id", 0))
+		r.Add(element.NewDefaultColumn(element.NewStringColumnValue(fmt.Sprintf("v%v", i)), "v", 0))
+		r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)), "t", 0))
+		records = append(records, r)
+	}
+	return
+}
+
+// mockClient client of the topic orders with 2 partitions, which keeps the produced messages
+type mockClient struct {
+	msgs [2][]kafka.Message
+	err  error // error of the next request
+}
+
+// testClient makes the tasks use the mock client
+func testClient(t *testing.T) *mockClient {
+	m := &mockClient{}
+	old := newClient
+	newClient = func(conf *kafka.Config) (client, error) {
+		return m, nil
+	}
+	t.Cleanup(func() { newClient = old })
+	return m
+}
+
+func (m *mockClient) Partitions(ctx context.Context, topic string) ([]int32, error) {
+	if topic != "orders" {
+		return nil, kafkago.UnknownTopicOrPartition
+	}
+	return []int32{0, 1}, nil
+}
+
+func (m *mockClient) Produce(ctx context.Context, topic string, partition int32, acks int16,
+	codec kafkago.CompressionCodec, msgs []kafka.Message) error {
+	if err := m.err; err != nil {
+		m.err = nil
+		return err
+	}
+	m.msgs[partition] = append(m.msgs[partition], msgs...)
+	return nil
+}
+
+func (m *mockClient) Close() error {
+	return nil
+}
+
+// values gets the keys and values of the messages of the partitions
+func (m *mockClient) values() (got [][]string) {
+	for _, ms := range m.msgs {
+		var msgs []string
+		for _, msg := range ms {
+			msgs = append(msgs, string(msg.Key)+"="+string(msg.Value))
+		}
+		got = append(got, msgs)
+	}
+	return
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		inject  error
+		records []element.Record
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			conf:    `"column":[{"name":"id"},{"name":"t","format":"yyyy-MM-dd"}],"batchSize":2`,
+			records: testRecords(3),
+			want: [][]string{
+				{`={"id":0,"t":"2026-10-19"}`, `={"id":2,"t":"2026-10-19"}`},
+				{`={"id":1,"t":"2026-10-19"}`},
+			},
+		},
+		{
+			name:    "2",
+			conf:    `"keyColumn":"v","compression":"gzip","acks":1`,
+			records: testRecords(2),
+			want: [][]string{
+				{`v0={"id":0,"v":"v0","t":"2026-10-19T08:00:00Z"}`},
+				{`v1={"id":1,"v":"v1","t":"2026-10-19T08:00:00Z"}`},
+			},
+		},
+		{
+			name:    "3",
+			conf:    `"partitioner":"random","job":{"setting":{"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}}}`,
+			inject:  kafkago.NotLeaderForPartition,
+			records: testRecords(1),
+		},
+		{
+			name:    "4",
+			conf:    `"job":{"setting":{"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}}}`,
+			inject:  kafkago.TopicAuthorizationFailed,
+			records: testRecords(1),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    `"format":"avro","column":[{"name":"id","type":"bigInt"}],"acks":1`,
+			records: testRecords(1),
+			want:    [][]string{{"=\x02\x00"}, nil},
+		},
+		{
+			name:    "6",
+			conf:    `"column":[{"name":"unknown"}]`,
+			records: testRecords(1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testClient(t)
+			m.err = tt.inject
+
+			task := NewTask()
+			task.SetPluginJobConf(testJSONFromString(fmt.Sprintf(`{"brokers":["127.0.0.1:9092"],"topic":"orders",%v}`, tt.conf)))
+			if err := task.Init(context.Background()); err != nil {
+				t.Fatalf("Task.Init() error = %v", err)
+			}
+			defer task.Destroy(context.Background())
+			err := task.StartWrite(context.Background(), &mockReceiver{records: tt.records, err: exchange.ErrTerminate})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				return
+			}
+			if got := m.values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Task.StartWrite() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_Init(t *testing.T) {
+	testClient(t)
+	task := NewTask()
+	task.SetPluginJobConf(testJSONFromString(`{"brokers":["127.0.0.1:9092"],"topic":"unknown"}`))
+	if err := task.Init(context.Background()); err == nil {
+		t.Errorf("Task.Init() error = nil")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"io"
+	"net"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pingcap/errors"
+)
+
+// client client of the redis server, which connects lazily and
+// reconnects after the connection fails
+type client struct {
+	conf *Config
+	conn redis.Conn
+}
+
+func newClient(conf *Config) *client {
+	return &client{
+		conf: conf,
+	}
+}
+
+func (c *client) dial() (err error) {
+	options := []redis.DialOption{
+		redis.DialConnectTimeout(c.conf.timeout()),
+		redis.DialReadTimeout(c.conf.timeout()),
+		redis.DialWriteTimeout(c.conf.timeout()),
+		redis.DialUsername(c.conf.Username),
+		redis.DialPassword(c.conf.Password),
+		redis.DialDatabase(c.conf.DB),
+		redis.DialUseTLS(c.conf.TLS),
+	}
+	c.conn, err = redis.Dial("tcp", c.conf.Address, options...)
+	return
+}
+
+// pipeline sends cmds in a pipeline and receives their replies. The connection is
+// closed on network errors, and the first error reply is returned after all replies
+// are received
+func (c *client) pipeline(cmds []command) (err error) {
+	if c.conn == nil {
+		if err = c.dial(); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if _, ok := errors.Cause(err).(redis.Error); err != nil && !ok {
+			c.close()
+		}
+	}()
+
+	for _, cmd := range cmds {
+		if err = c.conn.Send(cmd.name, cmd.args...); err != nil {
+			return err
+		}
+	}
+	if err = c.conn.Flush(); err != nil {
+		return err
+	}
+	var replyErr error
+	for i := range cmds {
+		if _, err = c.conn.Receive(); err != nil {
+			if _, ok := err.(redis.Error); !ok {
+				return err
+			}
+			if replyErr == nil {
+				replyErr = errors.Wrapf(err, "%v %v", cmds[i].name, cmds[i].args[0])
+			}
+		}
+	}
+	return replyErr
+}
+
+func (c *client) close() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// ShouldRetry retries the connection errors, and the error replies of the server are not retried
+func (c *client) ShouldRetry(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case net.Error:
+		return true
+	default:
+		return cause == io.EOF || cause == io.ErrUnexpectedEOF
+	}
+}
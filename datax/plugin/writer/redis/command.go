@@ -0,0 +1,223 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// command redis command with its arguments
+type command struct {
+	name string
+	args []any
+}
+
+// newCommands converts record to the configured command of its key,
+// followed by PEXPIRE if ttl is set and the command does not set it
+func newCommands(conf *Config, record element.Record) (cmds []command, err error) {
+	var key string
+	if key, err = conf.key.name(record); err != nil {
+		return nil, err
+	}
+
+	var cmd command
+	switch conf.Command {
+	case CommandHSet:
+		var fields []element.Column
+		if fields, err = columns(conf, record); err != nil {
+			return nil, err
+		}
+		for _, c := range fields {
+			if c.IsNil() {
+				continue
+			}
+			var v string
+			if v, err = stringValue(conf, c); err != nil {
+				return nil, errors.Wrapf(err, "column %v", c.Name())
+			}
+			cmds = append(cmds, command{name: "HSET", args: []any{key, c.Name(), v}})
+		}
+	case CommandZAdd:
+		var score string
+		if score, err = scoreValue(conf, record); err != nil {
+			return nil, err
+		}
+		var v []byte
+		if v, err = value(conf, record); err != nil {
+			return nil, err
+		}
+		cmd = command{name: "ZADD", args: []any{key, score, v}}
+	case CommandSet:
+		var v []byte
+		if v, err = value(conf, record); err != nil {
+			return nil, err
+		}
+		cmd = command{name: "SET", args: []any{key, v}}
+		if conf.TTL.Duration > 0 {
+			cmd.args = append(cmd.args, "PX", conf.TTL.Milliseconds())
+		}
+		return []command{cmd}, nil
+	default:
+		var v []byte
+		if v, err = value(conf, record); err != nil {
+			return nil, err
+		}
+		cmd = command{name: "RPUSH", args: []any{key, v}}
+	}
+	if cmd.name != "" {
+		cmds = append(cmds, cmd)
+	}
+	if conf.TTL.Duration > 0 && len(cmds) > 0 {
+		cmds = append(cmds, command{name: "PEXPIRE", args: []any{key, conf.TTL.Milliseconds()}})
+	}
+	return
+}
+
+// value gets the value of valueColumn, or the JSON object of the columns if valueColumn is empty
+func value(conf *Config, record element.Record) ([]byte, error) {
+	if conf.ValueColumn == "" {
+		return jsonObject(conf, record)
+	}
+	c, err := record.GetByName(conf.ValueColumn)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsNil() {
+		return nil, errors.Errorf("valueColumn %v is null", conf.ValueColumn)
+	}
+	if c.Type() == element.TypeBytes {
+		return c.AsBytes()
+	}
+	s, err := stringValue(conf, c)
+	if err != nil {
+		return nil, errors.Wrapf(err, "valueColumn %v", conf.ValueColumn)
+	}
+	return []byte(s), nil
+}
+
+// scoreValue gets the score of zadd from scoreColumn
+func scoreValue(conf *Config, record element.Record) (string, error) {
+	c, err := record.GetByName(conf.ScoreColumn)
+	if err != nil {
+		return "", err
+	}
+	if c.IsNil() {
+		return "", errors.Errorf("scoreColumn %v is null", conf.ScoreColumn)
+	}
+	if c.Type() == element.TypeTime {
+		t, err := c.AsTime()
+		if err != nil {
+			return "", errors.Wrapf(err, "scoreColumn %v", conf.ScoreColumn)
+		}
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	}
+	d, err := c.AsDecimal()
+	if err != nil {
+		return "", errors.Wrapf(err, "scoreColumn %v is not a number", conf.ScoreColumn)
+	}
+	return d.String(), nil
+}
+
+// columns gets the configured columns of record, all columns if not configured
+func columns(conf *Config, record element.Record) (cols []element.Column, err error) {
+	var c element.Column
+	if len(conf.Columns) == 0 {
+		for i := 0; i < record.ColumnNumber(); i++ {
+			if c, err = record.GetByIndex(i); err != nil {
+				return nil, err
+			}
+			cols = append(cols, c)
+		}
+		return
+	}
+	for _, name := range conf.Columns {
+		if c, err = record.GetByName(name); err != nil {
+			return nil, err
+		}
+		cols = append(cols, c)
+	}
+	return
+}
+
+// stringValue gets the string of the column, times are formatted by timeFormat
+func stringValue(conf *Config, c element.Column) (string, error) {
+	if c.Type() == element.TypeTime {
+		t, err := c.AsTime()
+		if err != nil {
+			return "", err
+		}
+		return t.Format(conf.goLayout), nil
+	}
+	return c.AsString()
+}
+
+// jsonObject gets the JSON object of the column names and values of record
+func jsonObject(conf *Config, record element.Record) ([]byte, error) {
+	cols, err := columns(conf, record)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, c := range cols {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(c.Name())
+		buf.Write(key)
+		buf.WriteByte(':')
+		v, err := jsonValue(conf, c)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %v", c.Name())
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonValue gets the JSON value of the column, numbers are kept without losing precision
+// and times are formatted by timeFormat
+func jsonValue(conf *Config, c element.Column) ([]byte, error) {
+	if c.IsNil() {
+		return []byte("null"), nil
+	}
+	switch c.Type() {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal:
+		return []byte(c.String()), nil
+	case element.TypeJSON:
+		j, err := c.AsJSON()
+		if err != nil {
+			return nil, err
+		}
+		return j.ToBytes(), nil
+	case element.TypeTime:
+		t, err := c.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(t.Format(conf.goLayout))
+	}
+	s, err := c.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go/time2"
+	"github.com/vjeantet/jodaTime"
+)
+
+// commands written for the records
+const (
+	CommandSet   = "set"   // SET key value
+	CommandHSet  = "hset"  // HSET key column value for each column
+	CommandZAdd  = "zadd"  // ZADD key score value
+	CommandRPush = "rpush" // RPUSH key value
+)
+
+const (
+	defaultBatchSize    = 1000
+	defaultBatchTimeout = 1 * time.Second
+	defaultTimeout      = 30 * time.Second
+)
+
+// Config redis writer configuration
+type Config struct {
+	Address      string         `json:"address"`      // Address of the server, such as 127.0.0.1:6379
+	Username     string         `json:"username"`     // Username of the ACL of redis 6, the default user if empty
+	Password     string         `json:"password"`     // Password, no authentication if empty
+	DB           int            `json:"db"`           // Database number
+	TLS          bool           `json:"tls"`          // Whether to connect by TLS
+	Timeout      time2.Duration `json:"timeout"`      // Timeout of connecting, reading and writing, 30s by default
+	Command      string         `json:"command"`      // Command of each record, set, hset, zadd or rpush
+	Key          string         `json:"key"`          // Key template with the placeholders {column} or {column:format}
+	ValueColumn  string         `json:"valueColumn"`  // Column of the value of set, zadd and rpush, the JSON object of column if empty
+	ScoreColumn  string         `json:"scoreColumn"`  // Column of the score of zadd
+	Columns      []string       `json:"column"`       // Columns of the JSON values and the hash fields, all columns if empty
+	TimeFormat   string         `json:"timeFormat"`   // Joda time format of time columns, RFC 3339 if empty
+	TTL          time2.Duration `json:"ttl"`          // Time to live of the keys, no expiration if 0
+	BatchSize    int            `json:"batchSize"`    // Number of records of each pipeline
+	BatchTimeout time2.Duration `json:"batchTimeout"` // Maximum time of waiting for a batch
+
+	key      *keyTemplate
+	goLayout string
+	setting  *config.JSON // job setting including retry
+}
+
+// NewConfig gets the redis writer configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+
+	if c.Address == "" {
+		return nil, fmt.Errorf("address is empty")
+	}
+	if c.DB < 0 {
+		return nil, fmt.Errorf("db should not be negative")
+	}
+	switch c.Command {
+	case CommandSet, CommandHSet, CommandRPush:
+	case CommandZAdd:
+		if c.ScoreColumn == "" {
+			return nil, fmt.Errorf("scoreColumn is empty with command zadd")
+		}
+	default:
+		return nil, fmt.Errorf("command %v is not supported", c.Command)
+	}
+	if c.key, err = parseKeyTemplate(c.Key); err != nil {
+		return nil, fmt.Errorf("key %v is not valid: %v", c.Key, err)
+	}
+	if c.TTL.Duration < 0 {
+		return nil, fmt.Errorf("ttl should not be negative")
+	}
+	if c.TTL.Duration > 0 && c.TTL.Duration < time.Millisecond {
+		return nil, fmt.Errorf("ttl should not be less than 1ms")
+	}
+	if c.BatchSize < 0 {
+		return nil, fmt.Errorf("batchSize should not be negative")
+	}
+	c.goLayout = time.RFC3339Nano
+	if c.TimeFormat != "" {
+		c.goLayout = jodaTime.GetLayout(c.TimeFormat)
+	}
+
+	if c.setting, err = conf.GetConfig(coreconst.DataxJobSetting); err != nil {
+		c.setting, _ = config.NewJSONFromString("{}")
+		err = nil
+	}
+	return
+}
+
+// GetBatchSize gets the number of records of each pipeline
+func (c *Config) GetBatchSize() int {
+	if c.BatchSize == 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
+
+// GetBatchTimeout gets the maximum time of waiting for a batch
+func (c *Config) GetBatchTimeout() time.Duration {
+	if c.BatchTimeout.Duration == 0 {
+		return defaultBatchTimeout
+	}
+	return c.BatchTimeout.Duration
+}
+
+// GetRetryStrategy gets the retry strategy of the pipelines from the retry of the job setting
+func (c *Config) GetRetryStrategy(j schedule.RetryJudger) (schedule.RetryStrategy, error) {
+	return schedule.NewRetryStrategy(j, c.setting)
+}
+
+func (c *Config) timeout() time.Duration {
+	if c.Timeout.Duration == 0 {
+		return defaultTimeout
+	}
+	return c.Timeout.Duration
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		wantErr bool
+	}{
+		{name: "1", conf: `{"address":"127.0.0.1:6379","command":"set","key":"user:{id}"}`},
+		{name: "2", conf: `{"address":"127.0.0.1:6379","command":"zadd","key":"rank","scoreColumn":"score","ttl":"1h"}`},
+		{name: "3", conf: `{"command":"set","key":"user:{id}"}`, wantErr: true},
+		{name: "4", conf: `{"address":"127.0.0.1:6379","command":"sadd","key":"user:{id}"}`, wantErr: true},
+		{name: "5", conf: `{"address":"127.0.0.1:6379","command":"zadd","key":"rank"}`, wantErr: true},
+		{name: "6", conf: `{"address":"127.0.0.1:6379","command":"set"}`, wantErr: true},
+		{name: "7", conf: `{"address":"127.0.0.1:6379","command":"set","key":"user:{id"}`, wantErr: true},
+		{name: "8", conf: `{"address":"127.0.0.1:6379","command":"set","key":"user:{id}","ttl":"1us"}`, wantErr: true},
+		{name: "9", conf: `{"address":"127.0.0.1:6379","command":"set","key":"user:{id}","db":-1}`, wantErr: true},
+		{name: "10", conf: `{"address":"127.0.0.1:6379","command":"set","key":"user:{id}","batchSize":-1}`, wantErr: true},
+		{name: "11", conf: `{"address":"127.0.0.1:6379","command":"set","key":"user:{id}","ttl":"-1s"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(testJSONFromString(tt.conf))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Default(t *testing.T) {
+	c, err := NewConfig(testJSONFromString(`{"address":"127.0.0.1:6379","command":"set","key":"user:{id}"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.GetBatchSize() != defaultBatchSize || c.GetBatchTimeout() != defaultBatchTimeout || c.timeout() != defaultTimeout {
+		t.Errorf("NewConfig() = %+v", c)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/pingcap/errors"
+)
+
+// Job redis writer job
+type Job struct {
+	*plugin.BaseJob
+
+	conf *Config
+}
+
+// NewJob creates a new redis writer job
+func NewJob() *Job {
+	return &Job{
+		BaseJob: plugin.NewBaseJob(),
+	}
+}
+
+// Init initializes the job
+func (j *Job) Init(ctx context.Context) (err error) {
+	j.conf, err = NewConfig(j.PluginJobConf())
+	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+}
+
+// Destroy destroys the job
+func (j *Job) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+// Split gives each of the number tasks the same configuration
+func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
+	for i := 0; i < number; i++ {
+		configs = append(configs, j.PluginJobConf().CloneConfig())
+	}
+	return
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testRecord(id int64, name string) element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(id), "id", 0))
+	if name == "" {
+		r.Add(element.NewDefaultColumn(element.NewNilStringColumnValue(), "name", 0))
+	} else {
+		r.Add(element.NewDefaultColumn(element.NewStringColumnValue(name), "name", 0))
+	}
+	score, _ := element.NewDecimalColumnValueFromString("98.5")
+	r.Add(element.NewDefaultColumn(score, "score", 0))
+	r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)), "t", 0))
+	return r
+}
+
+func TestKeyTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		record   element.Record
+		want     string
+		wantErr  bool
+	}{
+		{name: "1", template: "user:{id}", record: testRecord(1, "a"), want: "user:1"},
+		{name: "2", template: "{name}:{t:yyyyMMdd}:rank", record: testRecord(1, "A"), want: "A:20261019:rank"},
+		{name: "3", template: "rank", record: testRecord(1, "a"), want: "rank"},
+		{name: "4", template: "user:{name}", record: testRecord(1, ""), wantErr: true},
+		{name: "5", template: "user:{none}", record: testRecord(1, "a"), wantErr: true},
+		{name: "6", template: "user:{id:yyyy}", record: testRecord(1, "a"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := parseKeyTemplate(tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := k.name(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("name() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("name() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKeyTemplate(t *testing.T) {
+	for _, s := range []string{"", "user:{id", "user:}", "user:{}", "user:{{id}}"} {
+		if _, err := parseKeyTemplate(s); err == nil {
+			t.Errorf("parseKeyTemplate(%v) error = nil", s)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/dbms"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/schedule"
+)
+
+// Task redis writer task
+type Task struct {
+	*writer.BaseTask
+
+	conf     *Config
+	client   *client
+	strategy schedule.RetryStrategy
+}
+
+// NewTask creates a new redis writer task
+func NewTask() *Task {
+	return &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+}
+
+// Init initializes the task
+func (t *Task) Init(ctx context.Context) (err error) {
+	if t.conf, err = NewConfig(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewConfig fail")
+	}
+	t.client = newClient(t.conf)
+	if t.strategy, err = t.conf.GetRetryStrategy(t.client); err != nil {
+		return t.Wrapf(err, "GetRetryStrategy fail")
+	}
+	return
+}
+
+// Destroy closes the connection
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	if t.client != nil {
+		t.client.close()
+	}
+	return
+}
+
+// StartWrite writes the records from the receiver by pipelines in batches
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+	return dbms.StartWrite(ctx, t, receiver)
+}
+
+// BatchSize gets the number of records of each pipeline
+func (t *Task) BatchSize() int {
+	return t.conf.GetBatchSize()
+}
+
+// BatchTimeout gets the maximum time of waiting for a batch
+func (t *Task) BatchTimeout() time.Duration {
+	return t.conf.GetBatchTimeout()
+}
+
+// BatchWrite writes the commands of records by a pipeline, which is sent
+// again by the retry strategy on connection errors
+func (t *Task) BatchWrite(ctx context.Context, records []element.Record) (err error) {
+	var cmds []command
+	for _, r := range records {
+		var rcmds []command
+		if rcmds, err = newCommands(t.conf, r); err != nil {
+			return t.Wrapf(err, "convert record fail. record: %v", r)
+		}
+		cmds = append(cmds, rcmds...)
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+
+	err = schedule.NewRetryTask(ctx, t.strategy, schedule.TaskFunc(func() error {
+		err := t.client.pipeline(cmds)
+		if err != nil {
+			log.Warnf(t.Format("pipeline fail. err: %v"), err)
+		}
+		return err
+	})).Do()
+	return t.Wrapf(err, "pipeline fail")
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/alicebob/miniredis"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	if len(m.records) == 0 {
+		return nil, m.err
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testTask(t *testing.T, s *miniredis.Miniredis, conf string) *Task {
+	task := NewTask()
+	task.SetPluginJobConf(testJSONFromString(fmt.Sprintf(`{"address":"%v",%v}`, s.Addr(), conf)))
+	if err := task.Init(context.Background()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	t.Cleanup(func() { task.Destroy(context.Background()) })
+	return task
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		records []element.Record
+		check   func(s *miniredis.Miniredis) (got, want any)
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			conf:    `"command":"set","key":"user:{id}","column":["id","name","t"],"ttl":"1h","batchSize":1`,
+			records: []element.Record{testRecord(1, "a"), testRecord(2, "")},
+			check: func(s *miniredis.Miniredis) (got, want any) {
+				v1, _ := s.Get("user:1")
+				v2, _ := s.Get("user:2")
+				return []any{v1, v2, s.TTL("user:1")},
+					[]any{`{"id":1,"name":"a","t":"2026-10-19T08:00:00Z"}`, `{"id":2,"name":null,"t":"2026-10-19T08:00:00Z"}`, time.Hour}
+			},
+		},
+		{
+			name:    "2",
+			conf:    `"command":"hset","key":"user:{id}","column":["name","score","t"],"timeFormat":"yyyy-MM-dd","ttl":"10s"`,
+			records: []element.Record{testRecord(1, "a"), testRecord(2, "")},
+			check: func(s *miniredis.Miniredis) (got, want any) {
+				k1, _ := s.HKeys("user:1")
+				k2, _ := s.HKeys("user:2")
+				return []any{k1, s.HGet("user:1", "name"), s.HGet("user:1", "score"), s.HGet("user:1", "t"), k2, s.TTL("user:2")},
+					[]any{[]string{"name", "score", "t"}, "a", "98.5", "2026-10-19", []string{"score", "t"}, 10 * time.Second}
+			},
+		},
+		{
+			name:    "3",
+			conf:    `"command":"zadd","key":"rank:{t:yyyyMMdd}","scoreColumn":"score","valueColumn":"name"`,
+			records: []element.Record{testRecord(1, "a"), testRecord(2, "b")},
+			check: func(s *miniredis.Miniredis) (got, want any) {
+				members, _ := s.SortedSet("rank:20261019")
+				return members, map[string]float64{"a": 98.5, "b": 98.5}
+			},
+		},
+		{
+			name:    "4",
+			conf:    `"command":"rpush","key":"users","valueColumn":"name"`,
+			records: []element.Record{testRecord(1, "a"), testRecord(2, "b")},
+			check: func(s *miniredis.Miniredis) (got, want any) {
+				l, _ := s.List("users")
+				return l, []string{"a", "b"}
+			},
+		},
+		{
+			name:    "5",
+			conf:    `"command":"set","key":"user:{id}","valueColumn":"name"`,
+			records: []element.Record{testRecord(1, "")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := miniredis.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			task := testTask(t, s, tt.conf)
+			err = task.StartWrite(context.Background(), &mockReceiver{records: tt.records, err: exchange.ErrTerminate})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Task.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check == nil {
+				return
+			}
+			if got, want := tt.check(s); !reflect.DeepEqual(got, want) {
+				t.Errorf("Task.StartWrite() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTask_BatchWrite(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.RequireAuth("secret")
+	task := testTask(t, s, `"password":"secret","db":2,"command":"set","key":"user:{id}","valueColumn":"name",`+
+		`"job":{"setting":{"retry":{"type":"ntimes","strategy":{"n":3,"wait":"1ms"}}}}`)
+	if err = task.BatchWrite(context.Background(), []element.Record{testRecord(1, "a")}); err != nil {
+		t.Fatalf("Task.BatchWrite() error = %v", err)
+	}
+
+	// the broken connection is retried by a new connection
+	s.Close()
+	if err = s.Restart(); err != nil {
+		t.Fatal(err)
+	}
+	if err = task.BatchWrite(context.Background(), []element.Record{testRecord(2, "b")}); err != nil {
+		t.Fatalf("Task.BatchWrite() error = %v", err)
+	}
+	s.Select(2)
+	for k, want := range map[string]string{"user:1": "a", "user:2": "b"} {
+		if got, _ := s.Get(k); got != want {
+			t.Errorf("Get(%v) = %v, want %v", k, got, want)
+		}
+	}
+
+	// the error replies are not retried
+	s.Set("list", "string")
+	task.conf.Command = CommandRPush
+	task.conf.Key = "list"
+	task.conf.key, _ = parseKeyTemplate("list")
+	commands := s.CommandCount()
+	if err = task.BatchWrite(context.Background(), []element.Record{testRecord(3, "c")}); err == nil {
+		t.Fatalf("Task.BatchWrite() error = nil")
+	}
+	if s.CommandCount() != commands+1 {
+		t.Errorf("CommandCount() = %v, want %v", s.CommandCount(), commands+1)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+// output destinations
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+)
+
+// output formats
+const (
+	FormatTSV   = "tsv"   // tab-separated values
+	FormatCSV   = "csv"   // comma-separated values
+	FormatJSON  = "json"  // one JSON object per line
+	FormatTable = "table" // aligned table with the column names and types as header
+)
+
+const (
+	defaultNullFormat = `\N`
+	defaultBatchSize  = 1000
+)
+
+// Config stream writer configuration
+type Config struct {
+	Output     string `json:"output"`     // Output destination, stdout or stderr, stdout if empty
+	Format     string `json:"format"`     // Output format, tsv, csv, json or table, tsv if empty
+	Header     bool   `json:"header"`     // Whether to print the column names first in tsv and csv
+	NullFormat string `json:"nullFormat"` // Text of null values in tsv, csv and table, \N if empty
+	Limit      int64  `json:"limit"`      // Maximum number of printed records of each task, all records are printed if 0
+	BatchSize  int    `json:"batchSize"`  // Number of records printed at a time, and the widths of table are aligned in a batch
+}
+
+// NewConfig gets the stream writer configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	switch c.Output {
+	case "":
+		c.Output = OutputStdout
+	case OutputStdout, OutputStderr:
+	default:
+		return nil, fmt.Errorf("output %v is not supported", c.Output)
+	}
+	switch c.Format {
+	case "":
+		c.Format = FormatTSV
+	case FormatTSV, FormatCSV, FormatJSON, FormatTable:
+	default:
+		return nil, fmt.Errorf("format %v is not supported", c.Format)
+	}
+	if c.NullFormat == "" {
+		c.NullFormat = defaultNullFormat
+	}
+	if c.Limit < 0 {
+		return nil, fmt.Errorf("limit should not be negative")
+	}
+	if c.BatchSize < 0 {
+		return nil, fmt.Errorf("batchSize should not be negative")
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	return
+}
+
+// writer gets the writer of the output destination
+func (c *Config) writer() io.Writer {
+	if c.Output == OutputStderr {
+		return os.Stderr
+	}
+	return os.Stdout
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	j, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return j
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{}`),
+			want: &Config{
+				Output:     OutputStdout,
+				Format:     FormatTSV,
+				NullFormat: `\N`,
+				BatchSize:  defaultBatchSize,
+			},
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{"output":"stderr","format":"table","nullFormat":"NULL","limit":10,"batchSize":20}`),
+			want: &Config{
+				Output:     OutputStderr,
+				Format:     FormatTable,
+				NullFormat: "NULL",
+				Limit:      10,
+				BatchSize:  20,
+			},
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"output":"file"}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"format":"xml"}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"limit":-1}`),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    testJSONFromString(`{"batchSize":-1}`),
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			conf:    testJSONFromString(`{"limit":"1"}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
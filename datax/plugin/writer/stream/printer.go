@@ -0,0 +1,248 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// outputMu keeps the output of a batch from interleaving with other tasks
+var outputMu sync.Mutex
+
+// escaper escapes the separators of tsv and table
+var escaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// printer prints the records in batches with the configured format
+type printer struct {
+	conf *Config
+	out  io.Writer
+
+	buf     bytes.Buffer
+	csv     *csv.Writer
+	header  bool       // whether the header of tsv and csv is printed
+	rows    [][]string // rows of table in the batch, the first row is the header
+	printed int64      // number of printed records
+}
+
+func newPrinter(conf *Config, out io.Writer) *printer {
+	p := &printer{
+		conf: conf,
+		out:  out,
+	}
+	p.csv = csv.NewWriter(&p.buf)
+	return p
+}
+
+// full gets whether the limit of printed records is reached
+func (p *printer) full() bool {
+	return p.conf.Limit > 0 && p.printed >= p.conf.Limit
+}
+
+// add adds the record to the batch
+func (p *printer) add(record element.Record) (err error) {
+	if p.full() {
+		return
+	}
+	switch p.conf.Format {
+	case FormatJSON:
+		err = p.addJSON(record)
+	case FormatTable:
+		err = p.addTable(record)
+	default:
+		err = p.addText(record)
+	}
+	if err != nil {
+		return
+	}
+	p.printed++
+	return
+}
+
+// addText adds the record as a line of tsv or csv
+func (p *printer) addText(record element.Record) (err error) {
+	var values []string
+	if values, err = p.values(record); err != nil {
+		return
+	}
+	if p.conf.Header && !p.header {
+		p.header = true
+		if err = p.addLine(p.escape(names(record))); err != nil {
+			return
+		}
+	}
+	return p.addLine(values)
+}
+
+func (p *printer) addLine(values []string) (err error) {
+	if p.conf.Format == FormatCSV {
+		if err = p.csv.Write(values); err != nil {
+			return errors.Wrapf(err, "csv Write fail")
+		}
+		p.csv.Flush()
+		return errors.Wrapf(p.csv.Error(), "csv Flush fail")
+	}
+	for i, v := range values {
+		if i > 0 {
+			p.buf.WriteByte('\t')
+		}
+		p.buf.WriteString(v)
+	}
+	p.buf.WriteByte('\n')
+	return
+}
+
+// addJSON adds the record as a JSON object of the column names and values
+func (p *printer) addJSON(record element.Record) (err error) {
+	p.buf.WriteByte('{')
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var col element.Column
+		if col, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		if i > 0 {
+			p.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(col.Name())
+		p.buf.Write(key)
+		p.buf.WriteByte(':')
+		var value []byte
+		if value, err = jsonValue(col); err != nil {
+			return errors.Wrapf(err, "column %v", col.Name())
+		}
+		p.buf.Write(value)
+	}
+	p.buf.WriteString("}\n")
+	return
+}
+
+// addTable adds the record as a row of table, and the header of the batch is
+// the column names and types of its first record
+func (p *printer) addTable(record element.Record) (err error) {
+	var values []string
+	if values, err = p.values(record); err != nil {
+		return
+	}
+	if len(p.rows) == 0 {
+		header := p.escape(names(record))
+		for i := range header {
+			col, _ := record.GetByIndex(i)
+			header[i] += "(" + col.Type().String() + ")"
+		}
+		p.rows = append(p.rows, header)
+	}
+	p.rows = append(p.rows, values)
+	return
+}
+
+// flush prints the batch
+func (p *printer) flush() (err error) {
+	if len(p.rows) > 0 {
+		tw := tabwriter.NewWriter(&p.buf, 0, 0, 1, ' ', tabwriter.Debug)
+		for _, row := range p.rows {
+			for i, v := range row {
+				if i > 0 {
+					tw.Write([]byte{'\t'})
+				}
+				tw.Write([]byte(v))
+			}
+			tw.Write([]byte{'\n'})
+		}
+		if err = tw.Flush(); err != nil {
+			return errors.Wrapf(err, "tabwriter Flush fail")
+		}
+		p.rows = nil
+	}
+	if p.buf.Len() == 0 {
+		return
+	}
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	_, err = p.out.Write(p.buf.Bytes())
+	p.buf.Reset()
+	return errors.Wrapf(err, "Write fail")
+}
+
+// values gets the text of the columns of the record, which is escaped except in csv
+func (p *printer) values(record element.Record) (values []string, err error) {
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var col element.Column
+		if col, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		if col.IsNil() {
+			values = append(values, p.conf.NullFormat)
+			continue
+		}
+		var v string
+		if v, err = col.AsString(); err != nil {
+			return nil, errors.Wrapf(err, "column %v AsString fail", col.Name())
+		}
+		if p.conf.Format != FormatCSV {
+			v = escaper.Replace(v)
+		}
+		values = append(values, v)
+	}
+	return
+}
+
+// escape escapes the texts except in csv
+func (p *printer) escape(texts []string) []string {
+	if p.conf.Format != FormatCSV {
+		for i := range texts {
+			texts[i] = escaper.Replace(texts[i])
+		}
+	}
+	return texts
+}
+
+// names gets the column names of the record
+func names(record element.Record) (names []string) {
+	for i := 0; i < record.ColumnNumber(); i++ {
+		col, _ := record.GetByIndex(i)
+		names = append(names, col.Name())
+	}
+	return
+}
+
+// jsonValue gets the JSON value of the column, and numbers are kept without losing precision
+func jsonValue(col element.Column) ([]byte, error) {
+	if col.IsNil() {
+		return []byte("null"), nil
+	}
+	switch col.Type() {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal:
+		return []byte(col.String()), nil
+	case element.TypeJSON:
+		j, err := col.AsJSON()
+		if err != nil {
+			return nil, err
+		}
+		return j.ToBytes(), nil
+	}
+	s, err := col.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testRecords() []element.Record {
+	r1 := element.NewDefaultRecord()
+	r1.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0))
+	r1.Add(element.NewDefaultColumn(element.NewStringColumnValue("a\tb"), "name", 0))
+	d, _ := element.NewDecimalColumnValueFromString("12.5")
+	r1.Add(element.NewDefaultColumn(d, "amount", 0))
+	r1.Add(element.NewDefaultColumn(element.NewBoolColumnValue(true), "ok", 0))
+
+	r2 := element.NewDefaultRecord()
+	r2.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(20), "id", 0))
+	r2.Add(element.NewDefaultColumn(element.NewStringColumnValue(`c,"d"`), "name", 0))
+	r2.Add(element.NewDefaultColumn(element.NewNilDecimalColumnValue(), "amount", 0))
+	r2.Add(element.NewDefaultColumn(element.NewBoolColumnValue(false), "ok", 0))
+	return []element.Record{r1, r2}
+}
+
+func TestPrinter(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *Config
+		want string
+	}{
+		{
+			name: "1",
+			conf: &Config{Format: FormatTSV, NullFormat: `\N`, Header: true},
+			want: "id\tname\tamount\tok\n" +
+				"1\ta\\tb\t12.5\ttrue\n" +
+				"20\tc,\"d\"\t\\N\tfalse\n",
+		},
+		{
+			name: "2",
+			conf: &Config{Format: FormatCSV, NullFormat: "NULL"},
+			want: "1,a\tb,12.5,true\n" +
+				"20,\"c,\"\"d\"\"\",NULL,false\n",
+		},
+		{
+			name: "3",
+			conf: &Config{Format: FormatJSON},
+			want: `{"id":1,"name":"a\tb","amount":12.5,"ok":true}` + "\n" +
+				`{"id":20,"name":"c,\"d\"","amount":null,"ok":false}` + "\n",
+		},
+		{
+			name: "4",
+			conf: &Config{Format: FormatTable, NullFormat: `\N`},
+			want: "id(bigInt) |name(string) |amount(decimal) |ok(bool)\n" +
+				"1          |a\\tb         |12.5            |true\n" +
+				"20         |c,\"d\"        |\\N              |false\n",
+		},
+		{
+			name: "5",
+			conf: &Config{Format: FormatTSV, NullFormat: `\N`, Limit: 1},
+			want: "1\ta\\tb\t12.5\ttrue\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			p := newPrinter(tt.conf, out)
+			for _, r := range testRecords() {
+				if err := p.add(r); err != nil {
+					t.Fatalf("printer.add() error = %v", err)
+				}
+			}
+			if err := p.flush(); err != nil {
+				t.Fatalf("printer.flush() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("printer output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrinter_JSONColumn(t *testing.T) {
+	j, err := element.NewJsonColumnValueFromString(`{"a":[1,2]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(j, "j", 0))
+	out := &bytes.Buffer{}
+	p := newPrinter(&Config{Format: FormatJSON}, out)
+	if err = p.add(r); err != nil {
+		t.Fatalf("printer.add() error = %v", err)
+	}
+	if err = p.flush(); err != nil {
+		t.Fatalf("printer.flush() error = %v", err)
+	}
+	if got, want := out.String(), `{"j":{"a":[1,2]}}`+"\n"; got != want {
+		t.Errorf("printer output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+// Task stream writer task
+type Task struct {
+	*writer.BaseTask
+
+	conf    *Config
+	printer *printer
+}
+
+// NewTask creates a new stream writer task
+func NewTask() *Task {
+	return &Task{
+		BaseTask: writer.NewBaseTask(),
+	}
+}
+
+// Init initializes the task
+func (t *Task) Init(ctx context.Context) (err error) {
+	if t.conf, err = NewConfig(t.PluginJobConf()); err != nil {
+		return t.Wrapf(err, "NewConfig fail")
+	}
+	t.printer = newPrinter(t.conf, t.conf.writer())
+	return
+}
+
+// Destroy destroys the task
+func (t *Task) Destroy(ctx context.Context) (err error) {
+	return
+}
+
+// StartWrite prints the records from the receiver in batches, and the records
+// beyond the limit are received but not printed
+func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (err error) {
+	log.Infof(t.Format("startWrite begin"))
+	defer log.Infof(t.Format("startWrite end"))
+
+	cnt := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		var record element.Record
+		record, err = receiver.GetFromReader()
+		switch err {
+		case nil:
+		case exchange.ErrEmpty:
+			continue
+		case exchange.ErrTerminate:
+			return t.Wrapf(t.printer.flush(), "flush fail")
+		default:
+			return t.Wrapf(err, "GetFromReader fail")
+		}
+
+		if err = t.printer.add(record); err != nil {
+			return t.Wrapf(err, "add fail")
+		}
+		if cnt++; cnt >= t.conf.BatchSize {
+			if err = t.printer.flush(); err != nil {
+				return t.Wrapf(err, "flush fail")
+			}
+			cnt = 0
+		}
+	}
+}
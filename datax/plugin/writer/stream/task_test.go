@@ -0,0 +1,134 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockReceiver struct {
+	records []element.Record
+	err     error
+	empty   bool
+}
+
+func (m *mockReceiver) GetFromReader() (element.Record, error) {
+	if m.empty {
+		m.empty = false
+		return nil, exchange.ErrEmpty
+	}
+	if len(m.records) == 0 {
+		return nil, m.err
+	}
+	r := m.records[0]
+	m.records = m.records[1:]
+	m.empty = true
+	return r, nil
+}
+
+func (m *mockReceiver) Shutdown() error {
+	return nil
+}
+
+func testTask(conf string, out *bytes.Buffer) *Task {
+	t := NewTask()
+	t.SetPluginJobConf(testJSONFromString(conf))
+	if err := t.Init(context.Background()); err != nil {
+		panic(err)
+	}
+	t.printer.out = out
+	return t
+}
+
+func TestTask_StartWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		err     error
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: `{"batchSize":1}`,
+			err:  exchange.ErrTerminate,
+			want: "1\ta\\tb\t12.5\ttrue\n20\tc,\"d\"\t\\N\tfalse\n",
+		},
+		{
+			name: "2",
+			conf: `{"limit":1,"format":"csv"}`,
+			err:  exchange.ErrTerminate,
+			want: "1,a\tb,12.5,true\n",
+		},
+		{
+			name:    "3",
+			conf:    `{}`,
+			err:     errors.New("mock error"),
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			task := testTask(tt.conf, out)
+			err := task.StartWrite(context.Background(), &mockReceiver{records: testRecords(), err: tt.err})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("Task.StartWrite() output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	task := testTask(`{}`, &bytes.Buffer{})
+	if err := task.StartWrite(ctx, &mockReceiver{}); err != nil {
+		t.Errorf("Task.StartWrite() error = %v", err)
+	}
+}
+
+func TestJob_Split(t *testing.T) {
+	j := NewJob()
+	j.SetPluginJobConf(testJSONFromString(`{"format":"json"}`))
+	if err := j.Init(context.Background()); err != nil {
+		t.Fatalf("Job.Init() error = %v", err)
+	}
+	configs, err := j.Split(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Job.Split() error = %v", err)
+	}
+	if len(configs) != 3 {
+		t.Fatalf("Job.Split() = %v, want 3 configs", len(configs))
+	}
+	for _, c := range configs {
+		if got, _ := c.GetString("format"); got != FormatJSON {
+			t.Errorf("Job.Split() format = %v", got)
+		}
+	}
+	var _ *config.JSON = configs[0]
+}
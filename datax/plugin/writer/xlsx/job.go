@@ -37,8 +37,10 @@ func NewJob() *Job {
 
 // Init Initialization
 func (j *Job) Init(ctx context.Context) (err error) {
-	j.conf, err = NewConfig(j.PluginJobConf())
-	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+	if j.conf, err = NewConfig(j.PluginJobConf()); err != nil {
+		return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
+	}
+	return errors.Wrapf(j.ConfigureFileSystems(), "ConfigureFileSystems fail")
 }
 
 // Split Split the data
@@ -49,6 +51,9 @@ func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, er
 		conf.Set("content", j.conf.SingleConfig)
 		conf.Set("content.sheets", v.Sheets)
 		conf.Set("content.batchTimeout", j.conf.GetBatchTimeout().String())
+		if err = j.SetFileSystems(conf); err != nil {
+			return nil, errors.Wrapf(err, "SetFileSystems fail")
+		}
 		configs = append(configs, conf)
 	}
 	return
@@ -16,12 +16,133 @@ package element
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
 // DefaultTimeFormat - Default time format
 var DefaultTimeFormat = "2006-01-02 15:04:05.999999999Z07:00"
 
+// MaxTimePrecision - Maximum precision of fractional seconds, which is nanosecond
+const MaxTimePrecision = 9
+
+// TimeKind - Kind of time values
+type TimeKind uint8
+
+// Enumeration of time kinds
+const (
+	TimeKindDateTime TimeKind = iota // Date with time of day
+	TimeKindDate                     // Date only
+	TimeKindTime                     // Time of day only
+)
+
+var timeKindMap = map[TimeKind]string{
+	TimeKindDateTime: "datetime",
+	TimeKindDate:     "date",
+	TimeKindTime:     "time",
+}
+
+// String - Name of the time kind
+func (k TimeKind) String() string {
+	if s, ok := timeKindMap[k]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+// TimeLayout - The go time format of the time kind with the precision of fractional seconds,
+// the trailing zeros of fractional seconds are removed when formatting
+func TimeLayout(kind TimeKind, precision int) string {
+	if precision > MaxTimePrecision {
+		precision = MaxTimePrecision
+	}
+	fraction := ""
+	if precision > 0 {
+		fraction = "." + strings.Repeat("9", precision)
+	}
+	switch kind {
+	case TimeKindDate:
+		return DefaultTimeFormat[:10]
+	case TimeKindTime:
+		return DefaultTimeFormat[11:19] + fraction
+	}
+	return DefaultTimeFormat[:19] + fraction
+}
+
+// TimeKinder - The kind and the precision of fractional seconds of time values, which is implemented by time decoders
+type TimeKinder interface {
+	Kind() TimeKind
+	Precision() int
+}
+
+// TimeKindOf - The kind and the precision of fractional seconds of the time column value c,
+// date with time of day and nanosecond if c does not carry them
+func TimeKindOf(c ColumnValue) (TimeKind, int) {
+	if d, ok := c.(*DefaultColumn); ok {
+		c = d.ColumnValue
+	}
+	if k, ok := c.(TimeKinder); ok {
+		return k.Kind(), k.Precision()
+	}
+	return TimeKindDateTime, MaxTimePrecision
+}
+
+// KindLayout - The part of the go time format layout for the time kind, that is, the part before
+// the clock for date only and the part from the clock for time of day only. The layout is unchanged
+// if it does not have the date before the clock
+func KindLayout(layout string, kind TimeKind) string {
+	clock := strings.Index(layout, "15")
+	if i := strings.Index(layout, "03"); i >= 0 && (clock < 0 || i < clock) {
+		clock = i
+	}
+	if year := strings.Index(layout, "06"); year < 0 || clock < 0 || year > clock {
+		return layout
+	}
+	switch kind {
+	case TimeKindDate:
+		return strings.TrimRight(layout[:clock], " T")
+	case TimeKindTime:
+		return layout[clock:]
+	}
+	return layout
+}
+
+// FixedFractionLayout - The go time format layout whose fractional seconds of nines, which drop
+// the trailing zeros, are changed to zeros, so that the fractional seconds keep the width of the layout
+func FixedFractionLayout(layout string) string {
+	b := []byte(layout)
+	for i := 0; i < len(b); i++ {
+		if b[i] != '.' && b[i] != ',' {
+			continue
+		}
+		j := i + 1
+		for j < len(b) && b[j] == '9' {
+			j++
+		}
+		// the same as go, the nines followed by a digit are not the fractional seconds
+		if j == i+1 || j < len(b) && b[j] >= '0' && b[j] <= '9' {
+			continue
+		}
+		for k := i + 1; k < j; k++ {
+			b[k] = '0'
+		}
+		i = j - 1
+	}
+	return string(b)
+}
+
+// TruncateTime - Truncate the fractional seconds of t to the precision
+func TruncateTime(t time.Time, precision int) time.Time {
+	if precision >= MaxTimePrecision {
+		return t
+	}
+	d := time.Second
+	for i := 0; i < precision; i++ {
+		d /= 10
+	}
+	return t.Truncate(d)
+}
+
 // TimeDecoder - Time decoder
 type TimeDecoder interface {
 	TimeDecode(t time.Time) (any, error)
@@ -75,3 +196,35 @@ func (d *StringTimeDecoder) TimeDecode(t time.Time) (any, error) {
 func (d *StringTimeDecoder) Layout() string {
 	return d.layout
 }
+
+// Kind - Kind of time values inferred from the layout,
+// the layout without the clock is date only and the one without the year is time of day only
+func (d *StringTimeDecoder) Kind() TimeKind {
+	hasDate := strings.Contains(d.layout, "2006")
+	hasClock := strings.Contains(d.layout, "15") || strings.Contains(d.layout, "03")
+	switch {
+	case hasDate && !hasClock:
+		return TimeKindDate
+	case !hasDate && hasClock:
+		return TimeKindTime
+	}
+	return TimeKindDateTime
+}
+
+// Precision - Precision of fractional seconds inferred from the layout
+func (d *StringTimeDecoder) Precision() (precision int) {
+	i := strings.Index(d.layout, "05.")
+	if i < 0 {
+		i = strings.Index(d.layout, "05,")
+	}
+	if i < 0 {
+		return 0
+	}
+	for _, c := range d.layout[i+3:] {
+		if c != '9' && c != '0' {
+			break
+		}
+		precision++
+	}
+	return
+}
@@ -119,10 +119,27 @@ func (t *TimeColumnValue) String() string {
 // Clone clones a time column value
 func (t *TimeColumnValue) Clone() ColumnValue {
 	return &TimeColumnValue{
-		val: t.val,
+		TimeDecoder: t.TimeDecoder,
+		val:         t.val,
 	}
 }
 
+// Kind returns the kind of the time value by the time decoder, date with time of day by default
+func (t *TimeColumnValue) Kind() TimeKind {
+	if k, ok := t.TimeDecoder.(TimeKinder); ok {
+		return k.Kind()
+	}
+	return TimeKindDateTime
+}
+
+// Precision returns the precision of fractional seconds by the time decoder, nanosecond by default
+func (t *TimeColumnValue) Precision() int {
+	if k, ok := t.TimeDecoder.(TimeKinder); ok {
+		return k.Precision()
+	}
+	return MaxTimePrecision
+}
+
 // Cmp Returns 1 for greater than, 0 for equal, and -1 for less than
 func (t *TimeColumnValue) Cmp(right ColumnValue) (int, error) {
 	rightValue, err := right.AsTime()
@@ -266,12 +266,91 @@ func TestTimeColumnValue_Clone(t *testing.T) {
 			t:    NewTimeColumnValue(time.Date(2020, 12, 17, 22, 49, 56, 69-999-999, time.Local)).(*TimeColumnValue),
 			want: NewTimeColumnValue(time.Date(2020, 12, 17, 22, 49, 56, 69-999-999, time.Local)),
 		},
+		{
+			name: "2",
+			t: NewTimeColumnValueWithDecoder(time.Date(2020, 12, 17, 22, 49, 56, 0, time.UTC),
+				NewStringTimeDecoder(TimeLayout(TimeKindDate, 0))).(*TimeColumnValue),
+			want: NewTimeColumnValueWithDecoder(time.Date(2020, 12, 17, 22, 49, 56, 0, time.UTC),
+				NewStringTimeDecoder(TimeLayout(TimeKindDate, 0))),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.t.Clone(); !reflect.DeepEqual(got.String(), tt.want.String()) {
+			got := tt.t.Clone()
+			if !reflect.DeepEqual(got.String(), tt.want.String()) {
 				t.Errorf("TimeColumnValue.Clone() = %v, want %v", got, tt.want)
 			}
+			gotS, _ := got.AsString()
+			wantS, _ := tt.want.AsString()
+			if gotS != wantS {
+				t.Errorf("TimeColumnValue.Clone().AsString() = %v, want %v", gotS, wantS)
+			}
+		})
+	}
+}
+
+type plainTimeDecoder struct{}
+
+func (p *plainTimeDecoder) TimeDecode(t time.Time) (any, error) {
+	return t.String(), nil
+}
+
+func (p *plainTimeDecoder) Layout() string {
+	return ""
+}
+
+func TestTimeColumnValue_KindPrecision(t *testing.T) {
+	tests := []struct {
+		name          string
+		t             *TimeColumnValue
+		wantKind      TimeKind
+		wantPrecision int
+		wantString    string
+	}{
+		{
+			name:          "1",
+			t:             NewTimeColumnValue(time.Date(2020, 12, 17, 22, 49, 56, 123456789, time.UTC)).(*TimeColumnValue),
+			wantKind:      TimeKindDateTime,
+			wantPrecision: 9,
+			wantString:    "2020-12-17 22:49:56.123456789Z",
+		},
+		{
+			name: "2",
+			t: NewTimeColumnValueWithDecoder(time.Date(2020, 12, 17, 0, 0, 0, 0, time.UTC),
+				NewStringTimeDecoder(TimeLayout(TimeKindDate, 0))).(*TimeColumnValue),
+			wantKind:      TimeKindDate,
+			wantPrecision: 0,
+			wantString:    "2020-12-17",
+		},
+		{
+			name: "3",
+			t: NewTimeColumnValueWithDecoder(time.Date(0, 1, 1, 22, 49, 56, 123456700, time.UTC),
+				NewStringTimeDecoder(TimeLayout(TimeKindTime, 7))).(*TimeColumnValue),
+			wantKind:      TimeKindTime,
+			wantPrecision: 7,
+			wantString:    "22:49:56.1234567",
+		},
+		{
+			name:          "4",
+			t:             &TimeColumnValue{TimeDecoder: &plainTimeDecoder{}},
+			wantKind:      TimeKindDateTime,
+			wantPrecision: 9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.Kind(); got != tt.wantKind {
+				t.Errorf("TimeColumnValue.Kind() = %v, want %v", got, tt.wantKind)
+			}
+			if got := tt.t.Precision(); got != tt.wantPrecision {
+				t.Errorf("TimeColumnValue.Precision() = %v, want %v", got, tt.wantPrecision)
+			}
+			if tt.wantString == "" {
+				return
+			}
+			if got, _ := tt.t.AsString(); got != tt.wantString {
+				t.Errorf("TimeColumnValue.AsString() = %v, want %v", got, tt.wantString)
+			}
 		})
 	}
 }
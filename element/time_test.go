@@ -74,3 +74,302 @@ func TestStringTimeDecoder_Layout(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeLayout(t *testing.T) {
+	type args struct {
+		kind      TimeKind
+		precision int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			args: args{
+				kind: TimeKindDate,
+			},
+			want: "2006-01-02",
+		},
+		{
+			name: "2",
+			args: args{
+				kind:      TimeKindTime,
+				precision: 7,
+			},
+			want: "15:04:05.9999999",
+		},
+		{
+			name: "3",
+			args: args{
+				kind: TimeKindTime,
+			},
+			want: "15:04:05",
+		},
+		{
+			name: "4",
+			args: args{
+				kind:      TimeKindDateTime,
+				precision: 6,
+			},
+			want: "2006-01-02 15:04:05.999999",
+		},
+		{
+			name: "5",
+			args: args{
+				kind:      TimeKindDateTime,
+				precision: 12,
+			},
+			want: "2006-01-02 15:04:05.999999999",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TimeLayout(tt.args.kind, tt.args.precision); got != tt.want {
+				t.Errorf("TimeLayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeKind_String(t *testing.T) {
+	tests := []struct {
+		name string
+		k    TimeKind
+		want string
+	}{
+		{
+			name: "1",
+			k:    TimeKindDateTime,
+			want: "datetime",
+		},
+		{
+			name: "2",
+			k:    TimeKindDate,
+			want: "date",
+		},
+		{
+			name: "3",
+			k:    TimeKindTime,
+			want: "time",
+		},
+		{
+			name: "4",
+			k:    TimeKind(100),
+			want: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.k.String(); got != tt.want {
+				t.Errorf("TimeKind.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringTimeDecoder_KindPrecision(t *testing.T) {
+	tests := []struct {
+		name          string
+		d             *StringTimeDecoder
+		wantKind      TimeKind
+		wantPrecision int
+	}{
+		{
+			name:          "1",
+			d:             NewStringTimeDecoder(DefaultTimeFormat).(*StringTimeDecoder),
+			wantKind:      TimeKindDateTime,
+			wantPrecision: 9,
+		},
+		{
+			name:          "2",
+			d:             NewStringTimeDecoder("2006-01-02").(*StringTimeDecoder),
+			wantKind:      TimeKindDate,
+			wantPrecision: 0,
+		},
+		{
+			name:          "3",
+			d:             NewStringTimeDecoder("15:04:05.0000000").(*StringTimeDecoder),
+			wantKind:      TimeKindTime,
+			wantPrecision: 7,
+		},
+		{
+			name:          "4",
+			d:             NewStringTimeDecoder("2006/01/02 03:04:05,999 PM").(*StringTimeDecoder),
+			wantKind:      TimeKindDateTime,
+			wantPrecision: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Kind(); got != tt.wantKind {
+				t.Errorf("StringTimeDecoder.Kind() = %v, want %v", got, tt.wantKind)
+			}
+			if got := tt.d.Precision(); got != tt.wantPrecision {
+				t.Errorf("StringTimeDecoder.Precision() = %v, want %v", got, tt.wantPrecision)
+			}
+		})
+	}
+}
+
+func TestTimeKindOf(t *testing.T) {
+	tests := []struct {
+		name          string
+		c             ColumnValue
+		wantKind      TimeKind
+		wantPrecision int
+	}{
+		{
+			name:          "1",
+			c:             NewTimeColumnValueWithDecoder(time.Time{}, NewStringTimeDecoder("2006-01-02")),
+			wantKind:      TimeKindDate,
+			wantPrecision: 0,
+		},
+		{
+			name: "2",
+			c: NewDefaultColumn(NewTimeColumnValueWithDecoder(time.Time{},
+				NewStringTimeDecoder("15:04:05.999999")), "t", 0),
+			wantKind:      TimeKindTime,
+			wantPrecision: 6,
+		},
+		{
+			name:          "3",
+			c:             NewDefaultColumn(NewStringColumnValue("2006-01-02"), "t", 0),
+			wantKind:      TimeKindDateTime,
+			wantPrecision: MaxTimePrecision,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, precision := TimeKindOf(tt.c)
+			if kind != tt.wantKind || precision != tt.wantPrecision {
+				t.Errorf("TimeKindOf() = %v %v, want %v %v", kind, precision, tt.wantKind, tt.wantPrecision)
+			}
+		})
+	}
+}
+
+func TestFixedFractionLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		want   string
+	}{
+		{
+			name:   "1",
+			layout: "2006-01-02 15:04:05.999999",
+			want:   "2006-01-02 15:04:05.000000",
+		},
+		{
+			name:   "2",
+			layout: "15:04:05,999",
+			want:   "15:04:05,000",
+		},
+		{
+			name:   "3",
+			layout: "2006-01-02 15:04:05.000",
+			want:   "2006-01-02 15:04:05.000",
+		},
+		{
+			name:   "4",
+			layout: "2006-01-02 15:04:05",
+			want:   "2006-01-02 15:04:05",
+		},
+		{
+			name:   "5",
+			layout: "v.991 2006",
+			want:   "v.991 2006",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FixedFractionLayout(tt.layout); got != tt.want {
+				t.Errorf("FixedFractionLayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKindLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		kind   TimeKind
+		want   string
+	}{
+		{
+			name:   "1",
+			layout: "2006-01-02 15:04:05",
+			kind:   TimeKindDate,
+			want:   "2006-01-02",
+		},
+		{
+			name:   "2",
+			layout: "2006/01/02T03:04:05.000PM",
+			kind:   TimeKindTime,
+			want:   "03:04:05.000PM",
+		},
+		{
+			name:   "3",
+			layout: "2006-01-02 15:04:05",
+			kind:   TimeKindDateTime,
+			want:   "2006-01-02 15:04:05",
+		},
+		{
+			name:   "4",
+			layout: "15:04:05 2006-01-02",
+			kind:   TimeKindDate,
+			want:   "15:04:05 2006-01-02",
+		},
+		{
+			name:   "5",
+			layout: "2006-01-02",
+			kind:   TimeKindTime,
+			want:   "2006-01-02",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindLayout(tt.layout, tt.kind); got != tt.want {
+				t.Errorf("KindLayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateTime(t *testing.T) {
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	tests := []struct {
+		name      string
+		precision int
+		want      time.Time
+	}{
+		{
+			name: "1",
+			want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name:      "2",
+			precision: 3,
+			want:      time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC),
+		},
+		{
+			name:      "3",
+			precision: 7,
+			want:      time.Date(2024, 1, 2, 3, 4, 5, 123456700, time.UTC),
+		},
+		{
+			name:      "4",
+			precision: MaxTimePrecision,
+			want:      tm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateTime(tm, tt.precision); !got.Equal(tt.want) {
+				t.Errorf("TruncateTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
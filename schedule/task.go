@@ -19,6 +19,14 @@ type Task interface {
 	Do() error // Synchronous execution
 }
 
+// TaskFunc is an adapter to allow the use of ordinary functions as Task
+type TaskFunc func() error
+
+// Do calls f()
+func (f TaskFunc) Do() error {
+	return f()
+}
+
 // AsyncTask (Asynchronous Task)
 type AsyncTask interface {
 	Do() error   // Synchronous execution
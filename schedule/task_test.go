@@ -0,0 +1,45 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTaskFunc_Do(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       TaskFunc
+		wantErr bool
+	}{
+		{
+			name: "1",
+			f:    func() error { return nil },
+		},
+		{
+			name:    "2",
+			f:       func() error { return errors.New("mock error") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.f.Do(); (err != nil) != tt.wantErr {
+				t.Errorf("TaskFunc.Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// ColumnCatalog Column of a table in the catalog of the database, whose rows of the query generated by ColumnCataloger
+// have the column name, whether nullable (YES or NO), the default value, the maximum length of characters, the numeric precision
+// and the numeric scale in order, the unknown ones of which are null. The precision and scale are the ones of the decimal types
+// declared in decimal digits, so that they are comparable across databases, and the ones of integers and floats are null
+type ColumnCatalog struct {
+	Name      string         // Column name
+	Nullable  sql.NullBool   // Whether it is nullable
+	Default   sql.NullString // Default value, which is not valid if the column has no default
+	Length    sql.NullInt64  // Maximum length of characters
+	Precision sql.NullInt64  // Numeric precision
+	Scale     sql.NullInt64  // Numeric scale
+}
+
+// ScanColumnCatalog Scan a row of the query generated by ColumnCataloger into the column catalog
+func ScanColumnCatalog(rows *sql.Rows) (c *ColumnCatalog, err error) {
+	c = &ColumnCatalog{}
+	var nullable sql.NullString
+	if err = rows.Scan(&c.Name, &nullable, &c.Default, &c.Length, &c.Precision, &c.Scale); err != nil {
+		return nil, err
+	}
+	if nullable.Valid {
+		c.Nullable = sql.NullBool{
+			Bool:  strings.EqualFold(nullable.String, "YES"),
+			Valid: true,
+		}
+	}
+	return
+}
+
+// CatalogFieldType Field type whose nullable, length, precision and scale are taken from the column catalog,
+// the ones unknown in the catalog are reported by the driver
+type CatalogFieldType struct {
+	FieldType
+
+	Catalog *ColumnCatalog
+}
+
+// NewCatalogFieldType Get the field type of the field type typ reported by the driver and the column catalog c
+func NewCatalogFieldType(typ FieldType, c *ColumnCatalog) *CatalogFieldType {
+	return &CatalogFieldType{
+		FieldType: typ,
+		Catalog:   c,
+	}
+}
+
+// Length Maximum length of characters in the catalog, or the length reported by the driver if unknown
+func (c *CatalogFieldType) Length() (length int64, ok bool) {
+	if c.Catalog.Length.Valid {
+		return c.Catalog.Length.Int64, true
+	}
+	return c.FieldType.Length()
+}
+
+// DecimalSize Numeric precision and scale in the catalog, or the ones reported by the driver if unknown
+func (c *CatalogFieldType) DecimalSize() (precision, scale int64, ok bool) {
+	if c.Catalog.Precision.Valid {
+		return c.Catalog.Precision.Int64, c.Catalog.Scale.Int64, true
+	}
+	return c.FieldType.DecimalSize()
+}
+
+// Nullable Whether it is nullable in the catalog, or the one reported by the driver if unknown
+func (c *CatalogFieldType) Nullable() (nullable, ok bool) {
+	if c.Catalog.Nullable.Valid {
+		return c.Catalog.Nullable.Bool, true
+	}
+	return c.FieldType.Nullable()
+}
+
+// HasDefault Whether the column has a default value in the catalog
+func (c *CatalogFieldType) HasDefault() bool {
+	return c.Catalog.Default.Valid
+}
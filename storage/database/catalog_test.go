@@ -0,0 +1,90 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestCatalogFieldType(t *testing.T) {
+	// sql.ColumnType without metadata such as the nullable by lib/pq and the length by go-sql-driver/mysql
+	tests := []struct {
+		name          string
+		c             *ColumnCatalog
+		wantLength    int64
+		wantLengthOk  bool
+		wantPrecision int64
+		wantScale     int64
+		wantDecimalOk bool
+		wantNullable  bool
+		wantNullOk    bool
+		wantDefault   bool
+	}{
+		{
+			name: "1",
+			c:    &ColumnCatalog{Name: "f1"},
+		},
+		{
+			name: "2",
+			c: &ColumnCatalog{
+				Name:     "f1",
+				Nullable: sql.NullBool{Bool: false, Valid: true},
+				Default:  sql.NullString{String: "0", Valid: true},
+				Length:   sql.NullInt64{Int64: 10, Valid: true},
+			},
+			wantLength:   10,
+			wantLengthOk: true,
+			wantNullOk:   true,
+			wantDefault:  true,
+		},
+		{
+			name: "3",
+			c: &ColumnCatalog{
+				Name:      "f1",
+				Nullable:  sql.NullBool{Bool: true, Valid: true},
+				Precision: sql.NullInt64{Int64: 10, Valid: true},
+				Scale:     sql.NullInt64{Int64: 2, Valid: true},
+			},
+			wantPrecision: 10,
+			wantScale:     2,
+			wantDecimalOk: true,
+			wantNullable:  true,
+			wantNullOk:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := NewCatalogFieldType(newMockFieldType(GoTypeString), tt.c)
+			if length, ok := typ.Length(); length != tt.wantLength || ok != tt.wantLengthOk {
+				t.Errorf("Length() = %v, %v, want %v, %v", length, ok, tt.wantLength, tt.wantLengthOk)
+			}
+			if precision, scale, ok := typ.DecimalSize(); precision != tt.wantPrecision ||
+				scale != tt.wantScale || ok != tt.wantDecimalOk {
+				t.Errorf("DecimalSize() = %v, %v, %v, want %v, %v, %v",
+					precision, scale, ok, tt.wantPrecision, tt.wantScale, tt.wantDecimalOk)
+			}
+			if nullable, ok := typ.Nullable(); nullable != tt.wantNullable || ok != tt.wantNullOk {
+				t.Errorf("Nullable() = %v, %v, want %v, %v", nullable, ok, tt.wantNullable, tt.wantNullOk)
+			}
+			if got := typ.HasDefault(); got != tt.wantDefault {
+				t.Errorf("HasDefault() = %v, want %v", got, tt.wantDefault)
+			}
+			if !typ.IsSupported() {
+				t.Errorf("IsSupported() = false")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package clickhouse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	json, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return json
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		wantC   *Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{
+				"url":"tcp://127.0.0.1:9000?database=db",
+				"username":"user",
+				"password":"password"
+			}`),
+			wantC: &Config{
+				URL:      "tcp://127.0.0.1:9000?database=db",
+				Username: "user",
+				Password: "password",
+			},
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{
+				"url":"tcp://127.0.0.1:9000?database=db",
+				"username":"user",
+				"password":1
+			}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotC, err := NewConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotC, tt.wantC) {
+				t.Errorf("NewConfig() = %v, want %v", gotC, tt.wantC)
+			}
+		})
+	}
+}
+
+func TestConfig_FormatDSN(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Config
+		wantDsn string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c: &Config{
+				URL:      "tcp://127.0.0.1:9000?database=db&read_timeout=10",
+				Username: "user",
+				Password: "password",
+			},
+			wantDsn: "tcp://127.0.0.1:9000?database=db&password=password&read_timeout=10&username=user",
+		},
+		{
+			name: "2",
+			c: &Config{
+				URL:      "tcp://127.0.0.1:9000",
+				Username: "default",
+			},
+			wantDsn: "tcp://127.0.0.1:9000?username=default",
+		},
+		{
+			name: "3",
+			c: &Config{
+				URL: "tcp://127.0.0.1:9000%",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDsn, err := tt.c.FormatDSN()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.FormatDSN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotDsn != tt.wantDsn {
+				t.Errorf("Config.FormatDSN() = %v, want %v", gotDsn, tt.wantDsn)
+			}
+		})
+	}
+}
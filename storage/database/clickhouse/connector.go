@@ -0,0 +1,120 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go"
+)
+
+var selectRe = regexp.MustCompile(`\s+SELECT\s+`)
+
+// connector opens the connections of github.com/ClickHouse/clickhouse-go. The driver appends each execution of a
+// prepared insert statement in a transaction to a block sent on commit, so the statement of the columnar batch insert
+// has no fixed number of arguments and ignores the empty execution used to flush the batch.
+type connector struct {
+	dsn string
+}
+
+// Connect opens a connection
+func (c *connector) Connect(_ context.Context) (driver.Conn, error) {
+	return (&chDriver{}).Open(c.dsn)
+}
+
+// Driver is the driver of the connector
+func (c *connector) Driver() driver.Driver {
+	return &chDriver{}
+}
+
+type chDriver struct{}
+
+// Open opens a connection by dsn
+func (d *chDriver) Open(dsn string) (driver.Conn, error) {
+	c, err := clickhouse.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c}, nil
+}
+
+type conn struct {
+	driver.Conn
+}
+
+// Prepare prepares a statement
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext prepares a statement, and the insert statement is of the columnar batch insert
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if isInsert(query) {
+		return &insertStmt{Stmt: stmt}, nil
+	}
+	return stmt, nil
+}
+
+// BeginTx begins a transaction, in which the insert statements are executed in batch
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+// ExecContext executes a query
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+// Ping checks whether the connection is alive
+func (c *conn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+// CheckNamedValue checks and converts the arguments
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	return c.Conn.(driver.NamedValueChecker).CheckNamedValue(nv)
+}
+
+type insertStmt struct {
+	driver.Stmt
+}
+
+// NumInput is -1 as each execution appends a row of any number of columns to the block
+func (s *insertStmt) NumInput() int {
+	return -1
+}
+
+// ExecContext appends a row to the block, and ignores the empty execution
+func (s *insertStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if len(args) == 0 {
+		return driver.RowsAffected(0), nil
+	}
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+}
+
+func isInsert(query string) bool {
+	if f := strings.Fields(query); len(f) > 2 {
+		return strings.EqualFold("INSERT", f[0]) && strings.EqualFold("INTO", f[1]) &&
+			!selectRe.MatchString(strings.ToUpper(query))
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+type mockStmt struct{}
+
+func (s *mockStmt) Close() error {
+	return nil
+}
+
+func (s *mockStmt) NumInput() int {
+	return 0
+}
+
+func (s *mockStmt) Exec(_ []driver.Value) (driver.Result, error) {
+	return nil, errors.New("mock error")
+}
+
+func (s *mockStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("mock error")
+}
+
+func (s *mockStmt) ExecContext(_ context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(len(args)), nil
+}
+
+func Test_isInsert(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  bool
+	}{
+		{
+			name:  "1",
+			query: "insert into `db`.`table`(`f1`,`f2`) values(?,?)",
+			want:  true,
+		},
+		{
+			name:  "2",
+			query: "INSERT INTO `db`.`table` VALUES",
+			want:  true,
+		},
+		{
+			name:  "3",
+			query: "insert into `db`.`table` select * from `db`.`src`",
+		},
+		{
+			name:  "4",
+			query: "select * from `db`.`table`",
+		},
+		{
+			name:  "5",
+			query: "insert",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isInsert(tt.query); got != tt.want {
+				t.Errorf("isInsert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertStmt(t *testing.T) {
+	s := &insertStmt{Stmt: &mockStmt{}}
+	if got := s.NumInput(); got != -1 {
+		t.Errorf("insertStmt.NumInput() = %v, want %v", got, -1)
+	}
+
+	tests := []struct {
+		name string
+		args []driver.NamedValue
+		want driver.Result
+	}{
+		{
+			name: "1",
+			want: driver.RowsAffected(0),
+		},
+		{
+			name: "2",
+			args: []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: "a"}},
+			want: driver.RowsAffected(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ExecContext(context.TODO(), tt.args)
+			if err != nil {
+				t.Fatalf("insertStmt.ExecContext() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("insertStmt.ExecContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
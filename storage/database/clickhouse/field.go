@@ -0,0 +1,617 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/cockroachdb/apd/v3"
+)
+
+var (
+	dateLayout       = element.DefaultTimeFormat[:10]
+	datetimeLayout   = element.DefaultTimeFormat[:19]
+	datetime64Layout = element.DefaultTimeFormat[:29]
+)
+
+// kinds of the ClickHouse types, which are the types without Nullable and LowCardinality
+const (
+	kindUnknown = iota
+	kindInt
+	kindFloat
+	kindDecimal
+	kindString
+	kindDate
+	kindDateTime
+	kindDateTime64
+	kindArray
+	kindJSON
+)
+
+// Field represents a field of ClickHouse
+type Field struct {
+	*database.BaseField
+	database.BaseConfigSetter
+}
+
+// NewField generates a field based on basic column attributes
+func NewField(bf *database.BaseField) *Field {
+	return &Field{
+		BaseField: bf,
+	}
+}
+
+// Quoted is used for quoting in SQL statements
+func (f *Field) Quoted() string {
+	return Quoted(f.Name())
+}
+
+// BindVar is the SQL placeholder used in SQL statements
+func (f *Field) BindVar(_ int) string {
+	return "?"
+}
+
+// Select is the field for querying used in SQL query statements,
+// Decimal is queried as string to keep its precision, and Array, Map and Tuple are queried as JSON
+func (f *Field) Select() string {
+	switch typeKind(f.FieldType().DatabaseTypeName()) {
+	case kindDecimal:
+		return "toString(" + f.Quoted() + ")"
+	case kindArray, kindJSON:
+		return "toJSONString(" + f.Quoted() + ")"
+	}
+	return f.Quoted()
+}
+
+// Type represents the type of the field
+func (f *Field) Type() database.FieldType {
+	return NewFieldType(f.FieldType())
+}
+
+// Scanner is used for reading data from a field
+func (f *Field) Scanner() database.Scanner {
+	return NewScanner(f)
+}
+
+// Valuer converts the column to the value of the ClickHouse type
+func (f *Field) Valuer(c element.Column) database.Valuer {
+	return NewValuer(f, c)
+}
+
+// ColumnType is the column type described by DESCRIBE of ClickHouse
+type ColumnType struct {
+	name string
+	typ  string
+}
+
+// NewColumnType creates the column type by the column name and the ClickHouse type
+func NewColumnType(name, typ string) *ColumnType {
+	return &ColumnType{
+		name: name,
+		typ:  typ,
+	}
+}
+
+// Name is the column name
+func (c *ColumnType) Name() string {
+	return c.name
+}
+
+// ScanType is the scanning type, which is any as the value is converted by Scanner
+func (c *ColumnType) ScanType() reflect.Type {
+	return reflect.TypeOf((*any)(nil)).Elem()
+}
+
+// Length is the length of FixedString
+func (c *ColumnType) Length() (length int64, ok bool) {
+	base, _ := baseType(c.typ)
+	if strings.HasPrefix(base, "FixedString(") {
+		length, err := strconv.ParseInt(base[12:len(base)-1], 10, 64)
+		return length, err == nil
+	}
+	return 0, false
+}
+
+// DecimalSize is the precision and scale of Decimal
+func (c *ColumnType) DecimalSize() (precision, scale int64, ok bool) {
+	base, _ := baseType(c.typ)
+	p, s, ok := decimalSize(base)
+	return int64(p), int64(s), ok
+}
+
+// Nullable is whether the column is Nullable
+func (c *ColumnType) Nullable() (nullable, ok bool) {
+	_, nullable = baseType(c.typ)
+	return nullable, true
+}
+
+// DatabaseTypeName is the ClickHouse type, such as Nullable(Decimal(18, 4))
+func (c *ColumnType) DatabaseTypeName() string {
+	return c.typ
+}
+
+// FieldType represents the type of a field
+type FieldType struct {
+	*database.BaseFieldType
+
+	goType database.GoType
+}
+
+// NewFieldType creates a new field type
+func NewFieldType(typ database.ColumnType) *FieldType {
+	f := &FieldType{
+		BaseFieldType: database.NewBaseFieldType(typ),
+	}
+	switch typeKind(f.DatabaseTypeName()) {
+	case kindInt:
+		f.goType = database.GoTypeInt64
+	case kindFloat:
+		f.goType = database.GoTypeFloat64
+	case kindDecimal, kindString, kindArray, kindJSON:
+		f.goType = database.GoTypeString
+	case kindDate, kindDateTime, kindDateTime64:
+		f.goType = database.GoTypeTime
+	}
+	return f
+}
+
+// IsSupported indicates whether parsing is supported for the type
+func (f *FieldType) IsSupported() bool {
+	return f.GoType() != database.GoTypeUnknown
+}
+
+// GoType returns the Golang type used when processing values
+func (f *FieldType) GoType() database.GoType {
+	return f.goType
+}
+
+// Scanner is a scanner used for reading data based on the column type
+type Scanner struct {
+	database.BaseScanner
+
+	f *Field
+}
+
+// NewScanner generates a scanner based on the column type
+func NewScanner(f *Field) *Scanner {
+	return &Scanner{
+		f: f,
+	}
+}
+
+// Scan reads data from a column based on its type
+// Int8, Int16, Int32, Int64, UInt8, UInt16, UInt32, UInt64 are treated as integers.
+// Float32, Float64, Decimal are treated as high-precision real numbers.
+// Date, DateTime, DateTime64 are treated as time.
+// String, FixedString, UUID, Enum8, Enum16, IPv4, IPv6 are treated as strings.
+// Array, Map, Tuple are treated as JSON.
+func (s *Scanner) Scan(src any) (err error) {
+	defer s.f.SetError(&err)
+	var cv element.ColumnValue
+	byteSize := element.ByteSize(src)
+	typ, _ := baseType(s.f.Type().DatabaseTypeName())
+	switch typeKind(typ) {
+	case kindInt:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilBigIntColumnValue()
+		case int8:
+			cv = element.NewBigIntColumnValueFromInt64(int64(data))
+		case int16:
+			cv = element.NewBigIntColumnValueFromInt64(int64(data))
+		case int32:
+			cv = element.NewBigIntColumnValueFromInt64(int64(data))
+		case int64:
+			cv = element.NewBigIntColumnValueFromInt64(data)
+		case uint8:
+			cv = element.NewBigIntColumnValueFromUint64(uint64(data))
+		case uint16:
+			cv = element.NewBigIntColumnValueFromUint64(uint64(data))
+		case uint32:
+			cv = element.NewBigIntColumnValueFromUint64(uint64(data))
+		case uint64:
+			cv = element.NewBigIntColumnValueFromUint64(data)
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeBigInt)
+		}
+	case kindFloat:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilDecimalColumnValue()
+		case float32:
+			cv = element.NewDecimalColumnValueFromFloat32(data)
+		case float64:
+			cv = element.NewDecimalColumnValueFromFloat(data)
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeDecimal)
+		}
+	case kindDecimal:
+		_, scale, _ := decimalSize(typ)
+		var unscaled *big.Int
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilDecimalColumnValue()
+		case string:
+			if cv, err = element.NewDecimalColumnValueFromString(data); err != nil {
+				return
+			}
+		case int32:
+			unscaled = big.NewInt(int64(data))
+		case int64:
+			unscaled = big.NewInt(data)
+		case []byte:
+			if unscaled, err = decimal128ToBigInt(data); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeDecimal)
+		}
+		if unscaled != nil {
+			if cv, err = element.NewDecimalColumnValueFromString(
+				apd.NewWithBigInt(new(apd.BigInt).SetMathBigInt(unscaled), -int32(scale)).Text('f')); err != nil {
+				return
+			}
+		}
+	case kindString:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilStringColumnValue()
+		case string:
+			if strings.HasPrefix(typ, "FixedString(") {
+				data = strings.TrimRight(data, "\x00")
+			}
+			cv = element.NewStringColumnValue(data)
+		case []byte:
+			cv = element.NewStringColumnValue(string(data))
+		case net.IP:
+			cv = element.NewStringColumnValue(data.String())
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeString)
+		}
+	case kindDate, kindDateTime, kindDateTime64:
+		layout := dateLayout
+		switch typeKind(typ) {
+		case kindDateTime:
+			layout = datetimeLayout
+		case kindDateTime64:
+			layout = datetime64Layout
+		}
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(layout))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case kindArray, kindJSON:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilJsonColumnValue()
+		case string:
+			if cv, err = element.NewJsonColumnValueFromString(data); err != nil {
+				return
+			}
+		default:
+			// Array queried by querySql is scanned as the slice
+			var b []byte
+			if b, err = json.Marshal(data); err != nil {
+				return
+			}
+			if cv, err = element.NewJsonColumnValueFromBytes(b); err != nil {
+				return
+			}
+		}
+	default:
+		return fmt.Errorf("src is %v(%T), but db type is %v", src, src, s.f.Type().DatabaseTypeName())
+	}
+	s.SetColumn(element.NewDefaultColumn(cv, s.f.Name(), byteSize))
+	return
+}
+
+// Valuer converts the column to the value accepted by github.com/ClickHouse/clickhouse-go
+type Valuer struct {
+	f *Field
+	c element.Column
+}
+
+// NewValuer creates a new valuer
+func NewValuer(f *Field, c element.Column) *Valuer {
+	return &Valuer{
+		f: f,
+		c: c,
+	}
+}
+
+// Value converts the column by the ClickHouse type,
+// UInt64 is converted to uint64, Decimal to its unscaled integer, and Array from its JSON array.
+func (v *Valuer) Value() (value driver.Value, err error) {
+	defer v.f.SetError(&err)
+	if v.c.IsNil() {
+		return nil, nil
+	}
+	typ, _ := baseType(v.f.Type().DatabaseTypeName())
+	switch typeKind(typ) {
+	case kindInt:
+		if typ == "UInt64" {
+			var bi element.BigIntNumber
+			if bi, err = v.c.AsBigInt(); err != nil {
+				return nil, err
+			}
+			return strconv.ParseUint(bi.String(), 10, 64)
+		}
+		return v.c.AsInt64()
+	case kindFloat:
+		return v.c.AsFloat64()
+	case kindDecimal:
+		var d element.DecimalNumber
+		if d, err = v.c.AsDecimal(); err != nil {
+			return nil, err
+		}
+		return decimalValue(d.String(), typ)
+	case kindString:
+		return v.c.AsString()
+	case kindDate, kindDateTime, kindDateTime64:
+		return v.c.AsTime()
+	case kindArray:
+		var s string
+		if s, err = v.c.AsString(); err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(strings.NewReader(s))
+		decoder.UseNumber()
+		var a any
+		if err = decoder.Decode(&a); err != nil {
+			return nil, err
+		}
+		var rv reflect.Value
+		if rv, err = arrayValue(a, typ); err != nil {
+			return nil, err
+		}
+		return rv.Interface(), nil
+	}
+	return nil, fmt.Errorf("type(%v) is not supported to write", v.f.Type().DatabaseTypeName())
+}
+
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// arrayValue converts the JSON value a to the nested slices of the Array type typ,
+// whose elements are the values of the element type
+func arrayValue(a any, typ string) (reflect.Value, error) {
+	base, _ := baseType(typ)
+	if typeKind(base) != kindArray {
+		e, err := elementValue(a, typ)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv := reflect.New(anyType).Elem()
+		if e != nil {
+			rv.Set(reflect.ValueOf(e))
+		}
+		return rv, nil
+	}
+	elems, ok := a.([]any)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%v is not %v", a, typ)
+	}
+	elemType := base[6 : len(base)-1]
+	rv := reflect.MakeSlice(reflect.SliceOf(arrayType(elemType)), 0, len(elems))
+	for _, e := range elems {
+		ev, err := arrayValue(e, elemType)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv = reflect.Append(rv, ev)
+	}
+	return rv, nil
+}
+
+func arrayType(typ string) reflect.Type {
+	base, _ := baseType(typ)
+	if typeKind(base) != kindArray {
+		return anyType
+	}
+	return reflect.SliceOf(arrayType(base[6 : len(base)-1]))
+}
+
+// elementValue converts the JSON value e to the value of the array element type typ
+func elementValue(e any, typ string) (any, error) {
+	base, nullable := baseType(typ)
+	if e == nil {
+		if !nullable {
+			return nil, fmt.Errorf("null is not %v", typ)
+		}
+		return nil, nil
+	}
+	n, isNumber := e.(json.Number)
+	switch typeKind(base) {
+	case kindInt:
+		if !isNumber {
+			return nil, fmt.Errorf("%v is not %v", e, typ)
+		}
+		if base == "UInt64" {
+			return strconv.ParseUint(n.String(), 10, 64)
+		}
+		return n.Int64()
+	case kindFloat:
+		if !isNumber {
+			return nil, fmt.Errorf("%v is not %v", e, typ)
+		}
+		return n.Float64()
+	case kindDecimal:
+		s, ok := e.(string)
+		if isNumber {
+			s, ok = n.String(), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("%v is not %v", e, typ)
+		}
+		return decimalValue(s, base)
+	case kindString, kindDate, kindDateTime, kindDateTime64:
+		if isNumber {
+			return n.String(), nil
+		}
+		if s, ok := e.(string); ok {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%v is not %v", e, typ)
+}
+
+// decimalValue converts the decimal string s to the unscaled integer of the Decimal type typ,
+// which is int64 for Decimal32 and Decimal64, and 16 little-endian bytes for Decimal128
+func decimalValue(s string, typ string) (any, error) {
+	precision, scale, ok := decimalSize(typ)
+	if !ok {
+		return nil, fmt.Errorf("%v is not Decimal", typ)
+	}
+	d, _, err := apd.NewFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	q := new(apd.Decimal)
+	if _, err = apd.BaseContext.WithPrecision(uint32(precision+len(s))).Quantize(q, d, -int32(scale)); err != nil {
+		return nil, err
+	}
+	unscaled := q.Coeff.MathBigInt()
+	if unscaled.BitLen() > 127 || len(unscaled.String()) > precision {
+		return nil, fmt.Errorf("%v overflows %v", s, typ)
+	}
+	if q.Negative {
+		unscaled.Neg(unscaled)
+	}
+	if precision <= 18 {
+		return unscaled.Int64(), nil
+	}
+	if unscaled.Sign() < 0 {
+		unscaled.Add(unscaled, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	b := unscaled.FillBytes(make([]byte, 16))
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b, nil
+}
+
+// decimal128ToBigInt converts 16 little-endian bytes of Decimal128 to the unscaled integer
+func decimal128ToBigInt(b []byte) (*big.Int, error) {
+	if len(b) != 16 {
+		return nil, fmt.Errorf("Decimal128 is %v bytes, but not 16 bytes", len(b))
+	}
+	be := make([]byte, 16)
+	for i := range b {
+		be[15-i] = b[i]
+	}
+	unscaled := new(big.Int).SetBytes(be)
+	if be[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	return unscaled, nil
+}
+
+// baseType removes Nullable and LowCardinality from the ClickHouse type typ,
+// and reports whether typ is Nullable
+func baseType(typ string) (base string, nullable bool) {
+	base = strings.TrimSpace(typ)
+	for {
+		switch {
+		case strings.HasPrefix(base, "Nullable(") && strings.HasSuffix(base, ")"):
+			nullable = true
+			base = base[9 : len(base)-1]
+		case strings.HasPrefix(base, "LowCardinality(") && strings.HasSuffix(base, ")"):
+			base = base[15 : len(base)-1]
+		default:
+			return
+		}
+	}
+}
+
+func typeKind(typ string) int {
+	base, _ := baseType(typ)
+	switch base {
+	case "Int8", "Int16", "Int32", "Int64",
+		"UInt8", "UInt16", "UInt32", "UInt64":
+		return kindInt
+	case "Float32", "Float64":
+		return kindFloat
+	case "String", "UUID", "IPv4", "IPv6":
+		return kindString
+	case "Date":
+		return kindDate
+	}
+	switch {
+	case strings.HasPrefix(base, "Decimal"):
+		return kindDecimal
+	case strings.HasPrefix(base, "FixedString("),
+		strings.HasPrefix(base, "Enum8("), strings.HasPrefix(base, "Enum16("):
+		return kindString
+	case strings.HasPrefix(base, "DateTime64"):
+		return kindDateTime64
+	case strings.HasPrefix(base, "DateTime"):
+		return kindDateTime
+	case strings.HasPrefix(base, "Array("):
+		return kindArray
+	case strings.HasPrefix(base, "Map("), strings.HasPrefix(base, "Tuple("):
+		return kindJSON
+	}
+	return kindUnknown
+}
+
+// decimalSize parses the precision and scale of Decimal(P, S), Decimal32(S), Decimal64(S) and Decimal128(S)
+func decimalSize(typ string) (precision, scale int, ok bool) {
+	i := strings.Index(typ, "(")
+	if i < 0 || !strings.HasSuffix(typ, ")") {
+		return 0, 0, false
+	}
+	params := strings.Split(typ[i+1:len(typ)-1], ",")
+	var err error
+	switch typ[:i] {
+	case "Decimal":
+		if len(params) != 2 {
+			return 0, 0, false
+		}
+		if precision, err = strconv.Atoi(strings.TrimSpace(params[0])); err != nil {
+			return 0, 0, false
+		}
+		params = params[1:]
+	case "Decimal32":
+		precision = 9
+	case "Decimal64":
+		precision = 18
+	case "Decimal128":
+		precision = 38
+	case "Decimal256":
+		precision = 76
+	default:
+		return 0, 0, false
+	}
+	if len(params) != 1 {
+		return 0, 0, false
+	}
+	if scale, err = strconv.Atoi(strings.TrimSpace(params[0])); err != nil {
+		return 0, 0, false
+	}
+	return precision, scale, true
+}
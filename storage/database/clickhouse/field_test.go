@@ -0,0 +1,392 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+func mustDecimalColumnValueFromString(s string) element.ColumnValue {
+	cv, err := element.NewDecimalColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cv
+}
+
+func newTestField(typ string) *Field {
+	return NewField(database.NewBaseField(0, "f1", NewFieldType(NewColumnType("f1", typ))))
+}
+
+func TestField_Select(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  string
+		want string
+	}{
+		{
+			name: "1",
+			typ:  "Int32",
+			want: "`f1`",
+		},
+		{
+			name: "2",
+			typ:  "Nullable(Decimal(38, 6))",
+			want: "toString(`f1`)",
+		},
+		{
+			name: "3",
+			typ:  "Array(String)",
+			want: "toJSONString(`f1`)",
+		},
+		{
+			name: "4",
+			typ:  "Map(String, UInt8)",
+			want: "toJSONString(`f1`)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTestField(tt.typ).Select(); got != tt.want {
+				t.Errorf("Field.Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnType(t *testing.T) {
+	c := NewColumnType("f1", "Nullable(Decimal(18, 4))")
+	if got := c.Name(); got != "f1" {
+		t.Errorf("ColumnType.Name() = %v, want %v", got, "f1")
+	}
+	if p, s, ok := c.DecimalSize(); p != 18 || s != 4 || !ok {
+		t.Errorf("ColumnType.DecimalSize() = %v %v %v", p, s, ok)
+	}
+	if nullable, ok := c.Nullable(); !nullable || !ok {
+		t.Errorf("ColumnType.Nullable() = %v %v", nullable, ok)
+	}
+	if l, ok := NewColumnType("f1", "FixedString(16)").Length(); l != 16 || !ok {
+		t.Errorf("ColumnType.Length() = %v %v", l, ok)
+	}
+	if _, ok := NewColumnType("f1", "String").Length(); ok {
+		t.Errorf("ColumnType.Length() ok = %v", ok)
+	}
+}
+
+func TestFieldType_GoType(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  string
+		want database.GoType
+	}{
+		{name: "1", typ: "UInt64", want: database.GoTypeInt64},
+		{name: "2", typ: "Nullable(Int8)", want: database.GoTypeInt64},
+		{name: "3", typ: "Float32", want: database.GoTypeFloat64},
+		{name: "4", typ: "Decimal(38, 2)", want: database.GoTypeString},
+		{name: "5", typ: "Decimal64(3)", want: database.GoTypeString},
+		{name: "6", typ: "LowCardinality(String)", want: database.GoTypeString},
+		{name: "7", typ: "FixedString(8)", want: database.GoTypeString},
+		{name: "8", typ: "Enum8('a' = 1, 'b' = 2)", want: database.GoTypeString},
+		{name: "9", typ: "UUID", want: database.GoTypeString},
+		{name: "10", typ: "IPv4", want: database.GoTypeString},
+		{name: "11", typ: "Date", want: database.GoTypeTime},
+		{name: "12", typ: "DateTime('Asia/Shanghai')", want: database.GoTypeTime},
+		{name: "13", typ: "DateTime64(3)", want: database.GoTypeTime},
+		{name: "14", typ: "Array(Nullable(Int32))", want: database.GoTypeString},
+		{name: "15", typ: "Map(String, String)", want: database.GoTypeString},
+		{name: "16", typ: "Tuple(String, Int32)", want: database.GoTypeString},
+		{name: "17", typ: "AggregateFunction(uniq, UInt64)", want: database.GoTypeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFieldType(NewColumnType("f1", tt.typ))
+			if got := f.GoType(); got != tt.want {
+				t.Errorf("FieldType.GoType() = %v, want %v", got, tt.want)
+			}
+			if got := f.IsSupported(); got != (tt.want != database.GoTypeUnknown) {
+				t.Errorf("FieldType.IsSupported() = %v", got)
+			}
+		})
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	tests := []struct {
+		name    string
+		typ     string
+		src     any
+		want    element.Column
+		wantErr bool
+	}{
+		{
+			name: "1",
+			typ:  "UInt64",
+			src:  uint64(18446744073709551615),
+			want: element.NewDefaultColumn(element.NewBigIntColumnValueFromUint64(18446744073709551615), "f1", 16),
+		},
+		{
+			name: "2",
+			typ:  "Nullable(Int8)",
+			src:  int8(-1),
+			want: element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(-1), "f1", 16),
+		},
+		{
+			name: "3",
+			typ:  "Nullable(Int8)",
+			src:  nil,
+			want: element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "f1", 0),
+		},
+		{
+			name: "4",
+			typ:  "Float64",
+			src:  float64(1.5),
+			want: element.NewDefaultColumn(element.NewDecimalColumnValueFromFloat(1.5), "f1", 16),
+		},
+		{
+			name: "5",
+			typ:  "Decimal(38, 6)",
+			src:  "12345678901234567890.123456",
+			want: element.NewDefaultColumn(mustDecimalColumnValueFromString("12345678901234567890.123456"), "f1", 27),
+		},
+		{
+			name: "6",
+			typ:  "Decimal(18, 4)",
+			src:  int64(-123456),
+			want: element.NewDefaultColumn(mustDecimalColumnValueFromString("-12.3456"), "f1", 16),
+		},
+		{
+			name: "7",
+			typ:  "Decimal(38, 2)",
+			src:  []byte{0x39, 0x30, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			want: element.NewDefaultColumn(mustDecimalColumnValueFromString("123.45"), "f1", 16),
+		},
+		{
+			name: "8",
+			typ:  "FixedString(4)",
+			src:  "ab\x00\x00",
+			want: element.NewDefaultColumn(element.NewStringColumnValue("ab"), "f1", 4),
+		},
+		{
+			name: "9",
+			typ:  "IPv4",
+			src:  net.IPv4(127, 0, 0, 1),
+			want: element.NewDefaultColumn(element.NewStringColumnValue("127.0.0.1"), "f1", 16),
+		},
+		{
+			name: "10",
+			typ:  "DateTime64(3)",
+			src:  time.Date(2023, 1, 2, 3, 4, 5, 6000000, loc),
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(time.Date(2023, 1, 2, 3, 4, 5, 6000000, loc),
+				element.NewStringTimeDecoder(datetime64Layout)), "f1", 16),
+		},
+		{
+			name: "11",
+			typ:  "Map(String, String)",
+			src:  `{"a":"1"}`,
+			want: element.NewDefaultColumn(mustJSONColumnValueFromString(`{"a":"1"}`), "f1", 9),
+		},
+		{
+			name: "12",
+			typ:  "Array(Int32)",
+			src:  []int32{1, 2},
+			want: element.NewDefaultColumn(mustJSONColumnValueFromString(`[1,2]`), "f1", 16),
+		},
+		{
+			name:    "13",
+			typ:     "Int32",
+			src:     "1",
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			typ:     "AggregateFunction(uniq, UInt64)",
+			src:     "1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScanner(newTestField(tt.typ))
+			if err := s.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("Scanner.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := s.Column(); got.String() != tt.want.String() || got.ByteSize() != tt.want.ByteSize() {
+				t.Errorf("Scanner.Column() = %v(%v), want %v(%v)", got, got.ByteSize(), tt.want, tt.want.ByteSize())
+			}
+		})
+	}
+}
+
+func mustJSONColumnValueFromString(s string) element.ColumnValue {
+	cv, err := element.NewJsonColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cv
+}
+
+func TestValuer_Value(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	newColumn := func(cv element.ColumnValue) element.Column {
+		return element.NewDefaultColumn(cv, "f1", 0)
+	}
+	tests := []struct {
+		name    string
+		typ     string
+		c       element.Column
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "1",
+			typ:  "UInt64",
+			c:    newColumn(element.NewBigIntColumnValueFromUint64(18446744073709551615)),
+			want: uint64(18446744073709551615),
+		},
+		{
+			name: "2",
+			typ:  "Nullable(Int16)",
+			c:    newColumn(element.NewBigIntColumnValueFromInt64(-2)),
+			want: int64(-2),
+		},
+		{
+			name: "3",
+			typ:  "Nullable(Int16)",
+			c:    newColumn(element.NewNilBigIntColumnValue()),
+			want: nil,
+		},
+		{
+			name: "4",
+			typ:  "Float32",
+			c:    newColumn(mustDecimalColumnValueFromString("1.25")),
+			want: float64(1.25),
+		},
+		{
+			name: "5",
+			typ:  "Decimal(18, 4)",
+			c:    newColumn(mustDecimalColumnValueFromString("-12.34565")),
+			want: int64(-123457),
+		},
+		{
+			name: "6",
+			typ:  "Decimal(38, 2)",
+			c:    newColumn(mustDecimalColumnValueFromString("-1")),
+			want: []byte{0x9c, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+		{
+			name:    "7",
+			typ:     "Decimal(4, 2)",
+			c:       newColumn(mustDecimalColumnValueFromString("123.45")),
+			wantErr: true,
+		},
+		{
+			name: "8",
+			typ:  "LowCardinality(String)",
+			c:    newColumn(element.NewStringColumnValue("abc")),
+			want: "abc",
+		},
+		{
+			name: "9",
+			typ:  "DateTime",
+			c:    newColumn(element.NewTimeColumnValue(time.Date(2023, 1, 2, 3, 4, 5, 0, loc))),
+			want: time.Date(2023, 1, 2, 3, 4, 5, 0, loc),
+		},
+		{
+			name: "10",
+			typ:  "Array(Nullable(Int32))",
+			c:    newColumn(element.NewStringColumnValue("[1,null,3]")),
+			want: []any{int64(1), nil, int64(3)},
+		},
+		{
+			name: "11",
+			typ:  "Array(Array(String))",
+			c:    newColumn(element.NewStringColumnValue(`[["a"],["b","c"]]`)),
+			want: [][]any{{"a"}, {"b", "c"}},
+		},
+		{
+			name:    "12",
+			typ:     "Array(Int32)",
+			c:       newColumn(element.NewStringColumnValue("[1,null]")),
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			typ:     "Map(String, String)",
+			c:       newColumn(element.NewStringColumnValue(`{"a":"1"}`)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewValuer(newTestField(tt.typ), tt.c).Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Valuer.Value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Valuer.Value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_decimal128ToBigInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       []byte
+		want    *big.Int
+		wantErr bool
+	}{
+		{
+			name: "1",
+			b:    []byte{0x39, 0x30, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			want: big.NewInt(12345),
+		},
+		{
+			name: "2",
+			b:    []byte{0x9c, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			want: big.NewInt(-100),
+		},
+		{
+			name:    "3",
+			b:       []byte{0x01},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decimal128ToBigInt(tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decimal128ToBigInt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decimal128ToBigInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+func TestDialect_Name(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Dialect
+		want string
+	}{
+		{
+			name: "1",
+			d:    Dialect{},
+			want: "clickhouse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Name(); got != tt.want {
+				t.Errorf("Dialect.Name() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_Source(t *testing.T) {
+	tests := []struct {
+		name    string
+		bs      *database.BaseSource
+		wantDsn string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			bs: database.NewBaseSource(testJSONFromString(`{
+				"url":"tcp://127.0.0.1:9000?database=db",
+				"username":"user",
+				"password":"password"
+			}`)),
+			wantDsn: "tcp://127.0.0.1:9000?database=db&password=password&username=user",
+		},
+		{
+			name: "2",
+			bs: database.NewBaseSource(testJSONFromString(`{
+				"url":"tcp://127.0.0.1:9000?database=db",
+				"username":"user",
+				"password":1
+			}`)),
+			wantErr: true,
+		},
+		{
+			name: "3",
+			bs: database.NewBaseSource(testJSONFromString(`{
+				"url":"tcp://127.0.0.1:9000%"
+			}`)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Dialect{}.Source(tt.bs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Dialect.Source() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.DriverName() != "clickhouse" {
+				t.Errorf("Source.DriverName() = %v", got.DriverName())
+			}
+			if got.ConnectName() != tt.wantDsn {
+				t.Errorf("Source.ConnectName() = %v, want %v", got.ConnectName(), tt.wantDsn)
+			}
+			if got.Key() != tt.wantDsn {
+				t.Errorf("Source.Key() = %v, want %v", got.Key(), tt.wantDsn)
+			}
+			if _, ok := got.Table(database.NewBaseTable("db", "", "table")).(*Table); !ok {
+				t.Errorf("Source.Table() is not *Table")
+			}
+			if _, err := got.(database.WithConnector).Connector(); err != nil {
+				t.Errorf("Source.Connector() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestQuoted(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "1",
+			s:    "table",
+			want: "`table`",
+		},
+		{
+			name: "2",
+			s:    "ta`ble",
+			want: "`ta\\`ble`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Quoted(tt.s); got != tt.want {
+				t.Errorf("Quoted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
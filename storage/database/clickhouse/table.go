@@ -0,0 +1,217 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/ClickHouse/clickhouse-go"
+	"github.com/pingcap/errors"
+)
+
+// WriteModeBatch represents the columnar batch insert write mode, in which the records are appended to the columns of
+// a block sent to ClickHouse at once.
+const WriteModeBatch = "batch"
+
+// Table represents a ClickHouse table.
+type Table struct {
+	*database.BaseTable
+	database.BaseConfigSetter
+}
+
+// NewTable creates a new ClickHouse table. Note that at this point, the schema parameter in BaseTable is empty, instance is the database name, and name is the table name.
+func NewTable(b *database.BaseTable) *Table {
+	return &Table{
+		BaseTable: b,
+	}
+}
+
+// Quoted refers to the fully qualified name of the table.
+func (t *Table) Quoted() string {
+	return Quoted(t.Instance()) + "." + Quoted(t.Name())
+}
+
+func (t *Table) String() string {
+	return t.Quoted()
+}
+
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add column " + Quoted(column) + " " + typ
+}
+
+// SampleQuery Generate the query sampling about n values of the column without sorting the rows in random order, each of which
+// is kept in the probability of n divided by the number of the rows matching the condition where by the remainder of rand()
+func (t *Table) SampleQuery(column, where string, n int) string {
+	num := strconv.Itoa(n)
+	return "select " + column + " from " + t.Quoted() + " where rand() % (select greatest(intDiv(count(), " + num + "), 1) from " +
+		t.Quoted() + sampleWhere(where) + ") = 0" + sampleAnd(where) + " limit " + num
+}
+
+// AddField adds a new column to the table.
+func (t *Table) AddField(baseField *database.BaseField) {
+	f := NewField(baseField)
+	f.SetConfig(t.Config())
+	t.AppendField(f)
+}
+
+// FetchFieldsWithParam fetches the columns queried by param through DESCRIBE, as the driver does not know types such as Map.
+func (t *Table) FetchFieldsWithParam(ctx context.Context, db *database.DB, param database.Parameter) (err error) {
+	var query string
+	if query, err = param.Query(nil); err != nil {
+		return errors.Wrapf(err, "param.Query() fail")
+	}
+	var agrs []any
+	if agrs, err = param.Agrs(nil); err != nil {
+		return errors.Wrapf(err, "param.Agrs() fail")
+	}
+
+	var rows *sql.Rows
+	if rows, err = db.QueryContext(ctx, "describe ("+query+")", agrs...); err != nil {
+		return errors.Wrapf(err, "QueryContext(%v) fail", query)
+	}
+	defer rows.Close()
+
+	var names []string
+	if names, err = rows.Columns(); err != nil {
+		return errors.Wrapf(err, "rows.Columns() fail")
+	}
+	if len(names) < 2 {
+		return errors.Errorf("describe has %v columns, but not name and type", len(names))
+	}
+	for i := 0; rows.Next(); i++ {
+		var name, typ string
+		dest := []any{&name, &typ}
+		for range names[2:] {
+			dest = append(dest, new(any))
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return errors.Wrapf(err, "rows.Scan() fail")
+		}
+		t.AddField(database.NewBaseField(i, name, database.NewBaseFieldType(NewColumnType(name, typ))))
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrapf(err, "rows.Err() fail")
+	}
+
+	for _, v := range t.Fields() {
+		if !v.Type().IsSupported() {
+			return errors.Errorf("table: %v filed:%v type(%v) is not supportted", t.Quoted(), v.Name(), v.Type().DatabaseTypeName())
+		}
+	}
+	return nil
+}
+
+// ExecParam retrieves execution parameters, where the insert and batch modes are the columnar batch insert,
+// since the driver inserts only in batch.
+func (t *Table) ExecParam(mode string, txOpts *sql.TxOptions) (database.Parameter, bool) {
+	switch mode {
+	case database.WriteModeInsert, WriteModeBatch:
+		return NewBatchParam(t, txOpts), true
+	}
+	return nil, false
+}
+
+// ShouldRetry determines whether a retry is necessary.
+func (t *Table) ShouldRetry(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case net.Error:
+		return true
+	default:
+		return cause == driver.ErrBadConn || cause == io.EOF || cause == io.ErrUnexpectedEOF
+	}
+}
+
+// ShouldOneByOne specifies whether to retry one operation at a time.
+func (t *Table) ShouldOneByOne(err error) bool {
+	_, ok := errors.Cause(err).(*clickhouse.Exception)
+	return ok
+}
+
+// BatchParam represents the parameters for the columnar batch insert.
+type BatchParam struct {
+	*database.BaseParam
+}
+
+// NewBatchParam creates columnar batch insert parameters based on the table and transaction options (txOpts).
+func NewBatchParam(t database.Table, txOpts *sql.TxOptions) *BatchParam {
+	return &BatchParam{
+		BaseParam: database.NewBaseParam(t, txOpts),
+	}
+}
+
+// Query generates the insert statement of one row, each execution of which appends a record to the block.
+func (bp *BatchParam) Query(_ []element.Record) (query string, err error) {
+	buf := bytes.NewBufferString("insert into ")
+	buf.WriteString(bp.Table().Quoted())
+	buf.WriteString("(")
+	for fi, f := range bp.Table().Fields() {
+		if fi > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(f.Quoted())
+	}
+	buf.WriteString(") values(")
+	for fi, f := range bp.Table().Fields() {
+		if fi > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(f.BindVar(fi + 1))
+	}
+	buf.WriteString(")")
+	return buf.String(), nil
+}
+
+// Agrs generates the values of the records in sequence.
+func (bp *BatchParam) Agrs(records []element.Record) (valuers []any, err error) {
+	for _, r := range records {
+		for fi, f := range bp.Table().Fields() {
+			var c element.Column
+			if c, err = r.GetByIndex(fi); err != nil {
+				return nil, fmt.Errorf("GetByIndex(%v) err: %v", fi, err)
+			}
+			var v driver.Value
+			if v, err = f.Valuer(c).Value(); err != nil {
+				return nil, err
+			}
+
+			valuers = append(valuers, any(v))
+		}
+	}
+	return
+}
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
+
+func sampleAnd(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " and (" + where + ")"
+}
@@ -0,0 +1,465 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/ClickHouse/clickhouse-go"
+)
+
+type mockDescribeDriver struct{}
+
+func (d *mockDescribeDriver) Open(_ string) (driver.Conn, error) {
+	return &mockDescribeConn{}, nil
+}
+
+type mockDescribeConn struct{}
+
+func (c *mockDescribeConn) Prepare(query string) (driver.Stmt, error) {
+	return &mockDescribeStmt{query: query}, nil
+}
+
+func (c *mockDescribeConn) Close() error {
+	return nil
+}
+
+func (c *mockDescribeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("mock error")
+}
+
+type mockDescribeStmt struct {
+	query string
+}
+
+func (s *mockDescribeStmt) Close() error {
+	return nil
+}
+
+func (s *mockDescribeStmt) NumInput() int {
+	return -1
+}
+
+func (s *mockDescribeStmt) Exec(_ []driver.Value) (driver.Result, error) {
+	return nil, errors.New("mock error")
+}
+
+func (s *mockDescribeStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	if s.query != "describe (select id,attrs,amount from `db`.`table` where 1 = 2)" {
+		return nil, errors.New("mock error")
+	}
+	return &mockDescribeRows{
+		values: [][]driver.Value{
+			{"id", "UInt64", ""},
+			{"attrs", "Map(String, String)", ""},
+			{"amount", "Nullable(Decimal(38, 6))", ""},
+		},
+	}, nil
+}
+
+type mockDescribeRows struct {
+	values [][]driver.Value
+}
+
+func (r *mockDescribeRows) Columns() []string {
+	return []string{"name", "type", "default_type"}
+}
+
+func (r *mockDescribeRows) Close() error {
+	return nil
+}
+
+func (r *mockDescribeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+type mockSource struct {
+	*database.BaseSource
+}
+
+func (s *mockSource) DriverName() string {
+	return "mockClickhouse"
+}
+
+func (s *mockSource) ConnectName() string {
+	return "mock"
+}
+
+func (s *mockSource) Key() string {
+	return "mock"
+}
+
+func (s *mockSource) Table(b *database.BaseTable) database.Table {
+	return NewTable(b)
+}
+
+type mockParam struct {
+	*database.BaseParam
+
+	query string
+}
+
+func (m *mockParam) Query(_ []element.Record) (string, error) {
+	return m.query, nil
+}
+
+func (m *mockParam) Agrs(_ []element.Record) ([]any, error) {
+	return nil, nil
+}
+
+func init() {
+	sql.Register("mockClickhouse", &mockDescribeDriver{})
+}
+
+func TestTable_FetchFieldsWithParam(t *testing.T) {
+	db, err := database.NewDB(&mockSource{BaseSource: database.NewBaseSource(testJSONFromString(`{}`))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		name      string
+		query     string
+		wantNames []string
+		wantTypes []string
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			query:     "select id,attrs,amount from `db`.`table` where 1 = 2",
+			wantNames: []string{"id", "attrs", "amount"},
+			wantTypes: []string{"UInt64", "Map(String, String)", "Nullable(Decimal(38, 6))"},
+		},
+		{
+			name:    "2",
+			query:   "select id from `db`.`table` where 1 = 2",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("db", "", "table"))
+			got, err := db.FetchTableWithParam(context.TODO(), &mockParam{
+				BaseParam: database.NewBaseParam(table, nil),
+				query:     tt.query,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FetchTableWithParam() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var names, types []string
+			for _, f := range got.Fields() {
+				names = append(names, f.Name())
+				types = append(types, f.Type().DatabaseTypeName())
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("names = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("types = %v, want %v", types, tt.wantTypes)
+			}
+		})
+	}
+}
+
+func TestTable_Quoted(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   *Table
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "", "table")),
+			want: "`db`.`table`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.Quoted(); got != tt.want {
+				t.Errorf("Table.Quoted() = %v, want %v", got, tt.want)
+			}
+			if got := tt.tr.String(); got != tt.want {
+				t.Errorf("Table.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_AddColumnSQL(t *testing.T) {
+	type args struct {
+		column string
+		typ    string
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "", "table")),
+			args: args{
+				column: "id",
+				typ:    "bigint",
+			},
+			want: "alter table `db`.`table` add column `id` bigint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.AddColumnSQL(tt.args.column, tt.args.typ); got != tt.want {
+				t.Errorf("Table.AddColumnSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SampleQuery(t *testing.T) {
+	type args struct {
+		column string
+		where  string
+		n      int
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "", "table")),
+			args: args{
+				column: "`id`",
+				n:      100,
+			},
+			want: "select `id` from `db`.`table` where rand() % (select greatest(intDiv(count(), 100), 1) from `db`.`table`) = 0 limit 100",
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "", "table")),
+			args: args{
+				column: "`id`",
+				where:  "a = 1",
+				n:      100,
+			},
+			want: "select `id` from `db`.`table` where rand() % (select greatest(intDiv(count(), 100), 1) from `db`.`table` where a = 1) = 0" +
+				" and (a = 1) limit 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.SampleQuery(tt.args.column, tt.args.where, tt.args.n); got != tt.want {
+				t.Errorf("Table.SampleQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_ExecParam(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   string
+		wantOk bool
+	}{
+		{
+			name:   "1",
+			mode:   database.WriteModeInsert,
+			wantOk: true,
+		},
+		{
+			name:   "2",
+			mode:   WriteModeBatch,
+			wantOk: true,
+		},
+		{
+			name: "3",
+			mode: "replace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewTable(database.NewBaseTable("db", "", "table")).ExecParam(tt.mode, nil)
+			if ok != tt.wantOk {
+				t.Errorf("Table.ExecParam() ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if _, isBatch := got.(*BatchParam); isBatch != tt.wantOk {
+				t.Errorf("Table.ExecParam() = %T", got)
+			}
+		})
+	}
+}
+
+func TestBatchParam_Query(t *testing.T) {
+	table := NewTable(database.NewBaseTable("db", "", "table"))
+	table.AddField(database.NewBaseField(0, "id", NewFieldType(NewColumnType("id", "UInt64"))))
+	table.AddField(database.NewBaseField(1, "name", NewFieldType(NewColumnType("name", "LowCardinality(String)"))))
+	table.AddField(database.NewBaseField(2, "amount", NewFieldType(NewColumnType("amount", "Nullable(Decimal(18, 2))"))))
+
+	got, err := NewBatchParam(table, nil).Query(nil)
+	if err != nil {
+		t.Fatalf("BatchParam.Query() error = %v", err)
+	}
+	want := "insert into `db`.`table`(`id`,`name`,`amount`) values(?,?,?)"
+	if got != want {
+		t.Errorf("BatchParam.Query() = %v, want %v", got, want)
+	}
+}
+
+func TestBatchParam_Agrs(t *testing.T) {
+	table := NewTable(database.NewBaseTable("db", "", "table"))
+	table.AddField(database.NewBaseField(0, "id", NewFieldType(NewColumnType("id", "UInt64"))))
+	table.AddField(database.NewBaseField(1, "name", NewFieldType(NewColumnType("name", "LowCardinality(String)"))))
+	table.AddField(database.NewBaseField(2, "amount", NewFieldType(NewColumnType("amount", "Nullable(Decimal(18, 2))"))))
+
+	newRecord := func(id uint64, name string, amount element.ColumnValue) element.Record {
+		r := element.NewDefaultRecord()
+		r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromUint64(id), "id", 0))
+		r.Add(element.NewDefaultColumn(element.NewStringColumnValue(name), "name", 0))
+		r.Add(element.NewDefaultColumn(amount, "amount", 0))
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		records []element.Record
+		want    []any
+		wantErr bool
+	}{
+		{
+			name: "1",
+			records: []element.Record{
+				newRecord(18446744073709551615, "a", mustDecimalColumnValueFromString("12.345")),
+				newRecord(2, "b", element.NewNilDecimalColumnValue()),
+			},
+			want: []any{uint64(18446744073709551615), "a", int64(1235), uint64(2), "b", nil},
+		},
+		{
+			name: "2",
+			records: []element.Record{
+				newRecord(1, "a", element.NewStringColumnValue("abc")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			records: []element.Record{
+				element.NewDefaultRecord(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBatchParam(table, nil).Agrs(tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BatchParam.Agrs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BatchParam.Agrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "1",
+			err:  nil,
+		},
+		{
+			name: "2",
+			err:  &net.AddrError{},
+			want: true,
+		},
+		{
+			name: "3",
+			err:  driver.ErrBadConn,
+			want: true,
+		},
+		{
+			name: "4",
+			err:  io.EOF,
+			want: true,
+		},
+		{
+			name: "5",
+			err:  &clickhouse.Exception{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTable(database.NewBaseTable("db", "", "table")).ShouldRetry(tt.err); got != tt.want {
+				t.Errorf("Table.ShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_ShouldOneByOne(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "1",
+			err:  nil,
+		},
+		{
+			name: "2",
+			err:  driver.ErrBadConn,
+		},
+		{
+			name: "3",
+			err:  &clickhouse.Exception{},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewTable(database.NewBaseTable("db", "", "table")).ShouldOneByOne(tt.err); got != tt.want {
+				t.Errorf("Table.ShouldOneByOne() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,7 +17,9 @@ package database
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go/time2"
@@ -66,12 +68,13 @@ func (c *PoolConfig) GetMaxIdleConns() int {
 
 // ConfigSetter is an additional method for Table, used to set the JSON configuration file
 type ConfigSetter interface {
-	SetConfig(conf *config.JSON)
+	SetConfig(conf *config.JSON) error
 }
 
 // BaseConfig is the configuration for the base table
 type BaseConfig struct {
-	TrimChar bool `json:"trimChar"`
+	TrimChar bool   `json:"trimChar"`
+	Timezone string `json:"timezone"` // Time zone of the time types without time zone, unchanged if empty
 }
 
 // BaseConfigSetter is the setter for the base table configuration
@@ -79,14 +82,23 @@ type BaseConfigSetter struct {
 	BaseConfig
 
 	conf *config.JSON
+	loc  *time.Location
 }
 
 // SetConfig sets the table configuration
-func (b *BaseConfigSetter) SetConfig(conf *config.JSON) {
+// 'err' refers to an error where the timezone is not valid
+func (b *BaseConfigSetter) SetConfig(conf *config.JSON) (err error) {
 	b.conf = conf
 	if b.conf != nil {
 		json.Unmarshal([]byte(b.conf.String()), &b.BaseConfig)
 	}
+	b.loc = nil
+	if b.Timezone != "" {
+		if b.loc, err = time.LoadLocation(b.Timezone); err != nil {
+			return fmt.Errorf("timezone(%v) is not valid. error: %v", b.Timezone, err)
+		}
+	}
+	return
 }
 
 // Config retrieves the table configuration
@@ -109,3 +121,25 @@ func (b *BaseConfigSetter) TrimByteChar(char []byte) []byte {
 	}
 	return char
 }
+
+// Location retrieves the time zone of the time types without time zone, nil if not set
+func (b *BaseConfigSetter) Location() *time.Location {
+	return b.loc
+}
+
+// LocalTime interprets the wall clock of time t read from the time types without time zone in the configured time zone
+func (b *BaseConfigSetter) LocalTime(t time.Time) time.Time {
+	if b.loc == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), b.loc)
+}
+
+// WallTime converts time t to the wall clock in the configured time zone for the time types without time zone
+func (b *BaseConfigSetter) WallTime(t time.Time) time.Time {
+	if b.loc == nil {
+		return t
+	}
+	return t.In(b.loc)
+}
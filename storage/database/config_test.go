@@ -323,3 +323,86 @@ func TestBaseConfigSetter_TrimByteChar(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseConfigSetter_LocalTime(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		t       time.Time
+		want    time.Time
+		wantLoc *time.Location
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{}`),
+			t:    time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			want: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		},
+		{
+			name:    "2",
+			conf:    testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			t:       time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			want:    time.Date(2024, 1, 2, 3, 4, 5, 6, loc),
+			wantLoc: loc,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"timezone":"Mars/Olympus"}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BaseConfigSetter{}
+			if err := b.SetConfig(tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("BaseConfigSetter.SetConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := b.LocalTime(tt.t); !got.Equal(tt.want) {
+				t.Errorf("BaseConfigSetter.LocalTime() = %v, want %v", got, tt.want)
+			}
+			if got := b.Location(); got.String() != tt.wantLoc.String() {
+				t.Errorf("BaseConfigSetter.Location() = %v, want %v", got, tt.wantLoc)
+			}
+		})
+	}
+}
+
+func TestBaseConfigSetter_WallTime(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *config.JSON
+		t    time.Time
+		want string
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{}`),
+			t:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			want: "2024-01-02 03:04:05",
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			t:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			want: "2024-01-02 11:04:05",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BaseConfigSetter{}
+			b.SetConfig(tt.conf)
+			if got := b.WallTime(tt.t).Format("2006-01-02 15:04:05"); got != tt.want {
+				t.Errorf("BaseConfigSetter.WallTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -118,6 +118,12 @@ func (d *DB) FetchTableWithParam(ctx context.Context, param Parameter) (Table, e
 		}
 		return table, nil
 	}
+	if fetcher, ok := table.(ParamFieldsFetcher); ok {
+		if err := fetcher.FetchFieldsWithParam(ctx, d, param); err != nil {
+			return nil, err
+		}
+		return table, nil
+	}
 	query, agrs, err := getQueryAndAgrs(param, nil)
 	if err != nil {
 		return nil, err
@@ -23,9 +23,9 @@ import (
 )
 
 var (
-	dateLayout      = element.DefaultTimeFormat[:10]
-	timestampLayout = element.DefaultTimeFormat[:26]
-	timeLayout      = timestampLayout
+	dateLayout      = element.TimeLayout(element.TimeKindDate, 0)
+	timestampLayout = element.TimeLayout(element.TimeKindDateTime, element.MaxTimePrecision)
+	timeLayout      = element.TimeLayout(element.TimeKindTime, 0)
 )
 
 // Field represents a database field.
@@ -66,8 +66,14 @@ func (f *Field) Scanner() database.Scanner {
 	return NewScanner(f)
 }
 
-// Valuer adopts GoValuer for processing data.
+// Valuer adopts GoValuer for processing data, and WallTimeValuer for time types when the timezone is configured.
 func (f *Field) Valuer(c element.Column) database.Valuer {
+	switch f.FieldType().DatabaseTypeName() {
+	case "DATE", "TIME", "TIMESTAMP":
+		if loc := f.Location(); loc != nil {
+			return database.NewWallTimeValuer(f, c, loc, "")
+		}
+	}
 	return database.NewGoValuer(f, c)
 }
 
@@ -165,7 +171,7 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(dateLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(dateLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
@@ -174,7 +180,7 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(timeLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(timeLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
@@ -183,7 +189,7 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(timestampLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(timestampLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
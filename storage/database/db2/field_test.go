@@ -23,12 +23,18 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/storage/database/internal/testutil"
 )
 
 type mockFieldType struct {
 	name string
 }
 
+func testFieldWithConfig(f *Field, conf *config.JSON) *Field {
+	f.SetConfig(conf)
+	return f
+}
+
 func newMockFieldType(name string) *mockFieldType {
 	return &mockFieldType{
 		name: name,
@@ -201,6 +207,17 @@ func TestField_Valuer(t *testing.T) {
 			},
 			want: database.NewGoValuer(NewField(database.NewBaseField(0, "f1", newMockFieldType("BIGINT"))), element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(int64(123)), "f1", 0)),
 		},
+		{
+			name: "2",
+			f: testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("TIMESTAMP"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+			args: args{
+				c: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+			},
+			want: database.NewWallTimeValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("TIMESTAMP"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)), element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+				testutil.LoadLocation("Asia/Shanghai"), ""),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
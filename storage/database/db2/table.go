@@ -17,6 +17,7 @@ package db2
 import (
 	"database/sql"
 	"database/sql/driver"
+	"strconv"
 
 	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/ibmdb/go_ibm_db"
@@ -45,6 +46,24 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add " + Quoted(column) + " " + typ
+}
+
+// LimitClause Generate the clause following order by that limits the number of rows queried to n
+func (t *Table) LimitClause(n int) string {
+	return "offset 0 rows fetch next " + strconv.Itoa(n) + " rows only"
+}
+
+// SampleQuery Generate the query sampling about n values of the column without sorting the rows in random order, each of which
+// is kept in the probability of n divided by the number of the rows matching the condition where
+func (t *Table) SampleQuery(column, where string, n int) string {
+	num := strconv.Itoa(n)
+	return "select " + column + " from " + t.Quoted() + " where rand() * (select count(*) from " + t.Quoted() +
+		sampleWhere(where) + ") < " + num + sampleAnd(where) + " fetch first " + num + " rows only"
+}
+
 // AddField - Add a new column
 func (t *Table) AddField(baseField *database.BaseField) {
 	f := NewField(baseField)
@@ -67,3 +86,17 @@ func (t *Table) ShouldOneByOne(err error) bool {
 	_, ok := errors.Cause(err).(*go_ibm_db.Error)
 	return ok
 }
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
+
+func sampleAnd(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " and (" + where + ")"
+}
@@ -45,6 +45,101 @@ func TestTable_Quoted(t *testing.T) {
 	}
 }
 
+func TestTable_AddColumnSQL(t *testing.T) {
+	type args struct {
+		column string
+		typ    string
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("", "schema", "table")),
+			args: args{
+				column: "id",
+				typ:    "bigint",
+			},
+			want: `alter table "schema"."table" add "id" bigint`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.AddColumnSQL(tt.args.column, tt.args.typ); got != tt.want {
+				t.Errorf("Table.AddColumnSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SampleQuery(t *testing.T) {
+	type args struct {
+		column string
+		where  string
+		n      int
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("", "schema", "table")),
+			args: args{
+				column: `"id"`,
+				n:      100,
+			},
+			want: `select "id" from "schema"."table" where rand() * (select count(*) from "schema"."table") < 100 fetch first 100 rows only`,
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("", "schema", "table")),
+			args: args{
+				column: `"id"`,
+				where:  "a = 1",
+				n:      100,
+			},
+			want: `select "id" from "schema"."table" where rand() * (select count(*) from "schema"."table" where a = 1) < 100` +
+				` and (a = 1) fetch first 100 rows only`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.SampleQuery(tt.args.column, tt.args.where, tt.args.n); got != tt.want {
+				t.Errorf("Table.SampleQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_LimitClause(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   *Table
+		n    int
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("", "schema", "table")),
+			n:    1000,
+			want: "offset 0 rows fetch next 1000 rows only",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.LimitClause(tt.n); got != tt.want {
+				t.Errorf("Table.LimitClause() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTable_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -52,6 +52,19 @@ func (m *mockTableWithOther) ExecParam(mode string, txOpts *sql.TxOptions) (p Pa
 	return
 }
 
+type mockTableWithParamFetcher struct {
+	*mockTable
+	err error
+}
+
+func (m *mockTableWithParamFetcher) FetchFieldsWithParam(ctx context.Context, db *DB, param Parameter) error {
+	if m.err != nil {
+		return m.err
+	}
+	_, err := db.FetchTableWithParam(ctx, NewTableQueryParam(m.mockTable))
+	return err
+}
+
 type mockTableWithNoAdder struct {
 	*BaseTable
 }
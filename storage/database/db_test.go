@@ -319,6 +319,45 @@ func TestDB_FetchTableWithParam(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "5",
+			d:    testMustDB("mock", testJSONFromString("{}")),
+			args: args{
+				ctx: context.TODO(),
+				param: NewTableQueryParam(&mockTableWithParamFetcher{
+					mockTable: &mockTable{
+						BaseTable: NewBaseTable("db", "schema", "table"),
+					},
+				}),
+			},
+			want: &mockTable{
+				BaseTable: &BaseTable{
+					instance: "db",
+					schema:   "schema",
+					name:     "table",
+					fields: []Field{
+						newMockField(NewBaseField(0, "f1", newMockFieldType(GoTypeBool)), newMockFieldType(GoTypeBool)),
+						newMockField(NewBaseField(1, "f2", newMockFieldType(GoTypeInt64)), newMockFieldType(GoTypeInt64)),
+						newMockField(NewBaseField(2, "f3", newMockFieldType(GoTypeFloat64)), newMockFieldType(GoTypeFloat64)),
+						newMockField(NewBaseField(3, "f4", newMockFieldType(GoTypeString)), newMockFieldType(GoTypeString)),
+					},
+				},
+			},
+		},
+		{
+			name: "6",
+			d:    testMustDB("mock", testJSONFromString("{}")),
+			args: args{
+				ctx: context.TODO(),
+				param: NewTableQueryParam(&mockTableWithParamFetcher{
+					mockTable: &mockTable{
+						BaseTable: NewBaseTable("db", "schema", "table"),
+					},
+					err: errors.New("mock error"),
+				}),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
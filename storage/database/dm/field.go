@@ -30,8 +30,9 @@ type Field struct {
 }
 
 var (
-	dateLayout     = element.DefaultTimeFormat[:10]
-	datetimeLayout = element.DefaultTimeFormat[:26]
+	dateLayout     = element.TimeLayout(element.TimeKindDate, 0)
+	timeLayout     = element.TimeLayout(element.TimeKindTime, 6)
+	datetimeLayout = element.TimeLayout(element.TimeKindDateTime, 6)
 )
 
 // NewField generates a field based on basic column attributes.
@@ -66,8 +67,14 @@ func (f *Field) Scanner() database.Scanner {
 	return NewScanner(f)
 }
 
-// Valuer handles data processing using GoValuer.
+// Valuer handles data processing using GoValuer, and WallTimeValuer for time types when the timezone is configured.
 func (f *Field) Valuer(c element.Column) database.Valuer {
+	switch f.FieldType().DatabaseTypeName() {
+	case "DATE", "TIME", "DATETIME", "TIMESTAMP":
+		if loc := f.Location(); loc != nil {
+			return database.NewWallTimeValuer(f, c, loc, "")
+		}
+	}
 	return database.NewGoValuer(f, c)
 }
 
@@ -196,16 +203,25 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(dateLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(dateLayout))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case "TIME":
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(timeLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
-	case "TIME", "DATETIME", "TIMESTAMP":
+	case "DATETIME", "TIMESTAMP":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(datetimeLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(datetimeLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
@@ -23,6 +23,7 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/storage/database/internal/testutil"
 )
 
 type mockFieldType struct {
@@ -75,6 +76,11 @@ type mockColumnType struct {
 	name string
 }
 
+func testFieldWithConfig(f *Field, conf *config.JSON) *Field {
+	f.SetConfig(conf)
+	return f
+}
+
 func newMockColumnType(name string) *mockColumnType {
 	return &mockColumnType{
 		name: name,
@@ -237,6 +243,17 @@ func TestField_Valuer(t *testing.T) {
 			},
 			want: database.NewGoValuer(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType("1")))), element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "f1", 0)),
 		},
+		{
+			name: "2",
+			f: testFieldWithConfig(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType("DATETIME")))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+			args: args{
+				c: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+			},
+			want: database.NewWallTimeValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType("DATETIME")))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)), element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+				testutil.LoadLocation("Asia/Shanghai"), ""),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -821,6 +838,42 @@ func TestScanner_Scan(t *testing.T) {
 				element.ByteSize(time.Date(2023, 1, 1, 12, 30, 45, 123456789, time.UTC)),
 			),
 		},
+		{
+			name: "TIME-time",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType("TIME"))))),
+			args: args{
+				src: time.Date(1, 1, 1, 12, 30, 45, 123456000, time.UTC),
+			},
+			want: element.NewDefaultColumn(
+				element.NewTimeColumnValueWithDecoder(
+					time.Date(1, 1, 1, 12, 30, 45, 123456000, time.UTC),
+					element.NewStringTimeDecoder(timeLayout),
+				),
+				"f1",
+				element.ByteSize(time.Date(1, 1, 1, 12, 30, 45, 123456000, time.UTC)),
+			),
+		},
+		{
+			name: "TIMESTAMP-timezone",
+			s: func() *Scanner {
+				scanner := NewScanner(NewField(database.NewBaseField(0,
+					"f1", NewFieldType(newMockColumnType("TIMESTAMP")))))
+				scanner.f.SetConfig(testJSONFromString(`{"timezone":"Asia/Shanghai"}`))
+				return scanner
+			}(),
+			args: args{
+				src: time.Date(2023, 1, 1, 12, 30, 45, 123456789, time.UTC),
+			},
+			want: element.NewDefaultColumn(
+				element.NewTimeColumnValueWithDecoder(
+					time.Date(2023, 1, 1, 12, 30, 45, 123456789, testutil.LoadLocation("Asia/Shanghai")),
+					element.NewStringTimeDecoder(datetimeLayout),
+				),
+				"f1",
+				element.ByteSize(time.Date(2023, 1, 1, 12, 30, 45, 123456789, time.UTC)),
+			),
+		},
 		{
 			name: "DATETIME-invalid",
 			s: NewScanner(NewField(database.NewBaseField(0,
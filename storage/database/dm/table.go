@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"net"
+	"strconv"
 
 	"gitee.com/chunanyong/dm"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -46,6 +47,19 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add " + Quoted(column) + " " + typ
+}
+
+// SampleQuery Generate the query sampling about n values of the column without sorting the rows in random order, each of which
+// is kept in the probability of n divided by the number of the rows matching the condition where
+func (t *Table) SampleQuery(column, where string, n int) string {
+	num := strconv.Itoa(n)
+	return "select " + column + " from " + t.Quoted() + " where rand() * (select count(*) from " + t.Quoted() +
+		sampleWhere(where) + ") < " + num + sampleAnd(where) + " limit " + num
+}
+
 // AddField adds a new column to the table.
 func (t *Table) AddField(baseField *database.BaseField) {
 	f := NewField(baseField)
@@ -73,3 +87,17 @@ func (t *Table) ShouldOneByOne(err error) bool {
 	_, ok := errors.Cause(err).(*dm.DmError)
 	return ok
 }
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
+
+func sampleAnd(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " and (" + where + ")"
+}
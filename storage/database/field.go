@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/Breeze0806/go-etl/element"
 )
@@ -230,3 +231,42 @@ func (g *GoValuer) Value() (val driver.Value, err error) {
 	}
 	return nil, fmt.Errorf("%v type(%v)", typ.GoType(), g.f.Type().DatabaseTypeName())
 }
+
+// WallTimeValuer: Generates the wall clock of the incoming column value 'c' in the time zone 'loc' for the time types without time zone.
+// If the layout is not empty, the wall clock is formatted into a string by the layout, which suits the drivers that convert time by themselves.
+type WallTimeValuer struct {
+	f      Field
+	c      element.Column
+	loc    *time.Location
+	layout string
+}
+
+// NewWallTimeValuer: Generates a new Valuer of the wall clock in the time zone 'loc' by the field 'f', the incoming column value 'c' and the layout.
+func NewWallTimeValuer(f Field, c element.Column, loc *time.Location, layout string) *WallTimeValuer {
+	return &WallTimeValuer{
+		f:      f,
+		c:      c,
+		loc:    loc,
+		layout: layout,
+	}
+}
+
+// Value: Generates the wall clock in the time zone as the driver-accepted value.
+func (w *WallTimeValuer) Value() (val driver.Value, err error) {
+	defer w.f.SetError(&err)
+	if w.c.IsNil() {
+		return nil, nil
+	}
+
+	var t time.Time
+	if t, err = w.c.AsTime(); err != nil {
+		return nil, err
+	}
+	if w.loc != nil {
+		t = t.In(w.loc)
+	}
+	if w.layout == "" {
+		return t, nil
+	}
+	return t.Format(w.layout), nil
+}
@@ -348,3 +348,59 @@ func TestBaseField_SetError(t *testing.T) {
 		})
 	}
 }
+
+func TestWallTimeValuer_Value(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newMockField(NewBaseField(1, "f1", NewBaseFieldType(&sql.ColumnType{})), newMockFieldType(GoTypeTime))
+	tests := []struct {
+		name    string
+		w       *WallTimeValuer
+		want    driver.Value
+		wantErr bool
+	}{
+		{
+			name: "1",
+			w: NewWallTimeValuer(f, element.NewDefaultColumn(element.NewTimeColumnValue(
+				time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), "test", 0), loc, ""),
+			want: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).In(loc),
+		},
+		{
+			name: "2",
+			w: NewWallTimeValuer(f, element.NewDefaultColumn(element.NewTimeColumnValue(
+				time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), "test", 0), loc, "2006-01-02 15:04:05"),
+			want: "2024-01-02 11:04:05",
+		},
+		{
+			name: "3",
+			w: NewWallTimeValuer(f, element.NewDefaultColumn(element.NewTimeColumnValue(
+				time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), "test", 0), nil, "2006-01-02 15:04:05"),
+			want: "2024-01-02 03:04:05",
+		},
+		{
+			name: "4",
+			w:    NewWallTimeValuer(f, element.NewDefaultColumn(element.NewNilTimeColumnValue(), "test", 0), loc, ""),
+			want: nil,
+		},
+		{
+			name: "5",
+			w: NewWallTimeValuer(f, element.NewDefaultColumn(element.NewBoolColumnValue(true),
+				"test", 0), loc, ""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.w.Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WallTimeValuer.Value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WallTimeValuer.Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,7 +15,9 @@
 package mysql
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/Breeze0806/go-etl/element"
@@ -27,6 +29,12 @@ var (
 	datetimeLayout = element.DefaultTimeFormat[:26]
 )
 
+// Formats of GEOMETRY
+const (
+	GeometryFormatWKB = "wkb" // Well-known binary
+	GeometryFormatWKT = "wkt" // Well-known text
+)
+
 // Field Field
 type Field struct {
 	*database.BaseField
@@ -45,13 +53,30 @@ func (f *Field) Quoted() string {
 	return Quoted(f.Name())
 }
 
-// BindVar SQL placeholder, used in SQL statements
+// BindVar SQL placeholder, used in SQL statements, GEOMETRY is converted from WKB or WKT,
+// neither of which carries the SRID, so the SRID configured by geometrySrid is set if it is not 0
 func (f *Field) BindVar(_ int) string {
+	if f.FieldType().DatabaseTypeName() == "GEOMETRY" {
+		fn := "ST_GeomFromWKB"
+		if f.geometryFormat() == GeometryFormatWKT {
+			fn = "ST_GeomFromText"
+		}
+		if srid := f.geometrySrid(); srid != 0 {
+			return fn + "(?, " + strconv.FormatInt(srid, 10) + ")"
+		}
+		return fn + "(?)"
+	}
 	return "?"
 }
 
-// Select Field for querying, used in SQL query statements
+// Select Field for querying, used in SQL query statements, GEOMETRY is queried as WKB or WKT, which drops the SRID
 func (f *Field) Select() string {
+	if f.FieldType().DatabaseTypeName() == "GEOMETRY" {
+		if f.geometryFormat() == GeometryFormatWKT {
+			return "ST_AsText(" + Quoted(f.Name()) + ")"
+		}
+		return "ST_AsBinary(" + Quoted(f.Name()) + ")"
+	}
 	return Quoted(f.Name())
 }
 
@@ -65,11 +90,41 @@ func (f *Field) Scanner() database.Scanner {
 	return NewScanner(f)
 }
 
-// Valuer Valuer, using GoValuer to process data
+// Valuer Valuer, using GoValuer to process data, except that GEOMETRY uses GeometryValuer,
+// and the time types use WallTimeValuer when the timezone is configured
 func (f *Field) Valuer(c element.Column) database.Valuer {
+	switch f.FieldType().DatabaseTypeName() {
+	case "GEOMETRY":
+		return NewGeometryValuer(f, c)
+	case "DATE":
+		// The driver converts time into the loc of the DSN, so the wall clock is passed as a string
+		if loc := f.Location(); loc != nil {
+			return database.NewWallTimeValuer(f, c, loc, dateLayout)
+		}
+	case "DATETIME", "TIMESTAMP":
+		if loc := f.Location(); loc != nil {
+			return database.NewWallTimeValuer(f, c, loc, datetimeLayout)
+		}
+	}
 	return database.NewGoValuer(f, c)
 }
 
+// geometryFormat Format of GEOMETRY configured by geometryFormat, wkb by default
+func (f *Field) geometryFormat() string {
+	if f.Config() == nil {
+		return GeometryFormatWKB
+	}
+	return f.Config().GetStringOrDefaullt("geometryFormat", GeometryFormatWKB)
+}
+
+// geometrySrid SRID of GEOMETRY configured by geometrySrid, 0 by default
+func (f *Field) geometrySrid() int64 {
+	if f.Config() == nil {
+		return 0
+	}
+	return f.Config().GetInt64OrDefaullt("geometrySrid", 0)
+}
+
 // FieldType Field type
 type FieldType struct {
 	*database.BaseFieldType
@@ -86,12 +141,14 @@ func NewFieldType(typ database.ColumnType) *FieldType {
 	// Due to the existence of non-negative integers, directly converting them to the corresponding int type would result in conversion errors.
 	// TIME has negative values and cannot be converted normally, while YEAR is TINYINT.
 	case "MEDIUMINT", "INT", "BIGINT", "SMALLINT", "TINYINT",
-		"UNSIGNED INT", "UNSIGNED BIGINT", "UNSIGNED SMALLINT", "UNSIGNED TINYINT",
+		"UNSIGNED MEDIUMINT", "UNSIGNED INT", "UNSIGNED BIGINT", "UNSIGNED SMALLINT", "UNSIGNED TINYINT",
 		"TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR",
 		"TIME", "YEAR",
-		"DECIMAL":
+		"DECIMAL",
+		"JSON", "ENUM", "SET":
 		f.goType = database.GoTypeString
-	case "BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY", "BIT":
+	case "BLOB", "LONGBLOB", "MEDIUMBLOB", "BINARY", "TINYBLOB", "VARBINARY", "BIT",
+		"GEOMETRY":
 		f.goType = database.GoTypeBytes
 	case "DOUBLE", "FLOAT":
 		f.goType = database.GoTypeFloat64
@@ -125,11 +182,13 @@ func NewScanner(f *Field) *Scanner {
 }
 
 // Scan Read data based on the column type
-// MEDIUMINT, INT, BIGINT, SMALLINT, TINYINT, YEAR, UNSIGNED INT, UNSIGNED BIGINT, UNSIGNED SMALLINT, UNSIGNED TINYINT are treated as integers.
+// MEDIUMINT, INT, BIGINT, SMALLINT, TINYINT, YEAR, UNSIGNED MEDIUMINT, UNSIGNED INT, UNSIGNED BIGINT, UNSIGNED SMALLINT, UNSIGNED TINYINT are treated as integers.
 // DOUBLE, FLOAT, DECIMAL are treated as high-precision real numbers.
 // DATE, DATETIME, TIMESTAMP are treated as time.
-// TEXT, LONGTEXT, MEDIUMTEXT, TINYTEXT, CHAR, VARCHAR, TIME are treated as strings.
+// TEXT, LONGTEXT, MEDIUMTEXT, TINYTEXT, CHAR, VARCHAR, TIME, ENUM, SET are treated as strings.
 // BLOB, LONGBLOB, MEDIUMBLOB, BINARY, TINYBLOB, VARBINARY are treated as byte streams.
+// JSON is treated as JSON.
+// GEOMETRY is treated as byte streams of WKB, or strings of WKT.
 func (s *Scanner) Scan(src any) (err error) {
 	defer s.f.SetError(&err)
 	var cv element.ColumnValue
@@ -137,7 +196,7 @@ func (s *Scanner) Scan(src any) (err error) {
 
 	switch s.f.Type().DatabaseTypeName() {
 	case "MEDIUMINT", "INT", "BIGINT", "SMALLINT", "TINYINT", "YEAR",
-		"UNSIGNED INT", "UNSIGNED BIGINT", "UNSIGNED SMALLINT", "UNSIGNED TINYINT":
+		"UNSIGNED MEDIUMINT", "UNSIGNED INT", "UNSIGNED BIGINT", "UNSIGNED SMALLINT", "UNSIGNED TINYINT":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilBigIntColumnValue()
@@ -145,6 +204,11 @@ func (s *Scanner) Scan(src any) (err error) {
 			cv = element.NewBigIntColumnValueFromUint64(data)
 		case int64:
 			cv = element.NewBigIntColumnValueFromInt64(data)
+		case []byte:
+			// values of prepared statements and older servers are not parsed by the driver
+			if cv, err = element.NewBigIntColumnValueFromString(string(data)); err != nil {
+				return
+			}
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeBigInt)
 		}
@@ -162,7 +226,7 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(dateLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(dateLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
@@ -171,11 +235,12 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(datetimeLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(datetimeLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
-	case "TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME":
+	case "TEXT", "LONGTEXT", "MEDIUMTEXT", "TINYTEXT", "CHAR", "VARCHAR", "TIME",
+		"ENUM", "SET":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilStringColumnValue()
@@ -203,9 +268,64 @@ func (s *Scanner) Scan(src any) (err error) {
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeDecimal)
 		}
+	case "JSON":
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilJsonColumnValue()
+		case []byte:
+			if cv, err = element.NewJsonColumnValueFromBytes(data); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeJSON)
+		}
+	case "GEOMETRY":
+		wkt := s.f.geometryFormat() == GeometryFormatWKT
+		switch data := src.(type) {
+		case nil:
+			if wkt {
+				cv = element.NewNilStringColumnValue()
+			} else {
+				cv = element.NewNilBytesColumnValue()
+			}
+		case []byte:
+			if wkt {
+				cv = element.NewStringColumnValue(string(data))
+			} else {
+				cv = element.NewBytesColumnValue(data)
+			}
+		default:
+			return fmt.Errorf("src is %v(%T),but not %v", src, src, element.TypeBytes)
+		}
 	default:
 		return fmt.Errorf("src is %v(%T), but db type is %v", src, src, s.f.Type().DatabaseTypeName())
 	}
 	s.SetColumn(element.NewDefaultColumn(cv, s.f.Name(), byteSize))
 	return
 }
+
+// GeometryValuer Valuer of GEOMETRY, which converts the column to WKB or WKT by the format of GEOMETRY
+type GeometryValuer struct {
+	f *Field
+	c element.Column
+}
+
+// NewGeometryValuer Generate a Valuer of GEOMETRY based on the field f and the column c
+func NewGeometryValuer(f *Field, c element.Column) *GeometryValuer {
+	return &GeometryValuer{
+		f: f,
+		c: c,
+	}
+}
+
+// Value Convert the column to WKB bytes or WKT string
+func (g *GeometryValuer) Value() (v driver.Value, err error) {
+	defer g.f.SetError(&err)
+	if g.c.IsNil() {
+		return nil, nil
+	}
+	if g.f.geometryFormat() == GeometryFormatWKT {
+		return g.c.AsString()
+	}
+	return g.c.AsBytes()
+}
@@ -16,6 +16,7 @@ package mysql
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"math"
 	"reflect"
 	"testing"
@@ -24,12 +25,18 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/storage/database/internal/testutil"
 )
 
 type mockFieldType struct {
 	name string
 }
 
+func testFieldWithConfig(f *Field, conf *config.JSON) *Field {
+	f.SetConfig(conf)
+	return f
+}
+
 func newMockFieldType(name string) *mockFieldType {
 	return &mockFieldType{
 		name: name,
@@ -72,6 +79,14 @@ func mustDecimalColumnValueFromString(s string) element.ColumnValue {
 	return c
 }
 
+func mustJSONColumnValueFromString(s string) element.ColumnValue {
+	c, err := element.NewJsonColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
 func mustBigIntValueFromString(s string) element.ColumnValue {
 	c, err := element.NewBigIntColumnValueFromString(s)
 	if err != nil {
@@ -80,6 +95,14 @@ func mustBigIntValueFromString(s string) element.ColumnValue {
 	return c
 }
 
+func mustDecimalValueFromString(s string) element.ColumnValue {
+	c, err := element.NewDecimalColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
 func TestField_Quoted(t *testing.T) {
 	tests := []struct {
 		name string
@@ -108,6 +131,7 @@ func TestField_BindVar(t *testing.T) {
 	tests := []struct {
 		name string
 		f    *Field
+		conf *config.JSON
 		args args
 		want string
 	}{
@@ -127,9 +151,47 @@ func TestField_BindVar(t *testing.T) {
 			},
 			want: "?",
 		},
+		{
+			name: "2",
+			f:    NewField(database.NewBaseField(0, "table", newMockFieldType("GEOMETRY"))),
+			args: args{
+				i: 1,
+			},
+			want: "ST_GeomFromWKB(?)",
+		},
+		{
+			name: "3",
+			f:    NewField(database.NewBaseField(0, "table", newMockFieldType("GEOMETRY"))),
+			conf: testJSONFromString(`{"geometryFormat":"wkt"}`),
+			args: args{
+				i: 1,
+			},
+			want: "ST_GeomFromText(?)",
+		},
+		{
+			name: "4",
+			f:    NewField(database.NewBaseField(0, "table", newMockFieldType("GEOMETRY"))),
+			conf: testJSONFromString(`{"geometrySrid":4326}`),
+			args: args{
+				i: 1,
+			},
+			want: "ST_GeomFromWKB(?, 4326)",
+		},
+		{
+			name: "5",
+			f:    NewField(database.NewBaseField(0, "table", newMockFieldType("GEOMETRY"))),
+			conf: testJSONFromString(`{"geometryFormat":"wkt","geometrySrid":4326}`),
+			args: args{
+				i: 1,
+			},
+			want: "ST_GeomFromText(?, 4326)",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.conf != nil {
+				tt.f.SetConfig(tt.conf)
+			}
 			if got := tt.f.BindVar(tt.args.i); got != tt.want {
 				t.Errorf("Field.BindVar() = %v, want %v", got, tt.want)
 			}
@@ -141,6 +203,7 @@ func TestField_Select(t *testing.T) {
 	tests := []struct {
 		name string
 		f    *Field
+		conf *config.JSON
 		want string
 	}{
 		{
@@ -148,9 +211,23 @@ func TestField_Select(t *testing.T) {
 			f:    NewField(database.NewBaseField(0, "table", database.NewBaseFieldType(&sql.ColumnType{}))),
 			want: "`table`",
 		},
+		{
+			name: "2",
+			f:    NewField(database.NewBaseField(0, "table", newMockFieldType("GEOMETRY"))),
+			want: "ST_AsBinary(`table`)",
+		},
+		{
+			name: "3",
+			f:    NewField(database.NewBaseField(0, "table", newMockFieldType("GEOMETRY"))),
+			conf: testJSONFromString(`{"geometryFormat":"wkt"}`),
+			want: "ST_AsText(`table`)",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.conf != nil {
+				tt.f.SetConfig(tt.conf)
+			}
 			if got := tt.f.Select(); got != tt.want {
 				t.Errorf("Field.Select() = %v, want %v", got, tt.want)
 			}
@@ -218,6 +295,44 @@ func TestField_Valuer(t *testing.T) {
 			},
 			want: database.NewGoValuer(NewField(database.NewBaseField(0, "f1", NewFieldType(&sql.ColumnType{}))), element.NewDefaultColumn(nil, "", 0)),
 		},
+		{
+			name: "2",
+			f:    NewField(database.NewBaseField(0, "f1", newMockFieldType("GEOMETRY"))),
+			args: args{
+				c: element.NewDefaultColumn(nil, "", 0),
+			},
+			want: NewGeometryValuer(NewField(database.NewBaseField(0, "f1", newMockFieldType("GEOMETRY"))), element.NewDefaultColumn(nil, "", 0)),
+		},
+		{
+			name: "3",
+			f: testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("DATE"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+			args: args{
+				c: element.NewDefaultColumn(nil, "", 0),
+			},
+			want: database.NewWallTimeValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("DATE"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)), element.NewDefaultColumn(nil, "", 0),
+				testutil.LoadLocation("Asia/Shanghai"), dateLayout),
+		},
+		{
+			name: "4",
+			f: testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("DATETIME"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+			args: args{
+				c: element.NewDefaultColumn(nil, "", 0),
+			},
+			want: database.NewWallTimeValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("DATETIME"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)), element.NewDefaultColumn(nil, "", 0),
+				testutil.LoadLocation("Asia/Shanghai"), datetimeLayout),
+		},
+		{
+			name: "5",
+			f:    NewField(database.NewBaseField(0, "f1", newMockFieldType("DATETIME"))),
+			args: args{
+				c: element.NewDefaultColumn(nil, "", 0),
+			},
+			want: database.NewGoValuer(NewField(database.NewBaseField(0, "f1", newMockFieldType("DATETIME"))), element.NewDefaultColumn(nil, "", 0)),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -228,6 +343,59 @@ func TestField_Valuer(t *testing.T) {
 	}
 }
 
+func TestField_ValuerValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       *Field
+		c       element.Column
+		want    driver.Value
+		wantErr bool
+	}{
+		{
+			name: "1",
+			f:    NewField(database.NewBaseField(0, "f1", newMockFieldType("UNSIGNED BIGINT"))),
+			c:    element.NewDefaultColumn(element.NewBigIntColumnValueFromUint64(math.MaxUint64), "f1", 0),
+			want: "18446744073709551615",
+		},
+		{
+			name: "2",
+			f:    NewField(database.NewBaseField(0, "f1", newMockFieldType("UNSIGNED BIGINT"))),
+			c:    element.NewDefaultColumn(mustBigIntValueFromString("9223372036854775808"), "f1", 0),
+			want: "9223372036854775808",
+		},
+		{
+			name: "3",
+			f:    NewField(database.NewBaseField(0, "f1", newMockFieldType("DECIMAL"))),
+			c:    element.NewDefaultColumn(element.NewBigIntColumnValueFromUint64(math.MaxUint64), "f1", 0),
+			want: "18446744073709551615",
+		},
+		{
+			name: "4",
+			f:    NewField(database.NewBaseField(0, "f1", newMockFieldType("UNSIGNED BIGINT"))),
+			c:    element.NewDefaultColumn(mustDecimalValueFromString("18446744073709551615"), "f1", 0),
+			want: "18446744073709551615",
+		},
+		{
+			name: "5",
+			f:    NewField(database.NewBaseField(0, "f1", newMockFieldType("UNSIGNED BIGINT"))),
+			c:    element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "f1", 0),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.Valuer(tt.c).Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Valuer.Value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Valuer.Value() = %v(%T), want %v(%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
 func TestFieldType_GoType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -371,6 +539,31 @@ func TestFieldType_GoType(t *testing.T) {
 			f:    NewFieldType(newMockFieldType("NEWDATE")),
 			want: database.GoTypeUnknown,
 		},
+		{
+			name: "UNSIGNED MEDIUMINT",
+			f:    NewFieldType(newMockFieldType("UNSIGNED MEDIUMINT")),
+			want: database.GoTypeString,
+		},
+		{
+			name: "JSON",
+			f:    NewFieldType(newMockFieldType("JSON")),
+			want: database.GoTypeString,
+		},
+		{
+			name: "ENUM",
+			f:    NewFieldType(newMockFieldType("ENUM")),
+			want: database.GoTypeString,
+		},
+		{
+			name: "SET",
+			f:    NewFieldType(newMockFieldType("SET")),
+			want: database.GoTypeString,
+		},
+		{
+			name: "GEOMETRY",
+			f:    NewFieldType(newMockFieldType("GEOMETRY")),
+			want: database.GoTypeBytes,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -512,6 +705,16 @@ func TestScanner_Scan(t *testing.T) {
 			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(time.Date(2021, 1, 13, 18, 43, 12, 0, time.Local), element.NewStringTimeDecoder(datetimeLayout)),
 				"test", element.ByteSize(time.Date(2021, 1, 13, 18, 43, 12, 0, time.Local))),
 		},
+		{
+			name: "DATETIMETimezone",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("DATETIME")))),
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			args: args{
+				src: time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(time.Date(2021, 1, 13, 18, 43, 12, 0, testutil.LoadLocation("Asia/Shanghai")), element.NewStringTimeDecoder(datetimeLayout)),
+				"test", element.ByteSize(time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC))),
+		},
 		{
 			name: "TIMESTAMPErr",
 			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("TIMESTAMP")))),
@@ -634,6 +837,116 @@ func TestScanner_Scan(t *testing.T) {
 			},
 			wantErr: true,
 		},
+
+		{
+			name: "UNSIGNED BIGINT",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("UNSIGNED BIGINT")))),
+			args: args{
+				src: []byte("18446744073709551615"),
+			},
+			want: element.NewDefaultColumn(mustBigIntValueFromString("18446744073709551615"), "test", element.ByteSize([]byte("18446744073709551615"))),
+		},
+		{
+			name: "UNSIGNED MEDIUMINT",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("UNSIGNED MEDIUMINT")))),
+			args: args{
+				src: int64(16777215),
+			},
+			want: element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(16777215), "test", element.ByteSize(int64(16777215))),
+		},
+		{
+			name: "UNSIGNED INT",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("UNSIGNED INT")))),
+			args: args{
+				src: []byte("a"),
+			},
+			wantErr: true,
+		},
+
+		{
+			name: "JSON",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("JSON")))),
+			args: args{
+				src: []byte(`{"a":[1,2]}`),
+			},
+			want: element.NewDefaultColumn(mustJSONColumnValueFromString(`{"a":[1,2]}`), "test", element.ByteSize([]byte(`{"a":[1,2]}`))),
+		},
+		{
+			name: "JSONNil",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("JSON")))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilJsonColumnValue(), "test", 0),
+		},
+		{
+			name: "JSONErr",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("JSON")))),
+			args: args{
+				src: "{}",
+			},
+			wantErr: true,
+		},
+
+		{
+			name: "ENUM",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("ENUM")))),
+			args: args{
+				src: []byte("small"),
+			},
+			want: element.NewDefaultColumn(element.NewStringColumnValue("small"), "test", element.ByteSize([]byte("small"))),
+		},
+		{
+			name: "SET",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("SET")))),
+			args: args{
+				src: []byte("a,b"),
+			},
+			want: element.NewDefaultColumn(element.NewStringColumnValue("a,b"), "test", element.ByteSize([]byte("a,b"))),
+		},
+
+		{
+			name: "GEOMETRY",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("GEOMETRY")))),
+			args: args{
+				src: []byte{0x01, 0x01, 0x00, 0x00, 0x00},
+			},
+			want: element.NewDefaultColumn(element.NewBytesColumnValue([]byte{0x01, 0x01, 0x00, 0x00, 0x00}), "test", 5),
+		},
+		{
+			name: "GEOMETRYNil",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("GEOMETRY")))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilBytesColumnValue(), "test", 0),
+		},
+		{
+			name: "GEOMETRYWKT",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("GEOMETRY")))),
+			conf: testJSONFromString(`{"geometryFormat":"wkt"}`),
+			args: args{
+				src: []byte("POINT(1 2)"),
+			},
+			want: element.NewDefaultColumn(element.NewStringColumnValue("POINT(1 2)"), "test", element.ByteSize([]byte("POINT(1 2)"))),
+		},
+		{
+			name: "GEOMETRYWKTNil",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("GEOMETRY")))),
+			conf: testJSONFromString(`{"geometryFormat":"wkt"}`),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilStringColumnValue(), "test", 0),
+		},
+		{
+			name: "GEOMETRYErr",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("GEOMETRY")))),
+			args: args{
+				src: "POINT(1 2)",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -824,3 +1137,46 @@ func TestFieldType_IsSupportted(t *testing.T) {
 		})
 	}
 }
+
+func TestGeometryValuer_Value(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		c       element.Column
+		want    driver.Value
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    element.NewDefaultColumn(element.NewBytesColumnValue([]byte{0x01, 0x01}), "f1", 0),
+			want: []byte{0x01, 0x01},
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{"geometryFormat":"wkt"}`),
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("POINT(1 2)"), "f1", 0),
+			want: "POINT(1 2)",
+		},
+		{
+			name: "3",
+			c:    element.NewDefaultColumn(element.NewNilBytesColumnValue(), "f1", 0),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewField(database.NewBaseField(0, "f1", newMockFieldType("GEOMETRY")))
+			if tt.conf != nil {
+				f.SetConfig(tt.conf)
+			}
+			got, err := NewGeometryValuer(f, tt.c).Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GeometryValuer.Value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GeometryValuer.Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,7 +19,10 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"net"
+	"strconv"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -52,6 +55,57 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add " + Quoted(column) + " " + typ
+}
+
+// TypeDefinition Get the type definition of the column type reported by go-sql-driver/mysql, which does not report
+// the length of character and binary types unless taken from the catalog by database.CatalogFieldType, reports the fractional seconds precision of time types as the precision
+// and scale, and reports unsigned integer types like UNSIGNED INT
+func (t *Table) TypeDefinition(typ database.ColumnType) (string, error) {
+	name := typ.DatabaseTypeName()
+	if strings.HasPrefix(name, "UNSIGNED ") {
+		return strings.TrimPrefix(name, "UNSIGNED ") + " UNSIGNED", nil
+	}
+	switch name {
+	case "DECIMAL":
+		if precision, scale, ok := typ.DecimalSize(); ok && precision > 0 {
+			return fmt.Sprintf("%v(%v,%v)", name, precision, scale), nil
+		}
+	case "DATETIME", "TIMESTAMP", "TIME":
+		if fsp, _, ok := typ.DecimalSize(); ok && fsp > 0 && fsp <= 6 {
+			return fmt.Sprintf("%v(%v)", name, fsp), nil
+		}
+	case "CHAR", "VARCHAR", "BINARY", "VARBINARY", "BIT":
+		if length, ok := typ.Length(); ok && length > 0 && length != math.MaxInt64 {
+			return fmt.Sprintf("%v(%v)", name, length), nil
+		}
+		return "", errors.Errorf("length of %v is unknown", name)
+	case "ENUM", "SET":
+		return "", errors.Errorf("values of %v are unknown", name)
+	case "", "NULL":
+		return "", errors.Errorf("type %v is unknown", name)
+	}
+	return name, nil
+}
+
+// CatalogQuery Generate the query of the columns of the table in information_schema.columns
+func (t *Table) CatalogQuery() (string, []any) {
+	return "select column_name, is_nullable, column_default, character_maximum_length," +
+		" case when data_type = 'decimal' then numeric_precision end," +
+		" case when data_type = 'decimal' then numeric_scale end" +
+		" from information_schema.columns where table_schema = ? and table_name = ?", []any{t.Instance(), t.Name()}
+}
+
+// SampleQuery Generate the query sampling about n values of the column without sorting the rows in random order, each of which
+// is kept in the probability of n divided by the number of the rows matching the condition where
+func (t *Table) SampleQuery(column, where string, n int) string {
+	num := strconv.Itoa(n)
+	return "select " + column + " from " + t.Quoted() + " where rand() * (select count(*) from " + t.Quoted() +
+		sampleWhere(where) + ") < " + num + sampleAnd(where) + " limit " + num
+}
+
 // AddField adds a new column to the table.
 func (t *Table) AddField(baseField *database.BaseField) {
 	f := NewField(baseField)
@@ -144,3 +198,17 @@ func (rp *ReplaceParam) Agrs(records []element.Record) (valuers []any, err error
 	}
 	return
 }
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
+
+func sampleAnd(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " and (" + where + ")"
+}
@@ -17,6 +17,7 @@ package mysql
 import (
 	"database/sql"
 	"database/sql/driver"
+	"math"
 	"net"
 	"reflect"
 	"testing"
@@ -47,6 +48,190 @@ func TestTable_Quoted(t *testing.T) {
 	}
 }
 
+func TestTable_AddColumnSQL(t *testing.T) {
+	type args struct {
+		column string
+		typ    string
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "id",
+				typ:    "bigint",
+			},
+			want: "alter table `db`.`table` add `id` bigint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.AddColumnSQL(tt.args.column, tt.args.typ); got != tt.want {
+				t.Errorf("Table.AddColumnSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// driverColumnType column type reported by go-sql-driver/mysql v1.8.1 for the column of the type name
+// with the length and the decimals in the column definition packet
+type driverColumnType struct {
+	mockFieldType
+
+	length   int64
+	decimals int64
+}
+
+func newDriverColumnType(name string, length, decimals int64) *driverColumnType {
+	return &driverColumnType{
+		mockFieldType: mockFieldType{name: name},
+		length:        length,
+		decimals:      decimals,
+	}
+}
+
+// Length go-sql-driver/mysql does not implement ColumnTypeLength
+func (d *driverColumnType) Length() (int64, bool) {
+	return 0, false
+}
+
+// DecimalSize the same as ColumnTypePrecisionScale of go-sql-driver/mysql
+func (d *driverColumnType) DecimalSize() (int64, int64, bool) {
+	switch d.name {
+	case "DECIMAL":
+		if d.decimals > 0 {
+			return d.length - 2, d.decimals, true
+		}
+		return d.length - 1, d.decimals, true
+	case "TIMESTAMP", "DATETIME", "TIME":
+		return d.decimals, d.decimals, true
+	case "FLOAT", "DOUBLE":
+		if d.decimals == 0x1f {
+			return math.MaxInt64, math.MaxInt64, true
+		}
+		return math.MaxInt64, d.decimals, true
+	}
+	return 0, 0, false
+}
+
+func TestTable_TypeDefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     database.ColumnType
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			typ:  newDriverColumnType("DECIMAL", 12, 2),
+			want: "DECIMAL(10,2)",
+		},
+		{
+			name: "2",
+			typ:  newDriverColumnType("DATETIME", 26, 6),
+			want: "DATETIME(6)",
+		},
+		{
+			name: "3",
+			typ:  newDriverColumnType("TIMESTAMP", 19, 0),
+			want: "TIMESTAMP",
+		},
+		{
+			name: "4",
+			typ:  newDriverColumnType("DOUBLE", 22, 0x1f),
+			want: "DOUBLE",
+		},
+		{
+			name: "5",
+			typ:  newDriverColumnType("FLOAT", 12, 2),
+			want: "FLOAT",
+		},
+		{
+			name: "6",
+			typ:  newDriverColumnType("UNSIGNED BIGINT", 20, 0),
+			want: "BIGINT UNSIGNED",
+		},
+		{
+			name: "7",
+			typ:  newDriverColumnType("TEXT", 262140, 0),
+			want: "TEXT",
+		},
+		{
+			name:    "8",
+			typ:     newDriverColumnType("VARCHAR", 80, 0),
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			typ:     newDriverColumnType("ENUM", 4, 0),
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			typ:     newDriverColumnType("NULL", 0, 0),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTable(database.NewBaseTable("db", "", "table")).TypeDefinition(tt.typ)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.TypeDefinition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Table.TypeDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SampleQuery(t *testing.T) {
+	type args struct {
+		column string
+		where  string
+		n      int
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "`id`",
+				n:      100,
+			},
+			want: "select `id` from `db`.`table` where rand() * (select count(*) from `db`.`table`) < 100 limit 100",
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "`id`",
+				where:  "a = 1",
+				n:      100,
+			},
+			want: "select `id` from `db`.`table` where rand() * (select count(*) from `db`.`table` where a = 1) < 100 and (a = 1) limit 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.SampleQuery(tt.args.column, tt.args.where, tt.args.n); got != tt.want {
+				t.Errorf("Table.SampleQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTable_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -484,3 +669,17 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_CatalogQuery(t *testing.T) {
+	query, args := NewTable(database.NewBaseTable("db", "schema", "table")).CatalogQuery()
+	want := "select column_name, is_nullable, column_default, character_maximum_length," +
+		" case when data_type = 'decimal' then numeric_precision end," +
+		" case when data_type = 'decimal' then numeric_scale end" +
+		" from information_schema.columns where table_schema = ? and table_name = ?"
+	if query != want {
+		t.Errorf("CatalogQuery() query = %v, want %v", query, want)
+	}
+	if wantArgs := []any{"db", "table"}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("CatalogQuery() args = %v, want %v", args, wantArgs)
+	}
+}
@@ -26,8 +26,13 @@ import (
 )
 
 var (
-	dateLayout     = element.DefaultTimeFormat[:10]
-	datetimeLayout = element.DefaultTimeFormat[:26]
+	dateLayout        = element.TimeLayout(element.TimeKindDateTime, 0) // DATE has the time of day in seconds
+	datetimeLayout    = element.TimeLayout(element.TimeKindDateTime, element.MaxTimePrecision)
+	timestamptzLayout = datetimeLayout + element.DefaultTimeFormat[29:]
+
+	// layouts of time strings bound by BindVar
+	bindDateLayout      = element.DefaultTimeFormat[:19]
+	bindTimestampLayout = element.DefaultTimeFormat[:29]
 )
 
 // Field Field
@@ -173,16 +178,26 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(dateLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(dateLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
-	case "TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE":
+	case "TIMESTAMP":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(datetimeLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(datetimeLayout))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE":
+		// the offset is preserved
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(timestamptzLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
@@ -281,6 +296,17 @@ func (v *Valuer) Value() (value driver.Value, err error) {
 	if v.c.IsNil() {
 		return "", nil
 	}
+	// Time is formatted by the format of BindVar, and the wall clock of the types without time zone is in the configured time zone
+	if v.c.Type() == element.TypeTime {
+		switch v.f.Type().DatabaseTypeName() {
+		case "DATE":
+			return database.NewWallTimeValuer(v.f, v.c, v.f.Location(), bindDateLayout).Value()
+		case "TIMESTAMP":
+			return database.NewWallTimeValuer(v.f, v.c, v.f.Location(), bindTimestampLayout).Value()
+		case "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE":
+			return database.NewWallTimeValuer(v.f, v.c, nil, bindTimestampLayout).Value()
+		}
+	}
 	// Due to Oracle's special conversion mechanism, all data needs to be converted to string type for insertion
 	return v.c.AsString()
 }
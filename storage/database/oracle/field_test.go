@@ -24,6 +24,7 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/storage/database/internal/testutil"
 	"github.com/godror/godror"
 )
 
@@ -31,6 +32,11 @@ type mockColumnType struct {
 	name string
 }
 
+func testFieldWithConfig(f *Field, conf *config.JSON) *Field {
+	f.SetConfig(conf)
+	return f
+}
+
 func newMockColumnType(name string) *mockColumnType {
 	return &mockColumnType{
 		name: name,
@@ -392,6 +398,30 @@ func TestScanner_Scan(t *testing.T) {
 				element.NewStringTimeDecoder(datetimeLayout)), "f1",
 				element.ByteSize(time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.UTC))),
 		},
+		{
+			name: "TIMESTAMP timezone",
+			s:    NewScanner(NewField(database.NewBaseField(0, "f1", newMockColumnType("TIMESTAMP")))),
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			args: args{
+				src: time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(2022, 10, 16, 10, 18, 33, 999999999, testutil.LoadLocation("Asia/Shanghai")),
+				element.NewStringTimeDecoder(datetimeLayout)), "f1",
+				element.ByteSize(time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.UTC))),
+		},
+		{
+			name: "TIMESTAMP WITH TIME ZONE",
+			s:    NewScanner(NewField(database.NewBaseField(0, "f1", newMockColumnType("TIMESTAMP WITH TIME ZONE")))),
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			args: args{
+				src: time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.FixedZone("", 3600)),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.FixedZone("", 3600)),
+				element.NewStringTimeDecoder(timestamptzLayout)), "f1",
+				element.ByteSize(time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.UTC))),
+		},
 		{
 			name: "TIMESTAMP WITH TIME ZONE nil",
 			s:    NewScanner(NewField(database.NewBaseField(0, "f1", newMockColumnType("TIMESTAMP WITH TIME ZONE")))),
@@ -613,6 +643,32 @@ func TestValuer_Value(t *testing.T) {
 				element.NewDefaultColumn(element.NewStringColumnValue("we"), "f2", 0)),
 			wantErr: true,
 		},
+		{
+			name: "9",
+			v: NewValuer(NewField(database.NewBaseField(0, "f1", newMockColumnType("DATE"))),
+				element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.UTC)), "f2", 0)),
+			want: driver.Value("2022-10-16 10:18:33"),
+		},
+		{
+			name: "10",
+			v: NewValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockColumnType("TIMESTAMP"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+				element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2022, 10, 16, 10, 18, 33, 999999999, time.UTC)), "f2", 0)),
+			want: driver.Value("2022-10-16 18:18:33.999999999"),
+		},
+		{
+			name: "11",
+			v: NewValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockColumnType("TIMESTAMP WITH TIME ZONE"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+				element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2022, 10, 16, 10, 18, 33, 0, time.UTC)), "f2", 0)),
+			want: driver.Value("2022-10-16 10:18:33"),
+		},
+		{
+			name: "12",
+			v: NewValuer(NewField(database.NewBaseField(0, "f1", newMockColumnType("DATE"))),
+				element.NewDefaultColumn(element.NewStringColumnValue("2022-10-16 10:18:33"), "f2", 0)),
+			want: driver.Value("2022-10-16 10:18:33"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
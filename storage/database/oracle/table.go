@@ -19,6 +19,8 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -51,6 +53,66 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add " + Quoted(column) + " " + typ
+}
+
+// LimitClause Generate the clause following order by that limits the number of rows queried to n
+func (t *Table) LimitClause(n int) string {
+	return "offset 0 rows fetch next " + strconv.Itoa(n) + " rows only"
+}
+
+// CatalogQuery Generate the query of the columns of the table in all_tab_columns, whose char_length is 0 for the types
+// other than characters, and data_precision is null for number without precision
+func (t *Table) CatalogQuery() (string, []any) {
+	return "select column_name, case nullable when 'Y' then 'YES' else 'NO' end, data_default," +
+		" case when char_length > 0 then char_length end," +
+		" case when data_type = 'NUMBER' then data_precision end," +
+		" case when data_type = 'NUMBER' then data_scale end" +
+		" from all_tab_columns where owner = :1 and table_name = :2", []any{t.Schema(), t.Name()}
+}
+
+// SampleQuery Generate the query sampling about n values of the column without sorting the rows in random order, each of which
+// is kept in the probability of n divided by the number of the rows matching the condition where
+func (t *Table) SampleQuery(column, where string, n int) string {
+	num := strconv.Itoa(n)
+	return "select " + column + " from " + t.Quoted() + " where dbms_random.value * (select count(*) from " + t.Quoted() +
+		sampleWhere(where) + ") < " + num + sampleAnd(where) + " and rownum <= " + num
+}
+
+// ChunkQuery Generate the query of the ROWID ranges of about n chunks with about the same number of blocks by the extents of the table
+// in dba_extents, where is not used because extents are physical
+func (t *Table) ChunkQuery(_ string, n int) string {
+	num := strconv.Itoa(n)
+	return "select rowidtochar(min(l)) chunk_left, rowidtochar(max(r)) chunk_right from (" +
+		"select dbms_rowid.rowid_create(1, o.data_object_id, e.relative_fno, e.block_id, 0) l, " +
+		"dbms_rowid.rowid_create(1, o.data_object_id, e.relative_fno, e.block_id + e.blocks - 1, 32767) r, " +
+		"trunc((sum(e.blocks) over (order by o.data_object_id, e.relative_fno, e.block_id) - e.blocks) * " + num +
+		" / sum(e.blocks) over ()) g from dba_extents e join dba_objects o on o.owner = e.owner and o.object_name = e.segment_name " +
+		"and (o.subobject_name = e.partition_name or o.subobject_name is null and e.partition_name is null) " +
+		"where e.owner = " + literal(t.Schema()) + " and e.segment_name = " + literal(t.Name()) +
+		" and e.segment_type like 'TABLE%' and o.object_type like 'TABLE%' and o.data_object_id is not null) group by g order by g"
+}
+
+// ChunkFallbackQuery Generate the query of the ROWID ranges of n chunks with the same number of rows matching the condition where
+// by ntile, when dba_extents can not be queried without the privilege (ORA-00942)
+func (t *Table) ChunkFallbackQuery(where string, n int, err error) (string, bool) {
+	if err == nil || !strings.Contains(err.Error(), "ORA-00942") {
+		return "", false
+	}
+	return "select rowidtochar(min(r)) chunk_left, rowidtochar(max(r)) chunk_right from (select rowid r, ntile(" + strconv.Itoa(n) +
+		") over (order by rowid) g from " + t.Quoted() + sampleWhere(where) + ") group by g order by g", true
+}
+
+// ChunkWhere Generate the condition of the ROWID in the range, the last of which has no right bound for the extents added after splitting
+func (t *Table) ChunkWhere(left, right string, last bool) string {
+	if last {
+		return "rowid >= chartorowid('" + left + "')"
+	}
+	return "rowid between chartorowid('" + left + "') and chartorowid('" + right + "')"
+}
+
 // AddField adds a new column to the table.
 func (t *Table) AddField(baseField *database.BaseField) {
 	f := NewField(baseField)
@@ -150,3 +212,21 @@ func (ip *InsertParam) Agrs(records []element.Record) (valuers []any, err error)
 	}
 	return
 }
+
+func literal(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
+
+func sampleAnd(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " and (" + where + ")"
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/godror/godror"
+	"github.com/pingcap/errors"
 )
 
 func TestNewTable(t *testing.T) {
@@ -71,6 +72,223 @@ func TestTable_Quoted(t *testing.T) {
 	}
 }
 
+func TestTable_AddColumnSQL(t *testing.T) {
+	type args struct {
+		column string
+		typ    string
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "id",
+				typ:    "bigint",
+			},
+			want: `alter table "schema"."table" add "id" bigint`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.AddColumnSQL(tt.args.column, tt.args.typ); got != tt.want {
+				t.Errorf("Table.AddColumnSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SampleQuery(t *testing.T) {
+	type args struct {
+		column string
+		where  string
+		n      int
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: `"id"`,
+				n:      100,
+			},
+			want: `select "id" from "schema"."table" where dbms_random.value * (select count(*) from "schema"."table") < 100 and rownum <= 100`,
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: `"id"`,
+				where:  "a = 1",
+				n:      100,
+			},
+			want: `select "id" from "schema"."table" where dbms_random.value * (select count(*) from "schema"."table" where a = 1) < 100` +
+				` and (a = 1) and rownum <= 100`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.SampleQuery(tt.args.column, tt.args.where, tt.args.n); got != tt.want {
+				t.Errorf("Table.SampleQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_ChunkQuery(t *testing.T) {
+	tr := NewTable(database.NewBaseTable("db", "sch'ema", "table"))
+	want := "select rowidtochar(min(l)) chunk_left, rowidtochar(max(r)) chunk_right from (" +
+		"select dbms_rowid.rowid_create(1, o.data_object_id, e.relative_fno, e.block_id, 0) l, " +
+		"dbms_rowid.rowid_create(1, o.data_object_id, e.relative_fno, e.block_id + e.blocks - 1, 32767) r, " +
+		"trunc((sum(e.blocks) over (order by o.data_object_id, e.relative_fno, e.block_id) - e.blocks) * 4" +
+		" / sum(e.blocks) over ()) g from dba_extents e join dba_objects o on o.owner = e.owner and o.object_name = e.segment_name " +
+		"and (o.subobject_name = e.partition_name or o.subobject_name is null and e.partition_name is null) " +
+		"where e.owner = 'sch''ema' and e.segment_name = 'table'" +
+		" and e.segment_type like 'TABLE%' and o.object_type like 'TABLE%' and o.data_object_id is not null) group by g order by g"
+	if got := tr.ChunkQuery("a = 1", 4); got != want {
+		t.Errorf("Table.ChunkQuery() = %v, want %v", got, want)
+	}
+}
+
+func TestTable_ChunkFallbackQuery(t *testing.T) {
+	type args struct {
+		where string
+		n     int
+		err   error
+	}
+	tests := []struct {
+		name   string
+		tr     *Table
+		args   args
+		want   string
+		wantOk bool
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				where: "a = 1",
+				n:     4,
+				err:   errors.Wrap(errors.New("ORA-00942: table or view does not exist"), "FetchRecord fail"),
+			},
+			want: `select rowidtochar(min(r)) chunk_left, rowidtochar(max(r)) chunk_right from (select rowid r, ntile(4) over (order by rowid) g` +
+				` from "schema"."table" where a = 1) group by g order by g`,
+			wantOk: true,
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				n:   4,
+				err: errors.New("ORA-00942: table or view does not exist"),
+			},
+			want: `select rowidtochar(min(r)) chunk_left, rowidtochar(max(r)) chunk_right from (select rowid r, ntile(4) over (order by rowid) g` +
+				` from "schema"."table") group by g order by g`,
+			wantOk: true,
+		},
+		{
+			name: "3",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				n:   4,
+				err: errors.New("ORA-01031: insufficient privileges"),
+			},
+		},
+		{
+			name: "4",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				n: 4,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.tr.ChunkFallbackQuery(tt.args.where, tt.args.n, tt.args.err)
+			if ok != tt.wantOk {
+				t.Errorf("Table.ChunkFallbackQuery() ok = %v, wantOk %v", ok, tt.wantOk)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Table.ChunkFallbackQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_ChunkWhere(t *testing.T) {
+	type args struct {
+		left  string
+		right string
+		last  bool
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				left:  "0",
+				right: "100",
+			},
+			want: "rowid between chartorowid('0') and chartorowid('100')",
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				left:  "0",
+				right: "100",
+				last:  true,
+			},
+			want: "rowid >= chartorowid('0')",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.ChunkWhere(tt.args.left, tt.args.right, tt.args.last); got != tt.want {
+				t.Errorf("Table.ChunkWhere() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_LimitClause(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   *Table
+		n    int
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			n:    1000,
+			want: "offset 0 rows fetch next 1000 rows only",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.LimitClause(tt.n); got != tt.want {
+				t.Errorf("Table.LimitClause() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTable_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -476,3 +694,18 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_CatalogQuery(t *testing.T) {
+	query, args := NewTable(database.NewBaseTable("db", "schema", "table")).CatalogQuery()
+	want := "select column_name, case nullable when 'Y' then 'YES' else 'NO' end, data_default," +
+		" case when char_length > 0 then char_length end," +
+		" case when data_type = 'NUMBER' then data_precision end," +
+		" case when data_type = 'NUMBER' then data_scale end" +
+		" from all_tab_columns where owner = :1 and table_name = :2"
+	if query != want {
+		t.Errorf("CatalogQuery() query = %v, want %v", query, want)
+	}
+	if wantArgs := []any{"schema", "table"}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("CatalogQuery() args = %v, want %v", args, wantArgs)
+	}
+}
@@ -15,20 +15,56 @@
 package postgres
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/lib/pq"
 	"github.com/lib/pq/oid"
 )
 
 var (
-	dateLayout      = element.DefaultTimeFormat[:10]
-	timestampLayout = element.DefaultTimeFormat[:26]
+	dateLayout        = element.TimeLayout(element.TimeKindDate, 0)
+	timeLayout        = element.TimeLayout(element.TimeKindTime, 6)
+	timetzLayout      = timeLayout + element.DefaultTimeFormat[29:]
+	timestampLayout   = element.TimeLayout(element.TimeKindDateTime, 6)
+	timestamptzLayout = timestampLayout + element.DefaultTimeFormat[29:]
 )
 
+// arrayElemTypes - The element types of the supported one-dimensional arrays, keyed by the array types.
+var arrayElemTypes = map[string]string{
+	oid.TypeName[oid.T__bool]:        oid.TypeName[oid.T_bool],
+	oid.TypeName[oid.T__int2]:        oid.TypeName[oid.T_int2],
+	oid.TypeName[oid.T__int4]:        oid.TypeName[oid.T_int4],
+	oid.TypeName[oid.T__int8]:        oid.TypeName[oid.T_int8],
+	oid.TypeName[oid.T__float4]:      oid.TypeName[oid.T_float4],
+	oid.TypeName[oid.T__float8]:      oid.TypeName[oid.T_float8],
+	oid.TypeName[oid.T__numeric]:     oid.TypeName[oid.T_numeric],
+	oid.TypeName[oid.T__varchar]:     oid.TypeName[oid.T_varchar],
+	oid.TypeName[oid.T__text]:        oid.TypeName[oid.T_text],
+	oid.TypeName[oid.T__bpchar]:      oid.TypeName[oid.T_bpchar],
+	oid.TypeName[oid.T__uuid]:        oid.TypeName[oid.T_uuid],
+	oid.TypeName[oid.T__date]:        oid.TypeName[oid.T_date],
+	oid.TypeName[oid.T__time]:        oid.TypeName[oid.T_time],
+	oid.TypeName[oid.T__timetz]:      oid.TypeName[oid.T_timetz],
+	oid.TypeName[oid.T__timestamp]:   oid.TypeName[oid.T_timestamp],
+	oid.TypeName[oid.T__timestamptz]: oid.TypeName[oid.T_timestamptz],
+	oid.TypeName[oid.T__interval]:    oid.TypeName[oid.T_interval],
+	oid.TypeName[oid.T__inet]:        oid.TypeName[oid.T_inet],
+	oid.TypeName[oid.T__cidr]:        oid.TypeName[oid.T_cidr],
+	oid.TypeName[oid.T__macaddr]:     oid.TypeName[oid.T_macaddr],
+	oid.TypeName[oid.T__money]:       oid.TypeName[oid.T_money],
+	oid.TypeName[oid.T__json]:        oid.TypeName[oid.T_json],
+	oid.TypeName[oid.T__jsonb]:       oid.TypeName[oid.T_jsonb],
+}
+
 // Field - Represents a field in a database table.
 type Field struct {
 	*database.BaseField
@@ -67,8 +103,23 @@ func (f *Field) Scanner() database.Scanner {
 	return NewScanner(f)
 }
 
-// Valuer - Handles data processing using GoValuer.
+// Valuer - Handles data processing using GoValuer, except that arrays are handled by ArrayValuer,
+// date and time are handled by WallTimeValuer, and so is timestamp when the timezone is configured.
 func (f *Field) Valuer(c element.Column) database.Valuer {
+	if _, ok := arrayElemTypes[f.Type().DatabaseTypeName()]; ok {
+		return NewArrayValuer(f, c)
+	}
+	switch f.Type().DatabaseTypeName() {
+	case oid.TypeName[oid.T_date]:
+		// date and time are written as strings so that no fake time of day or date is passed
+		return database.NewWallTimeValuer(f, c, f.Location(), dateLayout)
+	case oid.TypeName[oid.T_time]:
+		return database.NewWallTimeValuer(f, c, f.Location(), timeLayout)
+	case oid.TypeName[oid.T_timestamp]:
+		if loc := f.Location(); loc != nil {
+			return database.NewWallTimeValuer(f, c, loc, "")
+		}
+	}
 	return database.NewGoValuer(f, c)
 }
 
@@ -103,6 +154,18 @@ func NewFieldType(typ database.ColumnType) *FieldType {
 		f.goType = database.GoTypeString
 	case oid.TypeName[oid.T_uuid]:
 		f.goType = database.GoTypeString
+	case oid.TypeName[oid.T_json], oid.TypeName[oid.T_jsonb]:
+		f.goType = database.GoTypeString
+	case oid.TypeName[oid.T_bytea]:
+		f.goType = database.GoTypeBytes
+	case oid.TypeName[oid.T_interval], oid.TypeName[oid.T_inet],
+		oid.TypeName[oid.T_cidr], oid.TypeName[oid.T_macaddr],
+		oid.TypeName[oid.T_money], oid.TypeName[oid.T_xml]:
+		f.goType = database.GoTypeString
+	default:
+		if _, ok := arrayElemTypes[f.DatabaseTypeName()]; ok {
+			f.goType = database.GoTypeString
+		}
 	}
 	return f
 }
@@ -171,18 +234,45 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(dateLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(dateLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
 
-	case oid.TypeName[oid.T_time], oid.TypeName[oid.T_timetz],
-		oid.TypeName[oid.T_timestamp], oid.TypeName[oid.T_timestamptz]:
+	case oid.TypeName[oid.T_time]:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(timeLayout))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case oid.TypeName[oid.T_timestamp]:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(timestampLayout))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case oid.TypeName[oid.T_timetz]:
+		// the offset is preserved
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(timestampLayout))
+			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(timetzLayout))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case oid.TypeName[oid.T_timestamptz]:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(timestamptzLayout))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
@@ -218,9 +308,170 @@ func (s *Scanner) Scan(src any) (err error) {
 		default:
 			return fmt.Errorf("src is %v(%T),but not %v", src, src, element.TypeBytes)
 		}
+	case oid.TypeName[oid.T_json], oid.TypeName[oid.T_jsonb]:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilJsonColumnValue()
+		case []byte:
+			if cv, err = element.NewJsonColumnValueFromBytes(data); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T),but not %v", src, src, element.TypeJSON)
+		}
+	case oid.TypeName[oid.T_bytea]:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilBytesColumnValue()
+		case []byte:
+			cv = element.NewBytesColumnValue(data)
+		default:
+			return fmt.Errorf("src is %v(%T),but not %v", src, src, element.TypeBytes)
+		}
+	case oid.TypeName[oid.T_interval], oid.TypeName[oid.T_inet],
+		oid.TypeName[oid.T_cidr], oid.TypeName[oid.T_macaddr],
+		oid.TypeName[oid.T_money], oid.TypeName[oid.T_xml]:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilStringColumnValue()
+		case []byte:
+			cv = element.NewStringColumnValue(string(data))
+		default:
+			return fmt.Errorf("src is %v(%T),but not %v", src, src, element.TypeString)
+		}
 	default:
-		return fmt.Errorf("src is %v(%T), but db type is %v", src, src, s.f.Type().DatabaseTypeName())
+		elemType, ok := arrayElemTypes[s.f.Type().DatabaseTypeName()]
+		if !ok {
+			return fmt.Errorf("src is %v(%T), but db type is %v", src, src, s.f.Type().DatabaseTypeName())
+		}
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilJsonColumnValue()
+		case []byte:
+			var b []byte
+			if b, err = arrayToJSON(data, elemType); err != nil {
+				return
+			}
+			if cv, err = element.NewJsonColumnValueFromBytes(b); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T),but not %v", src, src, element.TypeJSON)
+		}
 	}
 	s.SetColumn(element.NewDefaultColumn(cv, s.f.Name(), byteSize))
 	return
 }
+
+// ArrayValuer - Converts the JSON array in the column to the one-dimensional array of postgres.
+type ArrayValuer struct {
+	f *Field
+	c element.Column
+}
+
+// NewArrayValuer - Generates a Valuer for the array based on the field f and the column c.
+func NewArrayValuer(f *Field, c element.Column) *ArrayValuer {
+	return &ArrayValuer{
+		f: f,
+		c: c,
+	}
+}
+
+// Value - Generates the text of the postgres array from the JSON array, or from the elements separated by commas for strings.
+func (a *ArrayValuer) Value() (v driver.Value, err error) {
+	defer a.f.SetError(&err)
+	if a.c.IsNil() {
+		return nil, nil
+	}
+	var s string
+	if s, err = a.c.AsString(); err != nil {
+		return nil, err
+	}
+	elemType := arrayElemTypes[a.f.Type().DatabaseTypeName()]
+	// strings which are not json arrays, such as SET of mysql, are the elements separated by commas
+	if a.c.Type() == element.TypeString && !strings.HasPrefix(strings.TrimSpace(s), "[") {
+		return pq.GenericArray{A: splitElements(s)}.Value()
+	}
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	var elems []any
+	if err = decoder.Decode(&elems); err != nil {
+		return nil, fmt.Errorf("%v is not json array. err: %v", s, err)
+	}
+	isJSON := elemType == oid.TypeName[oid.T_json] || elemType == oid.TypeName[oid.T_jsonb]
+	for i, e := range elems {
+		switch data := e.(type) {
+		case nil:
+		case json.Number:
+			elems[i] = data.String()
+		case bool, string:
+			if isJSON {
+				var b []byte
+				if b, err = json.Marshal(data); err != nil {
+					return nil, err
+				}
+				elems[i] = string(b)
+			}
+		default:
+			if !isJSON {
+				return nil, fmt.Errorf("element %v of %v is not %v, as only one-dimensional array is supported", i, s, elemType)
+			}
+			var b []byte
+			if b, err = json.Marshal(data); err != nil {
+				return nil, err
+			}
+			elems[i] = string(b)
+		}
+	}
+	return pq.GenericArray{A: elems}.Value()
+}
+
+// splitElements - Splits the elements of s separated by commas.
+func splitElements(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
+
+// arrayToJSON - Converts the text of the one-dimensional postgres array src to the JSON array,
+// whose elements are numbers for numeric types, booleans for bool, raw JSON for json and jsonb, and strings otherwise.
+func arrayToJSON(src []byte, elemType string) ([]byte, error) {
+	var elems []sql.NullString
+	if err := (pq.GenericArray{A: &elems}).Scan(src); err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBufferString("[")
+	for i, e := range elems {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		if !e.Valid {
+			buf.WriteString("null")
+			continue
+		}
+		switch elemType {
+		case oid.TypeName[oid.T_bool]:
+			buf.WriteString(strconv.FormatBool(e.String == "t"))
+			continue
+		case oid.TypeName[oid.T_int2], oid.TypeName[oid.T_int4],
+			oid.TypeName[oid.T_int8], oid.TypeName[oid.T_float4],
+			oid.TypeName[oid.T_float8], oid.TypeName[oid.T_numeric]:
+			// NaN and Infinity are not json numbers
+			if json.Valid([]byte(e.String)) {
+				buf.WriteString(e.String)
+				continue
+			}
+		case oid.TypeName[oid.T_json], oid.TypeName[oid.T_jsonb]:
+			buf.WriteString(e.String)
+			continue
+		}
+		b, err := json.Marshal(e.String)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteString("]")
+	return buf.Bytes(), nil
+}
@@ -16,6 +16,7 @@ package postgres
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"reflect"
 	"testing"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/storage/database/internal/testutil"
 	"github.com/lib/pq/oid"
 )
 
@@ -34,10 +36,23 @@ func testDecimalColumnValueFromString(s string) element.ColumnValue {
 	return d
 }
 
+func testJSONColumnValueFromString(s string) element.ColumnValue {
+	j, err := element.NewJsonColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return j
+}
+
 type mockColumnType struct {
 	name string
 }
 
+func testFieldWithConfig(f *Field, conf *config.JSON) *Field {
+	f.SetConfig(conf)
+	return f
+}
+
 func newMockColumnType(name string) *mockColumnType {
 	return &mockColumnType{
 		name: name,
@@ -200,6 +215,53 @@ func TestField_Valuer(t *testing.T) {
 			},
 			want: database.NewGoValuer(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType("1")))), element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "f1", 0)),
 		},
+		{
+			name: "2",
+			f:    NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T__int4])))),
+			args: args{
+				c: element.NewDefaultColumn(element.NewStringColumnValue("[1]"), "f1", 0),
+			},
+			want: NewArrayValuer(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T__int4])))), element.NewDefaultColumn(element.NewStringColumnValue("[1]"), "f1", 0)),
+		},
+		{
+			name: "3",
+			f: testFieldWithConfig(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_timestamp])))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+			args: args{
+				c: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+			},
+			want: database.NewWallTimeValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_timestamp])))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)), element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+				testutil.LoadLocation("Asia/Shanghai"), ""),
+		},
+		{
+			name: "4",
+			f: testFieldWithConfig(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_timestamptz])))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+			args: args{
+				c: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+			},
+			want: database.NewGoValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_timestamptz])))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)), element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0)),
+		},
+		{
+			name: "5",
+			f:    NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_date])))),
+			args: args{
+				c: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+			},
+			want: database.NewWallTimeValuer(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_date])))),
+				element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0), nil, dateLayout),
+		},
+		{
+			name: "6",
+			f:    NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_time])))),
+			args: args{
+				c: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+			},
+			want: database.NewWallTimeValuer(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_time])))),
+				element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0), nil, timeLayout),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -210,6 +272,86 @@ func TestField_Valuer(t *testing.T) {
 	}
 }
 
+// TestField_ValuerFromMySQL writes the columns in the form scanned from mysql, where JSON is json,
+// SET is the members separated by commas and UNSIGNED BIGINT may be larger than the max of int64
+func TestField_ValuerFromMySQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		c       element.Column
+		want    driver.Value
+		wantErr bool
+	}{
+		{
+			name: "1",
+			typ:  oid.TypeName[oid.T_jsonb],
+			c:    element.NewDefaultColumn(testJSONColumnValueFromString(`{"a":[1,"b"]}`), "f1", 0),
+			want: `{"a":[1,"b"]}`,
+		},
+		{
+			name: "2",
+			typ:  oid.TypeName[oid.T_json],
+			c:    element.NewDefaultColumn(testJSONColumnValueFromString(`[1,{"a":null}]`), "f1", 0),
+			want: `[1,{"a":null}]`,
+		},
+		{
+			name: "3",
+			typ:  oid.TypeName[oid.T_jsonb],
+			c:    element.NewDefaultColumn(element.NewNilJsonColumnValue(), "f1", 0),
+			want: nil,
+		},
+		{
+			name: "4",
+			typ:  oid.TypeName[oid.T__jsonb],
+			c:    element.NewDefaultColumn(testJSONColumnValueFromString(`[{"a":1},"b",null]`), "f1", 0),
+			want: `{"{\"a\":1}","\"b\"",NULL}`,
+		},
+		{
+			name: "5",
+			typ:  oid.TypeName[oid.T__text],
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("a,b c,d"), "f1", 0),
+			want: `{"a","b c","d"}`,
+		},
+		{
+			name: "6",
+			typ:  oid.TypeName[oid.T__text],
+			c:    element.NewDefaultColumn(element.NewStringColumnValue(""), "f1", 0),
+			want: `{}`,
+		},
+		{
+			name: "7",
+			typ:  oid.TypeName[oid.T__text],
+			c:    element.NewDefaultColumn(testJSONColumnValueFromString(`["a","b"]`), "f1", 0),
+			want: `{"a","b"}`,
+		},
+		{
+			name:    "8",
+			typ:     oid.TypeName[oid.T__text],
+			c:       element.NewDefaultColumn(testJSONColumnValueFromString(`{"a":1}`), "f1", 0),
+			wantErr: true,
+		},
+		{
+			name: "9",
+			typ:  oid.TypeName[oid.T_numeric],
+			c:    element.NewDefaultColumn(element.NewBigIntColumnValueFromUint64(18446744073709551615), "f1", 0),
+			want: "18446744073709551615",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(tt.typ))))
+			got, err := f.Valuer(tt.c).Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Valuer.Value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Valuer.Value() = %v(%T), want %v(%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
 func TestFieldType_GoType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -304,7 +446,75 @@ func TestFieldType_GoType(t *testing.T) {
 		//unknown
 		{
 			name: "16",
-			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T__bool])),
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_point])),
+			want: database.GoTypeUnknown,
+		},
+
+		//json
+		{
+			name: "17",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_json])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "18",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_jsonb])),
+			want: database.GoTypeString,
+		},
+
+		//bytes
+		{
+			name: "19",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_bytea])),
+			want: database.GoTypeBytes,
+		},
+
+		//string
+		{
+			name: "20",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_interval])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "21",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_inet])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "22",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_cidr])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "23",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_macaddr])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "24",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_money])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "25",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_xml])),
+			want: database.GoTypeString,
+		},
+
+		//array
+		{
+			name: "26",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T__int4])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "27",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T__text])),
+			want: database.GoTypeString,
+		},
+		{
+			name: "28",
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T__bytea])),
 			want: database.GoTypeUnknown,
 		},
 	}
@@ -330,7 +540,7 @@ func TestFieldType_IsSupportted(t *testing.T) {
 		},
 		{
 			name: "2",
-			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T__bool])),
+			f:    NewFieldType(newMockColumnType(oid.TypeName[oid.T_point])),
 			want: false,
 		},
 	}
@@ -515,6 +725,48 @@ func TestScanner_Scan(t *testing.T) {
 			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
 				time.Date(2021, 6, 17, 22, 24, 8, 8, time.UTC), element.NewStringTimeDecoder(timestampLayout)), "f1", element.ByteSize(time.Date(2021, 6, 17, 22, 24, 8, 8, time.UTC))),
 		},
+		{
+			name: "11time",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_time]))))),
+			args: args{
+				src: time.Date(0, 1, 1, 22, 24, 8, 123456000, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(0, 1, 1, 22, 24, 8, 123456000, time.UTC), element.NewStringTimeDecoder(timeLayout)), "f1", element.ByteSize(time.Date(0, 1, 1, 22, 24, 8, 123456000, time.UTC))),
+		},
+		{
+			name: "11timetz",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_timetz]))))),
+			args: args{
+				src: time.Date(0, 1, 1, 22, 24, 8, 0, time.FixedZone("", 3600)),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(0, 1, 1, 22, 24, 8, 0, time.FixedZone("", 3600)), element.NewStringTimeDecoder(timetzLayout)), "f1", element.ByteSize(time.Date(0, 1, 1, 22, 24, 8, 0, time.UTC))),
+		},
+		{
+			name: "11timezone",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_timestamp]))))),
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			args: args{
+				src: time.Date(2021, 6, 17, 22, 24, 8, 8, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(2021, 6, 17, 22, 24, 8, 8, testutil.LoadLocation("Asia/Shanghai")), element.NewStringTimeDecoder(timestampLayout)), "f1", element.ByteSize(time.Date(2021, 6, 17, 22, 24, 8, 8, time.UTC))),
+		},
+		{
+			name: "12timezone",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_timestamptz]))))),
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			args: args{
+				src: time.Date(2021, 6, 17, 22, 24, 8, 8, time.FixedZone("", 3600)),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(2021, 6, 17, 22, 24, 8, 8, time.FixedZone("", 3600)), element.NewStringTimeDecoder(timestamptzLayout)), "f1", element.ByteSize(time.Date(2021, 6, 17, 22, 24, 8, 8, time.UTC))),
+		},
 		{
 			name: "12",
 			s: NewScanner(NewField(database.NewBaseField(0,
@@ -610,12 +862,112 @@ func TestScanner_Scan(t *testing.T) {
 		{
 			name: "21",
 			s: NewScanner(NewField(database.NewBaseField(0,
-				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T__bool]))))),
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_point]))))),
 			args: args{
 				src: "1234567890.1231233",
 			},
 			wantErr: true,
 		},
+
+		{
+			name: "json",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_jsonb]))))),
+			args: args{
+				src: []byte(`{"a":1}`),
+			},
+			want: element.NewDefaultColumn(testJSONColumnValueFromString(`{"a":1}`), "f1", element.ByteSize([]byte(`{"a":1}`))),
+		},
+		{
+			name: "jsonnil",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_json]))))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilJsonColumnValue(), "f1", 0),
+		},
+		{
+			name: "jsonerr",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_json]))))),
+			args: args{
+				src: []byte(`{"a":`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "bytea",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_bytea]))))),
+			args: args{
+				src: []byte{0x00, 0xff},
+			},
+			want: element.NewDefaultColumn(element.NewBytesColumnValue([]byte{0x00, 0xff}), "f1", element.ByteSize([]byte{0x00, 0xff})),
+		},
+		{
+			name: "byteanil",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_bytea]))))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilBytesColumnValue(), "f1", 0),
+		},
+		{
+			name: "interval",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_interval]))))),
+			args: args{
+				src: []byte("1 day 02:00:00"),
+			},
+			want: element.NewDefaultColumn(element.NewStringColumnValue("1 day 02:00:00"), "f1", element.ByteSize([]byte("1 day 02:00:00"))),
+		},
+		{
+			name: "inetnil",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_inet]))))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilStringColumnValue(), "f1", 0),
+		},
+		{
+			name: "moneyerr",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_money]))))),
+			args: args{
+				src: 1.5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "array",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T__int4]))))),
+			args: args{
+				src: []byte("{1,NULL,3}"),
+			},
+			want: element.NewDefaultColumn(testJSONColumnValueFromString(`[1,null,3]`), "f1", element.ByteSize([]byte("{1,NULL,3}"))),
+		},
+		{
+			name: "arraynil",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T__text]))))),
+			args: args{
+				src: nil,
+			},
+			want: element.NewDefaultColumn(element.NewNilJsonColumnValue(), "f1", 0),
+		},
+		{
+			name: "arrayerr",
+			s: NewScanner(NewField(database.NewBaseField(0,
+				"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T__int4]))))),
+			args: args{
+				src: []byte("{{1,2},{3,4}}"),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -634,3 +986,146 @@ func TestScanner_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestArrayValuer_Value(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		c       element.Column
+		want    driver.Value
+		wantErr bool
+	}{
+		{
+			name: "1",
+			typ:  oid.TypeName[oid.T__int8],
+			c:    element.NewDefaultColumn(testJSONColumnValueFromString(`[1,null,12345678901234567890]`), "f1", 0),
+			want: `{"1",NULL,"12345678901234567890"}`,
+		},
+		{
+			name: "2",
+			typ:  oid.TypeName[oid.T__text],
+			c:    element.NewDefaultColumn(element.NewStringColumnValue(`["a","b\"c"]`), "f1", 0),
+			want: `{"a","b\"c"}`,
+		},
+		{
+			name: "3",
+			typ:  oid.TypeName[oid.T__bool],
+			c:    element.NewDefaultColumn(element.NewStringColumnValue(`[true,false]`), "f1", 0),
+			want: `{true,false}`,
+		},
+		{
+			name: "4",
+			typ:  oid.TypeName[oid.T__jsonb],
+			c:    element.NewDefaultColumn(element.NewStringColumnValue(`[{"a":1},"b",2]`), "f1", 0),
+			want: `{"{\"a\":1}","\"b\"","2"}`,
+		},
+		{
+			name: "5",
+			typ:  oid.TypeName[oid.T__int4],
+			c:    element.NewDefaultColumn(element.NewNilStringColumnValue(), "f1", 0),
+			want: nil,
+		},
+		{
+			name:    "6",
+			typ:     oid.TypeName[oid.T__int4],
+			c:       element.NewDefaultColumn(element.NewStringColumnValue(`[[1,2],[3,4]]`), "f1", 0),
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			typ:     oid.TypeName[oid.T__int4],
+			c:       element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "f1", 0),
+			wantErr: true,
+		},
+		{
+			name: "8",
+			typ:  oid.TypeName[oid.T__text],
+			c:    element.NewDefaultColumn(element.NewStringColumnValue(`a,b c`), "f1", 0),
+			want: `{"a","b c"}`,
+		},
+		{
+			name: "9",
+			typ:  oid.TypeName[oid.T__text],
+			c:    element.NewDefaultColumn(element.NewStringColumnValue(``), "f1", 0),
+			want: `{}`,
+		},
+		{
+			name:    "10",
+			typ:     oid.TypeName[oid.T__text],
+			c:       element.NewDefaultColumn(testJSONColumnValueFromString(`{"a":1}`), "f1", 0),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType(tt.typ))))
+			got, err := NewArrayValuer(f, tt.c).Value()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ArrayValuer.Value() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ArrayValuer.Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_arrayToJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      []byte
+		elemType string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			src:      []byte(`{t,f,NULL}`),
+			elemType: oid.TypeName[oid.T_bool],
+			want:     `[true,false,null]`,
+		},
+		{
+			name:     "2",
+			src:      []byte(`{1.5,NaN,-Infinity}`),
+			elemType: oid.TypeName[oid.T_numeric],
+			want:     `[1.5,"NaN","-Infinity"]`,
+		},
+		{
+			name:     "3",
+			src:      []byte(`{a,"b c","d\"e"}`),
+			elemType: oid.TypeName[oid.T_varchar],
+			want:     `["a","b c","d\"e"]`,
+		},
+		{
+			name:     "4",
+			src:      []byte(`{"{\"a\": 1}","[1, 2]"}`),
+			elemType: oid.TypeName[oid.T_jsonb],
+			want:     `[{"a": 1},[1, 2]]`,
+		},
+		{
+			name:     "5",
+			src:      []byte(`{}`),
+			elemType: oid.TypeName[oid.T_int4],
+			want:     `[]`,
+		},
+		{
+			name:     "6",
+			src:      []byte(`{1,2`),
+			elemType: oid.TypeName[oid.T_int4],
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := arrayToJSON(tt.src, tt.elemType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("arrayToJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("arrayToJSON() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
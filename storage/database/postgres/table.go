@@ -18,7 +18,10 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"net"
+	"strconv"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -52,6 +55,68 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add " + Quoted(column) + " " + typ
+}
+
+// maxNumericPrecision the maximum precision of numeric, lib/pq reports the one without precision as 65535
+const maxNumericPrecision = 1000
+
+// TypeDefinition Get the type definition of the column type reported by lib/pq, which reports the array types
+// with the prefix _, the length of varchar and bpchar, and the precision and scale of numeric only
+func (t *Table) TypeDefinition(typ database.ColumnType) (string, error) {
+	name := typ.DatabaseTypeName()
+	if name == "" {
+		return "", errors.New("type is unknown")
+	}
+	def := strings.TrimPrefix(name, "_")
+	if precision, scale, ok := typ.DecimalSize(); ok && precision > 0 && precision <= maxNumericPrecision {
+		def = fmt.Sprintf("%v(%v,%v)", def, precision, scale)
+	} else if length, ok := typ.Length(); ok && length > 0 && length != math.MaxInt64 {
+		def = fmt.Sprintf("%v(%v)", def, length)
+	}
+	if strings.HasPrefix(name, "_") {
+		def += "[]"
+	}
+	return def, nil
+}
+
+// CatalogQuery Generate the query of the columns of the table in information_schema.columns,
+// whose numeric precision of numeric without precision is null
+func (t *Table) CatalogQuery() (string, []any) {
+	return "select column_name, is_nullable, column_default, character_maximum_length," +
+		" case when data_type = 'numeric' then numeric_precision end," +
+		" case when data_type = 'numeric' then numeric_scale end" +
+		" from information_schema.columns where table_schema = $1 and table_name = $2", []any{t.Schema(), t.Name()}
+}
+
+// SampleQuery Generate the query sampling about n values of the column by the blocks of tablesample system without sorting the rows
+// in random order, whose percentage is estimated by the number of rows in pg_class, or counted if the table has not been analyzed
+func (t *Table) SampleQuery(column, where string, n int) string {
+	num := strconv.Itoa(n)
+	return "select " + column + " from " + t.Quoted() + " tablesample system ((select case when reltuples > 0 then least(" + num +
+		" * 100 / reltuples, 100) else least(" + num + " * 100 / greatest((select count(*) from " + t.Quoted() + "), 1), 100) end" +
+		" from pg_class where oid = '" + strings.ReplaceAll(t.Quoted(), "'", "''") + "'::regclass))" +
+		sampleWhere(where) + " limit " + num
+}
+
+// ChunkQuery Generate the query of the block ranges of about n chunks by the size of the table, where is not used because blocks are physical
+func (t *Table) ChunkQuery(_ string, n int) string {
+	num := strconv.Itoa(n)
+	return "select s.i * c.size chunk_left, (s.i + 1) * c.size chunk_right from generate_series(0, " + num + " - 1) s(i), " +
+		"(select greatest(ceil(pg_relation_size('" + strings.ReplaceAll(t.Quoted(), "'", "''") + "'::regclass) / " +
+		"current_setting('block_size')::numeric / " + num + "), 1)::bigint size) c order by s.i"
+}
+
+// ChunkWhere Generate the condition of the ctid in the block range, the last of which has no right bound for the blocks added after splitting
+func (t *Table) ChunkWhere(left, right string, last bool) string {
+	if last {
+		return "ctid >= '(" + left + ",0)'::tid"
+	}
+	return "ctid >= '(" + left + ",0)'::tid and ctid < '(" + right + ",0)'::tid"
+}
+
 // AddField adds a new column to the table.
 func (t *Table) AddField(baseField *database.BaseField) {
 	f := NewField(baseField)
@@ -152,3 +217,10 @@ func (ci *UpsetParam) Query(records []element.Record) (query string, err error)
 	}
 	return query + " " + upsertSQL, nil
 }
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
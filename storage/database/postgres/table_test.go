@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"net"
 	"reflect"
 	"testing"
@@ -49,6 +50,236 @@ func TestTable_Quoted(t *testing.T) {
 	}
 }
 
+func TestTable_AddColumnSQL(t *testing.T) {
+	type args struct {
+		column string
+		typ    string
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "id",
+				typ:    "bigint",
+			},
+			want: `alter table "schema"."table" add "id" bigint`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.AddColumnSQL(tt.args.column, tt.args.typ); got != tt.want {
+				t.Errorf("Table.AddColumnSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// driverColumnType column type reported by lib/pq v1.10.9 for the column of the type oid with the type modifier mod
+type driverColumnType struct {
+	mockColumnType
+
+	oid oid.Oid
+	mod int
+}
+
+func newDriverColumnType(o oid.Oid, mod int) *driverColumnType {
+	return &driverColumnType{
+		mockColumnType: mockColumnType{name: oid.TypeName[o]},
+		oid:            o,
+		mod:            mod,
+	}
+}
+
+// Length the same as ColumnTypeLength of lib/pq
+func (d *driverColumnType) Length() (int64, bool) {
+	switch d.oid {
+	case oid.T_text, oid.T_bytea:
+		return math.MaxInt64, true
+	case oid.T_varchar, oid.T_bpchar:
+		return int64(d.mod - 4), true
+	}
+	return 0, false
+}
+
+// DecimalSize the same as ColumnTypePrecisionScale of lib/pq
+func (d *driverColumnType) DecimalSize() (int64, int64, bool) {
+	switch d.oid {
+	case oid.T_numeric, oid.T__numeric:
+		mod := d.mod - 4
+		return int64((mod >> 16) & 0xffff), int64(mod & 0xffff), true
+	}
+	return 0, 0, false
+}
+
+func TestTable_TypeDefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     database.ColumnType
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			typ:  newDriverColumnType(oid.T_varchar, 24),
+			want: "VARCHAR(20)",
+		},
+		{
+			name: "2",
+			typ:  newDriverColumnType(oid.T_varchar, -1),
+			want: "VARCHAR",
+		},
+		{
+			name: "3",
+			typ:  newDriverColumnType(oid.T_numeric, 10<<16|2+4),
+			want: "NUMERIC(10,2)",
+		},
+		{
+			name: "4",
+			typ:  newDriverColumnType(oid.T_numeric, -1),
+			want: "NUMERIC",
+		},
+		{
+			name: "5",
+			typ:  newDriverColumnType(oid.T_text, -1),
+			want: "TEXT",
+		},
+		{
+			name: "6",
+			typ:  newDriverColumnType(oid.T__numeric, 10<<16|2+4),
+			want: "NUMERIC(10,2)[]",
+		},
+		{
+			name: "7",
+			typ:  newDriverColumnType(oid.T__int4, -1),
+			want: "INT4[]",
+		},
+		{
+			name: "8",
+			typ:  newDriverColumnType(oid.T_timestamptz, 6),
+			want: "TIMESTAMPTZ",
+		},
+		{
+			name:    "9",
+			typ:     newDriverColumnType(oid.Oid(16385), -1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTable(database.NewBaseTable("db", "schema", "table")).TypeDefinition(tt.typ)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.TypeDefinition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Table.TypeDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SampleQuery(t *testing.T) {
+	type args struct {
+		column string
+		where  string
+		n      int
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: `"id"`,
+				n:      100,
+			},
+			want: `select "id" from "schema"."table" tablesample system ((select case when reltuples > 0 then least(100 * 100 / reltuples, 100) ` +
+				`else least(100 * 100 / greatest((select count(*) from "schema"."table"), 1), 100) end ` +
+				`from pg_class where oid = '"schema"."table"'::regclass)) limit 100`,
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: `"id"`,
+				where:  "a = 1",
+				n:      100,
+			},
+			want: `select "id" from "schema"."table" tablesample system ((select case when reltuples > 0 then least(100 * 100 / reltuples, 100) ` +
+				`else least(100 * 100 / greatest((select count(*) from "schema"."table"), 1), 100) end ` +
+				`from pg_class where oid = '"schema"."table"'::regclass)) where a = 1 limit 100`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.SampleQuery(tt.args.column, tt.args.where, tt.args.n); got != tt.want {
+				t.Errorf("Table.SampleQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_ChunkQuery(t *testing.T) {
+	tr := NewTable(database.NewBaseTable("db", "schema", "table"))
+	want := "select s.i * c.size chunk_left, (s.i + 1) * c.size chunk_right from generate_series(0, 4 - 1) s(i), " +
+		"(select greatest(ceil(pg_relation_size('\"schema\".\"table\"'::regclass) / " +
+		"current_setting('block_size')::numeric / 4), 1)::bigint size) c order by s.i"
+	if got := tr.ChunkQuery("a = 1", 4); got != want {
+		t.Errorf("Table.ChunkQuery() = %v, want %v", got, want)
+	}
+}
+
+func TestTable_ChunkWhere(t *testing.T) {
+	type args struct {
+		left  string
+		right string
+		last  bool
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				left:  "0",
+				right: "100",
+			},
+			want: "ctid >= '(0,0)'::tid and ctid < '(100,0)'::tid",
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				left:  "0",
+				right: "100",
+				last:  true,
+			},
+			want: "ctid >= '(0,0)'::tid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.ChunkWhere(tt.args.left, tt.args.right, tt.args.last); got != tt.want {
+				t.Errorf("Table.ChunkWhere() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTable_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -238,7 +469,7 @@ func TestCopyInParam_Agrs(t *testing.T) {
 				t: NewTable(database.NewBaseTable("db", "schema", "table")),
 				fields: []*database.BaseField{
 					database.NewBaseField(0,
-						"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T__bool]))),
+						"f1", NewFieldType(newMockColumnType(oid.TypeName[oid.T_point]))),
 					database.NewBaseField(0,
 						"f2", NewFieldType(newMockColumnType(oid.TypeName[oid.T_numeric]))),
 					database.NewBaseField(0,
@@ -546,3 +777,17 @@ func TestUpsetParam_Query(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_CatalogQuery(t *testing.T) {
+	query, args := NewTable(database.NewBaseTable("db", "schema", "table")).CatalogQuery()
+	want := "select column_name, is_nullable, column_default, character_maximum_length," +
+		" case when data_type = 'numeric' then numeric_precision end," +
+		" case when data_type = 'numeric' then numeric_scale end" +
+		" from information_schema.columns where table_schema = $1 and table_name = $2"
+	if query != want {
+		t.Errorf("CatalogQuery() query = %v, want %v", query, want)
+	}
+	if wantArgs := []any{"schema", "table"}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("CatalogQuery() args = %v, want %v", args, wantArgs)
+	}
+}
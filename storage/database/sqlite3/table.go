@@ -17,6 +17,7 @@ package sqlite3
 import (
 	"database/sql"
 	"database/sql/driver"
+	"strconv"
 
 	"github.com/Breeze0806/go-etl/storage/database"
 	sqlite3 "github.com/mattn/go-sqlite3"
@@ -45,6 +46,19 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add " + Quoted(column) + " " + typ
+}
+
+// SampleQuery Generate the query sampling about n values of the column without sorting the rows in random order, each of which
+// is kept in the probability of n divided by the number of the rows matching the condition where by the remainder of random()
+func (t *Table) SampleQuery(column, where string, n int) string {
+	num := strconv.Itoa(n)
+	return "select " + column + " from " + t.Quoted() + " where random() % (select max(count(*) / " + num + ", 1) from " +
+		t.Quoted() + sampleWhere(where) + ") = 0" + sampleAnd(where) + " limit " + num
+}
+
 // AddField adds a new column to the table.
 func (t *Table) AddField(baseField *database.BaseField) {
 	f := NewField(baseField)
@@ -75,3 +89,17 @@ func (t *Table) ShouldOneByOne(err error) bool {
 	}
 	return false
 }
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
+
+func sampleAnd(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " and (" + where + ")"
+}
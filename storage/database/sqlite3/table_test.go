@@ -46,6 +46,77 @@ func TestTable_Quoted(t *testing.T) {
 	}
 }
 
+func TestTable_AddColumnSQL(t *testing.T) {
+	type args struct {
+		column string
+		typ    string
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("", "", "table")),
+			args: args{
+				column: "id",
+				typ:    "bigint",
+			},
+			want: `alter table "table" add "id" bigint`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.AddColumnSQL(tt.args.column, tt.args.typ); got != tt.want {
+				t.Errorf("Table.AddColumnSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SampleQuery(t *testing.T) {
+	type args struct {
+		column string
+		where  string
+		n      int
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("", "", "table")),
+			args: args{
+				column: `"id"`,
+				n:      100,
+			},
+			want: `select "id" from "table" where random() % (select max(count(*) / 100, 1) from "table") = 0 limit 100`,
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("", "", "table")),
+			args: args{
+				column: `"id"`,
+				where:  "a = 1",
+				n:      100,
+			},
+			want: `select "id" from "table" where random() % (select max(count(*) / 100, 1) from "table" where a = 1) = 0 and (a = 1) limit 100`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.SampleQuery(tt.args.column, tt.args.where, tt.args.n); got != tt.want {
+				t.Errorf("Table.SampleQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTable_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -24,10 +24,12 @@ import (
 )
 
 var (
-	dateLayout     = element.DefaultTimeFormat[:10]
-	datetimeLayout = element.DefaultTimeFormat[:26]
+	dateLayout = element.TimeLayout(element.TimeKindDate, 0)
 )
 
+// defaultTimePrecision - Default precision of fractional seconds of time, datetime2 and datetimeoffset
+const defaultTimePrecision = 7
+
 // Field - Represents a field in a database table.
 type Field struct {
 	database.BaseConfigSetter
@@ -67,11 +69,25 @@ func (f *Field) Scanner() database.Scanner {
 	return NewScanner(f)
 }
 
-// Valuer - Handles data processing using GoValuer.
+// Valuer - Handles data processing using Valuer.
 func (f *Field) Valuer(c element.Column) database.Valuer {
 	return NewValuer(f, c)
 }
 
+// timePrecision - Precision of fractional seconds of the time types, which is the scale of time, datetime2 and datetimeoffset
+func (f *Field) timePrecision() int {
+	switch f.FieldType().DatabaseTypeName() {
+	case "SMALLDATETIME":
+		return 0
+	case "DATETIME":
+		return 3
+	}
+	if _, scale, ok := f.FieldType().DecimalSize(); ok {
+		return int(scale)
+	}
+	return defaultTimePrecision
+}
+
 // FieldType - Represents the type of a field.
 type FieldType struct {
 	*database.BaseFieldType
@@ -190,16 +206,38 @@ func (s *Scanner) Scan(src any) (err error) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(dateLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data), element.NewStringTimeDecoder(dateLayout))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case "SMALLDATETIME", "DATETIME", "DATETIME2":
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data),
+				element.NewStringTimeDecoder(element.TimeLayout(element.TimeKindDateTime, s.f.timePrecision())))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
-	case "SMALLDATETIME", "DATETIME", "DATETIME2", "TIME", "DATETIMEOFFSET":
+	case "TIME":
 		switch data := src.(type) {
 		case nil:
 			cv = element.NewNilTimeColumnValue()
 		case time.Time:
-			cv = element.NewTimeColumnValueWithDecoder(data, element.NewStringTimeDecoder(datetimeLayout))
+			cv = element.NewTimeColumnValueWithDecoder(s.f.LocalTime(data),
+				element.NewStringTimeDecoder(element.TimeLayout(element.TimeKindTime, s.f.timePrecision())))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+	case "DATETIMEOFFSET":
+		// the offset is preserved
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			cv = element.NewTimeColumnValueWithDecoder(data,
+				element.NewStringTimeDecoder(element.TimeLayout(element.TimeKindDateTime, s.f.timePrecision())+element.DefaultTimeFormat[29:]))
 		default:
 			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
 		}
@@ -237,5 +275,18 @@ func (v *Valuer) Value() (driver.Value, error) {
 		}
 	}
 
+	switch v.f.Type().DatabaseTypeName() {
+	case "TIME":
+		// The time of day is passed with the date 0001-01-01, since the date of time-of-day values such as 0000-01-01 may be out of range
+		val, err := database.NewWallTimeValuer(v.f, v.c, v.f.Location(), "").Value()
+		if t, ok := val.(time.Time); ok {
+			return time.Date(1, 1, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+		}
+		return val, err
+	case "SMALLDATETIME", "DATETIME", "DATETIME2", "DATE":
+		if loc := v.f.Location(); loc != nil {
+			return database.NewWallTimeValuer(v.f, v.c, loc, "").Value()
+		}
+	}
 	return database.NewGoValuer(v.f, v.c).Value()
 }
@@ -23,15 +23,30 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/storage/database/internal/testutil"
 )
 
 type mockFieldType struct {
-	name string
+	name  string
+	scale int64
+}
+
+func testFieldWithConfig(f *Field, conf *config.JSON) *Field {
+	f.SetConfig(conf)
+	return f
 }
 
 func newMockFieldType(name string) *mockFieldType {
 	return &mockFieldType{
-		name: name,
+		name:  name,
+		scale: -1,
+	}
+}
+
+func newMockTimeFieldType(name string, scale int64) *mockFieldType {
+	return &mockFieldType{
+		name:  name,
+		scale: scale,
 	}
 }
 
@@ -48,7 +63,10 @@ func (m *mockFieldType) Length() (length int64, ok bool) {
 }
 
 func (m *mockFieldType) DecimalSize() (precision, scale int64, ok bool) {
-	return
+	if m.scale < 0 {
+		return
+	}
+	return 0, m.scale, true
 }
 
 func (m *mockFieldType) Nullable() (nullable, ok bool) {
@@ -600,7 +618,7 @@ func TestScanner_Scan(t *testing.T) {
 			want: element.NewDefaultColumn(
 				element.NewTimeColumnValueWithDecoder(
 					time.Date(2022, 9, 4, 14, 56, 0, 0, time.Local),
-					element.NewStringTimeDecoder(datetimeLayout)), "test",
+					element.NewStringTimeDecoder("2006-01-02 15:04:05")), "test",
 				element.ByteSize(time.Date(2022, 9, 4, 14, 56, 0, 0, time.Local))),
 		},
 		{
@@ -611,6 +629,44 @@ func TestScanner_Scan(t *testing.T) {
 			},
 			want: element.NewDefaultColumn(element.NewNilTimeColumnValue(), "test", 0),
 		},
+		{
+			name: "DATETIMETimezone",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("DATETIME")))),
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			args: args{
+				src: time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(time.Date(2021, 1, 13, 18, 43, 12, 0, testutil.LoadLocation("Asia/Shanghai")), element.NewStringTimeDecoder("2006-01-02 15:04:05.999")),
+				"test", element.ByteSize(time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC))),
+		},
+		{
+			name: "DATETIMEOFFSET",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("DATETIMEOFFSET")))),
+			conf: testJSONFromString(`{"timezone":"Asia/Shanghai"}`),
+			args: args{
+				src: time.Date(2021, 1, 13, 18, 43, 12, 0, time.FixedZone("", 3600)),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(time.Date(2021, 1, 13, 18, 43, 12, 0, time.FixedZone("", 3600)), element.NewStringTimeDecoder("2006-01-02 15:04:05.9999999Z07:00")),
+				"test", element.ByteSize(time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC))),
+		},
+		{
+			name: "DATETIME2",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockTimeFieldType("DATETIME2", 7)))),
+			args: args{
+				src: time.Date(2021, 1, 13, 18, 43, 12, 123456700, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(time.Date(2021, 1, 13, 18, 43, 12, 123456700, time.UTC), element.NewStringTimeDecoder("2006-01-02 15:04:05.9999999")),
+				"test", element.ByteSize(time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC))),
+		},
+		{
+			name: "TIME",
+			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockTimeFieldType("TIME", 3)))),
+			args: args{
+				src: time.Date(1, 1, 1, 18, 43, 12, 123000000, time.UTC),
+			},
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(time.Date(1, 1, 1, 18, 43, 12, 123000000, time.UTC), element.NewStringTimeDecoder("15:04:05.999")),
+				"test", element.ByteSize(time.Date(1, 1, 1, 18, 43, 12, 0, time.UTC))),
+		},
 		{
 			name: "DATETIME2err",
 			s:    NewScanner(NewField(database.NewBaseField(0, "test", newMockFieldType("DATETIME2")))),
@@ -663,6 +719,38 @@ func TestValuer_Value(t *testing.T) {
 				element.NewDefaultColumn(element.NewNilBoolColumnValue(), "", 0)),
 			want: nil,
 		},
+		{
+			name: "3",
+			v: NewValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("DATETIME2"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+				element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC)), "", 0)),
+			want: time.Date(2021, 1, 14, 2, 43, 12, 0, testutil.LoadLocation("Asia/Shanghai")),
+		},
+		{
+			name: "4",
+			v: NewValuer(testFieldWithConfig(NewField(database.NewBaseField(0, "f1", newMockFieldType("DATETIMEOFFSET"))),
+				testJSONFromString(`{"timezone":"Asia/Shanghai"}`)),
+				element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC)), "", 0)),
+			want: time.Date(2021, 1, 13, 18, 43, 12, 0, time.UTC),
+		},
+		{
+			name: "5",
+			v: NewValuer(NewField(database.NewBaseField(0, "f1", newMockFieldType("TIME"))),
+				element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(0, 1, 1, 18, 43, 12, 100, time.UTC)), "", 0)),
+			want: time.Date(1, 1, 1, 18, 43, 12, 100, time.UTC),
+		},
+		{
+			name: "6",
+			v: NewValuer(NewField(database.NewBaseField(0, "f1", newMockFieldType("TIME"))),
+				element.NewDefaultColumn(element.NewNilTimeColumnValue(), "", 0)),
+			want: nil,
+		},
+		{
+			name: "7",
+			v: NewValuer(NewField(database.NewBaseField(0, "f1", newMockFieldType("TIME"))),
+				element.NewDefaultColumn(element.NewBoolColumnValue(true), "", 0)),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
@@ -53,6 +54,30 @@ func (t *Table) String() string {
 	return t.Quoted()
 }
 
+// AddColumnSQL Generate the statement adding the column of the type typ to the table
+func (t *Table) AddColumnSQL(column, typ string) string {
+	return "alter table " + t.Quoted() + " add " + Quoted(column) + " " + typ
+}
+
+// LimitClause Generate the clause following order by that limits the number of rows queried to n
+func (t *Table) LimitClause(n int) string {
+	return "offset 0 rows fetch next " + strconv.Itoa(n) + " rows only"
+}
+
+// CatalogQuery Generate the query of the columns of the table in information_schema.columns of its database,
+// whose maximum length of characters is -1 for the types like varchar(max)
+func (t *Table) CatalogQuery() (string, []any) {
+	return "select column_name, is_nullable, column_default, character_maximum_length," +
+		" case when data_type in ('decimal', 'numeric') then numeric_precision end," +
+		" case when data_type in ('decimal', 'numeric') then numeric_scale end" +
+		" from " + Quoted(t.Instance()) + ".information_schema.columns where table_schema = @p1 and table_name = @p2", []any{t.Schema(), t.Name()}
+}
+
+// SampleQuery Generate the query sampling about n values of the column by the pages of tablesample
+func (t *Table) SampleQuery(column, where string, n int) string {
+	return "select " + column + " from " + t.Quoted() + " tablesample (" + strconv.Itoa(n) + " rows)" + sampleWhere(where)
+}
+
 // AddField adds a new column to the table.
 func (t *Table) AddField(baseField *database.BaseField) {
 	f := NewField(baseField)
@@ -138,3 +163,10 @@ func (ci *CopyInParam) Agrs(records []element.Record) (valuers []any, err error)
 	}
 	return
 }
+
+func sampleWhere(where string) string {
+	if where == "" {
+		return ""
+	}
+	return " where " + where
+}
@@ -48,6 +48,100 @@ func TestTable_Quoted(t *testing.T) {
 	}
 }
 
+func TestTable_AddColumnSQL(t *testing.T) {
+	type args struct {
+		column string
+		typ    string
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "id",
+				typ:    "bigint",
+			},
+			want: `alter table [db].[schema].[table] add [id] bigint`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.AddColumnSQL(tt.args.column, tt.args.typ); got != tt.want {
+				t.Errorf("Table.AddColumnSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_SampleQuery(t *testing.T) {
+	type args struct {
+		column string
+		where  string
+		n      int
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "[id]",
+				n:      100,
+			},
+			want: `select [id] from [db].[schema].[table] tablesample (100 rows)`,
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				column: "[id]",
+				where:  "a = 1",
+				n:      100,
+			},
+			want: `select [id] from [db].[schema].[table] tablesample (100 rows) where a = 1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.SampleQuery(tt.args.column, tt.args.where, tt.args.n); got != tt.want {
+				t.Errorf("Table.SampleQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_LimitClause(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   *Table
+		n    int
+		want string
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			n:    1000,
+			want: "offset 0 rows fetch next 1000 rows only",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.LimitClause(tt.n); got != tt.want {
+				t.Errorf("Table.LimitClause() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTable_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -491,3 +585,17 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_CatalogQuery(t *testing.T) {
+	query, args := NewTable(database.NewBaseTable("db", "schema", "table")).CatalogQuery()
+	want := "select column_name, is_nullable, column_default, character_maximum_length," +
+		" case when data_type in ('decimal', 'numeric') then numeric_precision end," +
+		" case when data_type in ('decimal', 'numeric') then numeric_scale end" +
+		" from [db].information_schema.columns where table_schema = @p1 and table_name = @p2"
+	if query != want {
+		t.Errorf("CatalogQuery() query = %v, want %v", query, want)
+	}
+	if wantArgs := []any{"schema", "table"}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("CatalogQuery() args = %v, want %v", args, wantArgs)
+	}
+}
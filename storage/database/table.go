@@ -58,11 +58,65 @@ type FieldsFetcher interface {
 	FetchFields(ctx context.Context, db *DB) error // Get specific column
 }
 
+// ParamFieldsFetcher Supplementary method for Table, used to specially fetch the columns queried by the SQL parameter
+type ParamFieldsFetcher interface {
+	FetchFieldsWithParam(ctx context.Context, db *DB, param Parameter) error // Get the columns queried by param
+}
+
 // FieldAdder Supplementary method for Table, used to add columns to a table
 type FieldAdder interface {
 	AddField(*BaseField) // Add specific column
 }
 
+// FieldsRetainer Supplementary method for Table, used to retain only some of the columns of a table
+type FieldsRetainer interface {
+	RetainFields(retain func(Field) bool) // Retain the columns for which retain returns true
+}
+
+// ColumnAdder Supplementary method for Table, used to generate the statement adding a column of the type typ to a table
+type ColumnAdder interface {
+	AddColumnSQL(column, typ string) string // Generate the statement adding a column
+}
+
+// ColumnTypeDefiner Supplementary method for Table, used to get the type definition in statements like alter table add
+// from the column type reported by the driver of the same database, which is the database type name with the length,
+// precision and scale reported if not implemented. An error is returned if the definition can not be known from the column type
+type ColumnTypeDefiner interface {
+	TypeDefinition(typ ColumnType) (string, error) // Get the type definition of the column type
+}
+
+// ColumnCataloger Supplementary method for Table, used to generate the query of the columns of a table in the catalog of the database,
+// such as information_schema.columns, whose rows are scanned by ScanColumnCatalog. The metadata of the columns reported by the driver is
+// used if not implemented, which may be incomplete, such as no nullable by lib/pq, no length by go-sql-driver/mysql and no default by all
+type ColumnCataloger interface {
+	CatalogQuery() (query string, args []any) // Generate the query of the columns in the catalog
+}
+
+// Limiter Supplementary method for Table, used to generate the clause following order by that limits the number of rows queried to n,
+// which is limit n if not implemented
+type Limiter interface {
+	LimitClause(n int) string // Generate the clause limiting the number of rows
+}
+
+// Sampler Supplementary method for Table, used to generate the query randomly sampling about n values of the column
+// from the rows matching the condition where, which is empty if there is no condition
+type Sampler interface {
+	SampleQuery(column, where string, n int) string // Generate the query sampling the column
+}
+
+// Chunker Supplementary method for Table, used to split a table into about n physical chunks, such as ROWID ranges or blocks,
+// whose rows queried by ChunkQuery have the left and right bounds of the chunks in order
+type Chunker interface {
+	ChunkQuery(where string, n int) string           // Generate the query of the bounds of the chunks of the rows matching the condition where
+	ChunkWhere(left, right string, last bool) string // Generate the condition of the chunk, the last of which may have no right bound
+}
+
+// ChunkFallbacker Supplementary method for Chunker, used to generate the query of the bounds of the chunks in the same form as ChunkQuery
+// when the query of ChunkQuery fails with err, such as no privilege on the catalog, and ok is false if it can not fall back from err
+type ChunkFallbacker interface {
+	ChunkFallbackQuery(where string, n int, err error) (query string, ok bool) // Generate the query of the bounds of the chunks falling back from err
+}
+
 // ExecParameter Supplementary method for Table, used to get the method to generate SQL statements for write mode
 type ExecParameter interface {
 	ExecParam(string, *sql.TxOptions) (Parameter, bool)
@@ -122,6 +176,17 @@ func (b *BaseTable) AppendField(f Field) {
 	b.fields = append(b.fields, f)
 }
 
+// RetainFields Retain the columns for which retain returns true in order
+func (b *BaseTable) RetainFields(retain func(Field) bool) {
+	fields := b.fields[:0]
+	for _, f := range b.fields {
+		if retain(f) {
+			fields = append(fields, f)
+		}
+	}
+	b.fields = fields
+}
+
 // BaseParam Basic parameters, used to embed SQL parameters for various databases
 type BaseParam struct {
 	table  Table
@@ -136,6 +136,58 @@ func TestBaseTable_Fields(t *testing.T) {
 	}
 }
 
+func TestBaseTable_RetainFields(t *testing.T) {
+	newField := func(name string) Field {
+		return newMockField(NewBaseField(1, name, NewBaseFieldType(&sql.ColumnType{})), NewBaseFieldType(&sql.ColumnType{}))
+	}
+	tests := []struct {
+		name   string
+		fields []string
+		retain func(Field) bool
+		want   []string
+	}{
+		{
+			name:   "1",
+			fields: []string{"a", "b", "c"},
+			retain: func(f Field) bool {
+				return f.Name() != "b"
+			},
+			want: []string{"a", "c"},
+		},
+		{
+			name:   "2",
+			fields: []string{"a", "b"},
+			retain: func(f Field) bool {
+				return false
+			},
+		},
+		{
+			name:   "3",
+			fields: []string{"a", "b"},
+			retain: func(f Field) bool {
+				return true
+			},
+			want: []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBaseTable("", "", "")
+			for _, v := range tt.fields {
+				b.AppendField(newField(v))
+			}
+			b.RetainFields(tt.retain)
+			var got []string
+			for _, f := range b.Fields() {
+				got = append(got, f.Name())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BaseTable.RetainFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBaseParam_Table(t *testing.T) {
 	tests := []struct {
 		name string
@@ -15,26 +15,105 @@
 package compress
 
 import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
 	"compress/gzip"
 	"fmt"
 	"io"
-	"os"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
 )
 
+// File is the file read by a read closer, such as *os.File
+type File interface {
+	io.Reader
+	io.ReaderAt
+	Name() string
+	Stat() (fs.FileInfo, error)
+}
+
+// WriteFile is the file written by a write closer, such as *os.File
+type WriteFile interface {
+	io.Writer
+	Name() string
+}
+
 // Type represents the compression type
 type Type string
 
 // CompressionTypeEnum enumerates the compression types
 const (
-	TypeNone    Type = ""
-	TypeTarGzip Type = "targz"
-	TypeTar     Type = "tar"
+	TypeAuto    Type = ""      // detected by magic bytes when reading and by file extension when writing
+	TypeNone    Type = "none"  // no compression
+	TypeTarGzip Type = "targz" // tar.gz, not supported yet
+	TypeTar     Type = "tar"   // tar, not supported yet
 	TypeZip     Type = "zip"
 	TypeGzip    Type = "gz"
+	TypeZstd    Type = "zst"
+	TypeBzip2   Type = "bz2" // read only
+	TypeXz      Type = "xz"
+	TypeLz4     Type = "lz4"
 )
 
-// ReadCloser retrieves a read closer
-func (c Type) ReadCloser(f *os.File) (r io.ReadCloser, err error) {
+var (
+	magics = []struct {
+		typ   Type
+		magic []byte
+	}{
+		{TypeGzip, []byte{0x1f, 0x8b}},
+		{TypeZip, []byte{0x50, 0x4b, 0x03, 0x04}},
+		{TypeZstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+		{TypeBzip2, []byte{0x42, 0x5a, 0x68}},
+		{TypeXz, []byte{0xfd, 0x37, 0x7a, 0x58, 0x5a, 0x00}},
+		{TypeLz4, []byte{0x04, 0x22, 0x4d, 0x18}},
+	}
+
+	extensions = map[string]Type{
+		".zip":  TypeZip,
+		".gz":   TypeGzip,
+		".gzip": TypeGzip,
+		".zst":  TypeZstd,
+		".zstd": TypeZstd,
+		".bz2":  TypeBzip2,
+		".xz":   TypeXz,
+		".lz4":  TypeLz4,
+	}
+)
+
+// Detect detects the compression type by the magic bytes at the beginning
+// of the file header first, and then by the extension of filename.
+// TypeNone is returned when neither of them matches.
+func Detect(filename string, header []byte) Type {
+	for _, v := range magics {
+		if bytes.HasPrefix(header, v.magic) {
+			return v.typ
+		}
+	}
+	return DetectByFilename(filename)
+}
+
+// DetectByFilename detects the compression type by the extension of filename.
+// TypeNone is returned when the extension is unknown.
+func DetectByFilename(filename string) Type {
+	if c, ok := extensions[strings.ToLower(filepath.Ext(filename))]; ok {
+		return c
+	}
+	return TypeNone
+}
+
+// ReadCloser retrieves a read closer, TypeAuto detects the compression
+// type by the magic bytes of f
+func (c Type) ReadCloser(f File) (r io.ReadCloser, err error) {
+	if c == TypeAuto {
+		if c, err = detectFile(f); err != nil {
+			return
+		}
+	}
 	switch c {
 	case TypeNone:
 		r = NewNoneReadCloser(f)
@@ -45,13 +124,78 @@ func (c Type) ReadCloser(f *os.File) (r io.ReadCloser, err error) {
 	case TypeGzip:
 		r, err = NewGzipReadCloser(f)
 		return
+	case TypeZstd:
+		r, err = NewZstdReadCloser(f)
+		return
+	case TypeBzip2:
+		r = NewBzip2ReadCloser(f)
+		return
+	case TypeXz:
+		r, err = NewXzReadCloser(f)
+		return
+	case TypeLz4:
+		r = NewLz4ReadCloser(f)
+		return
 	}
 	err = fmt.Errorf("unsupported type %v", c)
 	return
 }
 
-// WriteCloser retrieves a write closer
-func (c Type) WriteCloser(f *os.File) (w io.WriteCloser, err error) {
+// StreamReadCloser retrieves a read closer of the stream r that cannot seek, such as standard input.
+// TypeAuto detects the compression type by the magic bytes peeked from r, and zip is not supported
+// because it has to read the central directory at the end
+func (c Type) StreamReadCloser(r io.Reader) (rc io.ReadCloser, err error) {
+	br := bufio.NewReader(r)
+	if c == TypeAuto {
+		header, _ := br.Peek(8)
+		c = Detect("", header)
+	}
+	switch c {
+	case TypeNone:
+		rc = &ReadCloser{Reader: br}
+		return
+	case TypeGzip:
+		r := &ReadCloser{}
+		if r.Reader, err = gzip.NewReader(br); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case TypeZstd:
+		var d *zstd.Decoder
+		if d, err = zstd.NewReader(br); err != nil {
+			return nil, err
+		}
+		rc = &ReadCloser{
+			Reader: d,
+			close: func() error {
+				d.Close()
+				return nil
+			},
+		}
+		return
+	case TypeBzip2:
+		rc = &ReadCloser{Reader: bzip2.NewReader(br)}
+		return
+	case TypeXz:
+		r := &ReadCloser{}
+		if r.Reader, err = xz.NewReader(br); err != nil {
+			return nil, err
+		}
+		return r, nil
+	case TypeLz4:
+		rc = &ReadCloser{Reader: NewLz4Reader(br)}
+		return
+	}
+	err = fmt.Errorf("unsupported type %v of stream", c)
+	return
+}
+
+// WriteCloser retrieves a write closer, TypeAuto detects the compression
+// type by the extension of the name of f
+func (c Type) WriteCloser(f WriteFile) (w io.WriteCloser, err error) {
+	if c == TypeAuto {
+		c = DetectByFilename(f.Name())
+	}
 	switch c {
 	case TypeNone:
 		w = NewNoneWriter(f)
@@ -62,14 +206,34 @@ func (c Type) WriteCloser(f *os.File) (w io.WriteCloser, err error) {
 	case TypeGzip:
 		w = NewGzipWriter(f)
 		return
+	case TypeZstd:
+		w, err = NewZstdWriter(f)
+		return
+	case TypeXz:
+		w, err = NewXzWriter(f)
+		return
+	case TypeLz4:
+		w = NewLz4Writer(f)
+		return
 	}
 	err = fmt.Errorf("unsupported type %v", c)
 	return
 }
 
+func detectFile(f File) (c Type, err error) {
+	header := make([]byte, 8)
+	var n int
+	if n, err = f.ReadAt(header, 0); err != nil && err != io.EOF {
+		return
+	}
+	return Detect(f.Name(), header[:n]), nil
+}
+
 // ReadCloser is a read closer
 type ReadCloser struct {
 	io.Reader
+
+	close func() error
 }
 
 // Read reads 'p'
@@ -79,18 +243,21 @@ func (r *ReadCloser) Read(p []byte) (n int, err error) {
 
 // Close closes the connection
 func (r *ReadCloser) Close() error {
+	if r.close != nil {
+		return r.close()
+	}
 	return nil
 }
 
 // NewNoneReadCloser retrieves a non-compression read closer
-func NewNoneReadCloser(f *os.File) *ReadCloser {
+func NewNoneReadCloser(f io.Reader) *ReadCloser {
 	return &ReadCloser{
 		Reader: f,
 	}
 }
 
 // NewZipReadCloser retrieves a zip compression read closer
-func NewZipReadCloser(f *os.File) (r *ReadCloser, err error) {
+func NewZipReadCloser(f File) (r *ReadCloser, err error) {
 	r = &ReadCloser{}
 	if r.Reader, err = NewZipReader(f); err != nil {
 		return nil, err
@@ -99,7 +266,7 @@ func NewZipReadCloser(f *os.File) (r *ReadCloser, err error) {
 }
 
 // NewGzipReadCloser retrieves a gzip compression read closer
-func NewGzipReadCloser(f *os.File) (r *ReadCloser, err error) {
+func NewGzipReadCloser(f io.Reader) (r *ReadCloser, err error) {
 	r = &ReadCloser{}
 
 	if r.Reader, err = gzip.NewReader(f); err != nil {
@@ -108,13 +275,51 @@ func NewGzipReadCloser(f *os.File) (r *ReadCloser, err error) {
 	return
 }
 
+// NewZstdReadCloser retrieves a zstd compression read closer
+func NewZstdReadCloser(f io.Reader) (r *ReadCloser, err error) {
+	var d *zstd.Decoder
+	if d, err = zstd.NewReader(f); err != nil {
+		return nil, err
+	}
+	return &ReadCloser{
+		Reader: d,
+		close: func() error {
+			d.Close()
+			return nil
+		},
+	}, nil
+}
+
+// NewBzip2ReadCloser retrieves a bzip2 compression read closer
+func NewBzip2ReadCloser(f io.Reader) *ReadCloser {
+	return &ReadCloser{
+		Reader: bzip2.NewReader(f),
+	}
+}
+
+// NewXzReadCloser retrieves a xz compression read closer
+func NewXzReadCloser(f io.Reader) (r *ReadCloser, err error) {
+	r = &ReadCloser{}
+	if r.Reader, err = xz.NewReader(f); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// NewLz4ReadCloser retrieves a lz4 frame compression read closer
+func NewLz4ReadCloser(f io.Reader) *ReadCloser {
+	return &ReadCloser{
+		Reader: NewLz4Reader(f),
+	}
+}
+
 // NoneWriter is a non-compression writer
 type NoneWriter struct {
-	file *os.File
+	file io.Writer
 }
 
 // NewNoneWriter creates a non-compression writer
-func NewNoneWriter(f *os.File) (nw *NoneWriter) {
+func NewNoneWriter(f io.Writer) (nw *NoneWriter) {
 	return &NoneWriter{
 		file: f,
 	}
@@ -136,7 +341,7 @@ type GzipWriter struct {
 }
 
 // NewGzipWriter creates a gzip compression writer
-func NewGzipWriter(f *os.File) (gw *GzipWriter) {
+func NewGzipWriter(f io.Writer) (gw *GzipWriter) {
 	return &GzipWriter{
 		writer: gzip.NewWriter(f),
 	}
@@ -152,3 +357,51 @@ func (g *GzipWriter) Write(p []byte) (n int, err error) {
 func (g *GzipWriter) Close() error {
 	return g.writer.Close()
 }
+
+// ZstdWriter is a zstd compression writer
+type ZstdWriter struct {
+	writer *zstd.Encoder
+}
+
+// NewZstdWriter creates a zstd compression writer
+func NewZstdWriter(f io.Writer) (zw *ZstdWriter, err error) {
+	zw = &ZstdWriter{}
+	if zw.writer, err = zstd.NewWriter(f); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Write writes 'p'
+func (z *ZstdWriter) Write(p []byte) (n int, err error) {
+	return z.writer.Write(p)
+}
+
+// Close closes the writer
+func (z *ZstdWriter) Close() error {
+	return z.writer.Close()
+}
+
+// XzWriter is a xz compression writer
+type XzWriter struct {
+	writer *xz.Writer
+}
+
+// NewXzWriter creates a xz compression writer
+func NewXzWriter(f io.Writer) (xw *XzWriter, err error) {
+	xw = &XzWriter{}
+	if xw.writer, err = xz.NewWriter(f); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Write writes 'p'
+func (x *XzWriter) Write(p []byte) (n int, err error) {
+	return x.writer.Write(p)
+}
+
+// Close closes the writer
+func (x *XzWriter) Close() error {
+	return x.writer.Close()
+}
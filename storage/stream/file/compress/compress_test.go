@@ -60,6 +60,51 @@ func TestType_ReadWrite(t *testing.T) {
 			},
 			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
 		},
+		{
+			name: "4",
+			c:    TypeZstd,
+			args: args{
+				filename: "a.zst",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "5",
+			c:    TypeXz,
+			args: args{
+				filename: "a.xz",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "6",
+			c:    TypeLz4,
+			args: args{
+				filename: "a.lz4",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "7",
+			c:    TypeAuto,
+			args: args{
+				filename: "b.zst",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "8",
+			c:    TypeAuto,
+			args: args{
+				filename: "b.csv",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -127,6 +172,60 @@ func TestType_ReadCloser(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "2",
+			c:    TypeZstd,
+			args: args{
+				filename: "a.zst",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "3",
+			c:    TypeBzip2,
+			args: args{
+				filename: "a.bz2",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "4",
+			c:    TypeXz,
+			args: args{
+				filename: "a.xz",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "5",
+			c:    TypeLz4,
+			args: args{
+				filename: "a.lz4",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "6",
+			c:    TypeAuto,
+			args: args{
+				filename: "a.bz2",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "7",
+			c:    TypeAuto,
+			args: args{
+				filename: "a.lz4",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,6 +252,95 @@ func TestType_ReadCloser(t *testing.T) {
 	}
 }
 
+// onlyReader hides the methods of the file other than Read, like a pipe
+type onlyReader struct {
+	io.Reader
+}
+
+func TestType_StreamReadCloser(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        Type
+		filename string
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			c:        TypeAuto,
+			filename: "a.zst",
+			want:     []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name:     "2",
+			c:        TypeAuto,
+			filename: "a.bz2",
+			want:     []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name:     "3",
+			c:        TypeAuto,
+			filename: "a.xz",
+			want:     []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name:     "4",
+			c:        TypeAuto,
+			filename: "a.lz4",
+			want:     []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name:     "5",
+			c:        TypeNone,
+			filename: "a.lz4",
+			want:     []byte{0x04, 0x22, 0x4d, 0x18},
+		},
+		{
+			name:     "6",
+			c:        TypeZip,
+			filename: "a.zip",
+			wantErr:  true,
+		},
+		{
+			name:     "7",
+			c:        TypeAuto,
+			filename: "a.zip",
+			wantErr:  true,
+		},
+		{
+			name:     "8",
+			c:        TypeGzip,
+			filename: "a.zst",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.filename))
+			if err != nil {
+				t.Fatalf("Open fail. err: %v", err)
+			}
+			defer f.Close()
+			r, err := tt.c.StreamReadCloser(onlyReader{f})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StreamReadCloser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			defer r.Close()
+			got := make([]byte, len(tt.want))
+			if _, err = io.ReadFull(r, got); err != nil {
+				t.Fatalf("ReadFull() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestType_WriteCloser(t *testing.T) {
 	type args struct {
 		f *os.File
@@ -170,6 +358,12 @@ func TestType_WriteCloser(t *testing.T) {
 			args:    args{},
 			wantErr: true,
 		},
+		{
+			name:    "2",
+			c:       TypeBzip2,
+			args:    args{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -184,3 +378,94 @@ func TestType_WriteCloser(t *testing.T) {
 		})
 	}
 }
+
+func TestDetect(t *testing.T) {
+	type args struct {
+		filename string
+		header   []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want Type
+	}{
+		{
+			name: "1",
+			args: args{
+				filename: "a.csv",
+				header:   []byte{0x1f, 0x8b, 0x08},
+			},
+			want: TypeGzip,
+		},
+		{
+			name: "2",
+			args: args{
+				filename: "a.csv",
+				header:   []byte{0x28, 0xb5, 0x2f, 0xfd, 0x04},
+			},
+			want: TypeZstd,
+		},
+		{
+			name: "3",
+			args: args{
+				filename: "a.csv",
+				header:   []byte("BZh91AY"),
+			},
+			want: TypeBzip2,
+		},
+		{
+			name: "4",
+			args: args{
+				filename: "a.csv",
+				header:   []byte{0xfd, 0x37, 0x7a, 0x58, 0x5a, 0x00, 0x00},
+			},
+			want: TypeXz,
+		},
+		{
+			name: "5",
+			args: args{
+				filename: "a.csv",
+				header:   []byte{0x04, 0x22, 0x4d, 0x18, 0x64},
+			},
+			want: TypeLz4,
+		},
+		{
+			name: "6",
+			args: args{
+				filename: "a.csv",
+				header:   []byte{0x50, 0x4b, 0x03, 0x04},
+			},
+			want: TypeZip,
+		},
+		{
+			name: "7",
+			args: args{
+				filename: "a.CSV.ZST",
+				header:   []byte("a,b"),
+			},
+			want: TypeZstd,
+		},
+		{
+			name: "8",
+			args: args{
+				filename: "a.csv",
+				header:   []byte("a,b"),
+			},
+			want: TypeNone,
+		},
+		{
+			name: "9",
+			args: args{
+				filename: "a.csv",
+			},
+			want: TypeNone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.args.filename, tt.args.header); got != tt.want {
+				t.Errorf("Detect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// Lz4Reader is a lz4 frame compression reader, which reads the concatenated
+// frames one after another, such as the files joined by cat
+type Lz4Reader struct {
+	src    *bufio.Reader
+	reader *lz4.Reader
+}
+
+// NewLz4Reader creates a lz4 frame compression reader
+func NewLz4Reader(f io.Reader) *Lz4Reader {
+	src := bufio.NewReader(f)
+	return &Lz4Reader{
+		src:    src,
+		reader: lz4.NewReader(src),
+	}
+}
+
+// Read reads 'p'
+func (l *Lz4Reader) Read(p []byte) (n int, err error) {
+	for {
+		if n, err = l.reader.Read(p); err != io.EOF {
+			return
+		}
+		if n > 0 {
+			return n, nil
+		}
+		// the next frame starts after the end of the frame
+		if _, err = l.src.Peek(1); err != nil {
+			return 0, err
+		}
+		l.reader.Reset(l.src)
+	}
+}
+
+// Lz4Writer is a lz4 frame compression writer
+type Lz4Writer struct {
+	writer *lz4.Writer
+}
+
+// NewLz4Writer creates a lz4 frame compression writer
+func NewLz4Writer(f io.Writer) *Lz4Writer {
+	return &Lz4Writer{
+		writer: lz4.NewWriter(f),
+	}
+}
+
+// Write writes 'p'
+func (l *Lz4Writer) Write(p []byte) (n int, err error) {
+	return l.writer.Write(p)
+}
+
+// Close closes the writer
+func (l *Lz4Writer) Close() error {
+	return l.writer.Close()
+}
@@ -0,0 +1,271 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testLz4Data(n int) []byte {
+	r := rand.New(rand.NewSource(1))
+	words := []string{"go-etl", "datax", "1234567890", ",", "\n", "abcdefghijklmnopqrstuvwxyz"}
+	var buf bytes.Buffer
+	for buf.Len() < n {
+		if r.Intn(10) == 0 {
+			buf.WriteByte(byte(r.Intn(256)))
+			continue
+		}
+		buf.WriteString(words[r.Intn(len(words))])
+	}
+	return buf.Bytes()[:n]
+}
+
+func TestLz4Writer_WriteRead(t *testing.T) {
+	tests := []struct {
+		name string
+		p    []byte
+	}{
+		{
+			name: "1",
+			p:    nil,
+		},
+		{
+			name: "2",
+			p:    []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "3",
+			p:    bytes.Repeat([]byte("a"), 3*64<<10+17),
+		},
+		{
+			name: "4",
+			p:    testLz4Data(5*64<<10 + 1234),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := NewLz4Writer(buf)
+			for p := tt.p; len(p) > 0; {
+				n := 1000
+				if n > len(p) {
+					n = len(p)
+				}
+				if _, err := w.Write(p[:n]); err != nil {
+					t.Fatalf("Write fail. err: %v", err)
+				}
+				p = p[n:]
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close fail. err: %v", err)
+			}
+			got, err := io.ReadAll(NewLz4Reader(buf))
+			if err != nil {
+				t.Fatalf("ReadAll fail. err: %v", err)
+			}
+			if !bytes.Equal(got, tt.p) {
+				t.Errorf("ReadAll() = %v, want %v", len(got), len(tt.p))
+			}
+		})
+	}
+}
+
+func TestLz4Reader_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			data:    []byte{0x01, 0x02, 0x03, 0x04},
+			wantErr: true,
+		},
+		{
+			name:    "2",
+			data:    []byte{0x04, 0x22, 0x4d, 0x18, 0x60},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			data: []byte{0x50, 0x2a, 0x4d, 0x18, 0x02, 0x00, 0x00, 0x00, 0xff, 0xff,
+				0x04, 0x22, 0x4d, 0x18, 0x60, 0x40, 0x82,
+				0x02, 0x00, 0x00, 0x80, 0x61, 0x62,
+				0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "4",
+			data: []byte{0x04, 0x22, 0x4d, 0x18, 0x60, 0x40, 0x82,
+				0x04, 0x00, 0x00, 0x00, 0x10, 0x61, 0x09, 0x00,
+				0x00, 0x00, 0x00, 0x00},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(NewLz4Reader(bytes.NewReader(tt.data)))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && string(got) != "ab" {
+				t.Errorf("ReadAll() = %s, want ab", got)
+			}
+		})
+	}
+}
+
+// testLz4Source is the content of the files in testdata/lz4, which are compressed by
+// the reference lz4 command line tool v1.9.4 with the options of their names:
+// default: no option, linked: -B4 -BD, blockcrc: -B4 -BX, nocrc: -B5 --no-frame-crc,
+// size: -B6 --content-size, hc: -9 -B4 -BD
+func testLz4Source() []byte {
+	var buf bytes.Buffer
+	for i := 0; i < 3500; i++ {
+		fmt.Fprintf(&buf, "%d,go-etl,datax,abcdefghijklmnopqrstuvwxyz\n", i)
+	}
+	return buf.Bytes()
+}
+
+func testLz4File(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", "lz4", name))
+	if err != nil {
+		t.Fatalf("ReadFile fail. err: %v", err)
+	}
+	return data
+}
+
+func TestLz4Reader_Reference(t *testing.T) {
+	src := testLz4Source()
+	tests := []struct {
+		name  string
+		files []string
+		want  []byte
+	}{
+		{
+			name:  "1",
+			files: []string{"default.lz4"},
+			want:  src,
+		},
+		{
+			name:  "2",
+			files: []string{"linked.lz4"},
+			want:  src,
+		},
+		{
+			name:  "3",
+			files: []string{"blockcrc.lz4"},
+			want:  src,
+		},
+		{
+			name:  "4",
+			files: []string{"nocrc.lz4"},
+			want:  src,
+		},
+		{
+			name:  "5",
+			files: []string{"size.lz4"},
+			want:  src,
+		},
+		{
+			name:  "6",
+			files: []string{"hc.lz4"},
+			want:  src,
+		},
+		{
+			name:  "7",
+			files: []string{"default.lz4", "blockcrc.lz4"},
+			want:  append(append([]byte{}, src...), src...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []byte
+			for _, v := range tt.files {
+				data = append(data, testLz4File(t, v)...)
+			}
+			got, err := io.ReadAll(NewLz4Reader(bytes.NewReader(data)))
+			if err != nil {
+				t.Fatalf("ReadAll fail. err: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("ReadAll() = %v, want %v", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestLz4Reader_Corrupted(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		offset func(n int) int
+	}{
+		{
+			name:   "1",
+			file:   "default.lz4",
+			offset: func(n int) int { return 6 },
+		},
+		{
+			name:   "2",
+			file:   "blockcrc.lz4",
+			offset: func(n int) int { return n / 2 },
+		},
+		{
+			name:   "3",
+			file:   "default.lz4",
+			offset: func(n int) int { return n - 1 },
+		},
+		{
+			name:   "4",
+			file:   "linked.lz4",
+			offset: func(n int) int { return n - 2 },
+		},
+		{
+			name:   "5",
+			file:   "size.lz4",
+			offset: func(n int) int { return 6 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testLz4File(t, tt.file)
+			data[tt.offset(len(data))] ^= 0x01
+			if _, err := io.ReadAll(NewLz4Reader(bytes.NewReader(data))); err == nil {
+				t.Errorf("ReadAll() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func TestLz4Reader_CorruptedLiteral(t *testing.T) {
+	// a literal is changed without breaking the block, which can be only found by the content checksum
+	buf := &bytes.Buffer{}
+	w := NewLz4Writer(buf)
+	w.Write([]byte("abcdefghijklmnopqrstuvwxyz"))
+	w.Close()
+	data := buf.Bytes()
+	i := bytes.IndexByte(data, 'm')
+	data[i] = 'M'
+	if _, err := io.ReadAll(NewLz4Reader(bytes.NewReader(data))); err == nil {
+		t.Errorf("ReadAll() error = nil, wantErr true")
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"archive/zip"
 	"io"
 	"io/fs"
-	"os"
 )
 
 // ZipReader - A reader for ZIP files.
@@ -29,7 +28,7 @@ type ZipReader struct {
 }
 
 // NewZipReader - Creates a ZIP reader using the file 'f'.
-func NewZipReader(f *os.File) (zr *ZipReader, err error) {
+func NewZipReader(f File) (zr *ZipReader, err error) {
 	var fi fs.FileInfo
 	fi, err = f.Stat()
 	if err != nil {
@@ -17,8 +17,7 @@ package compress
 import (
 	"archive/zip"
 	"io"
-	"io/fs"
-	"os"
+	"path/filepath"
 
 	"github.com/google/uuid"
 )
@@ -30,18 +29,12 @@ type ZipWriter struct {
 }
 
 // NewZipWriter creates a new zip compression writer
-func NewZipWriter(f *os.File) (zw *ZipWriter, err error) {
-	var fi fs.FileInfo
-
-	if fi, err = f.Stat(); err != nil {
-		return nil, err
-	}
-
+func NewZipWriter(f WriteFile) (zw *ZipWriter, err error) {
 	zw = &ZipWriter{
 		writer: zip.NewWriter(f),
 	}
 
-	if zw.nowWriter, err = zw.writer.Create(fi.Name() + uuid.New().String()); err != nil {
+	if zw.nowWriter, err = zw.writer.Create(filepath.Base(f.Name()) + uuid.New().String()); err != nil {
 		return nil, err
 	}
 	return
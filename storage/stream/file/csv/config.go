@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
@@ -27,13 +29,17 @@ import (
 
 // InConfig represents the CSV configuration
 type InConfig struct {
-	Columns    []Column `json:"column"`     // Column information
-	Encoding   string   `json:"encoding"`   // Encoding
-	Delimiter  string   `json:"delimiter"`  // Delimiter
-	NullFormat string   `json:"nullFormat"` // Null text
-	StartRow   int      `json:"startRow"`   // Starting row for reading, starting from 1
-	Comment    string   `json:"comment"`    // Comments
-	Compress   string   `json:"compress"`   // Compression
+	Columns    []Column `json:"column"`             // Column information
+	Encoding   string   `json:"encoding"`           // Encoding
+	Delimiter  string   `json:"delimiter"`          // Delimiter
+	NullFormat string   `json:"nullFormat"`         // Null text
+	StartRow   int      `json:"startRow"`           // Starting row for reading, starting from 1
+	Comment    string   `json:"comment"`            // Comments
+	Compress   string   `json:"compress"`           // Compression
+	Timezone   string   `json:"timezone,omitempty"` // Time zone of time values without offset, UTC if empty
+	// Whether quoted fields may contain newlines, the file is not divided into byte ranges if true
+	QuotedNewline bool `json:"quotedNewline,omitempty"`
+	loc           *time.Location
 }
 
 // NewInConfig retrieves the CSV configuration from the given conf
@@ -56,16 +62,19 @@ func NewInConfig(conf *config.JSON) (c *InConfig, err error) {
 		return nil, fmt.Errorf("comment is not valid")
 	}
 
-	switch c.encoding() {
-	case "utf-8", "gbk":
-	default:
+	if _, ok := decoders[c.encoding()]; !ok {
 		return nil, fmt.Errorf("encoding %v does not support", c.Encoding)
 	}
 
 	switch compress.Type(c.Compress) {
-	case compress.TypeNone, compress.TypeGzip, compress.TypeZip:
+	case compress.TypeAuto, compress.TypeNone, compress.TypeGzip, compress.TypeZip,
+		compress.TypeZstd, compress.TypeBzip2, compress.TypeXz, compress.TypeLz4:
 	default:
-		return nil, fmt.Errorf("compress %v does not support", c.Encoding)
+		return nil, fmt.Errorf("compress %v does not support", c.Compress)
+	}
+
+	if c.loc, err = loadLocation(c.Timezone); err != nil {
+		return nil, err
 	}
 
 	for _, v := range c.Columns {
@@ -84,10 +93,7 @@ func (c *InConfig) startRow() int {
 }
 
 func (c *InConfig) encoding() string {
-	if c.Encoding == "" {
-		return "utf-8"
-	}
-	return c.Encoding
+	return normalizeEncoding(c.Encoding)
 }
 
 func (c *InConfig) comma() rune {
@@ -106,13 +112,16 @@ func (c *InConfig) comment() rune {
 
 // OutConfig represents the CSV configuration
 type OutConfig struct {
-	Columns    []Column `json:"column"`     // Column information
-	Encoding   string   `json:"encoding"`   // Encoding
-	Delimiter  string   `json:"delimiter"`  // Delimiter
-	NullFormat string   `json:"nullFormat"` // Null text
-	HasHeader  bool     `json:"hasHeader"`  // Whether there is a column header
-	Header     []string `json:"header"`     // Column header
-	Compress   string   `json:"compress"`   // Compression
+	Columns    []Column `json:"column"`             // Column information
+	Encoding   string   `json:"encoding"`           // Encoding
+	Delimiter  string   `json:"delimiter"`          // Delimiter
+	NullFormat string   `json:"nullFormat"`         // Null text
+	HasHeader  bool     `json:"hasHeader"`          // Whether there is a column header
+	Header     []string `json:"header"`             // Column header
+	Compress   string   `json:"compress"`           // Compression
+	BOM        bool     `json:"bom,omitempty"`      // Whether to write the byte order mark
+	Timezone   string   `json:"timezone,omitempty"` // Time zone to format time values in, unchanged if empty
+	loc        *time.Location
 }
 
 // NewOutConfig retrieves the CSV configuration from the given conf
@@ -127,17 +136,24 @@ func NewOutConfig(conf *config.JSON) (c *OutConfig, err error) {
 		return nil, fmt.Errorf("delimiter is not valid")
 	}
 
-	switch c.encoding() {
-	case "utf-8", "gbk":
-	default:
+	if _, ok := encoders[c.encoding()]; !ok {
 		return nil, fmt.Errorf("encoding %v does not support", c.Encoding)
 	}
 
+	if c.BOM && bomOf(c.encoding()) == nil {
+		return nil, fmt.Errorf("encoding %v does not have bom", c.Encoding)
+	}
+
 	switch compress.Type(c.Compress) {
-	case compress.TypeNone, compress.TypeGzip, compress.TypeZip:
+	case compress.TypeAuto, compress.TypeNone, compress.TypeGzip, compress.TypeZip,
+		compress.TypeZstd, compress.TypeXz, compress.TypeLz4:
 	default:
-		return nil, fmt.Errorf("compress %v does not support", c.Encoding)
+		return nil, fmt.Errorf("compress %v does not support", c.Compress)
+	}
+	if c.loc, err = loadLocation(c.Timezone); err != nil {
+		return nil, err
 	}
+
 	for _, v := range c.Columns {
 		if err = v.validate(); err != nil {
 			return nil, err
@@ -147,10 +163,15 @@ func NewOutConfig(conf *config.JSON) (c *OutConfig, err error) {
 }
 
 func (c *OutConfig) encoding() string {
-	if c.Encoding == "" {
+	return normalizeEncoding(c.Encoding)
+}
+
+// normalizeEncoding normalizes the encoding name to lower case with hyphens, utf-8 if empty
+func normalizeEncoding(encoding string) string {
+	if encoding == "" {
 		return "utf-8"
 	}
-	return c.Encoding
+	return strings.ReplaceAll(strings.ToLower(encoding), "_", "-")
 }
 
 func (c *OutConfig) comma() rune {
@@ -162,11 +183,12 @@ func (c *OutConfig) comma() rune {
 
 // Column represents column information
 type Column struct {
-	Index    string `json:"index"`  // Index starts from 1 and represents the column number
-	Type     string `json:"type"`   // Type (bool, bigInt, decimal, string, time)
-	Format   string `json:"format"` // Joda time format
-	indexNum int
-	goLayout string
+	Index          string `json:"index"`  // Index starts from 1 and represents the column number
+	Type           string `json:"type"`   // Type (bool, bigInt, decimal, string, time)
+	Format         string `json:"format"` // Joda time format
+	indexNum       int
+	goLayout       string
+	goFormatLayout string
 }
 
 // Validate performs validation
@@ -209,3 +231,32 @@ func (c *Column) layout() string {
 	c.goLayout = jodaTime.GetLayout(c.Format)
 	return c.goLayout
 }
+
+// formatLayout converts to the Golang time format of writing, whose fractional seconds
+// keep the width of the format
+func (c *Column) formatLayout() string {
+	if c.goFormatLayout != "" {
+		return c.goFormatLayout
+	}
+	c.goFormatLayout = element.FixedFractionLayout(c.layout())
+	return c.goFormatLayout
+}
+
+// loadLocation loads the time zone by its name, nil is returned if the name is empty
+func loadLocation(name string) (loc *time.Location, err error) {
+	if name == "" {
+		return nil, nil
+	}
+	if loc, err = time.LoadLocation(name); err != nil {
+		return nil, fmt.Errorf("timezone %v is not valid", name)
+	}
+	return
+}
+
+// location gets the time zone of time values without offset, UTC by default
+func (c *InConfig) location() *time.Location {
+	if c.loc == nil {
+		return time.UTC
+	}
+	return c.loc
+}
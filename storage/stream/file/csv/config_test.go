@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
@@ -249,6 +250,41 @@ func TestNewInConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "11",
+			args: args{
+				conf: testJSONFromString(`{"compress":"bz2"}`),
+			},
+			wantC: &InConfig{
+				Compress: "bz2",
+			},
+		},
+		{
+			name: "12",
+			args: args{
+				conf: testJSONFromString(`{"encoding":"GB18030"}`),
+			},
+			wantC: &InConfig{
+				Encoding: "GB18030",
+			},
+		},
+		{
+			name: "13",
+			args: args{
+				conf: testJSONFromString(`{"timezone":"Mars/Olympus"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "14",
+			args: args{
+				conf: testJSONFromString(`{"timezone":"UTC"}`),
+			},
+			wantC: &InConfig{
+				Timezone: "UTC",
+				loc:      time.UTC,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -338,6 +374,13 @@ func TestConfig_encoding(t *testing.T) {
 			},
 			want: "gbk",
 		},
+		{
+			name: "3",
+			c: &InConfig{
+				Encoding: "Shift_JIS",
+			},
+			want: "shift-jis",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -466,6 +509,47 @@ func TestNewOutConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "11",
+			args: args{
+				conf: testJSONFromString(`{"compress":"bz2"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "12",
+			args: args{
+				conf: testJSONFromString(`{"encoding":"gbk","bom":true}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "13",
+			args: args{
+				conf: testJSONFromString(`{"encoding":"utf-16le","bom":true}`),
+			},
+			wantC: &OutConfig{
+				Encoding: "utf-16le",
+				BOM:      true,
+			},
+		},
+		{
+			name: "14",
+			args: args{
+				conf: testJSONFromString(`{"timezone":"Mars/Olympus"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "15",
+			args: args{
+				conf: testJSONFromString(`{"timezone":"UTC"}`),
+			},
+			wantC: &OutConfig{
+				Timezone: "UTC",
+				loc:      time.UTC,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -499,6 +583,13 @@ func TestOutConfig_encoding(t *testing.T) {
 			},
 			want: "gbk",
 		},
+		{
+			name: "3",
+			c: &OutConfig{
+				Encoding: "Shift_JIS",
+			},
+			want: "shift-jis",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -15,17 +15,58 @@
 package csv
 
 import (
+	"bytes"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
 	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
 )
 
 var (
 	encoders = map[string]encode{
-		"gbk":   gbkEncoder,
-		"utf-8": utf8Encoder,
+		"gbk":          gbkEncoder,
+		"utf-8":        utf8Encoder,
+		"gb18030":      newEncoder(simplifiedchinese.GB18030),
+		"big5":         newEncoder(traditionalchinese.Big5),
+		"shift-jis":    newEncoder(japanese.ShiftJIS),
+		"euc-kr":       newEncoder(korean.EUCKR),
+		"iso-8859-1":   newEncoder(charmap.ISO8859_1),
+		"windows-1252": newEncoder(charmap.Windows1252),
+		"utf-16le":     utf8Encoder,
+		"utf-16be":     utf8Encoder,
 	}
 	decoders = map[string]decode{
-		"gbk":   gbkDecoder,
-		"utf-8": utf8Decoder,
+		"gbk":          gbkDecoder,
+		"utf-8":        utf8Decoder,
+		"gb18030":      newDecoder(simplifiedchinese.GB18030),
+		"big5":         newDecoder(traditionalchinese.Big5),
+		"shift-jis":    newDecoder(japanese.ShiftJIS),
+		"euc-kr":       newDecoder(korean.EUCKR),
+		"iso-8859-1":   newDecoder(charmap.ISO8859_1),
+		"windows-1252": newDecoder(charmap.Windows1252),
+		"utf-16le":     utf8Decoder,
+		"utf-16be":     utf8Decoder,
+	}
+
+	// streamEncodings are the encodings not compatible with ascii, the whole stream of which
+	// is transcoded from or to utf-8, so the fields are not decoded or encoded any more
+	streamEncodings = map[string]encoding.Encoding{
+		"utf-16le": unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+		"utf-16be": unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	}
+
+	// boms are the byte order marks of encodings
+	boms = []struct {
+		encoding string
+		bom      []byte
+	}{
+		{"utf-8", []byte{0xEF, 0xBB, 0xBF}},
+		{"utf-16le", []byte{0xFF, 0xFE}},
+		{"utf-16be", []byte{0xFE, 0xFF}},
 	}
 )
 
@@ -50,3 +91,36 @@ func gbkEncoder(src string) (dest string, err error) {
 func utf8Encoder(src string) (dest string, err error) {
 	return src, nil
 }
+
+func newDecoder(e encoding.Encoding) decode {
+	return func(src string) (string, error) {
+		return e.NewDecoder().String(src)
+	}
+}
+
+func newEncoder(e encoding.Encoding) encode {
+	return func(src string) (string, error) {
+		return e.NewEncoder().String(src)
+	}
+}
+
+// detectBOM detects the encoding by the byte order mark at the start of header,
+// and returns the configured encoding and 0 if no byte order mark is found
+func detectBOM(header []byte, configured string) (enc string, n int) {
+	for _, v := range boms {
+		if bytes.HasPrefix(header, v.bom) {
+			return v.encoding, len(v.bom)
+		}
+	}
+	return configured, 0
+}
+
+// bomOf gets the byte order mark of the encoding, nil if it has no byte order mark
+func bomOf(enc string) []byte {
+	for _, v := range boms {
+		if v.encoding == enc {
+			return v.bom
+		}
+	}
+	return nil
+}
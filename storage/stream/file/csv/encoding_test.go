@@ -48,3 +48,64 @@ func Test_gbkEncodeDecode(t *testing.T) {
 		})
 	}
 }
+
+func Test_encodeDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		src      string
+	}{
+		{name: "1", encoding: "gb18030", src: "中文𠀀"},
+		{name: "2", encoding: "big5", src: "繁體中文"},
+		{name: "3", encoding: "shift-jis", src: "日本語ポート"},
+		{name: "4", encoding: "euc-kr", src: "한국어"},
+		{name: "5", encoding: "iso-8859-1", src: "café"},
+		{name: "6", encoding: "windows-1252", src: "€café"},
+		{name: "7", encoding: "utf-16le", src: "中文"},
+		{name: "8", encoding: "utf-16be", src: "中文"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := encoders[tt.encoding](tt.src)
+			if err != nil {
+				t.Fatalf("encode() error = %v", err)
+			}
+			got, err := decoders[tt.encoding](encoded)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if got != tt.src {
+				t.Errorf("encodeDecode() = %v, want %v", got, tt.src)
+			}
+		})
+	}
+}
+
+func Test_encodeUnrepresentable(t *testing.T) {
+	if _, err := encoders["iso-8859-1"]("中文"); err == nil {
+		t.Errorf("encode() error = nil, wantErr true")
+	}
+}
+
+func Test_detectBOM(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+		want   string
+		wantN  int
+	}{
+		{name: "1", header: []byte{0xEF, 0xBB, 0xBF, 'a'}, want: "utf-8", wantN: 3},
+		{name: "2", header: []byte{0xFF, 0xFE, 'a', 0}, want: "utf-16le", wantN: 2},
+		{name: "3", header: []byte{0xFE, 0xFF, 0, 'a'}, want: "utf-16be", wantN: 2},
+		{name: "4", header: []byte("abc"), want: "gbk", wantN: 0},
+		{name: "5", header: []byte{0xEF, 0xBB}, want: "gbk", wantN: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n := detectBOM(tt.header, "gbk")
+			if got != tt.want || n != tt.wantN {
+				t.Errorf("detectBOM() = %v %v, want %v %v", got, n, tt.want, tt.wantN)
+			}
+		})
+	}
+}
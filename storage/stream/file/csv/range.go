@@ -0,0 +1,190 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/Breeze0806/go-etl/storage/stream/file/compress"
+	"github.com/pingcap/errors"
+)
+
+const (
+	syncWindow    = 1 << 20  // the initial window to resynchronize
+	maxSyncWindow = 64 << 20 // the maximum window to resynchronize
+)
+
+var (
+	errRecordTooLarge = errors.New("no record start is found in 64MB")
+	errInvalidQuote   = errors.New("the quotes are not valid either inside or outside quotes")
+)
+
+// quoteState - the state of the speculative parse from a position of unknown quote state
+type quoteState struct {
+	inQuote    bool
+	fieldStart bool
+	invalid    bool
+	boundary   int // index after the first newline outside quotes, -1 if not found
+}
+
+func newQuoteState(inQuote bool) *quoteState {
+	return &quoteState{
+		inQuote:    inQuote,
+		fieldStart: true,
+		boundary:   -1,
+	}
+}
+
+// parse parses buf from the index i, and returns the next index to parse
+func (q *quoteState) parse(buf, comma []byte, i int, eof bool) int {
+	c := buf[i]
+	if q.inQuote {
+		// the quoted fields of the files read by ranges must not contain newlines
+		if c == '\n' {
+			q.invalid = true
+			return i + 1
+		}
+		if c != '"' {
+			return i + 1
+		}
+		if i+1 == len(buf) && !eof {
+			return i // need more bytes to check whether the quote is escaped
+		}
+		if i+1 < len(buf) && buf[i+1] == '"' {
+			return i + 2
+		}
+		// the closing quote must be followed by comma or newline
+		q.inQuote = false
+		q.fieldStart = false
+		if i+1 < len(buf) && buf[i+1] != '\n' && buf[i+1] != '\r' &&
+			!bytes.HasPrefix(buf[i+1:], comma) {
+			q.invalid = true
+		}
+		return i + 1
+	}
+
+	switch {
+	case c == '\n':
+		if q.boundary < 0 {
+			q.boundary = i + 1
+		}
+		q.fieldStart = true
+		return i + 1
+	case c == '"':
+		// the opening quote must be at the start of field
+		if !q.fieldStart {
+			q.invalid = true
+		}
+		q.inQuote = true
+		return i + 1
+	case bytes.HasPrefix(buf[i:], comma):
+		q.fieldStart = true
+		return i + len(comma)
+	case c == '\r':
+		return i + 1
+	}
+	q.fieldStart = false
+	return i + 1
+}
+
+// resync finds the first record start at or after the position pos in the file of size,
+// where a record starts at the beginning of the file or after a newline outside quotes.
+// As the quote state at pos is unknown, the bytes after pos are parsed by assuming both
+// outside and inside quotes, and the assumption which leads to an invalid quote is dropped.
+// As the quoted fields must not contain newlines, a newline inside quotes is invalid, so
+// only one assumption is valid after the first newline, and the window is widened until then.
+// An error is returned if neither is valid or no newline is found in the maximum window,
+// so a record is never split by a wrong guess. The quoted newlines in the files violating
+// it are reported by the rows of the ranges.
+// The same position is always resynchronized to the same record start, so the adjacent
+// ranges [resync(a), resync(b)) and [resync(b), resync(c)) never overlap or miss records.
+func resync(r io.ReaderAt, size, pos int64, comma rune) (int64, error) {
+	if pos <= 0 {
+		return 0, nil
+	}
+	if pos >= size {
+		return size, nil
+	}
+
+	sep := []byte(string(comma))
+	// the byte before pos is included to find the record starting exactly at pos
+	begin := pos - 1
+	for window := int64(syncWindow); ; window *= 2 {
+		n := window
+		if begin+n > size {
+			n = size - begin
+		}
+		buf := make([]byte, n)
+		if _, err := r.ReadAt(buf, begin); err != nil && err != io.EOF {
+			return 0, err
+		}
+		eof := begin+n == size
+
+		outside, inside := newQuoteState(false), newQuoteState(true)
+		for _, q := range []*quoteState{outside, inside} {
+			for i := 0; i < len(buf) && !q.invalid; {
+				next := q.parse(buf, sep, i, eof)
+				if next == i {
+					break
+				}
+				i = next
+			}
+			// the quote is never closed at the end of file
+			if eof && q.inQuote {
+				q.invalid = true
+			}
+		}
+
+		if outside.invalid && inside.invalid {
+			return 0, errInvalidQuote
+		}
+		q := outside
+		if outside.invalid {
+			q = inside
+		}
+		// both are valid only before the first newline, and neither has a record start
+		if q.boundary >= 0 && (outside.invalid || inside.invalid) {
+			return begin + int64(q.boundary), nil
+		}
+		if eof {
+			return size, nil
+		}
+		if window >= maxSyncWindow {
+			return 0, errRecordTooLarge
+		}
+	}
+}
+
+// CheckRange checks whether the records in byte ranges of the file named filename can be read,
+// header is the bytes at the start of the file. The file must not be compressed, its encoding
+// must be compatible with ascii, as the record starts are found in raw bytes, and its quoted
+// fields must not contain newlines, as they can not always be told from records
+func CheckRange(c *InConfig, filename string, header []byte) error {
+	if c.QuotedNewline {
+		return errors.New("quotedNewline does not support range")
+	}
+	typ := compress.Type(c.Compress)
+	if typ == compress.TypeAuto {
+		typ = compress.Detect(filename, header)
+	}
+	if typ != compress.TypeNone {
+		return errors.Errorf("compress %v does not support range", typ)
+	}
+	if enc, _ := detectBOM(header, c.encoding()); streamEncodings[enc] != nil {
+		return errors.Errorf("encoding %v does not support range", enc)
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testRangeRead(t *testing.T, filename string, conf string, start, end int64) (records [][]string) {
+	var opener Opener
+	in, err := opener.OpenRange(nil, filename, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	rows, err := in.Rows(testJSONFromString(conf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		cols, err := rows.Scan()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cols) == 0 {
+			continue
+		}
+		var record []string
+		for _, c := range cols {
+			record = append(record, c.String())
+		}
+		records = append(records, record)
+	}
+	if err = rows.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestRangeRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		conf    string
+	}{
+		{
+			name:    "1",
+			content: "a,b,c\n1,2,3\n4,5,6\n\n7,8,9\n",
+			conf:    `{}`,
+		},
+		{
+			name:    "2",
+			content: "h1,h2\n\"x,y\",1\n\"a,\"\"b\"\",c\",2\n\"\"\"\",3\nlast,4",
+			conf:    `{"startRow":2}`,
+		},
+		{
+			name:    "3",
+			content: "1;\"a\r\";2\r\n3;\"\";4\r\n5;\"b;c\"\"\";6\r\n",
+			conf:    `{"delimiter":";","nullFormat":"\\N"}`,
+		},
+		{
+			name:    "4",
+			content: "甲\u0010\"乙\u0010丙\"\n丁\u0010戊\n",
+			conf:    `{"delimiter":"\u0010"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "range.csv")
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			want := testRangeRead(t, filename, tt.conf, 0, int64(len(tt.content)))
+			r := csv.NewReader(strings.NewReader(tt.content))
+			r.Comma = []rune(testJSONFromString(tt.conf).GetStringOrDefaullt("delimiter", ","))[0]
+			all, err := r.ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if startRow := testJSONFromString(tt.conf).GetInt64OrDefaullt("startRow", 1); startRow > 1 {
+				all = all[startRow-1:]
+			}
+			if !reflect.DeepEqual(want, all) {
+				t.Fatalf("whole range = %v, want %v", want, all)
+			}
+
+			size := int64(len(tt.content))
+			for a := int64(0); a <= size; a++ {
+				for b := a; b <= size; b++ {
+					var got [][]string
+					got = append(got, testRangeRead(t, filename, tt.conf, 0, a)...)
+					got = append(got, testRangeRead(t, filename, tt.conf, a, b)...)
+					got = append(got, testRangeRead(t, filename, tt.conf, b, size)...)
+					if !reflect.DeepEqual(got, want) {
+						t.Fatalf("ranges [0,%v) [%v,%v) [%v,%v) = %v, want %v", a, a, b, b, size, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewRangeRowsCompress(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "range.csv")
+	if err := os.WriteFile(filename, []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err = NewRangeRows(f, testJSONFromString(`{"compress":"gz"}`), 0, 1); err == nil {
+		t.Fatal("NewRangeRows() error = nil, wantErr true")
+	}
+}
+
+func TestNewRangeRowsEncoding(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		data    []byte
+		conf    string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			data:    []byte{0xFF, 0xFE, 'a', 0, '\n', 0},
+			conf:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:    "2",
+			data:    []byte("a\n"),
+			conf:    `{"encoding":"utf-16be"}`,
+			wantErr: true,
+		},
+		{
+			name: "3",
+			data: []byte("\xEF\xBB\xBFa,b\nc,d\n"),
+			conf: `{"encoding":"gbk"}`,
+			want: [][]string{{"a", "b"}, {"c", "d"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(dir, tt.name+".csv")
+			if err := os.WriteFile(filename, tt.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Open(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			_, err = NewRangeRows(f, testJSONFromString(tt.conf), 0, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewRangeRows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			// the byte order mark is stripped in the first range, and the file is read as utf-8
+			var got [][]string
+			size := int64(len(tt.data))
+			for _, r := range [][2]int64{{0, 4}, {4, size}} {
+				got = append(got, testRangeRead(t, filename, tt.conf, r[0], r[1])...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeRows_QuotedNewline(t *testing.T) {
+	content := "1,\"a\nb\",2\n3,4\n"
+	filename := filepath.Join(t.TempDir(), "range.csv")
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// the record with the quoted newline is reported instead of being split
+	for _, r := range [][2]int64{{0, int64(len(content))}, {0, 5}, {5, 9}} {
+		var opener Opener
+		in, err := opener.OpenRange(nil, filename, r[0], r[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := in.Rows(testJSONFromString(`{}`))
+		if err == nil {
+			for rows.Next() {
+			}
+			err = rows.Error()
+			rows.Close()
+		}
+		in.Close()
+		if err == nil {
+			t.Errorf("range %v error = nil, wantErr true", r)
+		}
+	}
+}
+
+func Test_resync(t *testing.T) {
+	long := strings.Repeat("a", syncWindow+10)
+	tests := []struct {
+		name    string
+		content string
+		pos     int64
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			content: "a,b\n\"c\",d\n",
+			pos:     1,
+			want:    4,
+		},
+		{
+			name:    "2",
+			content: "a,b\n\"c\",d\n",
+			pos:     4,
+			want:    4,
+		},
+		{
+			name:    "3",
+			content: "1,\"a,b\"\n2\n",
+			pos:     4,
+			want:    8,
+		},
+		{
+			name:    "4",
+			content: "xa\"b\n",
+			pos:     1,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			content: long + "\nb\n",
+			pos:     1,
+			want:    int64(len(long)) + 1,
+		},
+		{
+			name:    "6",
+			content: "abc",
+			pos:     1,
+			want:    3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resync(strings.NewReader(tt.content), int64(len(tt.content)), tt.pos, ',')
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("resync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     string
+		filename string
+		header   []byte
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			conf:     `{}`,
+			filename: "a.csv",
+			header:   []byte("a,b\n"),
+		},
+		{
+			name:     "2",
+			conf:     `{"quotedNewline":true}`,
+			filename: "a.csv",
+			header:   []byte("a,b\n"),
+			wantErr:  true,
+		},
+		{
+			name:     "3",
+			conf:     `{"compress":"gz"}`,
+			filename: "a.csv",
+			header:   []byte("a,b\n"),
+			wantErr:  true,
+		},
+		{
+			name:     "4",
+			conf:     `{}`,
+			filename: "a.csv.gz",
+			header:   []byte{0x1f, 0x8b},
+			wantErr:  true,
+		},
+		{
+			name:     "5",
+			conf:     `{"encoding":"utf-16le"}`,
+			filename: "a.csv",
+			header:   []byte("a\x00"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewInConfig(testJSONFromString(tt.conf))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := CheckRange(c, tt.filename, tt.header); (err != nil) != tt.wantErr {
+				t.Errorf("CheckRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
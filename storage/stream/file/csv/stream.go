@@ -15,10 +15,13 @@
 package csv
 
 import (
+	"bufio"
 	"encoding/csv"
 	"io"
+	"io/fs"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
@@ -26,6 +29,7 @@ import (
 	"github.com/Breeze0806/go-etl/storage/stream/file"
 	"github.com/Breeze0806/go-etl/storage/stream/file/compress"
 	"github.com/pingcap/errors"
+	"golang.org/x/text/transform"
 )
 
 func init() {
@@ -39,41 +43,73 @@ func init() {
 type Opener struct {
 }
 
-// Open - Opens a CSV input stream named 'filename'.
-func (o *Opener) Open(filename string) (file.InStream, error) {
-	return NewInStream(filename)
+// Open - Opens a CSV input stream named 'filename' in 'fsys'.
+func (o *Opener) Open(fsys *file.FS, filename string) (file.InStream, error) {
+	return NewInStream(fsys, filename)
+}
+
+// OpenRange opens the csv input stream of the records starting in the byte range [start, end)
+func (o *Opener) OpenRange(fsys *file.FS, filename string, start, end int64) (file.InStream, error) {
+	return NewRangeInStream(fsys, filename, start, end)
 }
 
 // Creator - A utility for creating CSV output streams.
 type Creator struct {
 }
 
-// Create - Creates a CSV output stream named 'filename'.
-func (c *Creator) Create(filename string) (file.OutStream, error) {
-	return NewOutStream(filename)
+// Create - Creates a CSV output stream named 'filename' in 'fsys'.
+func (c *Creator) Create(fsys *file.FS, filename string) (file.OutStream, error) {
+	return NewOutStream(fsys, filename)
 }
 
 // Stream - Represents a CSV file stream.
 type Stream struct {
-	file *os.File
+	in  file.ReadFile
+	out file.WriteFile
+
+	stdin      bool
+	ranged     bool
+	start, end int64
 }
 
-// NewInStream - Creates a CSV input stream named 'filename'.
-func NewInStream(filename string) (file.InStream, error) {
+// NewInStream - Creates a CSV input stream named 'filename' in 'fsys', standard input is read if filename is file.Stdin.
+func NewInStream(fsys *file.FS, filename string) (file.InStream, error) {
+	if filename == file.Stdin {
+		return &Stream{in: os.Stdin, stdin: true}, nil
+	}
 	stream := &Stream{}
 	var err error
-	stream.file, err = os.Open(filename)
+	stream.in, err = fsys.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// NewRangeInStream opens the csv input stream of the records starting in the byte range [start, end)
+// of the file named filename in fsys, the file must not be compressed
+func NewRangeInStream(fsys *file.FS, filename string, start, end int64) (file.InStream, error) {
+	if filename == file.Stdin {
+		return nil, errors.New("standard input can not be read by range")
+	}
+	stream := &Stream{
+		ranged: true,
+		start:  start,
+		end:    end,
+	}
+	var err error
+	stream.in, err = fsys.Open(filename)
 	if err != nil {
 		return nil, err
 	}
 	return stream, nil
 }
 
-// NewOutStream - Creates a CSV output stream named 'filename'.
-func NewOutStream(filename string) (file.OutStream, error) {
+// NewOutStream - Creates a CSV output stream named 'filename' in 'fsys'.
+func NewOutStream(fsys *file.FS, filename string) (file.OutStream, error) {
 	stream := &Stream{}
 	var err error
-	stream.file, err = os.Create(filename)
+	stream.out, err = fsys.Create(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -82,17 +118,29 @@ func NewOutStream(filename string) (file.OutStream, error) {
 
 // Writer - Creates a new CSV stream writer with the given configuration 'conf'.
 func (s *Stream) Writer(conf *config.JSON) (file.StreamWriter, error) {
-	return NewWriter(s.file, conf)
+	return NewWriter(s.out, conf)
 }
 
 // Rows - Creates a new CSV row reader with the given configuration 'conf'.
 func (s *Stream) Rows(conf *config.JSON) (rows file.Rows, err error) {
-	return NewRows(s.file, conf)
+	if s.ranged {
+		return NewRangeRows(s.in, conf, s.start, s.end)
+	}
+	if s.stdin {
+		return NewStreamRows(s.in, conf)
+	}
+	return NewRows(s.in, conf)
 }
 
-// Close - Closes the file stream.
+// Close - Closes the file stream, standard input is left open.
 func (s *Stream) Close() (err error) {
-	return s.file.Close()
+	switch {
+	case s.stdin:
+		return nil
+	case s.in != nil:
+		return s.in.Close()
+	}
+	return s.out.Close()
 }
 
 // Rows - Represents a row reader for CSV data.
@@ -102,12 +150,14 @@ type Rows struct {
 	reader  *csv.Reader
 	record  []string
 	conf    *InConfig
+	decode  decode
 	row     int
+	ranged  bool
 	err     error
 }
 
 // NewRows - Creates a row reader using the file handle 'f' and configuration 'c'.
-func NewRows(f *os.File, c *config.JSON) (file.Rows, error) {
+func NewRows(f file.ReadFile, c *config.JSON) (file.Rows, error) {
 	var conf *InConfig
 	var err error
 	if conf, err = NewInConfig(c); err != nil {
@@ -120,17 +170,101 @@ func NewRows(f *os.File, c *config.JSON) (file.Rows, error) {
 	if rows.rc, err = compress.Type(conf.Compress).ReadCloser(f); err != nil {
 		return nil, err
 	}
+	rows.init(conf.encoding())
+	return rows, nil
+}
 
-	rows.reader = csv.NewReader(f)
-	rows.reader.Comma = conf.comma()
-	rows.reader.Comment = conf.comment()
+// NewStreamRows creates a row reader of the stream r that cannot seek, such as standard input,
+// using the JSON configuration c. The compression is detected by the magic bytes peeked from r
+func NewStreamRows(r io.Reader, c *config.JSON) (file.Rows, error) {
+	var conf *InConfig
+	var err error
+	if conf, err = NewInConfig(c); err != nil {
+		return nil, err
+	}
+	rows := &Rows{
+		columns: make(map[int]Column),
+		conf:    conf,
+	}
+	if rows.rc, err = compress.Type(conf.Compress).StreamReadCloser(r); err != nil {
+		return nil, err
+	}
+	rows.init(conf.encoding())
+	return rows, nil
+}
 
-	for _, v := range conf.Columns {
-		rows.columns[v.index()] = v
+// NewRangeRows creates a row reader for the records starting in the byte range [start, end)
+// of the uncompressed file f, using the JSON configuration c.
+// The range is resynchronized to the record start, so records are never split across ranges,
+// and an error is returned for the quoted fields with newlines, which need QuotedNewline.
+// The rows before startRow are skipped only in the range starting at the beginning of f.
+func NewRangeRows(f file.ReadFile, c *config.JSON, start, end int64) (file.Rows, error) {
+	var conf *InConfig
+	var err error
+	if conf, err = NewInConfig(c); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 8)
+	var n int
+	if n, err = f.ReadAt(header, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if err = CheckRange(conf, f.Name(), header[:n]); err != nil {
+		return nil, err
+	}
+	// the byte order mark is only at the start of file
+	enc, _ := detectBOM(header[:n], conf.encoding())
+
+	var fi fs.FileInfo
+	if fi, err = f.Stat(); err != nil {
+		return nil, err
+	}
+	if start, err = resync(f, fi.Size(), start, conf.comma()); err != nil {
+		return nil, errors.Wrapf(err, "resync(%v) fail", start)
+	}
+	if end, err = resync(f, fi.Size(), end, conf.comma()); err != nil {
+		return nil, errors.Wrapf(err, "resync(%v) fail", end)
+	}
+	if end < start {
+		end = start
+	}
+
+	rows := &Rows{
+		columns: make(map[int]Column),
+		conf:    conf,
+		rc:      io.NopCloser(io.NewSectionReader(f, start, end-start)),
+		ranged:  true,
 	}
+	if start > 0 {
+		rows.row = conf.startRow() - 1
+	}
+	rows.init(enc)
 	return rows, nil
 }
 
+// init initializes the csv reader with the encoding enc,
+// which is overridden by the byte order mark at the start of the stream
+func (r *Rows) init(enc string) {
+	br := bufio.NewReader(r.rc)
+	header, _ := br.Peek(3)
+	enc, n := detectBOM(header, enc)
+	br.Discard(n)
+
+	r.decode = decoders[enc]
+	var src io.Reader = br
+	if e, ok := streamEncodings[enc]; ok {
+		src = transform.NewReader(br, e.NewDecoder())
+	}
+	r.reader = csv.NewReader(src)
+	r.reader.Comma = r.conf.comma()
+	r.reader.Comment = r.conf.comment()
+
+	for _, v := range r.conf.Columns {
+		r.columns[v.index()] = v
+	}
+}
+
 // Next - Checks if there is a next row.
 func (r *Rows) Next() bool {
 	if r.record, r.err = r.reader.Read(); r.err != nil {
@@ -139,6 +273,14 @@ func (r *Rows) Next() bool {
 		}
 		return false
 	}
+	if r.ranged {
+		for _, v := range r.record {
+			if strings.ContainsRune(v, '\n') {
+				r.err = errors.Errorf("row %v has quoted field with newline, which can not be read by ranges, set quotedNewline", r.row+1)
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -178,7 +320,7 @@ func (r *Rows) getColum(index int, s string) (element.Column, error) {
 				strconv.Itoa(index), byteSize), nil
 		}
 		layout := c.layout()
-		t, err := time.Parse(layout, s)
+		t, err := time.ParseInLocation(layout, s, r.conf.location())
 		if err != nil {
 			return nil, errors.Wrapf(err, "Parse time fail. layout: %v", layout)
 		}
@@ -190,8 +332,7 @@ func (r *Rows) getColum(index int, s string) (element.Column, error) {
 		return element.NewDefaultColumn(element.NewNilStringColumnValue(),
 			strconv.Itoa(index), byteSize), nil
 	}
-	decodeFunc := decoders[r.conf.encoding()]
-	s, err := decodeFunc(s)
+	s, err := r.decode(s)
 	if err != nil {
 		return nil, err
 	}
@@ -202,12 +343,13 @@ func (r *Rows) getColum(index int, s string) (element.Column, error) {
 type Writer struct {
 	writer  *csv.Writer
 	wc      io.WriteCloser
+	tw      *transform.Writer // transcoder of the stream encoding, nil if the fields are encoded
 	columns map[int]Column
 	conf    *OutConfig
 }
 
 // NewWriter - Creates a CSV stream writer using the file handle 'f' and configuration 'c'.
-func NewWriter(f *os.File, c *config.JSON) (file.StreamWriter, error) {
+func NewWriter(f file.WriteFile, c *config.JSON) (file.StreamWriter, error) {
 	var conf *OutConfig
 	var err error
 	if conf, err = NewOutConfig(c); err != nil {
@@ -222,7 +364,17 @@ func NewWriter(f *os.File, c *config.JSON) (file.StreamWriter, error) {
 	if w.wc, err = compress.Type(conf.Compress).WriteCloser(f); err != nil {
 		return nil, err
 	}
-	w.writer = csv.NewWriter(w.wc)
+	if conf.BOM {
+		if _, err = w.wc.Write(bomOf(conf.encoding())); err != nil {
+			return nil, err
+		}
+	}
+	var dst io.Writer = w.wc
+	if e, ok := streamEncodings[conf.encoding()]; ok {
+		w.tw = transform.NewWriter(w.wc, e.NewEncoder())
+		dst = w.tw
+	}
+	w.writer = csv.NewWriter(dst)
 	w.writer.Comma = conf.comma()
 	for _, v := range conf.Columns {
 		w.columns[v.index()] = v
@@ -239,6 +391,12 @@ func (w *Writer) Flush() (err error) {
 // Close - Closes the writer.
 func (w *Writer) Close() (err error) {
 	w.writer.Flush()
+	if w.tw != nil {
+		if err = w.tw.Close(); err != nil {
+			w.wc.Close()
+			return
+		}
+	}
 	return w.wc.Close()
 }
 
@@ -284,7 +442,14 @@ func (w *Writer) getRecord(col element.Column, i int) (s string, err error) {
 		if t, err = col.AsTime(); err != nil {
 			return
 		}
-		s = t.Format(c.layout())
+		// the date only and the time of day are written as they are without the time zone
+		// conversion, the date only is written without the time of day and the fractional
+		// seconds are cut to the precision of the value
+		kind, precision := element.TimeKindOf(col)
+		if w.conf.loc != nil && kind == element.TimeKindDateTime {
+			t = t.In(w.conf.loc)
+		}
+		s = element.TruncateTime(t, precision).Format(element.KindLayout(c.formatLayout(), kind))
 		return
 	}
 
@@ -15,12 +15,17 @@
 package csv
 
 import (
+	"bytes"
+	"compress/gzip"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
 )
 
 func Test_ReadWrite(t *testing.T) {
@@ -94,6 +99,110 @@ func Test_ReadWrite(t *testing.T) {
 			},
 			wantStr: "0=<nil> 1=abc",
 		},
+		{
+			name: "5",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{}`),
+				out:      testJSONFromString(`{"compress":"zst"}`),
+				filename: filepath.Join(tmpDir, "5.csv"),
+			},
+			wantStr: "0=abc 1=def",
+		},
+		{
+			name: "6",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{"compress":"lz4"}`),
+				out:      testJSONFromString(`{}`),
+				filename: filepath.Join(tmpDir, "6.csv.lz4"),
+			},
+			wantStr: "0=abc 1=def",
+		},
+		{
+			name: "7",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{"compress":"gz"}`),
+				out:      testJSONFromString(`{"compress":"gz"}`),
+				filename: filepath.Join(tmpDir, "7.csv"),
+			},
+			wantStr: "0=abc 1=def",
+		},
+		{
+			name: "8",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("中文"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{}`),
+				out:      testJSONFromString(`{"encoding":"utf-16le","bom":true}`),
+				filename: filepath.Join(tmpDir, "8.csv"),
+			},
+			wantStr: "0=中文 1=def",
+		},
+		{
+			name: "9",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("中文"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{"encoding":"UTF-16BE"}`),
+				out:      testJSONFromString(`{"encoding":"utf-16be","compress":"gz"}`),
+				filename: filepath.Join(tmpDir, "9.csv"),
+			},
+			wantStr: "0=中文 1=def",
+		},
+		{
+			name: "10",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("繁體中文"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{"encoding":"big5"}`),
+				out:      testJSONFromString(`{"encoding":"big5"}`),
+				filename: filepath.Join(tmpDir, "10.csv"),
+			},
+			wantStr: "0=繁體中文 1=def",
+		},
+		{
+			name: "11",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("ポート"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{"encoding":"Shift_JIS"}`),
+				out:      testJSONFromString(`{"encoding":"shift-jis"}`),
+				filename: filepath.Join(tmpDir, "11.csv"),
+			},
+			wantStr: "0=ポート 1=def",
+		},
+		{
+			name: "12",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("def"), "2", 0),
+				},
+				in:       testJSONFromString(`{"startRow":2}`),
+				out:      testJSONFromString(`{"bom":true,"hasHeader":true}`),
+				filename: filepath.Join(tmpDir, "12.csv"),
+			},
+			wantStr: "0=abc 1=def",
+		},
 	}
 
 	for _, tt := range tests {
@@ -105,7 +214,7 @@ func Test_ReadWrite(t *testing.T) {
 			}
 			wFunc := func() {
 				var creator Creator
-				out, err := creator.Create(tt.args.filename)
+				out, err := creator.Create(nil, tt.args.filename)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -124,7 +233,7 @@ func Test_ReadWrite(t *testing.T) {
 			var got []element.Record
 			rFunc := func() {
 				var opener Opener
-				in, err := opener.Open(tt.args.filename)
+				in, err := opener.Open(nil, tt.args.filename)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -162,3 +271,279 @@ func Test_ReadWrite(t *testing.T) {
 		})
 	}
 }
+
+func testGzip(data string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(data))
+	w.Close()
+	return buf.Bytes()
+}
+
+func testReadRows(t *testing.T, rows file.Rows) (records [][]string) {
+	defer rows.Close()
+	for rows.Next() {
+		cols, err := rows.Scan()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var record []string
+		for _, c := range cols {
+			record = append(record, c.String())
+		}
+		records = append(records, record)
+	}
+	if err := rows.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestNewStreamRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		conf    string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			data: []byte("a,b\nc,d\n"),
+			conf: `{}`,
+			want: [][]string{{"a", "b"}, {"c", "d"}},
+		},
+		{
+			name: "2",
+			data: testGzip("\xEF\xBB\xBFa,b\n"),
+			conf: `{}`,
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "3",
+			data: testGzip("a|b\n"),
+			conf: `{"compress":"gz","delimiter":"|"}`,
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name:    "4",
+			data:    []byte("a,b\n"),
+			conf:    `{"compress":"zip"}`,
+			wantErr: true,
+		},
+		{
+			name: "5",
+			data: []byte("BZh,b\n"),
+			conf: `{"compress":"none"}`,
+			want: [][]string{{"BZh", "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := NewStreamRows(bytes.NewReader(tt.data), testJSONFromString(tt.conf))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewStreamRows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := testReadRows(t, rows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewInStream_Stdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() {
+		os.Stdin = stdin
+		r.Close()
+	}()
+	go func() {
+		w.Write(testGzip("a,b\nc,d\n"))
+		w.Close()
+	}()
+
+	var opener Opener
+	in, err := opener.Open(nil, file.Stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := in.Rows(testJSONFromString(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := testReadRows(t, rows), [][]string{{"a", "b"}, {"c", "d"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err = in.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	// standard input is left open
+	if _, err = r.Stat(); err != nil {
+		t.Errorf("Stat() error = %v", err)
+	}
+
+	if _, err = opener.OpenRange(nil, file.Stdin, 0, 1); err == nil {
+		t.Errorf("OpenRange() error = %v, wantErr true", err)
+	}
+}
+
+func TestTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		inTimezone  string
+		inLoc       *time.Location
+		outTimezone string
+		outLoc      *time.Location
+		want        time.Time
+		wantStr     string
+	}{
+		{
+			name:    "1",
+			want:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			wantStr: "2024-01-02 03:04:05",
+		},
+		{
+			name:        "2",
+			inTimezone:  "Asia/Shanghai",
+			inLoc:       loc,
+			outTimezone: "Asia/Shanghai",
+			outLoc:      loc,
+			want:        time.Date(2024, 1, 2, 3, 4, 5, 0, loc),
+			wantStr:     "2024-01-02 03:04:05",
+		},
+		{
+			name:        "3",
+			outTimezone: "Asia/Shanghai",
+			outLoc:      loc,
+			want:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			wantStr:     "2024-01-02 11:04:05",
+		},
+		{
+			name:       "4",
+			inTimezone: "Asia/Shanghai",
+			inLoc:      loc,
+			want:       time.Date(2024, 1, 1, 19, 4, 5, 0, time.UTC),
+			wantStr:    "2024-01-02 03:04:05",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := map[int]Column{
+				0: {
+					Type:   string(element.TypeTime),
+					Format: "yyyy-MM-dd HH:mm:ss",
+				},
+			}
+			r := &Rows{
+				columns: columns,
+				conf: &InConfig{
+					Timezone: tt.inTimezone,
+					loc:      tt.inLoc,
+				},
+			}
+			c, err := r.getColum(0, "2024-01-02 03:04:05")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.AsTime()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("getColum() = %v, want %v", got, tt.want)
+			}
+
+			w := &Writer{
+				columns: columns,
+				conf: &OutConfig{
+					Timezone: tt.outTimezone,
+					loc:      tt.outLoc,
+				},
+			}
+			s, err := w.getRecord(c, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.wantStr {
+				t.Fatalf("getRecord() = %v, want %v", s, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestWriter_TimeKind(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	tests := []struct {
+		name    string
+		c       element.Column
+		format  string
+		wantStr string
+	}{
+		{
+			name: "1",
+			c: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), element.NewStringTimeDecoder("2006-01-02")), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss",
+			wantStr: "2024-01-02",
+		},
+		{
+			name: "2",
+			c: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(0, 1, 1, 3, 4, 5, 0, time.UTC), element.NewStringTimeDecoder("15:04:05")), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss",
+			wantStr: "03:04:05",
+		},
+		{
+			name: "3",
+			c: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				tm, element.NewStringTimeDecoder("2006-01-02 15:04:05.999")), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss.SSSSSS",
+			wantStr: "2024-01-01 22:04:05.123000",
+		},
+		{
+			name:    "4",
+			c:       element.NewDefaultColumn(element.NewTimeColumnValue(tm), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss.SSSSSS",
+			wantStr: "2024-01-01 22:04:05.123456",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{
+				columns: map[int]Column{
+					0: {
+						Type:   string(element.TypeTime),
+						Format: tt.format,
+					},
+				},
+				conf: &OutConfig{
+					Timezone: "America/New_York",
+					loc:      loc,
+				},
+			}
+			s, err := w.getRecord(tt.c, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.wantStr {
+				t.Fatalf("getRecord() = %v, want %v", s, tt.wantStr)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+// ReadFile - A file opened for reading, which is implemented by *os.File
+type ReadFile interface {
+	io.Reader
+	io.ReaderAt
+	io.Closer
+	Name() string               // Get Name - Gets the name of the file
+	Stat() (fs.FileInfo, error) // Get File Info - Gets the file information such as the size
+}
+
+// WriteFile - A file created for writing, which is implemented by *os.File
+type WriteFile interface {
+	io.Writer
+	io.Closer
+	Name() string // Get Name - Gets the name of the file
+}
+
+// FileSystem - A file system whose file names start with its scheme, such as s3://bucket/key.
+// The names of the files in the local file system have no scheme
+type FileSystem interface {
+	Open(name string) (ReadFile, error)         // Open File - Opens the file named 'name' for reading
+	Create(name string) (WriteFile, error)      // Create File - Creates the file named 'name' for writing
+	Stat(name string) (fs.FileInfo, error)      // Get File Info - The error satisfies os.IsNotExist if the file does not exist
+	ReadDir(name string) ([]fs.DirEntry, error) // Read Directory - Reads the entries of the directory sorted by name
+	Rename(oldname, newname string) error       // Rename File - Renames the file in the same file system
+	Remove(name string) error                   // Remove File - Removes the file
+}
+
+// Configurer - A file system configured by the JSON configuration under its scheme, which is optionally implemented by FileSystem.
+// Configure returns a new file system configured by 'conf' and leaves the registered one unchanged
+type Configurer interface {
+	Configure(conf *config.JSON) (FileSystem, error)
+}
+
+// DirMaker - A file system with directories, which is optionally implemented by FileSystem
+type DirMaker interface {
+	MkdirAll(name string) error // Make Directories - Creates the directory named 'name' with its parents
+}
+
+// RegisterFileSystem - Registers the file system 'fsys' of the file names starting with 'scheme://'
+func RegisterFileSystem(scheme string, fsys FileSystem) {
+	if err := fileSystems.register(scheme, fsys); err != nil {
+		panic(err)
+	}
+}
+
+// UnregisterAllFileSystem - Unregisters all file systems
+func UnregisterAllFileSystem() {
+	fileSystems.unregisterAll()
+}
+
+// FS - The file systems of a plugin instance, in which the registered file systems implementing Configurer
+// are replaced by the ones configured by the configuration under their schemes, so that the plugins
+// such as an s3 reader and an s3 writer in the same job do not share their configurations.
+// The nil FS uses the registered file systems
+type FS struct {
+	fileSystems map[string]FileSystem
+	confs       map[string]*config.JSON
+}
+
+// NewFS - Creates the file systems configured by 'conf', in which each registered file system implementing
+// Configurer is configured by the configuration under its scheme, such as {"s3":{"region":"us-east-1"}}
+func NewFS(conf *config.JSON) (f *FS, err error) {
+	f = &FS{
+		fileSystems: make(map[string]FileSystem),
+		confs:       make(map[string]*config.JSON),
+	}
+	for scheme, fsys := range fileSystems.all() {
+		c, ok := fsys.(Configurer)
+		if !ok || !conf.Exists(scheme) {
+			continue
+		}
+		var sub *config.JSON
+		if sub, err = conf.GetConfig(scheme); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if fsys, err = c.Configure(sub); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("configure %v fail. err: %v", scheme, err)
+		}
+		f.fileSystems[scheme] = fsys
+		f.confs[scheme] = sub
+	}
+	return
+}
+
+// SetConfig - Sets the configurations of the configured file systems under their schemes into 'conf',
+// so that the same file systems are created by NewFS(conf), such as in the tasks of a job
+func (f *FS) SetConfig(conf *config.JSON) (err error) {
+	if f == nil {
+		return
+	}
+	for scheme, sub := range f.confs {
+		if err = conf.SetRawString(scheme, sub.String()); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Close - Closes the configured file systems implementing io.Closer, such as the connections of sftp
+func (f *FS) Close() (err error) {
+	if f == nil {
+		return
+	}
+	for _, fsys := range f.fileSystems {
+		if c, ok := fsys.(io.Closer); ok {
+			if cerr := c.Close(); cerr != nil {
+				err = cerr
+			}
+		}
+	}
+	return
+}
+
+// IsLocal - Checks whether the file named 'name' is in the local file system
+func IsLocal(name string) bool {
+	_, _, ok := lookup(name)
+	return !ok
+}
+
+// Open - Opens the file named 'name' for reading in its registered file system
+func Open(name string) (ReadFile, error) {
+	return (*FS)(nil).Open(name)
+}
+
+// Create - Creates the file named 'name' for writing in its registered file system
+func Create(name string) (WriteFile, error) {
+	return (*FS)(nil).Create(name)
+}
+
+// Stat - Gets the information of the file named 'name' in its registered file system
+func Stat(name string) (fs.FileInfo, error) {
+	return (*FS)(nil).Stat(name)
+}
+
+// ReadDir - Reads the entries of the directory named 'name' in its registered file system
+func ReadDir(name string) ([]fs.DirEntry, error) {
+	return (*FS)(nil).ReadDir(name)
+}
+
+// Rename - Renames the file 'oldname' to 'newname', which must be in the same registered file system
+func Rename(oldname, newname string) error {
+	return (*FS)(nil).Rename(oldname, newname)
+}
+
+// Remove - Removes the file named 'name' in its registered file system
+func Remove(name string) error {
+	return (*FS)(nil).Remove(name)
+}
+
+// MkdirAll - Creates the directory 'dir' with its parents in its registered file system
+func MkdirAll(dir string) error {
+	return (*FS)(nil).MkdirAll(dir)
+}
+
+// Open - Opens the file named 'name' for reading in its file system
+func (f *FS) Open(name string) (ReadFile, error) {
+	if fsys, _, ok := f.lookup(name); ok {
+		return fsys.Open(name)
+	}
+	return os.Open(name)
+}
+
+// Create - Creates the file named 'name' for writing in its file system
+func (f *FS) Create(name string) (WriteFile, error) {
+	if fsys, _, ok := f.lookup(name); ok {
+		return fsys.Create(name)
+	}
+	return os.Create(name)
+}
+
+// Stat - Gets the information of the file named 'name' in its file system
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	if fsys, _, ok := f.lookup(name); ok {
+		return fsys.Stat(name)
+	}
+	return os.Stat(name)
+}
+
+// ReadDir - Reads the entries of the directory named 'name' in its file system
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if fsys, _, ok := f.lookup(name); ok {
+		return fsys.ReadDir(name)
+	}
+	return os.ReadDir(name)
+}
+
+// Rename - Renames the file 'oldname' to 'newname', which must be in the same file system
+func (f *FS) Rename(oldname, newname string) error {
+	fsys, scheme, ok := f.lookup(oldname)
+	if _, newScheme, _ := f.lookup(newname); scheme != newScheme {
+		return fmt.Errorf("rename %v to %v across file systems", oldname, newname)
+	}
+	if ok {
+		return fsys.Rename(oldname, newname)
+	}
+	return os.Rename(oldname, newname)
+}
+
+// Remove - Removes the file named 'name' in its file system
+func (f *FS) Remove(name string) error {
+	if fsys, _, ok := f.lookup(name); ok {
+		return fsys.Remove(name)
+	}
+	return os.Remove(name)
+}
+
+// MkdirAll - Creates the directory 'dir' with its parents in its file system, nothing is done
+// in the file systems not implementing DirMaker such as object storages without directories
+func (f *FS) MkdirAll(dir string) error {
+	fsys, _, ok := f.lookup(dir)
+	if !ok {
+		return os.MkdirAll(dir, 0755)
+	}
+	if m, ok := fsys.(DirMaker); ok {
+		return m.MkdirAll(dir)
+	}
+	return nil
+}
+
+// lookup gets the configured file system of the scheme of the file named 'name',
+// or the registered one if the file system of the scheme is not configured
+func (f *FS) lookup(name string) (fsys FileSystem, scheme string, ok bool) {
+	if fsys, scheme, ok = lookup(name); !ok || f == nil {
+		return
+	}
+	if configured, exists := f.fileSystems[scheme]; exists {
+		fsys = configured
+	}
+	return
+}
+
+// Join - Joins 'name' with the elements 'elem' by the separator of its file system
+func Join(name string, elem ...string) string {
+	if _, scheme, ok := lookup(name); ok {
+		prefix := scheme + "://"
+		return prefix + path.Join(append([]string{strings.TrimPrefix(name, prefix)}, elem...)...)
+	}
+	return filepath.Join(append([]string{name}, elem...)...)
+}
+
+// Dir - Gets the directory of the file named 'name'
+func Dir(name string) string {
+	if _, scheme, ok := lookup(name); ok {
+		prefix := scheme + "://"
+		return prefix + path.Dir(strings.TrimPrefix(name, prefix))
+	}
+	return filepath.Dir(name)
+}
+
+// Base - Gets the last element of the file named 'name'
+func Base(name string) string {
+	if _, scheme, ok := lookup(name); ok {
+		return path.Base(strings.TrimPrefix(name, scheme+"://"))
+	}
+	return filepath.Base(name)
+}
+
+// lookup gets the registered file system of the scheme of the file named 'name'
+func lookup(name string) (fsys FileSystem, scheme string, ok bool) {
+	i := strings.Index(name, "://")
+	if i <= 0 {
+		return
+	}
+	scheme = name[:i]
+	if fsys, ok = fileSystems.fileSystem(scheme); !ok {
+		scheme = ""
+	}
+	return
+}
+
+var fileSystems = &fileSystemMap{
+	fileSystems: make(map[string]FileSystem),
+}
+
+type fileSystemMap struct {
+	sync.RWMutex
+	fileSystems map[string]FileSystem
+}
+
+func (f *fileSystemMap) register(scheme string, fsys FileSystem) error {
+	if fsys == nil {
+		return fmt.Errorf("file system %v is nil", scheme)
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.fileSystems[scheme]; ok {
+		return fmt.Errorf("file system %v exists", scheme)
+	}
+
+	f.fileSystems[scheme] = fsys
+	return nil
+}
+
+func (f *fileSystemMap) fileSystem(scheme string) (fsys FileSystem, ok bool) {
+	f.RLock()
+	defer f.RUnlock()
+	fsys, ok = f.fileSystems[scheme]
+	return
+}
+
+func (f *fileSystemMap) all() map[string]FileSystem {
+	f.RLock()
+	defer f.RUnlock()
+	all := make(map[string]FileSystem, len(f.fileSystems))
+	for k, v := range f.fileSystems {
+		all[k] = v
+	}
+	return all
+}
+
+func (f *fileSystemMap) unregisterAll() {
+	f.Lock()
+	defer f.Unlock()
+	f.fileSystems = make(map[string]FileSystem)
+}
@@ -0,0 +1,245 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+// mockFileSystem maps the file names mock://name to the local files under root
+type mockFileSystem struct {
+	root   string
+	conf   *config.JSON
+	closed bool
+}
+
+func (m *mockFileSystem) local(name string) string {
+	return filepath.Join(m.root, strings.TrimPrefix(name, "mock://"))
+}
+
+func (m *mockFileSystem) Open(name string) (ReadFile, error) {
+	return os.Open(m.local(name))
+}
+
+func (m *mockFileSystem) Create(name string) (WriteFile, error) {
+	return os.Create(m.local(name))
+}
+
+func (m *mockFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(m.local(name))
+}
+
+func (m *mockFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(m.local(name))
+}
+
+func (m *mockFileSystem) Rename(oldname, newname string) error {
+	return os.Rename(m.local(oldname), m.local(newname))
+}
+
+func (m *mockFileSystem) Remove(name string) error {
+	return os.Remove(m.local(name))
+}
+
+func (m *mockFileSystem) MkdirAll(name string) error {
+	return os.MkdirAll(m.local(name), 0755)
+}
+
+func (m *mockFileSystem) Configure(conf *config.JSON) (FileSystem, error) {
+	if conf.Exists("fail") {
+		return nil, errors.New("mock error")
+	}
+	root, _ := conf.GetString("root")
+	return &mockFileSystem{root: root, conf: conf}, nil
+}
+
+func (m *mockFileSystem) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestFileSystem(t *testing.T) {
+	UnregisterAllFileSystem()
+	defer UnregisterAllFileSystem()
+	m := &mockFileSystem{root: t.TempDir()}
+	RegisterFileSystem("mock", m)
+
+	if err := MkdirAll("mock://sub/dir"); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if fi, err := os.Stat(filepath.Join(m.root, "sub", "dir")); err != nil || !fi.IsDir() {
+		t.Errorf("MkdirAll() = %v, %v", fi, err)
+	}
+	w, err := Create("mock://a.csv")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	w.Write([]byte("abc"))
+	w.Close()
+	if err = Rename("mock://a.csv", "mock://b.csv"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if fi, err := Stat("mock://b.csv"); err != nil || fi.Size() != 3 {
+		t.Errorf("Stat() = %v, %v", fi, err)
+	}
+	if entries, err := ReadDir("mock://"); err != nil || len(entries) != 2 || entries[0].Name() != "b.csv" {
+		t.Errorf("ReadDir() = %v, %v", entries, err)
+	}
+	r, err := Open("mock://b.csv")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	r.Close()
+	if err = Rename("mock://b.csv", filepath.Join(m.root, "c.csv")); err == nil {
+		t.Errorf("Rename() across file systems error = %v, wantErr true", err)
+	}
+	if err = Remove("mock://b.csv"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err = Stat("mock://b.csv"); !os.IsNotExist(err) {
+		t.Errorf("Stat() error = %v, want not exist", err)
+	}
+
+	conf, _ := config.NewJSONFromString(`{"mock":{"fail":true}}`)
+	if _, err = NewFS(conf); err == nil {
+		t.Errorf("NewFS() error = %v, wantErr true", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterFileSystem() does not panic for a duplicate scheme")
+		}
+	}()
+	RegisterFileSystem("mock", m)
+}
+
+func TestFS(t *testing.T) {
+	UnregisterAllFileSystem()
+	defer UnregisterAllFileSystem()
+	m := &mockFileSystem{root: t.TempDir()}
+	RegisterFileSystem("mock", m)
+
+	newFS := func(root string) *FS {
+		conf, _ := config.NewJSONFromString(`{"other":{}}`)
+		conf.Set("mock.root", root)
+		f, err := NewFS(conf)
+		if err != nil {
+			t.Fatalf("NewFS() error = %v", err)
+		}
+		return f
+	}
+	in, out := t.TempDir(), t.TempDir()
+	reader, writer := newFS(in), newFS(out)
+	os.WriteFile(filepath.Join(in, "a.csv"), []byte("a"), 0644)
+
+	w, err := writer.Create("mock://b.csv")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	w.Close()
+	if _, err = os.Stat(filepath.Join(out, "b.csv")); err != nil {
+		t.Errorf("Create() of writer does not create in its root. error = %v", err)
+	}
+	if _, err = reader.Stat("mock://a.csv"); err != nil {
+		t.Errorf("Stat() of reader error = %v", err)
+	}
+	if _, err = writer.Stat("mock://a.csv"); !os.IsNotExist(err) {
+		t.Errorf("Stat() of writer error = %v, want not exist", err)
+	}
+	// the registered file system is not changed
+	if m.conf != nil {
+		t.Errorf("registered file system is configured. conf = %v", m.conf)
+	}
+	if _, err = (*FS)(nil).Stat("mock://a.csv"); !os.IsNotExist(err) {
+		t.Errorf("Stat() of nil FS error = %v, want not exist", err)
+	}
+
+	task, _ := config.NewJSONFromString(`{}`)
+	if err = reader.SetConfig(task); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+	if got, _ := task.GetString("mock.root"); got != in {
+		t.Errorf("SetConfig() = %v, want root %v", task, in)
+	}
+	if task.Exists("other") {
+		t.Errorf("SetConfig() = %v, want no other", task)
+	}
+
+	configured := reader.fileSystems["mock"].(*mockFileSystem)
+	if err = reader.Close(); err != nil || !configured.closed || m.closed {
+		t.Errorf("Close() error = %v, configured closed = %v, registered closed = %v", err, configured.closed, m.closed)
+	}
+}
+
+func TestPath(t *testing.T) {
+	UnregisterAllFileSystem()
+	defer UnregisterAllFileSystem()
+	RegisterFileSystem("mock", &mockFileSystem{})
+	tests := []struct {
+		name    string
+		path    string
+		isLocal bool
+		join    string
+		dir     string
+		base    string
+	}{
+		{
+			name: "1",
+			path: "mock://bucket/dir/a.csv",
+			join: "mock://bucket/dir/a.csv/x",
+			dir:  "mock://bucket/dir",
+			base: "a.csv",
+		},
+		{
+			name:    "2",
+			path:    "dir/a.csv",
+			isLocal: true,
+			join:    filepath.Join("dir", "a.csv", "x"),
+			dir:     "dir",
+			base:    "a.csv",
+		},
+		{
+			name:    "3",
+			path:    "other://bucket/a.csv",
+			isLocal: true,
+			join:    filepath.Join("other:", "bucket", "a.csv", "x"),
+			dir:     filepath.Join("other:", "bucket"),
+			base:    "a.csv",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsLocal(tt.path); got != tt.isLocal {
+				t.Errorf("IsLocal() = %v, want %v", got, tt.isLocal)
+			}
+			if got := Join(tt.path, "x"); got != tt.join {
+				t.Errorf("Join() = %v, want %v", got, tt.join)
+			}
+			if got := Dir(tt.path); got != tt.dir {
+				t.Errorf("Dir() = %v, want %v", got, tt.dir)
+			}
+			if got := Base(tt.path); got != tt.base {
+				t.Errorf("Base() = %v, want %v", got, tt.base)
+			}
+		})
+	}
+}
@@ -24,6 +24,9 @@ import (
 	"github.com/pingcap/errors"
 )
 
+// Stdin - The file name of standard input, which is opened instead of a file named '-'
+const Stdin = "-"
+
 // FetchHandler - Acquires the record handler
 type FetchHandler interface {
 	OnRecord(element.Record) error         // Process Record - Handles the record
@@ -32,7 +35,13 @@ type FetchHandler interface {
 
 // Opener - An opener used to open an input stream
 type Opener interface {
-	Open(filename string) (stream InStream, err error) // Open Input Stream - Opens an input stream for the file named 'filename'
+	Open(fsys *FS, filename string) (stream InStream, err error) // Open Input Stream - Opens an input stream for the file named 'filename' in 'fsys'
+}
+
+// RangeOpener - An opener that can open a byte range of the file, which is optionally implemented by Opener
+type RangeOpener interface {
+	// Open Input Stream of Range - Opens an input stream for the records starting in the byte range [start, end) of the file named 'filename' in 'fsys'
+	OpenRange(fsys *FS, filename string, start, end int64) (stream InStream, err error)
 }
 
 // InStream - Input stream
@@ -66,20 +75,40 @@ type InStreamer struct {
 	stream InStream
 }
 
-// NewInStreamer - Opens an input stream named 'filename' using the input stream opener with the given 'name'
-func NewInStreamer(name string, filename string) (streamer *InStreamer, err error) {
+// NewInStreamer - Opens an input stream named 'filename' in the file systems 'fsys' using the input stream opener with the given 'name'
+func NewInStreamer(fsys *FS, name string, filename string) (streamer *InStreamer, err error) {
 	opener, ok := openers.opener(name)
 	if !ok {
 		err = errors.Errorf("opener %v does not exist", name)
 		return
 	}
 	streamer = &InStreamer{}
-	if streamer.stream, err = opener.Open(filename); err != nil {
+	if streamer.stream, err = opener.Open(fsys, filename); err != nil {
 		return nil, errors.Wrapf(err, "open(%v) fail", filename)
 	}
 	return
 }
 
+// NewRangeInStreamer - Creates an input streamer for the records starting in the byte range [start, end)
+// of the file named 'filename' in the file systems 'fsys' using the opener 'name', the opener must implement RangeOpener
+func NewRangeInStreamer(fsys *FS, name string, filename string, start, end int64) (streamer *InStreamer, err error) {
+	opener, ok := openers.opener(name)
+	if !ok {
+		err = errors.Errorf("opener %v does not exist", name)
+		return
+	}
+	rangeOpener, ok := opener.(RangeOpener)
+	if !ok {
+		err = errors.Errorf("opener %v does not support range", name)
+		return
+	}
+	streamer = &InStreamer{}
+	if streamer.stream, err = rangeOpener.OpenRange(fsys, filename, start, end); err != nil {
+		return nil, errors.Wrapf(err, "openRange(%v, %v, %v) fail", filename, start, end)
+	}
+	return
+}
+
 // Read - Reads data using the record handler 'handler', context 'ctx', and configuration file 'conf'
 func (s *InStreamer) Read(ctx context.Context, conf *config.JSON, handler FetchHandler) (err error) {
 	var rows Rows
@@ -90,7 +90,7 @@ type mockOpener struct {
 	openErr  error
 }
 
-func (m *mockOpener) Open(filename string) (stream InStream, err error) {
+func (m *mockOpener) Open(fsys *FS, filename string) (stream InStream, err error) {
 	return m.inStream, m.openErr
 }
 
@@ -126,7 +126,7 @@ func TestInStreamer_Read(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s, err := NewInStreamer(tt.args.name, "")
+			s, err := NewInStreamer(nil, tt.args.name, "")
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -243,7 +243,7 @@ func TestInStreamer_ReadErr(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s, err := NewInStreamer(tt.args.name, "")
+			s, err := NewInStreamer(nil, tt.args.name, "")
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -289,7 +289,7 @@ func TestNewInStreamerErr(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewInStreamer(tt.args.name, tt.args.filename)
+			_, err := NewInStreamer(nil, tt.args.name, tt.args.filename)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewInStreamer() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -342,3 +342,56 @@ func Test_openerMap_registerErr(t *testing.T) {
 		})
 	}
 }
+
+type mockRangeOpener struct {
+	mockOpener
+}
+
+func (m *mockRangeOpener) OpenRange(fsys *FS, filename string, start, end int64) (stream InStream, err error) {
+	return m.inStream, m.openErr
+}
+
+func TestNewRangeInStreamer(t *testing.T) {
+	UnregisterAllOpener()
+	RegisterOpener("mock", &mockOpener{})
+	RegisterOpener("mockRange", &mockRangeOpener{})
+	RegisterOpener("mockRangeErr", &mockRangeOpener{
+		mockOpener: mockOpener{
+			openErr: errors.New("mock err"),
+		},
+	})
+	tests := []struct {
+		name    string
+		opener  string
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			opener:  "mockRange",
+			wantErr: false,
+		},
+		{
+			name:    "2",
+			opener:  "mock",
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			opener:  "mockRangeErr",
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			opener:  "mockNone",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRangeInStreamer(nil, tt.opener, "", 0, 10)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRangeInStreamer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -25,7 +25,7 @@ import (
 
 // Creator - The creator that generates the output stream.
 type Creator interface {
-	Create(filename string) (stream OutStream, err error) // Create an output stream named 'filename'.
+	Create(fsys *FS, filename string) (stream OutStream, err error) // Create an output stream named 'filename' in 'fsys'.
 }
 
 // OutStream - Represents the output stream.
@@ -58,15 +58,15 @@ type OutStreamer struct {
 	stream OutStream
 }
 
-// NewOutStreamer - Opens an output stream named 'filename' using the creator with the given name 'name'.
-func NewOutStreamer(name string, filename string) (streamer *OutStreamer, err error) {
+// NewOutStreamer - Opens an output stream named 'filename' in the file systems 'fsys' using the creator with the given name 'name'.
+func NewOutStreamer(fsys *FS, name string, filename string) (streamer *OutStreamer, err error) {
 	creator, ok := creators.creator(name)
 	if !ok {
 		err = errors.Errorf("creator %v does not exist", name)
 		return nil, err
 	}
 	streamer = &OutStreamer{}
-	if streamer.stream, err = creator.Create(filename); err != nil {
+	if streamer.stream, err = creator.Create(fsys, filename); err != nil {
 		return nil, errors.Wrapf(err, "create fail")
 	}
 	return
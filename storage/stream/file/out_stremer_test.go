@@ -54,7 +54,7 @@ type mockCreator struct {
 	createErr error
 }
 
-func (m *mockCreator) Create(filename string) (stream OutStream, err error) {
+func (m *mockCreator) Create(fsys *FS, filename string) (stream OutStream, err error) {
 	return &mockOutStream{}, m.createErr
 }
 
@@ -62,7 +62,7 @@ func TestOutStreamer_Write(t *testing.T) {
 	UnregisterAllCreater()
 	RegisterCreator("mock", &mockCreator{})
 
-	s, err := NewOutStreamer("mock", "")
+	s, err := NewOutStreamer(nil, "mock", "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -141,7 +141,7 @@ func TestNewOutStreamerErr(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewOutStreamer(tt.args.name, tt.args.filename)
+			_, err := NewOutStreamer(nil, tt.args.name, tt.args.filename)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewOutStreamer() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,329 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Error error response of s3
+type Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("s3 error. status: %v code: %v message: %v", e.StatusCode, e.Code, e.Message)
+}
+
+var (
+	maxCopySize  int64 = 5 << 30 // maximum bytes of an object copied by a single request
+	copyPartSize int64 = 1 << 30 // bytes of each part copied by multipart copy
+)
+
+// client the client of the s3 REST API used by the file system
+type client struct {
+	conf *Config
+	http *http.Client
+	now  func() time.Time
+}
+
+func newClient(conf *Config) *client {
+	// the whole request is not limited by a timeout, as an object of gigabytes may be read
+	// by a single request, while connecting and waiting for the response headers are limited
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   conf.timeout(),
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = conf.timeout()
+	transport.ResponseHeaderTimeout = conf.timeout()
+	return &client{
+		conf: conf,
+		http: &http.Client{Transport: transport},
+		now:  time.Now,
+	}
+}
+
+// url gets the url of the object key in bucket
+func (c *client) url(bucket, key string, query url.Values) (u *url.URL, err error) {
+	if u, err = url.Parse(c.conf.Endpoint); err != nil {
+		return nil, err
+	}
+	if c.conf.PathStyle {
+		u.Path += "/" + bucket + "/" + key
+	} else {
+		u.Host = bucket + "." + u.Host
+		u.Path += "/" + key
+	}
+	u.RawPath = escape(u.Path, false)
+	u.RawQuery = query.Encode()
+	return
+}
+
+// do sends the request, and the error response is returned as *Error
+func (c *client) do(method, bucket, key string, query url.Values, header http.Header, body []byte) (resp *http.Response, err error) {
+	var u *url.URL
+	if u, err = c.url(bucket, key, query); err != nil {
+		return nil, err
+	}
+	var req *http.Request
+	if req, err = http.NewRequest(method, u.String(), bytes.NewReader(body)); err != nil {
+		return nil, err
+	}
+	req.URL = u
+	req.ContentLength = int64(len(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if c.conf.AccessKeyID != "" {
+		payloadHash := emptyHash
+		if len(body) > 0 {
+			payloadHash = hashHex(body)
+		}
+		sign(req, payloadHash, c.conf, c.now())
+	}
+
+	if resp, err = c.http.Do(req); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		e := &Error{StatusCode: resp.StatusCode}
+		data, _ := io.ReadAll(resp.Body)
+		xml.Unmarshal(data, e)
+		return nil, e
+	}
+	return
+}
+
+// doXML sends the request and decodes the xml response into v if v is not nil
+func (c *client) doXML(method, bucket, key string, query url.Values, header http.Header, body []byte, v any) (err error) {
+	var resp *http.Response
+	if resp, err = c.do(method, bucket, key, query, header, body); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if v == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return
+	}
+	return xml.NewDecoder(resp.Body).Decode(v)
+}
+
+// object object information
+type object struct {
+	Key          string    `xml:"Key"`
+	Size         int64     `xml:"Size"`
+	LastModified time.Time `xml:"LastModified"`
+}
+
+// listResult result of ListObjectsV2
+type listResult struct {
+	IsTruncated           bool     `xml:"IsTruncated"`
+	NextContinuationToken string   `xml:"NextContinuationToken"`
+	Contents              []object `xml:"Contents"`
+	CommonPrefixes        []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+// headObject gets the information of the object
+func (c *client) headObject(bucket, key string) (o object, err error) {
+	var resp *http.Response
+	if resp, err = c.do(http.MethodHead, bucket, key, nil, nil, nil); err != nil {
+		return
+	}
+	resp.Body.Close()
+	o.Key = key
+	o.Size = resp.ContentLength
+	o.LastModified, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+	return
+}
+
+// getObject gets the content of the object from offset
+func (c *client) getObject(bucket, key string, offset int64) (io.ReadCloser, error) {
+	header := http.Header{}
+	header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	resp, err := c.do(http.MethodGet, bucket, key, nil, header, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// listObjects lists a page of the objects with prefix, the keys are grouped
+// into the common prefixes by delimiter if not empty
+func (c *client) listObjects(bucket, prefix, delimiter, token string, maxKeys int) (r *listResult, err error) {
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", prefix)
+	if delimiter != "" {
+		query.Set("delimiter", delimiter)
+	}
+	if token != "" {
+		query.Set("continuation-token", token)
+	}
+	if maxKeys > 0 {
+		query.Set("max-keys", strconv.Itoa(maxKeys))
+	}
+	r = &listResult{}
+	if err = c.doXML(http.MethodGet, bucket, "", query, nil, nil, r); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// putObject puts the object with data
+func (c *client) putObject(bucket, key string, data []byte) error {
+	return c.doXML(http.MethodPut, bucket, key, nil, nil, data, nil)
+}
+
+// copyResult result of CopyObject and UploadPartCopy, which may be an error
+// in the response of 200 OK if the error occurs while copying
+type copyResult struct {
+	XMLName xml.Name
+	ETag    string `xml:"ETag"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (r *copyResult) err() error {
+	if r.XMLName.Local == "Error" {
+		return &Error{StatusCode: http.StatusOK, Code: r.Code, Message: r.Message}
+	}
+	return nil
+}
+
+// copyObject copies the object srcKey in srcBucket to key in bucket by a single request,
+// which is limited to maxCopySize
+func (c *client) copyObject(srcBucket, srcKey, bucket, key string) (err error) {
+	header := http.Header{}
+	header.Set("X-Amz-Copy-Source", escape("/"+srcBucket+"/"+srcKey, false))
+	var result copyResult
+	if err = c.doXML(http.MethodPut, bucket, key, nil, header, nil, &result); err != nil {
+		return
+	}
+	return result.err()
+}
+
+// multipartCopy copies the object srcKey of size in srcBucket to key in bucket by the parts
+// of copyPartSize, and the multipart upload is aborted if any part fails
+func (c *client) multipartCopy(srcBucket, srcKey, bucket, key string, size int64) (err error) {
+	var uploadID string
+	if uploadID, err = c.createMultipartUpload(bucket, key); err != nil {
+		return
+	}
+	var parts []completedPart
+	for start := int64(0); start < size; start += copyPartSize {
+		end := start + copyPartSize
+		if end > size {
+			end = size
+		}
+		var part completedPart
+		if part, err = c.uploadPartCopy(srcBucket, srcKey, bucket, key, uploadID,
+			len(parts)+1, start, end-1); err != nil {
+			c.abortMultipartUpload(bucket, key, uploadID)
+			return
+		}
+		parts = append(parts, part)
+	}
+	if err = c.completeMultipartUpload(bucket, key, uploadID, parts); err != nil {
+		c.abortMultipartUpload(bucket, key, uploadID)
+	}
+	return
+}
+
+// uploadPartCopy copies the bytes [first, last] of the object srcKey in srcBucket as the part
+// numbered number and gets its ETag
+func (c *client) uploadPartCopy(srcBucket, srcKey, bucket, key, uploadID string,
+	number int, first, last int64) (part completedPart, err error) {
+	query := url.Values{}
+	query.Set("partNumber", strconv.Itoa(number))
+	query.Set("uploadId", uploadID)
+	header := http.Header{}
+	header.Set("X-Amz-Copy-Source", escape("/"+srcBucket+"/"+srcKey, false))
+	header.Set("X-Amz-Copy-Source-Range", "bytes="+strconv.FormatInt(first, 10)+"-"+strconv.FormatInt(last, 10))
+	var result copyResult
+	if err = c.doXML(http.MethodPut, bucket, key, query, header, nil, &result); err != nil {
+		return
+	}
+	if err = result.err(); err != nil {
+		return
+	}
+	return completedPart{PartNumber: number, ETag: result.ETag}, nil
+}
+
+// deleteObject deletes the object
+func (c *client) deleteObject(bucket, key string) error {
+	return c.doXML(http.MethodDelete, bucket, key, nil, nil, nil, nil)
+}
+
+// createMultipartUpload starts a multipart upload and gets its upload ID
+func (c *client) createMultipartUpload(bucket, key string) (string, error) {
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := c.doXML(http.MethodPost, bucket, key, url.Values{"uploads": {""}}, nil, nil, &result); err != nil {
+		return "", err
+	}
+	return result.UploadID, nil
+}
+
+// completedPart part of the completed multipart upload
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// uploadPart uploads the part numbered number and gets its ETag
+func (c *client) uploadPart(bucket, key, uploadID string, number int, data []byte) (part completedPart, err error) {
+	query := url.Values{}
+	query.Set("partNumber", strconv.Itoa(number))
+	query.Set("uploadId", uploadID)
+	var resp *http.Response
+	if resp, err = c.do(http.MethodPut, bucket, key, query, nil, data); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")}, nil
+}
+
+// completeMultipartUpload completes the multipart upload with the uploaded parts
+func (c *client) completeMultipartUpload(bucket, key, uploadID string, parts []completedPart) (err error) {
+	var body []byte
+	if body, err = xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts}); err != nil {
+		return
+	}
+	return c.doXML(http.MethodPost, bucket, key, url.Values{"uploadId": {uploadID}}, nil, body, nil)
+}
+
+// abortMultipartUpload aborts the multipart upload and the uploaded parts are deleted
+func (c *client) abortMultipartUpload(bucket, key, uploadID string) error {
+	return c.doXML(http.MethodDelete, bucket, key, url.Values{"uploadId": {uploadID}}, nil, nil, nil)
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go/time2"
+)
+
+const (
+	defaultRegion   = "us-east-1"
+	defaultPartSize = 16 * 1024 * 1024
+	minPartSize     = 5 * 1024 * 1024
+	defaultTimeout  = time.Minute
+)
+
+// Config s3 configuration, the empty fields are taken from the environment variables of AWS
+type Config struct {
+	Endpoint        string `json:"endpoint"`        // Endpoint URL, AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL if empty, https://s3.<region>.amazonaws.com by default
+	Region          string `json:"region"`          // Region, AWS_REGION or AWS_DEFAULT_REGION if empty, us-east-1 by default
+	AccessKeyID     string `json:"accessKeyID"`     // Access key ID, AWS_ACCESS_KEY_ID if empty, the requests are not signed without it
+	SecretAccessKey string `json:"secretAccessKey"` // Secret access key, AWS_SECRET_ACCESS_KEY if empty
+	SessionToken    string `json:"sessionToken"`    // Session token of temporary credentials, AWS_SESSION_TOKEN if empty
+	PathStyle       bool   `json:"pathStyle"`       // Whether to use the path style URL endpoint/bucket/key instead of bucket.endpoint/key
+	PartSize        int64  `json:"partSize"`        // Byte size of each part of multipart upload, 16MiB by default and 5MiB at least
+	// Timeout of connecting and waiting for the response headers of each request, 1m by default
+	Timeout time2.Duration `json:"timeout"`
+}
+
+// NewConfig gets the s3 configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if err = c.init(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// NewConfigFromEnv gets the s3 configuration from the environment variables
+func NewConfigFromEnv() *Config {
+	c := &Config{}
+	c.init()
+	return c
+}
+
+// init fills the empty fields by the environment variables and the defaults
+func (c *Config) init() error {
+	c.Endpoint = firstNonEmpty(c.Endpoint, os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL"))
+	c.Region = firstNonEmpty(c.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), defaultRegion)
+	if c.AccessKeyID == "" {
+		c.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		c.SecretAccessKey = firstNonEmpty(c.SecretAccessKey, os.Getenv("AWS_SECRET_ACCESS_KEY"))
+		c.SessionToken = firstNonEmpty(c.SessionToken, os.Getenv("AWS_SESSION_TOKEN"))
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = "https://s3." + c.Region + ".amazonaws.com"
+	}
+	c.Endpoint = strings.TrimSuffix(c.Endpoint, "/")
+	if c.AccessKeyID != "" && c.SecretAccessKey == "" {
+		return fmt.Errorf("secretAccessKey is empty")
+	}
+	switch {
+	case c.PartSize == 0:
+		c.PartSize = defaultPartSize
+	case c.PartSize < minPartSize:
+		return fmt.Errorf("partSize should be at least %v", minPartSize)
+	}
+	return nil
+}
+
+// timeout gets the timeout of connecting and waiting for the response headers
+func (c *Config) timeout() time.Duration {
+	if c.Timeout.Duration <= 0 {
+		return defaultTimeout
+	}
+	return c.Timeout.Duration
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
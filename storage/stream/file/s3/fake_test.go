@@ -0,0 +1,221 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fakeS3 in-process fake of the s3 REST API with path style URLs, which implements the
+// operations used by the file system
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte         // bucket/key -> content
+	uploads  map[string]map[int][]byte // upload ID -> part number -> content
+	pageSize int                       // maximum keys of a list page
+	requests []string                  // method and path of the requests
+	unsigned int                       // number of the requests not signed
+}
+
+func newFakeS3() (*fakeS3, *httptest.Server) {
+	f := &fakeS3{
+		objects:  make(map[string][]byte),
+		uploads:  make(map[string]map[int][]byte),
+		pageSize: 1000,
+	}
+	return f, httptest.NewServer(f)
+}
+
+func (f *fakeS3) put(name string, data string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[name] = []byte(data)
+}
+
+func (f *fakeS3) get(name string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.objects[name]
+	return string(data), ok
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	if !strings.HasPrefix(r.Header.Get("Authorization"), signAlgorithm+" Credential=") {
+		f.unsigned++
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	name := bucket + "/" + key
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case r.Method == http.MethodGet && key == "" && query.Get("list-type") == "2":
+		f.list(w, bucket, query)
+	case r.Method == http.MethodHead, r.Method == http.MethodGet:
+		data, ok := f.objects[name]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Last-Modified", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+		if v := r.Header.Get("Range"); v != "" {
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(v, "bytes="), "-"))
+			data = data[start:]
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		}
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		id := strconv.Itoa(len(f.uploads) + 1)
+		f.uploads[id] = make(map[int][]byte)
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%v</UploadId></InitiateMultipartUploadResult>", id)
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		var complete struct {
+			Parts []completedPart `xml:"Part"`
+		}
+		xml.Unmarshal(body, &complete)
+		var data []byte
+		for _, p := range complete.Parts {
+			data = append(data, parts[p.PartNumber]...)
+		}
+		f.objects[name] = data
+		delete(f.uploads, query.Get("uploadId"))
+		w.Write([]byte("<CompleteMultipartUploadResult></CompleteMultipartUploadResult>"))
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		if source := r.Header.Get("X-Amz-Copy-Source"); source != "" {
+			data, ok := f.objects[strings.TrimPrefix(source, "/")]
+			if !ok {
+				f.error(w, http.StatusNotFound, "NoSuchKey")
+				return
+			}
+			var first, last int
+			fmt.Sscanf(r.Header.Get("X-Amz-Copy-Source-Range"), "bytes=%d-%d", &first, &last)
+			parts[number] = data[first : last+1]
+			fmt.Fprintf(w, `<CopyPartResult><ETag>"%v"</ETag></CopyPartResult>`, number)
+			return
+		}
+		parts[number] = body
+		w.Header().Set("ETag", `"`+strconv.Itoa(number)+`"`)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		data, ok := f.objects[strings.TrimPrefix(r.Header.Get("X-Amz-Copy-Source"), "/")]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		f.objects[name] = data
+		w.Write([]byte("<CopyObjectResult></CopyObjectResult>"))
+	case r.Method == http.MethodPut:
+		f.objects[name] = body
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(f.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, bucket string, query map[string][]string) {
+	get := func(k string) string {
+		if v := query[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	prefix, delimiter := get("prefix"), get("delimiter")
+	// the token is the hex of the last key, or the common prefix followed by 0xff to skip its keys
+	last, _ := hex.DecodeString(get("continuation-token"))
+	token := string(last)
+	maxKeys := f.pageSize
+	if v, _ := strconv.Atoi(get("max-keys")); v > 0 && v < maxKeys {
+		maxKeys = v
+	}
+
+	var keys []string
+	for name := range f.objects {
+		if key, ok := strings.CutPrefix(name, bucket+"/"); ok && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var r listResult
+	prefixes := make(map[string]bool)
+	n := 0
+	for _, key := range keys {
+		if key <= token {
+			continue
+		}
+		if n >= maxKeys {
+			r.IsTruncated = true
+			break
+		}
+		if i := strings.Index(key[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+			p := key[:len(prefix)+i+1]
+			if !prefixes[p] {
+				prefixes[p] = true
+				r.CommonPrefixes = append(r.CommonPrefixes, struct {
+					Prefix string `xml:"Prefix"`
+				}{p})
+				n++
+			}
+			r.NextContinuationToken = hex.EncodeToString([]byte(p + "\xff"))
+		} else {
+			r.Contents = append(r.Contents, object{Key: key, Size: int64(len(f.objects[bucket+"/"+key]))})
+			n++
+			r.NextContinuationToken = hex.EncodeToString([]byte(key))
+		}
+	}
+	data, _ := xml.Marshal(struct {
+		XMLName xml.Name `xml:"ListBucketResult"`
+		listResult
+	}{listResult: r})
+	w.Write(data)
+}
+
+func (f *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%v</Code><Message>%v</Message></Error>", code, http.StatusText(status))
+}
@@ -0,0 +1,418 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+// Scheme scheme of the file names in s3, such as s3://bucket/key
+const Scheme = "s3"
+
+func init() {
+	file.RegisterFileSystem(Scheme, NewFileSystem(NewConfigFromEnv()))
+}
+
+// FileSystem file system of s3, each bucket is a directory and
+// the keys are separated into directories by '/'
+type FileSystem struct {
+	client *client
+}
+
+// NewFileSystem creates the file system of s3 by conf
+func NewFileSystem(conf *Config) *FileSystem {
+	return &FileSystem{
+		client: newClient(conf),
+	}
+}
+
+// Configure creates a new file system by conf, whose empty fields are taken from the environment variables,
+// the registered file system is not changed
+func (f *FileSystem) Configure(conf *config.JSON) (file.FileSystem, error) {
+	c, err := NewConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileSystem(c), nil
+}
+
+// Open opens the object named name for reading, which is read by ranged requests
+func (f *FileSystem) Open(name string) (file.ReadFile, error) {
+	bucket, key, err := split(name)
+	if err != nil {
+		return nil, err
+	}
+	c := f.client
+	o, err := c.headObject(bucket, key)
+	if err != nil {
+		return nil, pathError("open", name, err)
+	}
+	return &reader{
+		client: c,
+		bucket: bucket,
+		key:    key,
+		name:   name,
+		info:   newFileInfo(o),
+	}, nil
+}
+
+// Create creates the object named name for writing, which is visible only after closed
+func (f *FileSystem) Create(name string) (file.WriteFile, error) {
+	bucket, key, err := split(name)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return nil, pathError("create", name, fs.ErrInvalid)
+	}
+	return &writer{
+		client: f.client,
+		bucket: bucket,
+		key:    key,
+		name:   name,
+	}, nil
+}
+
+// Stat gets the information of the object named name, or the directory if
+// there are objects with the prefix name/
+func (f *FileSystem) Stat(name string) (fs.FileInfo, error) {
+	bucket, key, err := split(name)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return &fileInfo{name: bucket, dir: true}, nil
+	}
+	c := f.client
+	o, err := c.headObject(bucket, key)
+	if err == nil {
+		return newFileInfo(o), nil
+	}
+	if !isNotFound(err) {
+		return nil, pathError("stat", name, err)
+	}
+	r, err := c.listObjects(bucket, key+"/", "", "", 1)
+	if err != nil {
+		return nil, pathError("stat", name, err)
+	}
+	if len(r.Contents) == 0 {
+		return nil, pathError("stat", name, fs.ErrNotExist)
+	}
+	return &fileInfo{name: path.Base(key), dir: true}, nil
+}
+
+// ReadDir reads the objects and the directories directly under name
+func (f *FileSystem) ReadDir(name string) (entries []fs.DirEntry, err error) {
+	var bucket, prefix string
+	if bucket, prefix, err = split(name); err != nil {
+		return nil, err
+	}
+	if prefix != "" {
+		prefix += "/"
+	}
+	c := f.client
+	token := ""
+	for {
+		var r *listResult
+		if r, err = c.listObjects(bucket, prefix, "/", token, 0); err != nil {
+			return nil, pathError("readdir", name, err)
+		}
+		for _, p := range r.CommonPrefixes {
+			entries = append(entries, &fileInfo{name: strings.TrimSuffix(strings.TrimPrefix(p.Prefix, prefix), "/"), dir: true})
+		}
+		for _, o := range r.Contents {
+			// the empty object created as a directory by some consoles
+			if o.Key == prefix {
+				continue
+			}
+			entries = append(entries, newFileInfo(o))
+		}
+		if !r.IsTruncated {
+			break
+		}
+		token = r.NextContinuationToken
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return
+}
+
+// Rename copies the object oldname to newname and then deletes oldname, the object larger
+// than 5GB is copied by multipart copy as a single copy request is limited to 5GB
+func (f *FileSystem) Rename(oldname, newname string) (err error) {
+	var srcBucket, srcKey, bucket, key string
+	if srcBucket, srcKey, err = split(oldname); err != nil {
+		return
+	}
+	if bucket, key, err = split(newname); err != nil {
+		return
+	}
+	c := f.client
+	var o object
+	if o, err = c.headObject(srcBucket, srcKey); err != nil {
+		return pathError("rename", oldname, err)
+	}
+	if o.Size > maxCopySize {
+		err = c.multipartCopy(srcBucket, srcKey, bucket, key, o.Size)
+	} else {
+		err = c.copyObject(srcBucket, srcKey, bucket, key)
+	}
+	if err != nil {
+		return pathError("rename", oldname, err)
+	}
+	if err = c.deleteObject(srcBucket, srcKey); err != nil {
+		return pathError("rename", oldname, err)
+	}
+	return
+}
+
+// Remove deletes the object named name
+func (f *FileSystem) Remove(name string) (err error) {
+	var bucket, key string
+	if bucket, key, err = split(name); err != nil {
+		return
+	}
+	if err = f.client.deleteObject(bucket, key); err != nil {
+		return pathError("remove", name, err)
+	}
+	return
+}
+
+// split splits the name s3://bucket/key into bucket and key
+func split(name string) (bucket, key string, err error) {
+	p := strings.TrimPrefix(name, Scheme+"://")
+	if p == name {
+		return "", "", fmt.Errorf("%v is not a name of %v", name, Scheme)
+	}
+	bucket, key, _ = strings.Cut(p, "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("bucket of %v is empty", name)
+	}
+	return bucket, strings.TrimSuffix(key, "/"), nil
+}
+
+func isNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// pathError wraps err as *fs.PathError, and the not found error satisfies os.IsNotExist
+func pathError(op, name string, err error) error {
+	if isNotFound(err) {
+		err = fs.ErrNotExist
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// fileInfo information of the object or the directory, which is also a directory entry
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func newFileInfo(o object) *fileInfo {
+	return &fileInfo{
+		name:    path.Base(o.Key),
+		size:    o.Size,
+		modTime: o.LastModified,
+	}
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) ModTime() time.Time { return i.modTime }
+func (i *fileInfo) IsDir() bool        { return i.dir }
+func (i *fileInfo) Sys() any           { return nil }
+
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+func (i *fileInfo) Type() fs.FileMode          { return i.Mode().Type() }
+func (i *fileInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+// reader reader of the object, the sequential reads share one streaming response
+// and a new ranged request is sent only when the offset jumps
+type reader struct {
+	client *client
+	bucket string
+	key    string
+	name   string
+	info   *fileInfo
+
+	mu     sync.Mutex
+	body   io.ReadCloser // response body of the current ranged request
+	pos    int64         // offset of body
+	offset int64         // offset of Read
+}
+
+// Read reads the object from the offset of the last Read
+func (r *reader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadAt(p, r.offset)
+	r.offset += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return
+}
+
+// ReadAt reads len(p) bytes of the object from off
+func (r *reader) ReadAt(p []byte, off int64) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if off >= r.info.size {
+		return 0, io.EOF
+	}
+	if r.body == nil || r.pos != off {
+		r.closeBody()
+		if r.body, err = r.client.getObject(r.bucket, r.key, off); err != nil {
+			return 0, pathError("read", r.name, err)
+		}
+		r.pos = off
+	}
+
+	want := p
+	if rest := r.info.size - off; int64(len(want)) > rest {
+		want = want[:rest]
+	}
+	n, err = io.ReadFull(r.body, want)
+	r.pos += int64(n)
+	if err != nil {
+		r.closeBody()
+		return n, pathError("read", r.name, err)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return
+}
+
+func (r *reader) closeBody() {
+	if r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+}
+
+// Name gets the name of the object such as s3://bucket/key
+func (r *reader) Name() string {
+	return r.name
+}
+
+// Stat gets the information of the object
+func (r *reader) Stat() (fs.FileInfo, error) {
+	return r.info, nil
+}
+
+// Close closes the current response body
+func (r *reader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closeBody()
+	return nil
+}
+
+// writer writer of the object, which is put at once if its size is less than
+// the part size, or uploaded by multipart upload otherwise
+type writer struct {
+	client *client
+	bucket string
+	key    string
+	name   string
+
+	buf      []byte
+	uploadID string
+	parts    []completedPart
+	err      error // the first error, the upload is aborted when closed if not nil
+}
+
+// Write writes p into the buffer, and a part is uploaded when the buffer is full
+func (w *writer) Write(p []byte) (n int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.buf = append(w.buf, p...)
+	for int64(len(w.buf)) >= w.client.conf.PartSize {
+		if err = w.upload(w.buf[:w.client.conf.PartSize]); err != nil {
+			w.err = err
+			return 0, err
+		}
+		w.buf = w.buf[w.client.conf.PartSize:]
+	}
+	return len(p), nil
+}
+
+// upload uploads data as the next part
+func (w *writer) upload(data []byte) (err error) {
+	if w.uploadID == "" {
+		if w.uploadID, err = w.client.createMultipartUpload(w.bucket, w.key); err != nil {
+			return pathError("write", w.name, err)
+		}
+	}
+	var part completedPart
+	if part, err = w.client.uploadPart(w.bucket, w.key, w.uploadID, len(w.parts)+1, data); err != nil {
+		return pathError("write", w.name, err)
+	}
+	w.parts = append(w.parts, part)
+	return
+}
+
+// Name gets the name of the object such as s3://bucket/key
+func (w *writer) Name() string {
+	return w.name
+}
+
+// Close puts the object or completes the multipart upload,
+// and the multipart upload is aborted if any error occurs
+func (w *writer) Close() (err error) {
+	if w.err == nil && w.uploadID == "" {
+		if err = w.client.putObject(w.bucket, w.key, w.buf); err != nil {
+			return pathError("close", w.name, err)
+		}
+		return
+	}
+
+	if w.err == nil && len(w.buf) > 0 {
+		w.err = w.upload(w.buf)
+	}
+	if w.err == nil {
+		if err = w.client.completeMultipartUpload(w.bucket, w.key, w.uploadID, w.parts); err == nil {
+			return
+		}
+		w.err = pathError("close", w.name, err)
+	}
+	if w.uploadID != "" {
+		w.client.abortMultipartUpload(w.bucket, w.key, w.uploadID)
+	}
+	return w.err
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat = "20060102T150405Z"
+	emptyHash     = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// sign signs the request by AWS Signature Version 4 with the hex sha256 of the payload at t
+func sign(req *http.Request, payloadHash string, c *Config, t time.Time) {
+	amzDate := t.UTC().Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.SessionToken)
+	}
+
+	headers, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escape(req.URL.Path, false),
+		canonicalQuery(req),
+		headers,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := amzDate[:8] + "/" + c.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		signAlgorithm,
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := []byte("AWS4" + c.SecretAccessKey)
+	for _, v := range []string{amzDate[:8], c.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, v)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", signAlgorithm+" Credential="+c.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalHeaders gets the canonical headers and the signed header names,
+// host, content-md5, content-type, date, range and x-amz-* are signed
+func canonicalHeaders(req *http.Request) (headers, signedHeaders string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	values := map[string]string{"host": host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		switch {
+		case k == "content-md5", k == "content-type", k == "date", k == "range", strings.HasPrefix(k, "x-amz-"):
+			values[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	var names []string
+	for k := range values {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, k := range names {
+		b.WriteString(k + ":" + values[k] + "\n")
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+// canonicalQuery gets the query string sorted by the escaped names and values
+func canonicalQuery(req *http.Request) string {
+	var pairs []string
+	for k, vs := range req.URL.Query() {
+		for _, v := range vs {
+			pairs = append(pairs, escape(k, true)+"="+escape(v, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// escape escapes s by URI encoding of AWS, where only the unreserved characters are kept,
+// and '/' is kept unless escapeSlash
+func escape(s string, escapeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !escapeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
+func hashHex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go/time2"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	defaultPort    = "22"
+	defaultTimeout = 30 * time.Second
+)
+
+// Config sftp configuration, the user in the file name takes precedence over User
+type Config struct {
+	User                  string         `json:"user"`                  // User name
+	Password              string         `json:"password"`              // Password
+	PrivateKey            string         `json:"privateKey"`            // Path of the private key file
+	PrivateKeyPassphrase  string         `json:"privateKeyPassphrase"`  // Passphrase of the encrypted private key
+	KnownHosts            string         `json:"knownHosts"`            // Path of the known_hosts file, ~/.ssh/known_hosts by default
+	InsecureIgnoreHostKey bool           `json:"insecureIgnoreHostKey"` // Whether to accept any host key without checking known_hosts, only for tests
+	Timeout               time2.Duration `json:"timeout"`               // Timeout of connecting, 30s by default
+}
+
+// NewConfig gets the sftp configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if c.Password == "" && c.PrivateKey == "" {
+		return nil, fmt.Errorf("password and privateKey are both empty")
+	}
+	return
+}
+
+// clientConfig gets the ssh client configuration of user
+func (c *Config) clientConfig(user string) (conf *ssh.ClientConfig, err error) {
+	if user == "" {
+		user = c.User
+	}
+	if user == "" {
+		return nil, fmt.Errorf("user is empty")
+	}
+	conf = &ssh.ClientConfig{
+		User:    user,
+		Timeout: c.Timeout.Duration,
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = defaultTimeout
+	}
+
+	if c.PrivateKey != "" {
+		var signer ssh.Signer
+		if signer, err = c.signer(); err != nil {
+			return nil, err
+		}
+		conf.Auth = append(conf.Auth, ssh.PublicKeys(signer))
+	}
+	if c.Password != "" {
+		conf.Auth = append(conf.Auth, ssh.Password(c.Password))
+	}
+
+	if c.InsecureIgnoreHostKey {
+		conf.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+		return
+	}
+	knownHosts := c.KnownHosts
+	if knownHosts == "" {
+		var home string
+		if home, err = os.UserHomeDir(); err != nil {
+			return nil, fmt.Errorf("get home directory fail. err: %v", err)
+		}
+		knownHosts = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	if conf.HostKeyCallback, err = knownhosts.New(knownHosts); err != nil {
+		return nil, fmt.Errorf("read knownHosts %v fail. err: %v", knownHosts, err)
+	}
+	return
+}
+
+// signer gets the signer of the private key
+func (c *Config) signer() (signer ssh.Signer, err error) {
+	var key []byte
+	if key, err = os.ReadFile(c.PrivateKey); err != nil {
+		return nil, fmt.Errorf("read privateKey %v fail. err: %v", c.PrivateKey, err)
+	}
+	if c.PrivateKeyPassphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(c.PrivateKeyPassphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse privateKey %v fail. err: %v", c.PrivateKey, err)
+	}
+	return
+}
@@ -0,0 +1,335 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Scheme scheme of the file names in sftp, such as sftp://user@host:port/path
+const Scheme = "sftp"
+
+const posixRename = "posix-rename@openssh.com"
+
+func init() {
+	file.RegisterFileSystem(Scheme, NewFileSystem(&Config{}))
+}
+
+// FileSystem file system of sftp servers, the connection to each server is
+// established when first used and shared by all the files of the server
+type FileSystem struct {
+	mu      sync.Mutex
+	conf    *Config
+	clients map[string]*client // clients by user@host:port
+}
+
+type client struct {
+	ssh  *ssh.Client
+	sftp *sftp.Client
+}
+
+func (c *client) close() error {
+	c.sftp.Close()
+	return c.ssh.Close()
+}
+
+// NewFileSystem creates the file system of sftp by conf
+func NewFileSystem(conf *Config) *FileSystem {
+	return &FileSystem{
+		conf:    conf,
+		clients: make(map[string]*client),
+	}
+}
+
+// Configure creates a new file system by conf with its own connections, which are closed by its Close,
+// the registered file system and its connections are not changed
+func (f *FileSystem) Configure(conf *config.JSON) (file.FileSystem, error) {
+	c, err := NewConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileSystem(c), nil
+}
+
+// Close closes the established connections
+func (f *FileSystem) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closeAll()
+	return nil
+}
+
+func (f *FileSystem) closeAll() {
+	for k, c := range f.clients {
+		c.close()
+		delete(f.clients, k)
+	}
+}
+
+// client gets the client of the server of the file named name and the path in the server,
+// the connection is established if not yet, and dropped after lost so that it is established again
+func (f *FileSystem) client(name string) (c *sftp.Client, p string, err error) {
+	var user, addr string
+	if user, addr, p, err = split(name); err != nil {
+		return
+	}
+	key := user + "@" + addr
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if cli, ok := f.clients[key]; ok {
+		return cli.sftp, p, nil
+	}
+	var conf *ssh.ClientConfig
+	if conf, err = f.conf.clientConfig(user); err != nil {
+		return
+	}
+	cli := &client{}
+	if cli.ssh, err = ssh.Dial("tcp", addr, conf); err != nil {
+		return nil, "", fmt.Errorf("dial %v fail. err: %v", addr, err)
+	}
+	if cli.sftp, err = sftp.NewClient(cli.ssh); err != nil {
+		cli.ssh.Close()
+		return nil, "", fmt.Errorf("start sftp of %v fail. err: %v", addr, err)
+	}
+	f.clients[key] = cli
+	go func() {
+		cli.sftp.Wait()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.clients[key] == cli {
+			delete(f.clients, key)
+		}
+		cli.ssh.Close()
+	}()
+	return cli.sftp, p, nil
+}
+
+// Open opens the file named name for reading
+func (f *FileSystem) Open(name string) (file.ReadFile, error) {
+	c, p, err := f.client(name)
+	if err != nil {
+		return nil, err
+	}
+	sf, err := c.Open(p)
+	if err != nil {
+		return nil, pathError("open", name, err)
+	}
+	return &reader{File: sf, name: name}, nil
+}
+
+// Create creates or truncates the file named name for writing
+func (f *FileSystem) Create(name string) (file.WriteFile, error) {
+	c, p, err := f.client(name)
+	if err != nil {
+		return nil, err
+	}
+	sf, err := c.Create(p)
+	if err != nil {
+		return nil, pathError("create", name, err)
+	}
+	return newWriter(sf, name), nil
+}
+
+// Stat gets the information of the file named name
+func (f *FileSystem) Stat(name string) (fs.FileInfo, error) {
+	c, p, err := f.client(name)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := c.Stat(p)
+	if err != nil {
+		return nil, pathError("stat", name, err)
+	}
+	return fi, nil
+}
+
+// ReadDir reads the entries of the directory named name sorted by name
+func (f *FileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	c, p, err := f.client(name)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := c.ReadDir(p)
+	if err != nil {
+		return nil, pathError("readdir", name, err)
+	}
+	entries := make([]fs.DirEntry, 0, len(infos))
+	for _, fi := range infos {
+		entries = append(entries, fs.FileInfoToDirEntry(fi))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+// Rename renames oldname to newname in the same server, newname is replaced if it exists
+func (f *FileSystem) Rename(oldname, newname string) (err error) {
+	var user, addr, newUser, newAddr, newpath string
+	if user, addr, _, err = split(oldname); err != nil {
+		return
+	}
+	if newUser, newAddr, newpath, err = split(newname); err != nil {
+		return
+	}
+	if user != newUser || addr != newAddr {
+		return fmt.Errorf("rename %v to %v across servers", oldname, newname)
+	}
+	c, oldpath, err := f.client(oldname)
+	if err != nil {
+		return err
+	}
+	// the rename of sftp v3 fails if newname exists
+	if _, ok := c.HasExtension(posixRename); ok {
+		err = c.PosixRename(oldpath, newpath)
+	} else {
+		if err = c.Remove(newpath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return pathError("rename", newname, err)
+		}
+		err = c.Rename(oldpath, newpath)
+	}
+	if err != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: underlying(err)}
+	}
+	return nil
+}
+
+// Remove removes the file or the empty directory named name
+func (f *FileSystem) Remove(name string) error {
+	c, p, err := f.client(name)
+	if err != nil {
+		return err
+	}
+	if err = c.Remove(p); err != nil {
+		return pathError("remove", name, err)
+	}
+	return nil
+}
+
+// MkdirAll creates the directory named name with its parents
+func (f *FileSystem) MkdirAll(name string) error {
+	c, p, err := f.client(name)
+	if err != nil {
+		return err
+	}
+	if err = c.MkdirAll(p); err != nil {
+		return pathError("mkdir", name, err)
+	}
+	return nil
+}
+
+// split splits the file name like sftp://user@host:port/path into user,
+// host:port and the absolute path in the server, the port is 22 by default
+func split(name string) (user, addr, p string, err error) {
+	rest := strings.TrimPrefix(name, Scheme+"://")
+	if rest == name {
+		return "", "", "", fmt.Errorf("%v is not a name of %v", name, Scheme)
+	}
+	addr, p, _ = strings.Cut(rest, "/")
+	p = "/" + p
+	if i := strings.LastIndexByte(addr, '@'); i >= 0 {
+		user, addr = addr[:i], addr[i+1:]
+	}
+	if addr == "" {
+		return "", "", "", fmt.Errorf("host of %v is empty", name)
+	}
+	if _, _, serr := net.SplitHostPort(addr); serr != nil {
+		addr = net.JoinHostPort(strings.Trim(addr, "[]"), defaultPort)
+	}
+	return
+}
+
+// underlying gets the error of sftp without the path in the server
+func underlying(err error) error {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		err = pe.Err
+	}
+	var se *sftp.StatusError
+	if errors.As(err, &se) {
+		switch se.FxCode() {
+		case sftp.ErrSSHFxNoSuchFile:
+			return fs.ErrNotExist
+		case sftp.ErrSSHFxPermissionDenied:
+			return fs.ErrPermission
+		}
+	}
+	return err
+}
+
+// pathError wraps err as *fs.PathError of name, and the error of the file not found satisfies os.IsNotExist
+func pathError(op, name string, err error) error {
+	return &fs.PathError{Op: op, Path: name, Err: underlying(err)}
+}
+
+// reader file for reading, whose name is the name in the file system
+type reader struct {
+	*sftp.File
+
+	name string
+}
+
+func (r *reader) Name() string {
+	return r.name
+}
+
+// writer file for writing, the data is buffered to reduce round trips of small writes
+type writer struct {
+	file *sftp.File
+	buf  *bufio.Writer
+	name string
+}
+
+func newWriter(f *sftp.File, name string) *writer {
+	return &writer{
+		file: f,
+		buf:  bufio.NewWriterSize(f, 256*1024),
+		name: name,
+	}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *writer) Name() string {
+	return w.name
+}
+
+// Close flushes the buffered data and closes the file
+func (w *writer) Close() (err error) {
+	err = w.buf.Flush()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return pathError("close", w.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,476 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/Breeze0806/go-etl/storage/stream/file/csv"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	testUser     = "etl"
+	testPassword = "secret"
+)
+
+// testServer is an in-process sftp server of the local file system
+type testServer struct {
+	addr       string
+	dir        string // temporary directory for the files of tests
+	knownHosts string // path of known_hosts with the host key of the server
+	privateKey string // path of the private key authorized by the server
+}
+
+func newTestServer(t *testing.T) *testServer {
+	dir := t.TempDir()
+	_, hostKey, _ := ed25519.GenerateKey(rand.Reader)
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userPub, userKey, _ := ed25519.GenerateKey(rand.Reader)
+	authorized, err := ssh.NewPublicKey(userPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if c.User() == testUser && string(password) == testPassword {
+				return nil, nil
+			}
+			return nil, os.ErrPermission
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == testUser && bytes.Equal(key.Marshal(), authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, os.ErrPermission
+		},
+	}
+	conf.AddHostKey(hostSigner)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			nc, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(nc, conf)
+		}
+	}()
+
+	s := &testServer{
+		addr:       l.Addr().String(),
+		dir:        filepath.ToSlash(dir),
+		knownHosts: filepath.Join(t.TempDir(), "known_hosts"),
+		privateKey: filepath.Join(t.TempDir(), "id_ed25519"),
+	}
+	line := knownhosts.Line([]string{knownhosts.Normalize(s.addr)}, hostSigner.PublicKey())
+	if err = os.WriteFile(s.knownHosts, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(userKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(s.privateKey, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func serveSSH(nc net.Conn, conf *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(nc, conf)
+	if err != nil {
+		nc.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for nch := range chans {
+		if nch.ChannelType() != "session" {
+			nch.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		ch, reqs, err := nch.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			for req := range reqs {
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				req.Reply(ok, nil)
+				if !ok {
+					continue
+				}
+				server, err := sftp.NewServer(ch)
+				if err != nil {
+					ch.Close()
+					return
+				}
+				server.Serve()
+				server.Close()
+				return
+			}
+		}()
+	}
+}
+
+// name gets the sftp file name of the relative path p in the temporary directory
+func (s *testServer) name(p string) string {
+	return "sftp://" + testUser + "@" + s.addr + strings.TrimSuffix(s.dir+"/"+p, "/")
+}
+
+func (s *testServer) fileSystem(t *testing.T, conf string) *FileSystem {
+	c, err := NewConfig(testJSONFromString(conf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.KnownHosts == "" {
+		c.KnownHosts = s.knownHosts
+	}
+	fsys := NewFileSystem(c)
+	t.Cleanup(func() { fsys.Close() })
+	return fsys
+}
+
+func testJSONFromString(s string) *config.JSON {
+	j, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return j
+}
+
+func TestFileSystem(t *testing.T) {
+	s := newTestServer(t)
+	fsys := s.fileSystem(t, `{"password":"secret"}`)
+
+	if err := fsys.MkdirAll(s.name("in/sub")); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	w, err := fsys.Create(s.name("in/a.csv"))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if w.Name() != s.name("in/a.csv") {
+		t.Errorf("Name() = %v", w.Name())
+	}
+	io.WriteString(w, "a,b\n")
+	io.WriteString(w, "c,d\n")
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(s.dir, "in", "a.csv")); string(data) != "a,b\nc,d\n" {
+		t.Errorf("file = %q", data)
+	}
+	os.WriteFile(filepath.Join(s.dir, "in", "b.csv"), []byte("b"), 0644)
+
+	r, err := fsys.Open(s.name("in/a.csv"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	p := make([]byte, 3)
+	if n, err := r.ReadAt(p, 4); n != 3 || err != nil || string(p) != "c,d" {
+		t.Errorf("ReadAt() = %v %v %q", n, err, p)
+	}
+	if data, err := io.ReadAll(r); err != nil || string(data) != "a,b\nc,d\n" {
+		t.Errorf("ReadAll() = %q, %v", data, err)
+	}
+	if fi, err := r.Stat(); err != nil || fi.Size() != 8 {
+		t.Errorf("Stat() = %v, %v", fi, err)
+	}
+	r.Close()
+
+	entries, err := fsys.ReadDir(s.name("in"))
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name()+map[bool]string{true: "/"}[e.IsDir()])
+	}
+	if want := []string{"a.csv", "b.csv", "sub/"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadDir() = %v, want %v", got, want)
+	}
+
+	// the existing file is replaced
+	if err = fsys.Rename(s.name("in/a.csv"), s.name("in/b.csv")); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(s.dir, "in", "b.csv")); string(data) != "a,b\nc,d\n" {
+		t.Errorf("file = %q", data)
+	}
+	if err = fsys.Rename(s.name("in/b.csv"), "sftp://other@"+s.addr+"/b.csv"); err == nil {
+		t.Errorf("Rename() error = %v, wantErr true", err)
+	}
+	if err = fsys.Remove(s.name("in/b.csv")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err = fsys.Stat(s.name("in/b.csv")); !os.IsNotExist(err) {
+		t.Errorf("Stat() error = %v, want not exist", err)
+	}
+	if _, err = fsys.Open(s.name("in/none.csv")); !os.IsNotExist(err) {
+		t.Errorf("Open() error = %v, want not exist", err)
+	}
+	if _, err = fsys.Open("sftp:///none.csv"); err == nil {
+		t.Errorf("Open() error = %v, wantErr true", err)
+	}
+}
+
+func TestFileSystem_Auth(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		name    string
+		conf    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: `{"privateKey":"` + filepath.ToSlash(s.privateKey) + `"}`,
+		},
+		{
+			name:    "2",
+			conf:    `{"password":"wrong"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    `{"password":"secret","knownHosts":"` + filepath.ToSlash(filepath.Join(t.TempDir(), "none")) + `"}`,
+			wantErr: true,
+		},
+		{
+			name: "4",
+			conf: `{"password":"secret","knownHosts":"` + filepath.ToSlash(s.privateKey) + `.none","insecureIgnoreHostKey":true}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := s.fileSystem(t, tt.conf)
+			_, err := fsys.Stat(s.name(""))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Stat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// the host key not in known_hosts is rejected
+	other := newTestServer(t)
+	os.WriteFile(s.knownHosts, nil, 0600)
+	fsys := other.fileSystem(t, `{"password":"secret","knownHosts":"`+filepath.ToSlash(s.knownHosts)+`"}`)
+	if _, err := fsys.Stat(other.name("")); err == nil {
+		t.Errorf("Stat() error = %v, wantErr true", err)
+	}
+}
+
+func TestFileSystem_Registered(t *testing.T) {
+	s := newTestServer(t)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("a,b\nc,d\n"))
+	zw.Close()
+	os.WriteFile(filepath.Join(s.dir, "a.csv.gz"), buf.Bytes(), 0644)
+
+	conf := testJSONFromString(`{"sftp":{"password":"secret"}}`)
+	conf.Set("sftp.knownHosts", s.knownHosts)
+	fsys, err := file.NewFS(conf)
+	if err != nil {
+		t.Fatalf("NewFS() error = %v", err)
+	}
+	defer fsys.Close()
+	if err := fsys.MkdirAll(s.name("out/dt=1")); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if fi, err := os.Stat(filepath.Join(s.dir, "out", "dt=1")); err != nil || !fi.IsDir() {
+		t.Errorf("MkdirAll() = %v, %v", fi, err)
+	}
+
+	// the compressed file is streamed by the csv opener
+	in, err := csv.NewInStream(fsys, s.name("a.csv.gz"))
+	if err != nil {
+		t.Fatalf("NewInStream() error = %v", err)
+	}
+	defer in.Close()
+	rows, err := in.Rows(testJSONFromString(`{}`))
+	if err != nil {
+		t.Fatalf("Rows() error = %v", err)
+	}
+	defer rows.Close()
+	var got [][]string
+	for rows.Next() {
+		columns, err := rows.Scan()
+		if err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		var row []string
+		for _, c := range columns {
+			row = append(row, c.String())
+		}
+		got = append(got, row)
+	}
+	if want := [][]string{{"a", "b"}, {"c", "d"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestFileSystem_Instances(t *testing.T) {
+	s := newTestServer(t)
+	os.WriteFile(filepath.Join(s.dir, "a.csv"), []byte("a,b\n"), 0644)
+
+	newFS := func(password string) *file.FS {
+		conf := testJSONFromString(`{}`)
+		conf.Set("sftp.password", password)
+		conf.Set("sftp.knownHosts", s.knownHosts)
+		fsys, err := file.NewFS(conf)
+		if err != nil {
+			t.Fatalf("NewFS() error = %v", err)
+		}
+		return fsys
+	}
+	reader := newFS(testPassword)
+	defer reader.Close()
+	r, err := reader.Open(s.name("a.csv"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer r.Close()
+
+	// another instance neither shares the configuration nor closes the connections of the reader
+	writer := newFS("wrong")
+	if _, err = writer.Stat(s.name("a.csv")); err == nil {
+		t.Errorf("Stat() of writer error = %v, wantErr true", err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Errorf("Close() of writer error = %v", err)
+	}
+	// the registered file system is not configured
+	if _, err = file.Stat(s.name("a.csv")); err == nil {
+		t.Errorf("Stat() of registered error = %v, wantErr true", err)
+	}
+
+	got, err := io.ReadAll(r)
+	if err != nil || string(got) != "a,b\n" {
+		t.Errorf("ReadAll() = %q, %v", got, err)
+	}
+	if _, err = reader.Stat(s.name("a.csv")); err != nil {
+		t.Errorf("Stat() of reader error = %v", err)
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: `{"user":"etl","password":"secret","timeout":"10s"}`,
+		},
+		{
+			name:    "2",
+			conf:    `{"user":"etl"}`,
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    `{"password":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(testJSONFromString(tt.conf))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		user    string
+		addr    string
+		path    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			file: "sftp://etl@example.com:2222/data/in/*.csv",
+			user: "etl",
+			addr: "example.com:2222",
+			path: "/data/in/*.csv",
+		},
+		{
+			name: "2",
+			file: "sftp://example.com",
+			addr: "example.com:22",
+			path: "/",
+		},
+		{
+			name: "3",
+			file: "sftp://a@b@[::1]/x",
+			user: "a@b",
+			addr: "[::1]:22",
+			path: "/x",
+		},
+		{
+			name:    "4",
+			file:    "sftp://etl@/x",
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			file:    "/x",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, addr, path, err := split(tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("split() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if user != tt.user || addr != tt.addr || path != tt.path {
+				t.Errorf("split() = %v %v %v, want %v %v %v", user, addr, path, tt.user, tt.addr, tt.path)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package xlsx
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
@@ -26,10 +27,12 @@ import (
 
 // InConfig represents the input XLSX configuration
 type InConfig struct {
-	Columns    []Column `json:"column"`     // Column information array
-	Sheet      string   `json:"sheet"`      // Sheet name
-	NullFormat string   `json:"nullFormat"` // Null text
-	StartRow   int      `json:"startRow"`   // Starting row for reading, starting from the 1st row
+	Columns    []Column `json:"column"`             // Column information array
+	Sheet      string   `json:"sheet"`              // Sheet name
+	NullFormat string   `json:"nullFormat"`         // Null text
+	StartRow   int      `json:"startRow"`           // Starting row for reading, starting from the 1st row
+	Timezone   string   `json:"timezone,omitempty"` // Time zone of time values without offset, UTC if empty
+	loc        *time.Location
 }
 
 // NewInConfig creates a new input XLSX configuration based on the JSON configuration conf
@@ -44,6 +47,10 @@ func NewInConfig(conf *config.JSON) (c *InConfig, err error) {
 		return nil, fmt.Errorf("sheet should not be empty")
 	}
 
+	if c.loc, err = loadLocation(c.Timezone); err != nil {
+		return nil, err
+	}
+
 	for _, v := range c.Columns {
 		if err = v.validate(); err != nil {
 			return nil, err
@@ -61,12 +68,14 @@ func (c *InConfig) startRow() int {
 
 // OutConfig represents the output XLSX configuration
 type OutConfig struct {
-	Columns    []Column `json:"column"`     // Column information array
-	Sheets     []string `json:"sheets"`     // Sheet name
-	NullFormat string   `json:"nullFormat"` // Null text
-	HasHeader  bool     `json:"hasHeader"`  // Whether there is a column header
-	Header     []string `json:"header"`     // Column header
-	SheetRow   int      `json:"sheetRow"`   // Maximum number of rows in the sheet
+	Columns    []Column `json:"column"`             // Column information array
+	Sheets     []string `json:"sheets"`             // Sheet name
+	NullFormat string   `json:"nullFormat"`         // Null text
+	HasHeader  bool     `json:"hasHeader"`          // Whether there is a column header
+	Header     []string `json:"header"`             // Column header
+	SheetRow   int      `json:"sheetRow"`           // Maximum number of rows in the sheet
+	Timezone   string   `json:"timezone,omitempty"` // Time zone to format time values in, unchanged if empty
+	loc        *time.Location
 }
 
 // NewOutConfig creates a new output XLSX configuration based on the JSON configuration conf
@@ -84,6 +93,10 @@ func NewOutConfig(conf *config.JSON) (c *OutConfig, err error) {
 		return nil, fmt.Errorf("sheetRow should be not less than %v and positive", excelize.TotalRows)
 	}
 
+	if c.loc, err = loadLocation(c.Timezone); err != nil {
+		return nil, err
+	}
+
 	for _, v := range c.Columns {
 		if err = v.validate(); err != nil {
 			return nil, err
@@ -101,11 +114,12 @@ func (c *OutConfig) sheetRow() int {
 
 // Column represents column information
 type Column struct {
-	Index    string `json:"index"`  // Column index, e.g., A, B, C, ..., AA, ...
-	Type     string `json:"type"`   // Type (bool, bigInt, decimal, string, time)
-	Format   string `json:"format"` // Joda time format
-	indexNum int
-	goLayout string
+	Index          string `json:"index"`  // Column index, e.g., A, B, C, ..., AA, ...
+	Type           string `json:"type"`   // Type (bool, bigInt, decimal, string, time)
+	Format         string `json:"format"` // Joda time format
+	indexNum       int
+	goLayout       string
+	goFormatLayout string
 }
 
 // Validate performs validation
@@ -144,3 +158,32 @@ func (c *Column) layout() string {
 	c.goLayout = jodaTime.GetLayout(c.Format)
 	return c.goLayout
 }
+
+// formatLayout converts to the Golang time format of writing, whose fractional seconds
+// keep the width of the format
+func (c *Column) formatLayout() string {
+	if c.goFormatLayout != "" {
+		return c.goFormatLayout
+	}
+	c.goFormatLayout = element.FixedFractionLayout(c.layout())
+	return c.goFormatLayout
+}
+
+// loadLocation loads the time zone by its name, nil is returned if the name is empty
+func loadLocation(name string) (loc *time.Location, err error) {
+	if name == "" {
+		return nil, nil
+	}
+	if loc, err = time.LoadLocation(name); err != nil {
+		return nil, fmt.Errorf("timezone %v is not valid", name)
+	}
+	return
+}
+
+// location gets the time zone of time values without offset, UTC by default
+func (c *InConfig) location() *time.Location {
+	if c.loc == nil {
+		return time.UTC
+	}
+	return c.loc
+}
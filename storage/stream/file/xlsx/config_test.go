@@ -17,6 +17,7 @@ package xlsx
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
@@ -194,6 +195,24 @@ func TestNewInConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "6",
+			args: args{
+				conf: testJSONFromString(`{"sheet":"sheet1","timezone":"Mars/Olympus"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "7",
+			args: args{
+				conf: testJSONFromString(`{"sheet":"sheet1","timezone":"UTC"}`),
+			},
+			wantC: &InConfig{
+				Sheet:    "sheet1",
+				Timezone: "UTC",
+				loc:      time.UTC,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -281,6 +300,24 @@ func TestNewOutConfig(t *testing.T) {
 				SheetRow: 1048576,
 			},
 		},
+		{
+			name: "8",
+			args: args{
+				conf: testJSONFromString(`{"sheets":["sheet1"],"timezone":"Mars/Olympus"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "9",
+			args: args{
+				conf: testJSONFromString(`{"sheets":["sheet1"],"timezone":"UTC"}`),
+			},
+			wantC: &OutConfig{
+				Sheets:   []string{"sheet1"},
+				Timezone: "UTC",
+				loc:      time.UTC,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
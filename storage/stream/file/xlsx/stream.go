@@ -16,6 +16,7 @@ package xlsx
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"time"
 
@@ -37,41 +38,61 @@ func init() {
 type Opener struct {
 }
 
-// Open - Opens an XLSX input stream named 'filename'.
-func (o *Opener) Open(filename string) (file.InStream, error) {
-	return NewInStream(filename)
+// Open - Opens an XLSX input stream named 'filename' in 'fsys'.
+func (o *Opener) Open(fsys *file.FS, filename string) (file.InStream, error) {
+	return NewInStream(fsys, filename)
 }
 
 // Creator - A utility for creating XLSX output streams.
 type Creator struct {
 }
 
-// Create - Creates an XLSX output stream named 'filename'.
-func (c *Creator) Create(filename string) (file.OutStream, error) {
-	return NewOutStream(filename)
+// Create - Creates an XLSX output stream named 'filename' in 'fsys'.
+func (c *Creator) Create(fsys *file.FS, filename string) (file.OutStream, error) {
+	return NewOutStream(fsys, filename)
 }
 
 // Stream - Represents an XLSX file stream.
 type Stream struct {
 	file     *excelize.File
 	filename string
+	fsys     *file.FS
 }
 
-// NewInStream - Creates an XLSX input stream named 'filename'.
-func NewInStream(filename string) (file.InStream, error) {
+// NewInStream - Creates an XLSX input stream named 'filename' in 'fsys', standard input is read if filename is file.Stdin.
+func NewInStream(fsys *file.FS, filename string) (file.InStream, error) {
 	stream := &Stream{}
 	var err error
-	stream.file, err = excelize.OpenFile(filename)
+	switch {
+	case filename == file.Stdin:
+		// the whole workbook is read into memory as xlsx is a zip archive
+		stream.file, err = excelize.OpenReader(os.Stdin)
+	case !file.IsLocal(filename):
+		stream.file, err = openReader(fsys, filename)
+	default:
+		stream.file, err = excelize.OpenFile(filename)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return stream, nil
 }
 
-// NewOutStream - Creates an XLSX output stream named 'filename'.
-func NewOutStream(filename string) (file.OutStream, error) {
+// openReader reads the whole workbook named filename in the file system such as s3 into memory
+func openReader(fsys *file.FS, filename string) (*excelize.File, error) {
+	f, err := fsys.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return excelize.OpenReader(f)
+}
+
+// NewOutStream - Creates an XLSX output stream named 'filename' in 'fsys'.
+func NewOutStream(fsys *file.FS, filename string) (file.OutStream, error) {
 	stream := &Stream{
 		filename: filename,
+		fsys:     fsys,
 	}
 	stream.file = excelize.NewFile()
 	return stream, nil
@@ -89,6 +110,9 @@ func (s *Stream) Writer(conf *config.JSON) (file.StreamWriter, error) {
 
 // Close - Closes the file stream.
 func (s *Stream) Close() (err error) {
+	if s.filename != "" && !file.IsLocal(s.filename) {
+		return s.saveTo(s.filename)
+	}
 	if s.filename != "" {
 		err = s.file.SaveAs(s.filename)
 		return
@@ -96,6 +120,19 @@ func (s *Stream) Close() (err error) {
 	return s.file.Close()
 }
 
+// saveTo writes the workbook into the file named filename in the file system such as s3
+func (s *Stream) saveTo(filename string) (err error) {
+	var f file.WriteFile
+	if f, err = s.fsys.Create(filename); err != nil {
+		return
+	}
+	if err = s.file.Write(f); err != nil {
+		f.Close()
+		return
+	}
+	return f.Close()
+}
+
 // Rows - Represents a row reader for CSV data.
 type Rows struct {
 	*excelize.Rows
@@ -155,7 +192,7 @@ func (r *Rows) getColum(index int, s string) (element.Column, error) {
 				strconv.Itoa(index), byteSize), nil
 		}
 		layout := c.layout()
-		t, err := time.Parse(layout, s)
+		t, err := time.ParseInLocation(layout, s, r.config.location())
 		if err != nil {
 			return nil, errors.Wrapf(err, "Parse time fail. layout: %v", layout)
 		}
@@ -287,7 +324,14 @@ func (w *Writer) getRecord(col element.Column, i int) (s string, err error) {
 		if t, err = col.AsTime(); err != nil {
 			return
 		}
-		s = t.Format(c.layout())
+		// the date only and the time of day are written as they are without the time zone
+		// conversion, the date only is written without the time of day and the fractional
+		// seconds are cut to the precision of the value
+		kind, precision := element.TimeKindOf(col)
+		if w.conf.loc != nil && kind == element.TimeKindDateTime {
+			t = t.In(w.conf.loc)
+		}
+		s = element.TruncateTime(t, precision).Format(element.KindLayout(c.formatLayout(), kind))
 		return
 	}
 	s, err = col.AsString()
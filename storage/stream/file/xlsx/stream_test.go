@@ -18,6 +18,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
@@ -105,7 +106,7 @@ func Test_WriteRead(t *testing.T) {
 			}
 			wFunc := func() {
 				var creator Creator
-				out, err := creator.Create(tt.args.filename)
+				out, err := creator.Create(nil, tt.args.filename)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -124,7 +125,7 @@ func Test_WriteRead(t *testing.T) {
 			var got []element.Record
 			rFunc := func() {
 				var opener Opener
-				in, err := opener.Open(tt.args.filename)
+				in, err := opener.Open(nil, tt.args.filename)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -204,7 +205,7 @@ func TestWriter_Write(t *testing.T) {
 			defer os.Remove(tt.args.filename)
 
 			var creator Creator
-			out, err := creator.Create(tt.args.filename)
+			out, err := creator.Create(nil, tt.args.filename)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -225,3 +226,156 @@ func TestWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestTimezone(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		inTimezone  string
+		inLoc       *time.Location
+		outTimezone string
+		outLoc      *time.Location
+		want        time.Time
+		wantStr     string
+	}{
+		{
+			name:    "1",
+			want:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			wantStr: "2024-01-02 03:04:05",
+		},
+		{
+			name:        "2",
+			inTimezone:  "Asia/Shanghai",
+			inLoc:       loc,
+			outTimezone: "Asia/Shanghai",
+			outLoc:      loc,
+			want:        time.Date(2024, 1, 2, 3, 4, 5, 0, loc),
+			wantStr:     "2024-01-02 03:04:05",
+		},
+		{
+			name:        "3",
+			outTimezone: "Asia/Shanghai",
+			outLoc:      loc,
+			want:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			wantStr:     "2024-01-02 11:04:05",
+		},
+		{
+			name:       "4",
+			inTimezone: "Asia/Shanghai",
+			inLoc:      loc,
+			want:       time.Date(2024, 1, 1, 19, 4, 5, 0, time.UTC),
+			wantStr:    "2024-01-02 03:04:05",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := map[int]Column{
+				0: {
+					Type:   string(element.TypeTime),
+					Format: "yyyy-MM-dd HH:mm:ss",
+				},
+			}
+			r := &Rows{
+				columns: columns,
+				config: &InConfig{
+					Timezone: tt.inTimezone,
+					loc:      tt.inLoc,
+				},
+			}
+			c, err := r.getColum(0, "2024-01-02 03:04:05")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.AsTime()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("getColum() = %v, want %v", got, tt.want)
+			}
+
+			w := &Writer{
+				columns: columns,
+				conf: &OutConfig{
+					Timezone: tt.outTimezone,
+					loc:      tt.outLoc,
+				},
+			}
+			s, err := w.getRecord(c, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.wantStr {
+				t.Fatalf("getRecord() = %v, want %v", s, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestWriter_TimeKind(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	tests := []struct {
+		name    string
+		c       element.Column
+		format  string
+		wantStr string
+	}{
+		{
+			name: "1",
+			c: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), element.NewStringTimeDecoder("2006-01-02")), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss",
+			wantStr: "2024-01-02",
+		},
+		{
+			name: "2",
+			c: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				time.Date(0, 1, 1, 3, 4, 5, 0, time.UTC), element.NewStringTimeDecoder("15:04:05")), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss",
+			wantStr: "03:04:05",
+		},
+		{
+			name: "3",
+			c: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(
+				tm, element.NewStringTimeDecoder("2006-01-02 15:04:05.999")), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss.SSSSSS",
+			wantStr: "2024-01-01 22:04:05.123000",
+		},
+		{
+			name:    "4",
+			c:       element.NewDefaultColumn(element.NewTimeColumnValue(tm), "c", 0),
+			format:  "yyyy-MM-dd HH:mm:ss.SSSSSS",
+			wantStr: "2024-01-01 22:04:05.123456",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Writer{
+				columns: map[int]Column{
+					0: {
+						Type:   string(element.TypeTime),
+						Format: tt.format,
+					},
+				},
+				conf: &OutConfig{
+					Timezone: "America/New_York",
+					loc:      loc,
+				},
+			}
+			s, err := w.getRecord(tt.c, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.wantStr {
+				t.Fatalf("getRecord() = %v, want %v", s, tt.wantStr)
+			}
+		})
+	}
+}
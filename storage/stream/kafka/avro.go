@@ -0,0 +1,275 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// AvroSchema gets the avro record schema named name of the columns, whose fields
+// are unions of null and the avro types of the column types:
+// bool is boolean, bigInt is long, time is long of timestamp-millis, bytes is bytes,
+// and decimal, string and json are string
+func (c *Codec) AvroSchema(name string) string {
+	type field struct {
+		Name    string `json:"name"`
+		Type    []any  `json:"type"`
+		Default any    `json:"default"`
+	}
+	schema := struct {
+		Type   string  `json:"type"`
+		Name   string  `json:"name"`
+		Fields []field `json:"fields"`
+	}{
+		Type: "record",
+		Name: name,
+	}
+	for _, col := range c.columns {
+		var typ any
+		switch element.ColumnType(col.Type) {
+		case element.TypeBool:
+			typ = "boolean"
+		case element.TypeBigInt:
+			typ = "long"
+		case element.TypeBytes:
+			typ = "bytes"
+		case element.TypeTime:
+			typ = map[string]string{"type": "long", "logicalType": "timestamp-millis"}
+		default:
+			typ = "string"
+		}
+		schema.Fields = append(schema.Fields, field{Name: col.Name, Type: []any{"null", typ}})
+	}
+	s, _ := json.Marshal(schema)
+	return string(s)
+}
+
+func (c *Codec) encodeAvro(record element.Record) (value []byte, err error) {
+	var e avroEncoder
+	if c.schemaID > 0 {
+		e.Raw(confluentHeader(c.schemaID))
+	}
+	for _, col := range c.columns {
+		var v element.Column
+		if v, err = record.GetByName(col.Name); err != nil {
+			return
+		}
+		if v.IsNil() {
+			e.Varint(0)
+			continue
+		}
+		e.Varint(1)
+		if err = encodeAvroValue(&e, element.ColumnType(col.Type), v); err != nil {
+			return nil, errors.Wrapf(err, "column %v", col.Name)
+		}
+	}
+	return e.Bytes(), nil
+}
+
+func encodeAvroValue(e *avroEncoder, typ element.ColumnType, v element.Column) error {
+	switch typ {
+	case element.TypeBool:
+		b, err := v.AsBool()
+		if err != nil {
+			return err
+		}
+		e.Bool(b)
+	case element.TypeBigInt:
+		i, err := v.AsInt64()
+		if err != nil {
+			return err
+		}
+		e.Varint(i)
+	case element.TypeTime:
+		t, err := v.AsTime()
+		if err != nil {
+			return err
+		}
+		e.Varint(t.UnixMilli())
+	case element.TypeBytes:
+		b, err := v.AsBytes()
+		if err != nil {
+			return err
+		}
+		e.VarBytes(b)
+	case element.TypeDecimal:
+		d, err := v.AsDecimal()
+		if err != nil {
+			return err
+		}
+		e.VarBytes([]byte(d.String()))
+	default:
+		s, err := v.AsString()
+		if err != nil {
+			return err
+		}
+		e.VarBytes([]byte(s))
+	}
+	return nil
+}
+
+func (c *Codec) decodeAvro(value []byte) (cols []element.Column, err error) {
+	if c.schemaID > 0 {
+		if len(value) < 5 || value[0] != 0 {
+			return nil, errors.New("value is not in the confluent wire format")
+		}
+		value = value[5:]
+	}
+	d := &avroDecoder{b: value}
+	for _, col := range c.columns {
+		typ := element.ColumnType(col.Type)
+		var v element.ColumnValue
+		switch d.Varint() {
+		case 0:
+			v = nilValue(typ)
+		case 1:
+			if v, err = decodeAvroValue(d, typ); err != nil {
+				return nil, errors.Wrapf(err, "column %v", col.Name)
+			}
+		default:
+			return nil, errors.Errorf("union index of column %v is not valid", col.Name)
+		}
+		if err = d.err; err != nil {
+			return nil, errors.Wrapf(err, "column %v", col.Name)
+		}
+		cols = append(cols, element.NewDefaultColumn(v, col.Name, 0))
+	}
+	if len(d.b) > 0 {
+		return nil, errors.Errorf("%v bytes are left after the columns", len(d.b))
+	}
+	return
+}
+
+func decodeAvroValue(d *avroDecoder, typ element.ColumnType) (element.ColumnValue, error) {
+	switch typ {
+	case element.TypeBool:
+		return element.NewBoolColumnValue(d.Bool()), nil
+	case element.TypeBigInt:
+		return element.NewBigIntColumnValueFromInt64(d.Varint()), nil
+	case element.TypeTime:
+		return element.NewTimeColumnValue(time.UnixMilli(d.Varint()).UTC()), nil
+	case element.TypeBytes:
+		return element.NewBytesColumnValue(d.VarBytes()), nil
+	case element.TypeDecimal:
+		return element.NewDecimalColumnValueFromString(string(d.VarBytes()))
+	case element.TypeJSON:
+		return element.NewJsonColumnValueFromBytes(d.VarBytes())
+	}
+	return element.NewStringColumnValue(string(d.VarBytes())), nil
+}
+
+// isAvroName whether name matches [A-Za-z_][A-Za-z0-9_]*
+func isAvroName(name string) bool {
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// avroEncoder encodes the primitive types of avro binary encoding
+type avroEncoder struct {
+	b []byte
+}
+
+// Bytes gets the encoded bytes
+func (e *avroEncoder) Bytes() []byte {
+	return e.b
+}
+
+// Raw appends v as it is
+func (e *avroEncoder) Raw(v []byte) {
+	e.b = append(e.b, v...)
+}
+
+// Bool encodes bool as a byte
+func (e *avroEncoder) Bool(v bool) {
+	if v {
+		e.b = append(e.b, 1)
+		return
+	}
+	e.b = append(e.b, 0)
+}
+
+// Varint encodes int and long as zigzag varint
+func (e *avroEncoder) Varint(v int64) {
+	e.b = binary.AppendVarint(e.b, v)
+}
+
+// VarBytes encodes bytes and string with the varint length
+func (e *avroEncoder) VarBytes(v []byte) {
+	e.Varint(int64(len(v)))
+	e.b = append(e.b, v...)
+}
+
+var errShortBuffer = errors.New("avro: short buffer")
+
+// avroDecoder decodes the primitive types of avro binary encoding,
+// and the first error is kept so that the decoding can be checked once at the end
+type avroDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *avroDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.b) < n {
+		d.err = errShortBuffer
+		d.b = nil
+		return nil
+	}
+	v := d.b[:n]
+	d.b = d.b[n:]
+	return v
+}
+
+// Bool decodes bool
+func (d *avroDecoder) Bool() bool {
+	if b := d.next(1); b != nil {
+		return b[0] != 0
+	}
+	return false
+}
+
+// Varint decodes int and long of zigzag varint
+func (d *avroDecoder) Varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.err = errShortBuffer
+		d.b = nil
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+// VarBytes decodes bytes and string with the varint length
+func (d *avroDecoder) VarBytes() []byte {
+	return d.next(int(d.Varint()))
+}
@@ -0,0 +1,237 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// special timestamps of ListOffset
+const (
+	OffsetLatest   int64 = -1 // offset of the next message
+	OffsetEarliest int64 = -2 // offset of the first message
+)
+
+// Message message of kafka, Topic and Partition must not be set when producing
+type Message = kafkago.Message
+
+// Client client of the kafka cluster, the connections to the leaders of the partitions
+// are created lazily and dropped on errors so that the leaders are looked up again.
+// The requests of the same partition should not be sent concurrently
+type Client struct {
+	conf   *Config
+	dialer *kafkago.Dialer
+
+	mu    sync.Mutex
+	conns map[partitionKey]*kafkago.Conn // connections to the leaders by partition
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+// NewClient creates the client by conf
+func NewClient(conf *Config) (c *Client, err error) {
+	c = &Client{
+		conf:  conf,
+		conns: make(map[partitionKey]*kafkago.Conn),
+	}
+	if c.dialer, err = conf.dialer(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Close closes all connections
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, cn := range c.conns {
+		cn.Close()
+		delete(c.conns, key)
+	}
+	return nil
+}
+
+// Partitions gets the sorted partitions of topic
+func (c *Client) Partitions(ctx context.Context, topic string) (partitions []int32, err error) {
+	var ps []kafkago.Partition
+	if ps, err = c.lookup(ctx, topic); err != nil {
+		return nil, err
+	}
+	for _, p := range ps {
+		partitions = append(partitions, int32(p.ID))
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i] < partitions[j]
+	})
+	return
+}
+
+// lookup gets the partitions of topic with their leaders from the first bootstrap broker answering
+func (c *Client) lookup(ctx context.Context, topic string) (partitions []kafkago.Partition, err error) {
+	for _, addr := range c.conf.Brokers {
+		if partitions, err = c.lookupBroker(ctx, addr, topic); err == nil {
+			return
+		}
+		if _, ok := err.(kafkago.Error); ok {
+			break
+		}
+	}
+	return nil, fmt.Errorf("kafka: lookup partitions of topic %v fail. err: %w", topic, err)
+}
+
+func (c *Client) lookupBroker(ctx context.Context, addr, topic string) ([]kafkago.Partition, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.conf.timeout())
+	defer cancel()
+	return c.dialer.LookupPartitions(ctx, "tcp", addr, topic)
+}
+
+// conn gets the connection to the leader of the partition
+func (c *Client) conn(ctx context.Context, key partitionKey) (cn *kafkago.Conn, err error) {
+	c.mu.Lock()
+	cn, ok := c.conns[key]
+	c.mu.Unlock()
+	if ok {
+		return cn, nil
+	}
+
+	var partitions []kafkago.Partition
+	if partitions, err = c.lookup(ctx, key.topic); err != nil {
+		return nil, err
+	}
+	for _, p := range partitions {
+		if p.ID == int(key.partition) {
+			return c.dial(ctx, key, p)
+		}
+	}
+	return nil, fmt.Errorf("kafka: partition %v of topic %v: %w", key.partition, key.topic, kafkago.UnknownTopicOrPartition)
+}
+
+// dial connects to the leader of the partition p and caches the connection
+func (c *Client) dial(ctx context.Context, key partitionKey, p kafkago.Partition) (cn *kafkago.Conn, err error) {
+	ctx, cancel := context.WithTimeout(ctx, c.conf.timeout())
+	defer cancel()
+	if cn, err = c.dialer.DialPartition(ctx, "tcp", "", p); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conns[key] = cn
+	return cn, nil
+}
+
+// do calls fn with the connection to the leader of the partition under the deadline of
+// the timeout and ctx, and the connection is dropped if fn fails
+func (c *Client) do(ctx context.Context, topic string, partition int32, fn func(cn *kafkago.Conn) error) (err error) {
+	key := partitionKey{topic: topic, partition: partition}
+	var cn *kafkago.Conn
+	if cn, err = c.conn(ctx, key); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(c.conf.timeout())
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err = cn.SetDeadline(deadline); err == nil {
+		err = fn(cn)
+	}
+	if err != nil {
+		c.mu.Lock()
+		if c.conns[key] == cn {
+			delete(c.conns, key)
+		}
+		c.mu.Unlock()
+		cn.Close()
+	}
+	return
+}
+
+// ListOffset gets the offset of the first message whose timestamp is not less than timestamp
+// in milliseconds, or the special offsets by OffsetLatest and OffsetEarliest. The offset is -1
+// if there is no such message
+func (c *Client) ListOffset(ctx context.Context, topic string, partition int32, timestamp int64) (offset int64, err error) {
+	err = c.do(ctx, topic, partition, func(cn *kafkago.Conn) (err error) {
+		switch timestamp {
+		case OffsetEarliest:
+			offset, err = cn.ReadFirstOffset()
+		case OffsetLatest:
+			offset, err = cn.ReadLastOffset()
+		default:
+			offset, err = cn.ReadOffset(time.UnixMilli(timestamp))
+		}
+		return
+	})
+	return
+}
+
+// Produce sends msgs to the leader of the partition in a record batch compressed by codec,
+// which is not compressed if codec is nil. acks is 1 for the leader and -1 for all in-sync replicas
+func (c *Client) Produce(ctx context.Context, topic string, partition int32, acks int16,
+	codec kafkago.CompressionCodec, msgs []Message) error {
+	return c.do(ctx, topic, partition, func(cn *kafkago.Conn) (err error) {
+		if err = cn.SetRequiredAcks(int(acks)); err != nil {
+			return
+		}
+		_, err = cn.WriteCompressedMessages(codec, msgs...)
+		return
+	})
+}
+
+// FetchResult result of fetching a partition
+type FetchResult struct {
+	Messages      []Message // messages from the fetched offset
+	NextOffset    int64     // offset of the next fetch
+	HighWatermark int64     // offset of the next message to be committed
+}
+
+// Fetch fetches the messages of the partition from offset at most about maxBytes, the messages
+// before offset in the first batch are dropped, and no message is fetched at the high watermark
+func (c *Client) Fetch(ctx context.Context, topic string, partition int32, offset int64, maxBytes int32) (res *FetchResult, err error) {
+	err = c.do(ctx, topic, partition, func(cn *kafkago.Conn) (err error) {
+		if _, err = cn.Seek(offset, kafkago.SeekAbsolute|kafkago.SeekDontCheck); err != nil {
+			return
+		}
+		batch := cn.ReadBatch(1, int(maxBytes))
+		res = &FetchResult{
+			HighWatermark: batch.HighWaterMark(),
+		}
+		for {
+			m, rerr := batch.ReadMessage()
+			if rerr != nil {
+				break
+			}
+			res.Messages = append(res.Messages, m)
+		}
+		res.NextOffset = batch.Offset()
+		// the empty batch at the high watermark is reported as timed out
+		if err = batch.Close(); errors.Is(err, kafkago.RequestTimedOut) && len(res.Messages) == 0 {
+			err = nil
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
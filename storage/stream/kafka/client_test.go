@@ -0,0 +1,427 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/storage/stream/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/apiversions"
+	"github.com/segmentio/kafka-go/protocol/fetch"
+	"github.com/segmentio/kafka-go/protocol/listoffsets"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/produce"
+)
+
+// closedAddr gets the address refusing the connections
+func closedAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *kafka.Config
+		wantErr bool
+	}{
+		{name: "1", conf: &kafka.Config{Brokers: []string{"127.0.0.1:9092"}}},
+		{name: "2", conf: &kafka.Config{Brokers: []string{"127.0.0.1:9092"}, Username: "etl", Password: "secret", TLS: true}},
+		{name: "3", conf: &kafka.Config{Brokers: []string{"127.0.0.1:9092"}, TLS: true, CAFile: "not_exist.pem"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := kafka.NewClient(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c != nil {
+				c.Close()
+			}
+		})
+	}
+}
+
+func TestClient_Unreachable(t *testing.T) {
+	c, err := kafka.NewClient(&kafka.Config{Brokers: []string{closedAddr(t), closedAddr(t)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		do   func() error
+	}{
+		{name: "1", do: func() error {
+			_, err := c.Partitions(ctx, "test")
+			return err
+		}},
+		{name: "2", do: func() error {
+			_, err := c.ListOffset(ctx, "test", 0, kafka.OffsetLatest)
+			return err
+		}},
+		{name: "3", do: func() error {
+			_, err := c.Fetch(ctx, "test", 0, 0, 1<<20)
+			return err
+		}},
+		{name: "4", do: func() error {
+			return c.Produce(ctx, "test", 0, -1, nil, []kafka.Message{{Value: []byte("{}")}})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.do()
+			if err == nil || !kafka.IsRetriable(err) {
+				t.Errorf("error = %v, want retriable", err)
+			}
+		})
+	}
+}
+
+func TestIsRetriable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "1", err: kafkago.NotLeaderForPartition, want: true},
+		{name: "2", err: fmt.Errorf("produce fail. err: %w", kafkago.RequestTimedOut), want: true},
+		{name: "3", err: kafkago.TopicAuthorizationFailed},
+		{name: "4", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "5", err: io.ErrUnexpectedEOF, want: true},
+		{name: "6", err: errors.New("unknown")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kafka.IsRetriable(tt.err); got != tt.want {
+				t.Errorf("IsRetriable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testBroker in-process broker of a single node speaking the kafka protocol, which keeps
+// the produced records of the partitions of topic in memory
+type testBroker struct {
+	listener   net.Listener
+	topic      string
+	mu         sync.Mutex
+	partitions [][]testRecord
+}
+
+type testRecord struct {
+	time       time.Time
+	key, value []byte
+}
+
+// newTestBroker starts the broker of topic with n partitions
+func newTestBroker(t *testing.T, topic string, n int) *testBroker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &testBroker{
+		listener:   l,
+		topic:      topic,
+		partitions: make([][]testRecord, n),
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+func (b *testBroker) addr() string {
+	return b.listener.Addr().String()
+}
+
+func (b *testBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		version, id, _, req, err := protocol.ReadRequest(conn)
+		if err != nil {
+			return
+		}
+		if f, ok := req.(*fetch.Request); ok {
+			err = b.fetch(conn, id, f)
+		} else {
+			var resp protocol.Message
+			if resp, err = b.handle(req); err == nil {
+				err = protocol.WriteResponse(conn, version, id, resp)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (b *testBroker) handle(req protocol.Message) (protocol.Message, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch req := req.(type) {
+	case *apiversions.Request:
+		return &apiversions.Response{ApiKeys: []apiversions.ApiKeyResponse{
+			{ApiKey: int16(protocol.Produce), MinVersion: 3, MaxVersion: 3},
+			{ApiKey: int16(protocol.Fetch), MinVersion: 5, MaxVersion: 5},
+			{ApiKey: int16(protocol.ListOffsets), MinVersion: 1, MaxVersion: 1},
+			{ApiKey: int16(protocol.Metadata), MinVersion: 1, MaxVersion: 1},
+			{ApiKey: int16(protocol.ApiVersions), MinVersion: 0, MaxVersion: 0},
+		}}, nil
+	case *metadata.Request:
+		host, port, _ := net.SplitHostPort(b.addr())
+		p, _ := strconv.Atoi(port)
+		resp := &metadata.Response{
+			Brokers:      []metadata.ResponseBroker{{NodeID: 1, Host: host, Port: int32(p)}},
+			ControllerID: 1,
+		}
+		topic := metadata.ResponseTopic{Name: b.topic}
+		for i := range b.partitions {
+			topic.Partitions = append(topic.Partitions, metadata.ResponsePartition{
+				PartitionIndex: int32(i), LeaderID: 1, ReplicaNodes: []int32{1}, IsrNodes: []int32{1},
+			})
+		}
+		resp.Topics = append(resp.Topics, topic)
+		return resp, nil
+	case *produce.Request:
+		resp := &produce.Response{}
+		for _, rt := range req.Topics {
+			topic := produce.ResponseTopic{Topic: rt.Topic}
+			for _, rp := range rt.Partitions {
+				base := int64(len(b.partitions[rp.Partition]))
+				for {
+					r, err := rp.RecordSet.Records.ReadRecord()
+					if err != nil {
+						break
+					}
+					key, _ := protocol.ReadAll(r.Key)
+					value, _ := protocol.ReadAll(r.Value)
+					b.partitions[rp.Partition] = append(b.partitions[rp.Partition], testRecord{
+						time: r.Time, key: key, value: value,
+					})
+				}
+				topic.Partitions = append(topic.Partitions, produce.ResponsePartition{
+					Partition: rp.Partition, BaseOffset: base,
+				})
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+	case *listoffsets.Request:
+		resp := &listoffsets.Response{}
+		for _, rt := range req.Topics {
+			topic := listoffsets.ResponseTopic{Topic: rt.Topic}
+			for _, rp := range rt.Partitions {
+				records := b.partitions[rp.Partition]
+				offset := int64(len(records))
+				switch rp.Timestamp {
+				case kafka.OffsetEarliest:
+					offset = 0
+				case kafka.OffsetLatest:
+				default:
+					offset = -1
+					for i, r := range records {
+						if r.time.UnixMilli() >= rp.Timestamp {
+							offset = int64(i)
+							break
+						}
+					}
+				}
+				topic.Partitions = append(topic.Partitions, listoffsets.ResponsePartition{
+					Partition: rp.Partition, Timestamp: rp.Timestamp, Offset: offset,
+				})
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("unsupported request %T", req)
+}
+
+// fetch writes the fetch response of v5 from the fetch offsets in batches of two records,
+// which is encoded here as the record sets of the protocol package always start at offset 0
+func (b *testBroker) fetch(w io.Writer, id int32, req *fetch.Request) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var buf bytes.Buffer
+	write := func(v ...any) {
+		for _, x := range v {
+			if s, ok := x.(string); ok {
+				binary.Write(&buf, binary.BigEndian, int16(len(s)))
+				buf.WriteString(s)
+				continue
+			}
+			binary.Write(&buf, binary.BigEndian, x)
+		}
+	}
+	write(int32(0), id, int32(0), int32(len(req.Topics)))
+	for _, rt := range req.Topics {
+		write(rt.Topic, int32(len(rt.Partitions)))
+		for _, rp := range rt.Partitions {
+			records := b.partitions[rp.Partition]
+			hw := int64(len(records))
+			write(rp.Partition, int16(0), hw, hw, int64(0), int32(-1))
+
+			var batch []protocol.Record
+			for i := rp.FetchOffset; i < hw && len(batch) < 2; i++ {
+				batch = append(batch, protocol.Record{
+					Time:  records[i].time,
+					Key:   protocol.NewBytes(records[i].key),
+					Value: protocol.NewBytes(records[i].value),
+				})
+			}
+			if len(batch) == 0 {
+				write(int32(0))
+				continue
+			}
+			var set bytes.Buffer
+			rs := protocol.RecordSet{Version: 2, Records: protocol.NewRecordReader(batch...)}
+			if _, err := rs.WriteTo(&set); err != nil {
+				return err
+			}
+			// the base offset after the size is not covered by the checksum
+			data := set.Bytes()
+			binary.BigEndian.PutUint64(data[4:12], uint64(rp.FetchOffset))
+			buf.Write(data)
+		}
+	}
+	binary.BigEndian.PutUint32(buf.Bytes(), uint32(buf.Len()-4))
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func TestClient_ProduceFetch(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression string
+	}{
+		{name: "1", compression: "none"},
+		{name: "2", compression: "gzip"},
+		{name: "3", compression: "snappy"},
+		{name: "4", compression: "lz4"},
+		{name: "5", compression: "zstd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBroker(t, "orders", 2)
+			c, err := kafka.NewClient(&kafka.Config{Brokers: []string{closedAddr(t), b.addr()}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			ctx := context.Background()
+
+			partitions, err := c.Partitions(ctx, "orders")
+			if err != nil {
+				t.Fatalf("Partitions() error = %v", err)
+			}
+			if !reflect.DeepEqual(partitions, []int32{0, 1}) {
+				t.Fatalf("Partitions() = %v", partitions)
+			}
+
+			codec, err := kafka.CompressionCodec(tt.compression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+			var msgs []kafka.Message
+			for i := 0; i < 5; i++ {
+				msgs = append(msgs, kafka.Message{
+					Key:   []byte(fmt.Sprint(i)),
+					Value: []byte(fmt.Sprintf(`{"id":%v}`, i)),
+					Time:  start.Add(time.Duration(i) * time.Second),
+				})
+			}
+			if err = c.Produce(ctx, "orders", 1, -1, codec, msgs[:3]); err != nil {
+				t.Fatalf("Produce() error = %v", err)
+			}
+			if err = c.Produce(ctx, "orders", 1, 1, codec, msgs[3:]); err != nil {
+				t.Fatalf("Produce() error = %v", err)
+			}
+
+			for _, v := range []struct {
+				timestamp int64
+				want      int64
+			}{
+				{timestamp: kafka.OffsetEarliest, want: 0},
+				{timestamp: kafka.OffsetLatest, want: 5},
+				{timestamp: start.Add(1500 * time.Millisecond).UnixMilli(), want: 2},
+				{timestamp: start.Add(time.Minute).UnixMilli(), want: -1},
+			} {
+				offset, err := c.ListOffset(ctx, "orders", 1, v.timestamp)
+				if err != nil {
+					t.Fatalf("ListOffset(%v) error = %v", v.timestamp, err)
+				}
+				if offset != v.want {
+					t.Fatalf("ListOffset(%v) = %v, want %v", v.timestamp, offset, v.want)
+				}
+			}
+
+			var got []string
+			for offset := int64(1); offset < 5; {
+				res, err := c.Fetch(ctx, "orders", 1, offset, 1<<20)
+				if err != nil {
+					t.Fatalf("Fetch(%v) error = %v", offset, err)
+				}
+				if res.HighWatermark != 5 || res.NextOffset <= offset {
+					t.Fatalf("Fetch(%v) = %v %v", offset, res.NextOffset, res.HighWatermark)
+				}
+				for _, m := range res.Messages {
+					got = append(got, fmt.Sprintf("%v %s %s %v", m.Offset, m.Key, m.Value, m.Time.Sub(start)))
+				}
+				offset = res.NextOffset
+			}
+			want := []string{`1 1 {"id":1} 1s`, `2 2 {"id":2} 2s`, `3 3 {"id":3} 3s`, `4 4 {"id":4} 4s`}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Fetch() = %v, want %v", got, want)
+			}
+
+			res, err := c.Fetch(ctx, "orders", 0, 0, 1<<20)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if len(res.Messages) != 0 || res.HighWatermark != 0 {
+				t.Errorf("Fetch() = %v %v", len(res.Messages), res.HighWatermark)
+			}
+		})
+	}
+}
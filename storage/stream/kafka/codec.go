@@ -0,0 +1,317 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+	"github.com/tidwall/gjson"
+	"github.com/vjeantet/jodaTime"
+)
+
+// formats of the message values
+const (
+	FormatJSON = "json" // JSON object of the column names and values
+	FormatAvro = "avro" // Avro binary of the record schema of the columns
+)
+
+// Column column of the message values
+type Column struct {
+	Name   string `json:"name"`   // Column name
+	Path   string `json:"path"`   // JSON path of the field decoded from JSON, name if empty
+	Type   string `json:"type"`   // Type (bool, bigInt, decimal, string, bytes, time, json), inferred by JSON values if empty
+	Format string `json:"format"` // Joda time format of time in JSON, RFC 3339 if empty
+
+	goLayout string
+}
+
+func (c *Column) layout() string {
+	if c.goLayout != "" {
+		return c.goLayout
+	}
+	c.goLayout = time.RFC3339Nano
+	if c.Format != "" {
+		c.goLayout = jodaTime.GetLayout(c.Format)
+	}
+	return c.goLayout
+}
+
+// Codec converts between the records and the message values
+type Codec struct {
+	format   string
+	columns  []Column
+	schemaID int32 // schema id of the confluent wire format of avro if positive
+}
+
+// NewCodec creates the codec of format. The columns of avro are required with types, and
+// the schemaID of the schema registered in the confluent schema registry is put before avro
+// values if positive. The columns of JSON are required for decoding, and the encoded JSON
+// objects have all columns of the records if the columns are empty
+func NewCodec(format string, columns []Column, schemaID int32) (c *Codec, err error) {
+	c = &Codec{
+		format:   format,
+		columns:  columns,
+		schemaID: schemaID,
+	}
+	for i := range columns {
+		if columns[i].Name == "" {
+			return nil, fmt.Errorf("name of column %v is empty", i+1)
+		}
+		switch element.ColumnType(columns[i].Type) {
+		case "", element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+			element.TypeString, element.TypeBytes, element.TypeTime, element.TypeJSON:
+		default:
+			return nil, fmt.Errorf("type %v of column %v is not valid", columns[i].Type, columns[i].Name)
+		}
+	}
+	switch format {
+	case FormatJSON:
+	case FormatAvro:
+		if len(columns) == 0 {
+			return nil, fmt.Errorf("column of avro is empty")
+		}
+		for _, col := range columns {
+			if col.Type == "" {
+				return nil, fmt.Errorf("type of column %v of avro is empty", col.Name)
+			}
+			if !isAvroName(col.Name) {
+				return nil, fmt.Errorf("name of column %v is not a valid avro name", col.Name)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("format %v is not supported", format)
+	}
+	return
+}
+
+// Encode encodes record into the message value
+func (c *Codec) Encode(record element.Record) ([]byte, error) {
+	if c.format == FormatAvro {
+		return c.encodeAvro(record)
+	}
+	return c.encodeJSON(record)
+}
+
+// Decode decodes the message value into the columns of the codec
+func (c *Codec) Decode(value []byte) ([]element.Column, error) {
+	if c.format == FormatAvro {
+		return c.decodeAvro(value)
+	}
+	return c.decodeJSON(value)
+}
+
+func (c *Codec) encodeJSON(record element.Record) (value []byte, err error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	add := func(i int, col element.Column, layout string) error {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(col.Name())
+		buf.Write(key)
+		buf.WriteByte(':')
+		v, err := jsonValue(col, layout)
+		if err != nil {
+			return errors.Wrapf(err, "column %v", col.Name())
+		}
+		buf.Write(v)
+		return nil
+	}
+
+	var col element.Column
+	if len(c.columns) == 0 {
+		for i := 0; i < record.ColumnNumber(); i++ {
+			if col, err = record.GetByIndex(i); err != nil {
+				return
+			}
+			if err = add(i, col, time.RFC3339Nano); err != nil {
+				return
+			}
+		}
+	} else {
+		for i := range c.columns {
+			if col, err = record.GetByName(c.columns[i].Name); err != nil {
+				return
+			}
+			if err = add(i, col, c.columns[i].layout()); err != nil {
+				return
+			}
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonValue gets the JSON value of the column, numbers are kept without losing precision
+// and times are formatted by layout
+func jsonValue(col element.Column, layout string) ([]byte, error) {
+	if col.IsNil() {
+		return []byte("null"), nil
+	}
+	switch col.Type() {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal:
+		return []byte(col.String()), nil
+	case element.TypeJSON:
+		j, err := col.AsJSON()
+		if err != nil {
+			return nil, err
+		}
+		return j.ToBytes(), nil
+	case element.TypeTime:
+		t, err := col.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(t.Format(layout))
+	}
+	s, err := col.AsString()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+func (c *Codec) decodeJSON(value []byte) (cols []element.Column, err error) {
+	if !gjson.ValidBytes(value) {
+		return nil, errors.New("value is not valid JSON")
+	}
+	obj := gjson.ParseBytes(value)
+	if !obj.IsObject() {
+		return nil, errors.New("value is not a JSON object")
+	}
+	fields := obj.Map()
+	for i := range c.columns {
+		field := fields[c.columns[i].Name]
+		if c.columns[i].Path != "" {
+			field = obj.Get(c.columns[i].Path)
+		}
+		var v element.ColumnValue
+		if v, err = c.columns[i].jsonColumnValue(field); err != nil {
+			return nil, errors.Wrapf(err, "column %v value %v is not valid", c.columns[i].Name, field.Raw)
+		}
+		cols = append(cols, element.NewDefaultColumn(v, c.columns[i].Name, len(field.Raw)))
+	}
+	return
+}
+
+// jsonColumnValue converts the JSON value field to the column value of its type,
+// the type is inferred from the JSON value if not configured
+func (c *Column) jsonColumnValue(field gjson.Result) (element.ColumnValue, error) {
+	typ := element.ColumnType(c.Type)
+	if typ == "" {
+		typ = inferType(field)
+	}
+	if field.Type == gjson.Null {
+		return nilValue(typ), nil
+	}
+
+	switch typ {
+	case element.TypeBool:
+		switch field.Type {
+		case gjson.True, gjson.False:
+			return element.NewBoolColumnValue(field.Bool()), nil
+		case gjson.String:
+			b, err := strconv.ParseBool(field.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewBoolColumnValue(b), nil
+		}
+	case element.TypeBigInt:
+		switch field.Type {
+		case gjson.Number:
+			return element.NewBigIntColumnValueFromString(field.Raw)
+		case gjson.String:
+			return element.NewBigIntColumnValueFromString(field.Str)
+		}
+	case element.TypeDecimal:
+		switch field.Type {
+		case gjson.Number:
+			return element.NewDecimalColumnValueFromString(field.Raw)
+		case gjson.String:
+			return element.NewDecimalColumnValueFromString(field.Str)
+		}
+	case element.TypeString:
+		if field.Type == gjson.String {
+			return element.NewStringColumnValue(field.Str), nil
+		}
+		return element.NewStringColumnValue(field.Raw), nil
+	case element.TypeBytes:
+		if field.Type == gjson.String {
+			return element.NewBytesColumnValue([]byte(field.Str)), nil
+		}
+	case element.TypeTime:
+		if field.Type == gjson.String {
+			layout := c.layout()
+			t, err := time.Parse(layout, field.Str)
+			if err != nil {
+				return nil, err
+			}
+			return element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout)), nil
+		}
+	case element.TypeJSON:
+		return element.NewJsonColumnValueFromString(field.Raw)
+	}
+	return nil, errors.Errorf("%v can not be converted to %v", field.Type, typ)
+}
+
+// inferType infers the column type of the JSON value, the integer is bigInt and other numbers are decimal
+func inferType(field gjson.Result) element.ColumnType {
+	switch field.Type {
+	case gjson.True, gjson.False:
+		return element.TypeBool
+	case gjson.Number:
+		if strings.ContainsAny(field.Raw, ".eE") {
+			return element.TypeDecimal
+		}
+		return element.TypeBigInt
+	case gjson.JSON:
+		return element.TypeJSON
+	}
+	return element.TypeString
+}
+
+func nilValue(typ element.ColumnType) element.ColumnValue {
+	switch typ {
+	case element.TypeBool:
+		return element.NewNilBoolColumnValue()
+	case element.TypeBigInt:
+		return element.NewNilBigIntColumnValue()
+	case element.TypeDecimal:
+		return element.NewNilDecimalColumnValue()
+	case element.TypeBytes:
+		return element.NewNilBytesColumnValue()
+	case element.TypeTime:
+		return element.NewNilTimeColumnValue()
+	case element.TypeJSON:
+		return element.NewNilJsonColumnValue()
+	}
+	return element.NewNilStringColumnValue()
+}
+
+// confluentHeader gets the header of the confluent wire format with the schema id
+func confluentHeader(schemaID int32) []byte {
+	b := make([]byte, 5)
+	binary.BigEndian.PutUint32(b[1:], uint32(schemaID))
+	return b
+}
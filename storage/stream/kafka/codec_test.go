@@ -0,0 +1,237 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testRecord(t *testing.T, i string) element.Record {
+	r := element.NewDefaultRecord()
+	bigInt, _ := element.NewBigIntColumnValueFromString(i)
+	decimal, _ := element.NewDecimalColumnValueFromString("3.1415926535897932384626")
+	j, _ := element.NewJsonColumnValueFromString(`{"a":[1,2]}`)
+	for _, c := range []element.Column{
+		element.NewDefaultColumn(element.NewBoolColumnValue(true), "b", 0),
+		element.NewDefaultColumn(bigInt, "i", 0),
+		element.NewDefaultColumn(decimal, "d", 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(`say "hi"`), "s", 0),
+		element.NewDefaultColumn(element.NewBytesColumnValue([]byte("raw")), "y", 0),
+		element.NewDefaultColumn(element.NewTimeColumnValue(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)), "t", 0),
+		element.NewDefaultColumn(j, "j", 0),
+		element.NewDefaultColumn(element.NewNilStringColumnValue(), "n", 0),
+	} {
+		if err := r.Add(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r
+}
+
+func testColumns() []Column {
+	return []Column{
+		{Name: "b", Type: "bool"},
+		{Name: "i", Type: "bigInt"},
+		{Name: "d", Type: "decimal"},
+		{Name: "s", Type: "string"},
+		{Name: "y", Type: "bytes"},
+		{Name: "t", Type: "time"},
+		{Name: "j", Type: "json"},
+		{Name: "n", Type: "string"},
+	}
+}
+
+func TestNewCodec(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		columns []Column
+		wantErr bool
+	}{
+		{name: "1", format: FormatJSON},
+		{name: "2", format: FormatAvro, columns: testColumns()},
+		{name: "3", format: FormatAvro, wantErr: true},
+		{name: "4", format: FormatAvro, columns: []Column{{Name: "a"}}, wantErr: true},
+		{name: "5", format: FormatAvro, columns: []Column{{Name: "a-b", Type: "string"}}, wantErr: true},
+		{name: "6", format: FormatJSON, columns: []Column{{Name: "a", Type: "int"}}, wantErr: true},
+		{name: "7", format: FormatJSON, columns: []Column{{Type: "string"}}, wantErr: true},
+		{name: "8", format: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCodec(tt.format, tt.columns, 0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCodec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCodec_EncodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		want    string
+	}{
+		{
+			name: "1",
+			want: `{"b":true,"i":12345678901234567890,"d":3.1415926535897932384626,"s":"say \"hi\"",` +
+				`"y":"raw","t":"2026-10-19T08:00:00Z","j":{"a":[1,2]},"n":null}`,
+		},
+		{
+			name:    "2",
+			columns: []Column{{Name: "t", Format: "yyyy-MM-dd"}, {Name: "i"}},
+			want:    `{"t":"2026-10-19","i":12345678901234567890}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCodec(FormatJSON, tt.columns, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.Encode(testRecord(t, "12345678901234567890"))
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Encode() = %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodec_DecodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		value   string
+		want    []string
+		types   []element.ColumnType
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			columns: []Column{{Name: "b"}, {Name: "i"}, {Name: "d"}, {Name: "s"}, {Name: "j"}, {Name: "n"}, {Name: "m"}},
+			value:   `{"b":true,"i":12345678901234567890,"d":1.5,"s":"x","j":{"a":1},"n":null}`,
+			want:    []string{"true", "12345678901234567890", "1.5", "x", `{"a":1}`, "<nil>", "<nil>"},
+			types: []element.ColumnType{element.TypeBool, element.TypeBigInt, element.TypeDecimal,
+				element.TypeString, element.TypeJSON, element.TypeString, element.TypeString},
+		},
+		{
+			name:    "2",
+			columns: []Column{{Name: "i", Type: "bigInt"}, {Name: "s", Type: "string"}, {Name: "t", Type: "time", Format: "yyyy-MM-dd"}},
+			value:   `{"i":"12","s":12,"t":"2026-10-19"}`,
+			want:    []string{"12", "12", "2026-10-19 00:00:00Z"},
+			types:   []element.ColumnType{element.TypeBigInt, element.TypeString, element.TypeTime},
+		},
+		{
+			name:    "3",
+			columns: []Column{{Name: "a", Path: "o.a.1"}, {Name: "o.a"}},
+			value:   `{"o":{"a":[1,"x"]},"o.a":2}`,
+			want:    []string{"x", "2"},
+			types:   []element.ColumnType{element.TypeString, element.TypeBigInt},
+		},
+		{
+			name:    "4",
+			columns: []Column{{Name: "i", Type: "bigInt"}},
+			value:   `{"i":true}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			columns: []Column{{Name: "i"}},
+			value:   `[1]`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			columns: []Column{{Name: "i"}},
+			value:   `{"i":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCodec(FormatJSON, tt.columns, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.Decode([]byte(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i, col := range got {
+				if col.String() != tt.want[i] || col.Type() != tt.types[i] {
+					t.Errorf("Decode() %v = %v %v, want %v %v", col.Name(), col.Type(), col, tt.types[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCodec_Avro(t *testing.T) {
+	tests := []struct {
+		name     string
+		schemaID int32
+	}{
+		{name: "1"},
+		{name: "2", schemaID: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCodec(FormatAvro, testColumns(), tt.schemaID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			record := testRecord(t, "-1234567890123")
+			value, err := c.Encode(record)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if tt.schemaID > 0 && (value[0] != 0 || value[4] != 7) {
+				t.Errorf("Encode() header = %v", value[:5])
+			}
+			got, err := c.Decode(value)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			for i, col := range got {
+				want, _ := record.GetByIndex(i)
+				if col.Name() != want.Name() || col.String() != want.String() {
+					t.Errorf("Decode() = %v %v, want %v %v", col.Name(), col, want.Name(), want)
+				}
+			}
+			if _, err = c.Decode(value[:len(value)-1]); err == nil {
+				t.Errorf("Decode() error = nil")
+			}
+		})
+	}
+}
+
+func TestCodec_AvroSchema(t *testing.T) {
+	c, err := NewCodec(FormatAvro, []Column{{Name: "id", Type: "bigInt"}, {Name: "t", Type: "time"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"record","name":"test","fields":[{"name":"id","type":["null","long"],"default":null},` +
+		`{"name":"t","type":["null",{"logicalType":"timestamp-millis","type":"long"}],"default":null}]}`
+	if got := c.AvroSchema("test"); got != want {
+		t.Errorf("AvroSchema() = %v, want %v", got, want)
+	}
+}
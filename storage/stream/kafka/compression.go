@@ -0,0 +1,39 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// CompressionCodec gets the codec of the compression of the record batches by name,
+// which is none, gzip, snappy, lz4 or zstd, and the codec is nil for none
+func CompressionCodec(name string) (kafkago.CompressionCodec, error) {
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "gzip":
+		return kafkago.Gzip.Codec(), nil
+	case "snappy":
+		return kafkago.Snappy.Codec(), nil
+	case "lz4":
+		return kafkago.Lz4.Codec(), nil
+	case "zstd":
+		return kafkago.Zstd.Codec(), nil
+	}
+	return nil, fmt.Errorf("compression %v is not supported", name)
+}
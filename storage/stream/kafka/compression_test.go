@@ -0,0 +1,85 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestCompressionCodec(t *testing.T) {
+	tests := []struct {
+		name     string
+		codec    string
+		wantCode int8
+		wantNil  bool
+		wantErr  bool
+	}{
+		{name: "1", codec: "", wantNil: true},
+		{name: "2", codec: "none", wantNil: true},
+		{name: "3", codec: "gzip", wantCode: 1},
+		{name: "4", codec: "snappy", wantCode: 2},
+		{name: "5", codec: "lz4", wantCode: 3},
+		{name: "6", codec: "zstd", wantCode: 4},
+		{name: "7", codec: "brotli", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec, err := CompressionCodec(tt.codec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompressionCodec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (codec == nil) != tt.wantNil {
+				t.Fatalf("CompressionCodec() = %v, wantNil %v", codec, tt.wantNil)
+			}
+			if codec == nil {
+				return
+			}
+			if codec.Code() != tt.wantCode || codec.Name() != tt.codec {
+				t.Errorf("CompressionCodec() = %v %v", codec.Code(), codec.Name())
+			}
+
+			var data []byte
+			for i := 0; i < 1000; i++ {
+				data = append(data, fmt.Sprintf(`{"id":%v,"v":"value"}`, i)...)
+			}
+			var buf bytes.Buffer
+			w := codec.NewWriter(&buf)
+			if _, err = w.Write(data); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err = w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if buf.Len() >= len(data) {
+				t.Errorf("compressed %v bytes into %v bytes", len(data), buf.Len())
+			}
+			r := codec.NewReader(&buf)
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			r.Close()
+			if !bytes.Equal(got, data) {
+				t.Errorf("ReadAll() = %v bytes, want %v bytes", len(got), len(data))
+			}
+		})
+	}
+}
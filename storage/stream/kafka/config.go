@@ -0,0 +1,112 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go/time2"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+const (
+	defaultClientID = "go-etl"
+	defaultTimeout  = 30 * time.Second
+)
+
+// Config kafka client configuration
+type Config struct {
+	Brokers            []string       `json:"brokers"`            // Addresses of the bootstrap brokers, such as 127.0.0.1:9092
+	ClientID           string         `json:"clientId"`           // Client id, go-etl by default
+	Username           string         `json:"username"`           // Username of SASL/PLAIN, no authentication if empty
+	Password           string         `json:"password"`           // Password of SASL/PLAIN
+	TLS                bool           `json:"tls"`                // Whether to connect by TLS
+	CAFile             string         `json:"caFile"`             // Path of the CA certificates of TLS, the system ones by default
+	InsecureSkipVerify bool           `json:"insecureSkipVerify"` // Whether to skip verifying the certificates of the brokers, only for tests
+	Timeout            time2.Duration `json:"timeout"`            // Timeout of connecting and each request, 30s by default
+}
+
+// NewConfig gets the kafka client configuration from conf
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if len(c.Brokers) == 0 {
+		return nil, fmt.Errorf("brokers is empty")
+	}
+	return
+}
+
+func (c *Config) clientID() string {
+	if c.ClientID == "" {
+		return defaultClientID
+	}
+	return c.ClientID
+}
+
+func (c *Config) timeout() time.Duration {
+	if c.Timeout.Duration == 0 {
+		return defaultTimeout
+	}
+	return c.Timeout.Duration
+}
+
+// tlsConfig gets the TLS configuration, nil if TLS is not enabled
+func (c *Config) tlsConfig() (conf *tls.Config, err error) {
+	if !c.TLS {
+		return nil, nil
+	}
+	conf = &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CAFile != "" {
+		var pem []byte
+		if pem, err = os.ReadFile(c.CAFile); err != nil {
+			return nil, fmt.Errorf("read caFile %v fail. err: %v", c.CAFile, err)
+		}
+		conf.RootCAs = x509.NewCertPool()
+		if !conf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("caFile %v has no certificates", c.CAFile)
+		}
+	}
+	return
+}
+
+// dialer gets the dialer of the connections to the brokers
+func (c *Config) dialer() (d *kafkago.Dialer, err error) {
+	d = &kafkago.Dialer{
+		ClientID:  c.clientID(),
+		Timeout:   c.timeout(),
+		DualStack: true,
+	}
+	if d.TLS, err = c.tlsConfig(); err != nil {
+		return nil, err
+	}
+	if c.Username != "" {
+		d.SASLMechanism = plain.Mechanism{
+			Username: c.Username,
+			Password: c.Password,
+		}
+	}
+	return
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        string
+		wantTimeout time.Duration
+		wantErr     bool
+	}{
+		{name: "1", conf: `{"brokers":["127.0.0.1:9092"]}`, wantTimeout: defaultTimeout},
+		{name: "2", conf: `{"brokers":["127.0.0.1:9092"],"timeout":"5s"}`, wantTimeout: 5 * time.Second},
+		{name: "3", conf: `{}`, wantErr: true},
+		{name: "4", conf: `{"brokers":"127.0.0.1:9092"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfig(testJSONFromString(tt.conf))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.timeout() != tt.wantTimeout {
+				t.Errorf("timeout() = %v, want %v", got.timeout(), tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestConfig_tlsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *Config
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "1", conf: &Config{}, wantNil: true},
+		{name: "2", conf: &Config{TLS: true, InsecureSkipVerify: true}},
+		{name: "3", conf: &Config{TLS: true, CAFile: "not_exist.pem"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.conf.tlsConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tlsConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got == nil) != tt.wantNil {
+				t.Errorf("tlsConfig() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func testJSONFromString(s string) *config.JSON {
+	j, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return j
+}
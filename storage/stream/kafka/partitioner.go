@@ -0,0 +1,27 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import kafkago "github.com/segmentio/kafka-go"
+
+// HashPartition gets the partition of key by murmur2 like the default partitioner of the Java client,
+// so that the messages with the same key are in the same partition whichever client produces them
+func HashPartition(key []byte, partitions int32) int32 {
+	indexes := make([]int, partitions)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return int32(kafkago.Murmur2Balancer{Consistent: true}.Balance(Message{Key: key}, indexes...))
+}
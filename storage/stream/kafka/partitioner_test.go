@@ -0,0 +1,45 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import "testing"
+
+// the expected values are taken from the murmur2 of the tests of the Java client
+func TestHashPartition(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want int32
+	}{
+		{name: "1", key: "21", want: 340},
+		{name: "2", key: "foobar", want: 166},
+		{name: "3", key: "a-little-bit-long-string", want: 112},
+		{name: "4", key: "a-little-bit-longer-string", want: 819},
+		{name: "5", key: "lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8", want: 677},
+		{name: "6", key: "abc", want: 107},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HashPartition([]byte(tt.key), 1000); got != tt.want {
+				t.Errorf("HashPartition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	for _, key := range []string{"", "a", "ab", "abc", "abcd"} {
+		if got := HashPartition([]byte(key), 7); got < 0 || got >= 7 {
+			t.Errorf("HashPartition(%v) = %v", key, got)
+		}
+	}
+}